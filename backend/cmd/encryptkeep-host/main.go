@@ -0,0 +1,91 @@
+// encryptkeep-host is the native messaging host of the EncryptKeep browser
+// extension. Browsers start it themselves; "encryptkeep-host manifest"
+// prints the manifest that tells them where it is.
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"encryptkeep-backend/internal/agent"
+	"encryptkeep-backend/internal/askpass"
+	"encryptkeep-backend/internal/nativehost"
+)
+
+func main() {
+	if len(os.Args) > 1 && os.Args[1] == "manifest" {
+		printManifest(os.Args[2:])
+		return
+	}
+
+	// the messages are on stdout, so nothing else may be written there
+	log.SetOutput(os.Stderr)
+	log.SetPrefix("encryptkeep-host: ")
+
+	h := &nativehost.Host{
+		Caller:  caller(os.Args[1:]),
+		Dial:    func() (*agent.Client, error) { return agent.Dial(agent.DefaultSocketPath()) },
+		Confirm: askpass.Confirm,
+	}
+	if askpass.Program() == "" {
+		log.Print("neither ENCRYPTKEEP_ASKPASS nor SSH_ASKPASS is set; fills will be refused")
+	}
+	if err := h.Serve(os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// caller picks the extension out of the arguments the browser passes:
+// Chromium gives its origin, chrome-extension://<id>/, first; Firefox
+// gives the manifest path, then the add-on ID.
+func caller(args []string) string {
+	for _, arg := range args {
+		if strings.Contains(arg, "-extension://") {
+			return arg
+		}
+	}
+	if len(args) >= 2 {
+		return args[1]
+	}
+	return "an unknown extension"
+}
+
+// printManifest writes the native messaging manifest for this binary.
+// Save it as com.encryptkeep.host.json in the browser's
+// NativeMessagingHosts directory.
+func printManifest(args []string) {
+	fs := flag.NewFlagSet("manifest", flag.ExitOnError)
+	browser := fs.String("browser", "chrome", "chrome (and other Chromium browsers) or firefox")
+	extension := fs.String("extension", "", "ID of the EncryptKeep extension allowed to use the host")
+	fs.Parse(args)
+	if *extension == "" {
+		fmt.Fprintln(os.Stderr, "usage: encryptkeep-host manifest [-browser chrome|firefox] -extension <id>")
+		os.Exit(2)
+	}
+	path, err := os.Executable()
+	if err != nil {
+		log.Fatalf("locate executable: %v", err)
+	}
+
+	manifest := map[string]any{
+		"name":        nativehost.Name,
+		"description": "EncryptKeep autofill",
+		"path":        path,
+		"type":        "stdio",
+	}
+	switch *browser {
+	case "chrome":
+		manifest["allowed_origins"] = []string{"chrome-extension://" + *extension + "/"}
+	case "firefox":
+		manifest["allowed_extensions"] = []string{*extension}
+	default:
+		log.Fatalf("unknown browser %q", *browser)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	enc.Encode(manifest)
+}
@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"encryptkeep-backend/internal/agent"
+	"encryptkeep-backend/internal/blockchain"
+	"encryptkeep-backend/internal/syncer"
+)
+
+// follow runs the background sync of s until ctx, the unlocked period,
+// ends, reporting changes from other devices at the prompt or, in agent
+// mode, as JSON lines on stdout.
+func (o *opener) follow(ctx context.Context, s *vaultSession) *syncer.Syncer {
+	if o.syncInterval <= 0 {
+		return nil
+	}
+	// entries are stored under the account that signs, which -signer-account
+	// may set apart from the vault's own address
+	sy := syncer.New(s.name, s.signer.Address().Hex(), s.svc, s.vm, s.vault, s.sess,
+		syncer.Config{PollInterval: o.syncInterval})
+
+	notify := promptNotifier()
+	if o.headless {
+		enc := json.NewEncoder(os.Stdout)
+		notify = func(ev syncer.Event) { enc.Encode(ev) }
+	}
+	events, unsubscribe := sy.Subscribe(16)
+	go func() {
+		defer unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev := <-events:
+				notify(ev)
+			}
+		}
+	}()
+	go sy.Run(ctx)
+	return sy
+}
+
+// promptNotifier prints sync events between commands. A failure is shown
+// once rather than at every poll while the network stays down.
+func promptNotifier() func(syncer.Event) {
+	var lastErr string
+	return func(ev syncer.Event) {
+		switch ev.Kind {
+		case syncer.EventChanged:
+			lastErr = ""
+			fmt.Printf("\nVault %s changed on chain: %d added, %d updated, %d removed.\n> ",
+				ev.Vault, len(ev.Added), len(ev.Updated), len(ev.Removed))
+		case syncer.EventError:
+			if ev.Error != lastErr {
+				lastErr = ev.Error
+				fmt.Printf("\nVault %s background sync error: %s\n> ", ev.Vault, ev.Error)
+			}
+		}
+	}
+}
+
+// runAgent keeps s unlocked and in sync without a prompt, serving it on
+// the Unix socket at path, until the process is interrupted or the vault
+// locks. -idle-timeout and -max-session still apply: calls count as
+// activity, background syncs do not. Writes whose fee is above the
+// -confirm-above limits are refused, as nobody is there to confirm them.
+// Unless sshPath is empty, the vault's SSH keys are served there too.
+func runAgent(s *vaultSession, path, sshPath string, policy costPolicy) {
+	ln, err := agent.Listen(path)
+	if err != nil {
+		s.close()
+		fmt.Fprintf(os.Stderr, "agent socket %s: %v\n", path, err)
+		os.Exit(1)
+	}
+	srv := agent.NewServer(s.name, s.vm, s.vault, s.sess, s.syncer)
+	srv.Approve = func(e *blockchain.CostEstimate) error {
+		if policy.exceeded(e) {
+			return fmt.Errorf("estimated cost %s is above the -confirm-above limit", e)
+		}
+		return nil
+	}
+
+	var sshLn *net.UnixListener
+	if sshPath != "" {
+		if sshLn, err = agent.Listen(sshPath); err != nil {
+			ln.Close()
+			s.close()
+			fmt.Fprintf(os.Stderr, "ssh-agent socket %s: %v\n", sshPath, err)
+			os.Exit(1)
+		}
+	}
+
+	ctx, stop := context.WithCancel(context.Background())
+	served := make(chan error, 2)
+	go func() { served <- srv.Serve(ctx, ln) }()
+	if sshLn != nil {
+		keys := agent.NewSSHAgent(s.vault, s.sess)
+		keys.Confirm = askpassConfirm
+		go func() { served <- keys.Serve(ctx, sshLn) }()
+		fmt.Fprintf(os.Stderr, "SSH_AUTH_SOCK=%s; export SSH_AUTH_SOCK;\n", sshPath)
+	}
+
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sig)
+
+	fmt.Fprintf(os.Stderr, "Agent for vault %s listening on %s. Press Ctrl-C to stop.\n", s.name, path)
+	select {
+	case <-sig:
+	case <-s.locked:
+	case err := <-served:
+		fmt.Fprintf(os.Stderr, "agent socket error: %v\n", err)
+	}
+	stop()
+	s.close()
+	fmt.Fprintf(os.Stderr, "Agent for vault %s stopped.\n", s.name)
+}
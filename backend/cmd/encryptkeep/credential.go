@@ -0,0 +1,239 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"encryptkeep-backend/internal/agent"
+	"encryptkeep-backend/internal/gitcred"
+	"encryptkeep-backend/internal/secretref"
+	"encryptkeep-backend/internal/vault"
+)
+
+// The git credential helper, run, inject and read take their secrets from
+// a running agent: they are started by scripts and other programs, with
+// no terminal to ask for the master password.
+
+func dialAgent(path string) (*agent.Client, error) {
+	client, err := agent.Dial(path)
+	if err != nil {
+		return nil, fmt.Errorf("no agent on %s (start one with \"encryptkeep agent\"): %w", path, err)
+	}
+	return client, nil
+}
+
+// gitCredential is "encryptkeep git-credential get|store|erase", for
+// credential.helper. Entries are found by their addresses; store and erase
+// change the vault, so the agent's -confirm-above limits apply.
+func gitCredential(socketPath string, args []string) int {
+	if len(args) != 1 {
+		fmt.Fprintln(os.Stderr, "usage: encryptkeep git-credential get|store|erase")
+		return 2
+	}
+	cred, err := gitcred.Read(os.Stdin)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encryptkeep: %v\n", err)
+		return 1
+	}
+	client, err := dialAgent(socketPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encryptkeep: %v\n", err)
+		return 1
+	}
+	defer client.Close()
+
+	switch args[0] {
+	case "get":
+		err = credentialGet(client, cred)
+	case "store":
+		err = credentialStore(client, cred)
+	case "erase":
+		err = credentialErase(client, cred)
+	default:
+		// git asks helpers for operations they may not know; ignore them
+		return 0
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encryptkeep: git-credential %s: %v\n", args[0], err)
+		return 1
+	}
+	return 0
+}
+
+// credentialEntries returns the entries matching cred, best first, by
+// the same rules as autofill. git only sends the repository path with
+// credential.useHttpPath, so starts_with addresses naming a repository
+// need that setting.
+func credentialEntries(client *agent.Client, cred *gitcred.Credential) ([]*vault.PasswordEntry, error) {
+	var list []agent.Summary
+	if err := client.Call("search", agent.SearchParams{URL: cred.URL()}, &list); err != nil {
+		return nil, err
+	}
+	entries := make([]*vault.PasswordEntry, 0, len(list))
+	for _, s := range list {
+		if cred.Username != "" && s.Username != cred.Username {
+			continue
+		}
+		var e vault.PasswordEntry
+		if err := client.Call("get", agent.IDParams{ID: s.ID}, &e); err != nil {
+			return nil, err
+		}
+		entries = append(entries, &e)
+	}
+	return entries, nil
+}
+
+func credentialGet(client *agent.Client, cred *gitcred.Credential) error {
+	entries, err := credentialEntries(client, cred)
+	if err != nil || len(entries) == 0 {
+		return err
+	}
+	out := &gitcred.Credential{Username: entries[0].Username, Password: entries[0].Password}
+	return out.Write(os.Stdout)
+}
+
+// credentialStore saves a credential git has seen work: a changed
+// password updates the best matching entry, an unknown one is added.
+func credentialStore(client *agent.Client, cred *gitcred.Credential) error {
+	if cred.Host == "" || cred.Password == "" {
+		return nil
+	}
+	entries, err := credentialEntries(client, cred)
+	if err != nil {
+		return err
+	}
+	if len(entries) > 0 {
+		e := entries[0]
+		if e.Password == cred.Password && e.Username == cred.Username {
+			return nil
+		}
+		e.Username, e.Password = cred.Username, cred.Password
+		return client.Call("update", e, nil)
+	}
+	entry := &vault.PasswordEntry{Title: cred.Host, Username: cred.Username, Password: cred.Password, URL: cred.URL()}
+	return client.Call("add", entry, nil)
+}
+
+// credentialErase deletes the entries holding a password git reports as
+// rejected. Without the password nothing is deleted, so a failed login
+// with some other credential never costs a vault entry.
+func credentialErase(client *agent.Client, cred *gitcred.Credential) error {
+	if cred.Password == "" {
+		return nil
+	}
+	entries, err := credentialEntries(client, cred)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.Password != cred.Password {
+			continue
+		}
+		if err := client.Call("delete", agent.IDParams{ID: e.ID}, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// envFlag collects the repeated -env flags of run.
+type envFlag []string
+
+func (f *envFlag) String() string     { return strings.Join(*f, ",") }
+func (f *envFlag) Set(v string) error { *f = append(*f, v); return nil }
+
+// runCommand is "encryptkeep run --env VAR=entry:field ... -- command",
+// which starts command with the secrets in its environment. They are
+// never written to disk, but other processes of the same user can read a
+// process's environment.
+func runCommand(socketPath string, args []string) int {
+	fs := flag.NewFlagSet("run", flag.ContinueOnError)
+	var envs envFlag
+	fs.Var(&envs, "env", "set `VAR=entry:field`, where entry is an ID or title and field one of "+strings.Join(secretref.Fields, ", ")+" (repeatable)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: encryptkeep run --env VAR=entry:field [--env ...] -- command [args...]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 || len(envs) == 0 {
+		fs.Usage()
+		return 2
+	}
+
+	client, err := dialAgent(socketPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encryptkeep: %v\n", err)
+		return 1
+	}
+	env, err := resolveEnv(client, envs)
+	client.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encryptkeep: %v\n", err)
+		return 1
+	}
+
+	cmd := exec.Command(fs.Arg(0), fs.Args()[1:]...)
+	cmd.Env = append(os.Environ(), env...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+
+	// the child decides what Ctrl-C and termination mean
+	sig := make(chan os.Signal, 1)
+	signal.Notify(sig, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(sig)
+	if err := cmd.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "encryptkeep: %v\n", err)
+		return 127
+	}
+	go func() {
+		for s := range sig {
+			cmd.Process.Signal(s)
+		}
+	}()
+
+	err = cmd.Wait()
+	var exit *exec.ExitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &exit) && exit.ExitCode() >= 0:
+		return exit.ExitCode()
+	default:
+		fmt.Fprintf(os.Stderr, "encryptkeep: %v\n", err)
+		return 1
+	}
+}
+
+// resolveEnv turns VAR=entry:field specs into VAR=value pairs. The field
+// follows the last colon, so titles may contain colons; a full reference,
+// VAR=ek://vault/entry/field, works as well.
+func resolveEnv(client *agent.Client, specs []string) ([]string, error) {
+	var status agent.Status
+	if err := client.Call("status", nil, &status); err != nil {
+		return nil, err
+	}
+	env := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		name, ref, ok := strings.Cut(spec, "=")
+		i := strings.LastIndex(ref, ":")
+		if !ok || name == "" || i <= 0 {
+			return nil, fmt.Errorf("bad --env %q: want VAR=entry:field", spec)
+		}
+		if !strings.HasPrefix(ref, secretref.Scheme) {
+			ref = secretref.Ref{Vault: status.Vault, Entry: ref[:i], Field: ref[i+1:]}.String()
+		}
+		var value agent.ResolveResult
+		if err := client.Call("resolve", agent.ResolveParams{Ref: ref}, &value); err != nil {
+			return nil, fmt.Errorf("--env %s: %w", name, err)
+		}
+		env = append(env, name+"="+value.Value)
+	}
+	return env, nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"encryptkeep-backend/internal/agent"
+	"encryptkeep-backend/internal/secretref"
+)
+
+// agentResolver resolves secret references through the running agent.
+func agentResolver(client *agent.Client) func(secretref.Ref) (string, error) {
+	return func(ref secretref.Ref) (string, error) {
+		var value agent.ResolveResult
+		if err := client.Call("resolve", agent.ResolveParams{Ref: ref.String()}, &value); err != nil {
+			return "", fmt.Errorf("%s: %w", ref, err)
+		}
+		return value.Value, nil
+	}
+}
+
+// injectCommand is "encryptkeep inject [-i template] [-o file]", which
+// renders a template whose ek:// references are replaced with their
+// values. The output file is only readable by the user.
+func injectCommand(socketPath string, args []string) int {
+	fs := flag.NewFlagSet("inject", flag.ContinueOnError)
+	in := fs.String("i", "", "template to render (default: standard input)")
+	out := fs.String("o", "", "file to write, with mode 0600 (default: standard output)")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: encryptkeep inject [-i template] [-o file]")
+		fmt.Fprintln(fs.Output(), "references are ek://vault/entry/field, bare or as {{ ek://vault/entry/field }}")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return 2
+	}
+
+	var tpl []byte
+	var err error
+	if *in == "" {
+		tpl, err = io.ReadAll(os.Stdin)
+	} else {
+		tpl, err = os.ReadFile(*in)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encryptkeep: %v\n", err)
+		return 1
+	}
+
+	client, err := dialAgent(socketPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encryptkeep: %v\n", err)
+		return 1
+	}
+	rendered, err := secretref.Render(tpl, agentResolver(client))
+	client.Close()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encryptkeep: %v\n", err)
+		return 1
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(rendered)
+	} else {
+		err = writePrivate(*out, rendered)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encryptkeep: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// writePrivate replaces path with data, readable by the user only. The
+// file is written next to path and renamed over it, so readers never see
+// it half written and a looser mode of the old file does not carry over.
+func writePrivate(path string, data []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if err := f.Chmod(0o600); err != nil {
+		f.Close()
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// readCommand is "encryptkeep read [-n] ek://vault/entry/field", which
+// prints one value.
+func readCommand(socketPath string, args []string) int {
+	fs := flag.NewFlagSet("read", flag.ContinueOnError)
+	noNewline := fs.Bool("n", false, "do not print a newline after the value")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: encryptkeep read [-n] ek://vault/entry/field")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	ref, err := secretref.Parse(fs.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encryptkeep: %v\n", err)
+		return 2
+	}
+
+	client, err := dialAgent(socketPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encryptkeep: %v\n", err)
+		return 1
+	}
+	defer client.Close()
+	value, err := agentResolver(client)(ref)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "encryptkeep: %v\n", err)
+		return 1
+	}
+	if !*noNewline {
+		value += "\n"
+	}
+	os.Stdout.WriteString(value)
+	return 0
+}
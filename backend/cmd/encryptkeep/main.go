@@ -3,104 +3,167 @@ package main
 import (
 	"bufio"
 	"context"
+	"crypto/ecdsa"
 	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"math/big"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"encryptkeep-backend/internal/agent"
 	"encryptkeep-backend/internal/blockchain"
+	"encryptkeep-backend/internal/codec"
+	"encryptkeep-backend/internal/config"
+	"encryptkeep-backend/internal/hdwallet"
 	"encryptkeep-backend/internal/keymanager"
+	"encryptkeep-backend/internal/otp"
+	"encryptkeep-backend/internal/pricing"
+	"encryptkeep-backend/internal/session"
+	"encryptkeep-backend/internal/syncer"
 	"encryptkeep-backend/internal/vault"
 	"encryptkeep-backend/internal/vaultmanager"
 
-	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // CLI entrypoint
 func main() {
-	km := keymanager.NewKeyManager(keymanager.KeyManagerConfig{})
+	vaultName := flag.String("vault", "", "vault to open (default: the last one used)")
+	profileName := flag.String("profile", "", "network profile from config.json (default: the vault's profile or the file's default_profile)")
+	priceFile := flag.String("price-file", "", "JSON file with token prices, e.g. {\"ETH\": {\"USD\": 3000}}")
+	currency := flag.String("currency", "USD", "fiat currency for cost previews")
+	var policy costPolicy
+	flag.Float64Var(&policy.maxNative, "confirm-above", 0.0005, "ask before writes whose fee exceeds this amount of native token (0 disables)")
+	flag.Float64Var(&policy.maxFiat, "confirm-above-fiat", 0, "ask before writes whose fee exceeds this fiat amount (0 disables)")
+	signer := flag.String("signer", "", "sign transactions with keystore:<dir> or clef:<endpoint>; the wallet key in keys.json stays locked, so shared collections, emergency access, recovery and keystore export are unavailable")
+	signerAccount := flag.String("signer-account", "", "account of -signer (default: the vault's address)")
+	var sessionCfg session.Config
+	flag.DurationVar(&sessionCfg.IdleTimeout, "idle-timeout", session.DefaultIdleTimeout, "lock the vault after this long without a command")
+	flag.DurationVar(&sessionCfg.MaxLifetime, "max-session", session.DefaultMaxLifetime, "lock the vault this long after unlocking, however active")
+	socketPath := flag.String("socket", agent.DefaultSocketPath(), "Unix socket the agent serves the vault on")
+	sshSocketPath := flag.String("ssh-socket", agent.DefaultSSHSocketPath(), "Unix socket the agent serves the vault's SSH keys on as an ssh-agent (empty disables)")
+	syncInterval := flag.Duration("sync-interval", syncer.DefaultPollInterval, "check the chain for changes from other devices this often while unlocked (0 disables)")
+	flag.Parse()
+
+	switch flag.Arg(0) {
+	case "git-credential":
+		os.Exit(gitCredential(*socketPath, flag.Args()[1:]))
+	case "run":
+		os.Exit(runCommand(*socketPath, flag.Args()[1:]))
+	case "inject":
+		os.Exit(injectCommand(*socketPath, flag.Args()[1:]))
+	case "read":
+		os.Exit(readCommand(*socketPath, flag.Args()[1:]))
+	}
+
+	baseDir := keymanager.DefaultConfigDir()
 	reader := bufio.NewReader(os.Stdin)
 
-	fmt.Print("Enter master password: ")
-	masterPassword, err := readLine(reader)
+	cfg, err := loadConfig(baseDir)
 	if err != nil {
-		log.Fatalf("read master password: %v", err)
+		log.Fatalf("load config: %v", err)
 	}
-	if len(masterPassword) < 8 {
-		log.Fatalf("master password too short (min 8)")
+	reg, err := keymanager.NewRegistry(baseDir)
+	if err != nil {
+		log.Fatalf("load vaults: %v", err)
 	}
-
-	if km.HasStoredKeys() {
-		if err := km.LoadFromStorage(masterPassword); err != nil {
-			log.Fatalf("load keys: %v", err)
-		}
-		fmt.Println("Keys loaded from storage.")
-	} else {
-		fmt.Print("Enter private key (64 hex chars): ")
-		privHex, err := readLine(reader)
-		if err != nil {
-			log.Fatalf("read private key: %v", err)
-		}
-		privHex = strings.TrimSpace(privHex)
-		if len(privHex) != 64 {
-			log.Fatalf("invalid private key length")
-		}
-		if _, err := hex.DecodeString(privHex); err != nil {
-			log.Fatalf("invalid private key hex: %v", err)
-		}
-		if err := km.InitializeFirstTime(privHex, masterPassword); err != nil {
-			log.Fatalf("init keys: %v", err)
-		}
-		fmt.Println("Keys initialized and stored.")
+	if *vaultName == "" {
+		*vaultName = reg.Active()
 	}
 
-	privKey, err := km.GetPrivateKey()
-	if err != nil {
-		log.Fatalf("get private key: %v", err)
+	op := &opener{reader: reader, cfg: cfg, reg: reg, currency: *currency, signer: *signer, signerAccount: *signerAccount, session: sessionCfg,
+		syncInterval: *syncInterval, headless: flag.Arg(0) == "agent"}
+	if *priceFile != "" {
+		op.prices = pricing.NewFileSource(*priceFile)
 	}
-	privHex := hex.EncodeToString(crypto.FromECDSA(privKey))
-
-	svc := blockchain.NewBlockchainService(blockchain.GetDefaultConfig())
-	if err := svc.Connect(); err != nil {
-		log.Fatalf("blockchain connect: %v", err)
+	if flag.Arg(0) == "recover" {
+		if err := op.recover(*vaultName, *profileName); err != nil {
+			log.Fatalf("recover vault %s: %v", *vaultName, err)
+		}
+		fmt.Println("Master password reset. Open the vault with the new password.")
 	}
-	if _, err := svc.StartSession(privHex, masterPassword); err != nil {
-		log.Fatalf("start session: %v", err)
+	cur, err := op.open(*vaultName, *profileName)
+	if err != nil {
+		log.Fatalf("open vault %s: %v", *vaultName, err)
 	}
-
-	localVault := vault.NewLocalVault()
-	if err := svc.SyncVault(localVault); err != nil {
-		log.Fatalf("sync vault: %v", err)
+	if op.headless {
+		runAgent(cur, *socketPath, *sshSocketPath, policy)
+		return
 	}
-	fmt.Printf("Sync complete. Entries: %d, LastSync: %s\n", len(localVault.Entries), localVault.LastSyncTime.Format("2006-01-02 15:04:05"))
 
 	ctx := context.Background()
-	vm := vaultmanager.NewVaultManager(svc, masterPassword)
 
+	// held is the session a running command holds; it is released before
+	// waiting for the next one, so idle time only counts at the prompt
+	var held *session.Session
 	for {
-		fmt.Print("\nCommands: list, get, add, update, delete, sync, exit\n> ")
-		cmd, err := readLine(reader)
+		if held != nil {
+			held.Release()
+			held = nil
+		}
+
+		fmt.Print("\nCommands: list, get, add, update, delete, import, sync, migrate, collection, emergency, recovery, keystore, ssh, uri, find, lock, unlock, vaults, vault, profiles, exit\n> ")
+		line, err := readLine(reader)
 		if err != nil {
 			log.Fatalf("read command: %v", err)
 		}
-		cmd = strings.ToLower(cmd)
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		cmd, args := strings.ToLower(fields[0]), fields[1:]
+
+		if !lockedCommands[cmd] {
+			if err := cur.sess.Hold(); err != nil {
+				fmt.Printf("Vault %s is locked. Type unlock to continue.\n", cur.name)
+				continue
+			}
+			held = cur.sess
+		}
+		if op.signer != "" && walletKeyCommands[cmd] {
+			fmt.Printf("%s needs the vault's wallet key, which stays locked with -signer.\n", cmd)
+			continue
+		}
 
 		switch cmd {
+		case "lock":
+			cur.sess.Lock()
+			fmt.Printf("Vault %s locked.\n", cur.name)
+
+		case "unlock":
+			if !cur.sess.Locked() {
+				fmt.Printf("Vault %s is already unlocked.\n", cur.name)
+				continue
+			}
+			_, profile, err := op.profile(cur.name, cur.profile)
+			if err != nil {
+				fmt.Printf("unlock error: %v\n", err)
+				continue
+			}
+			if err := op.unlock(cur, profile); err != nil {
+				fmt.Printf("unlock error: %v\n", err)
+			}
+
 		case "list":
-			if len(localVault.Entries) == 0 {
+			entries := cur.vault.List()
+			if len(entries) == 0 {
 				fmt.Println("No entries.")
 				continue
 			}
 			fmt.Println("Entries:")
-			for id, e := range localVault.Entries {
+			for _, e := range entries {
 				fmt.Printf("- ID: %s | Title: %s | Username: %s | Updated: %s\n",
-					id, e.Title, e.Username, e.UpdatedAt.Format("2006-01-02 15:04:05"))
+					e.ID, e.Title, e.Username, e.UpdatedAt.Format("2006-01-02 15:04:05"))
 			}
 		case "get":
 			id := prompt(reader, "Entry ID", false)
-			entry, ok := localVault.Entries[id]
+			entry, ok := cur.vault.Get(id)
 			if !ok {
 				fmt.Println("entry not found")
 				continue
@@ -108,16 +171,37 @@ func main() {
 
 			fmt.Printf("ID: %s\nTitle: %s\nUsername: %s\nPassword: %s\nURL: %s\nUpdated: %s\n",
         		entry.ID, entry.Title, entry.Username, entry.Password, entry.URL, entry.UpdatedAt.Format("2006-01-02 15:04:05"))
+			if entry.URLMatch != "" {
+				fmt.Printf("URL match: %s\n", entry.URLMatch)
+			}
+			for _, u := range entry.URIs {
+				fmt.Printf("URI: %s | %s\n", u.URI, orDefault(u.Match))
+			}
+			if entry.OTP != "" {
+				printOTP(entry.OTP)
+			}
 		case "add":
 			title := prompt(reader, "Title", false)
 			username := prompt(reader, "Username", false)
 			password := prompt(reader, "Password", false)
 			url := prompt(reader, "URL (optional)", true)
+			otpSecret := prompt(reader, "OTP secret or otpauth:// URI (optional)", true)
+			if otpSecret != "" {
+				if _, err := otp.Parse(otpSecret); err != nil {
+					fmt.Printf("add entry error: %v\n", err)
+					continue
+				}
+			}
 
 			entry := vault.NewPasswordEntry(title, username, password)
 			entry.URL = url
+			entry.OTP = otpSecret
 
-			if err := vm.AddEntry(ctx, localVault, entry); err != nil {
+			estimate, err := cur.vm.PreviewAddEntry(ctx, entry)
+			if !confirmCost(reader, policy, estimate, err) {
+				continue
+			}
+			if err := cur.vm.AddEntry(ctx, cur.vault, entry); err != nil {
 				fmt.Printf("add entry error: %v\n", err)
 				continue
 			}
@@ -125,7 +209,8 @@ func main() {
 
 		case "update":
 			id := prompt(reader, "Entry ID", false)
-			entry, ok := localVault.Entries[id]
+			// Get returns a copy, so a declined or failed write leaves the vault as it was
+			entry, ok := cur.vault.Get(id)
 			if !ok {
 				fmt.Println("entry not found")
 				continue
@@ -135,6 +220,7 @@ func main() {
 			username := prompt(reader, fmt.Sprintf("Username [%s]", entry.Username), true)
 			password := prompt(reader, "Password [leave empty to keep]", true)
 			url := prompt(reader, fmt.Sprintf("URL [%s]", entry.URL), true)
+			otpSecret := prompt(reader, "OTP secret [leave empty to keep, - to remove]", true)
 
 			if title != "" {
 				entry.Title = title
@@ -148,9 +234,24 @@ func main() {
 			if url != "" {
 				entry.URL = url
 			}
+			switch otpSecret {
+			case "":
+			case "-":
+				entry.OTP = ""
+			default:
+				if _, err := otp.Parse(otpSecret); err != nil {
+					fmt.Printf("update entry error: %v\n", err)
+					continue
+				}
+				entry.OTP = otpSecret
+			}
 			entry.UpdatedAt = time.Now()
 
-			if err := vm.UpdateEntry(ctx, localVault, entry); err != nil {
+			estimate, err := cur.vm.PreviewUpdateEntry(ctx, cur.vault, entry)
+			if !confirmCost(reader, policy, estimate, err) {
+				continue
+			}
+			if err := cur.vm.UpdateEntry(ctx, cur.vault, entry); err != nil {
 				fmt.Printf("update entry error: %v\n", err)
 				continue
 			}
@@ -158,24 +259,208 @@ func main() {
 
 		case "delete":
 			id := prompt(reader, "Entry ID", false)
-			if _, ok := localVault.Entries[id]; !ok {
+			if _, ok := cur.vault.Get(id); !ok {
 				fmt.Println("entry not found")
 				continue
 			}
-			if err := vm.DeleteEntry(ctx, localVault, id); err != nil {
+			estimate, err := cur.vm.PreviewDeleteEntry(ctx, cur.vault, id)
+			if !confirmCost(reader, policy, estimate, err) {
+				continue
+			}
+			if err := cur.vm.DeleteEntry(ctx, cur.vault, id); err != nil {
 				fmt.Printf("delete entry error: %v\n", err)
 				continue
 			}
 			fmt.Println("Entry deleted and synced.")
 
+		case "import":
+			path := flagValue(args, "--file")
+			if path == "" {
+				fmt.Println("usage: import --file <entries.json>")
+				continue
+			}
+
+			entries, err := readImportFile(path)
+			if err != nil {
+				fmt.Printf("import error: %v\n", err)
+				continue
+			}
+			estimate, err := cur.vm.PreviewAddEntries(ctx, entries)
+			if !confirmCost(reader, policy, estimate, err) {
+				continue
+			}
+			if err := cur.vm.AddEntries(ctx, cur.vault, entries); err != nil {
+				fmt.Printf("import error: %v\n", err)
+				continue
+			}
+			fmt.Printf("Imported. Entries: %d\n", cur.vault.Len())
+
 		case "sync":
-			if err := svc.SyncVault(localVault); err != nil {
+			if err := cur.sync(reg); err != nil {
+				fmt.Printf("sync error: %v\n", err)
+				continue
+			}
+			fmt.Printf("Synced. Entries: %d, LastSync: %s\n", cur.vault.Len(), cur.vault.LastSync().Format("2006-01-02 15:04:05"))
+
+		case "migrate":
+			to := flagValue(args, "--to")
+			if to == "" {
+				fmt.Println("usage: migrate --to <contract address>")
+				continue
+			}
+
+			report, err := cur.svc.MigrateTo(ctx, to, func(p blockchain.MigrationProgress) {
+				fmt.Printf("  %s: %d/%d\n", p.Stage, p.Done, p.Total)
+			})
+			if err != nil {
+				fmt.Printf("migrate error: %v\n", err)
+				continue
+			}
+			fmt.Printf("Migrated %s (v%d) -> %s (v%d): %d entries, %d copied, %d already present, %d txs, %d gas. Verified: %t\n",
+				report.From, report.FromVersion, report.To, report.ToVersion,
+				report.Entries, report.Copied, report.Skipped, report.Transactions, report.GasUsed, report.Verified)
+
+			question := fmt.Sprintf("Switch to the new contract and save it in profile %s? [y/N]", cur.profile)
+			if answer := prompt(reader, question, true); !strings.EqualFold(answer, "y") {
+				fmt.Printf("To use it later, set contract_address of profile %q in %s to %s.\n", cur.profile, config.Path(baseDir), report.To)
+				continue
+			}
+			if err := cur.svc.UseContract(ctx, report.To); err != nil {
+				fmt.Printf("switch contract error: %v\n", err)
+				continue
+			}
+			if err := saveContract(baseDir, cfg, cur.profile, report.To); err != nil {
+				fmt.Printf("save config error: %v\n", err)
+				fmt.Printf("Set contract_address of profile %q in %s to %s by hand.\n", cur.profile, config.Path(baseDir), report.To)
+			}
+			if others := profileVaults(reg, cfg, cur.profile, cur.name); len(others) > 0 {
+				fmt.Printf("Vaults %s use profile %s too; migrate them before their next sync.\n", strings.Join(others, ", "), cur.profile)
+			}
+			if err := cur.svc.SyncVault(cur.vault); err != nil {
 				fmt.Printf("sync error: %v\n", err)
 				continue
 			}
-			fmt.Printf("Synced. Entries: %d, LastSync: %s\n", len(localVault.Entries), localVault.LastSyncTime.Format("2006-01-02 15:04:05"))
+			fmt.Printf("Now using %s. Entries: %d\n", report.To, cur.vault.Len())
+
+		case "profiles":
+			for _, name := range cfg.Names() {
+				p := cfg.Profiles[name]
+				marker := " "
+				if name == cur.profile {
+					marker = "*"
+				}
+				fmt.Printf("%s %s | chain %d | contract %s | rpc %s\n", marker, name, p.ChainID, p.ContractAddress, strings.Join(p.RPCURLs, ", "))
+			}
+			fmt.Printf("Config file: %s (set a vault's profile with vault create --profile <name>)\n", config.Path(baseDir))
+
+		case "collection":
+			if len(args) == 0 {
+				fmt.Println("usage: collection publish-key | create | open <id> | add <id> | add-member <id> <address> | remove-member <id> <address>")
+				continue
+			}
+			handleCollection(ctx, reader, cur, args)
+
+		case "emergency":
+			if len(args) == 0 {
+				fmt.Println("usage: emergency contacts | add <address> [--wait 72h] | remove <address> | approve <address> | reject <address>")
+				fmt.Println("       emergency request <owner> | open <owner>")
+				continue
+			}
+			handleEmergency(ctx, cur, args)
+
+		case "ssh":
+			if len(args) == 0 {
+				fmt.Println("usage: ssh list | add <title> --key <file> [--confirm] [--lifetime 1h]")
+				continue
+			}
+			handleSSH(ctx, reader, cur, policy, args)
+
+		case "uri":
+			handleURI(ctx, reader, cur, policy, args)
+
+		case "find":
+			findEntries(cur, args)
+
+		case "recovery":
+			n, k, err := recoveryShares(args)
+			if err != nil {
+				fmt.Printf("recovery error: %v\n", err)
+				fmt.Println("usage: recovery [<shares> <threshold>]")
+				continue
+			}
+			fmt.Print("Enter master password: ")
+			masterPassword, err := readLine(reader)
+			if err != nil {
+				log.Fatalf("read master password: %v", err)
+			}
+			if err := printRecoveryShares(cur.km, masterPassword, n, k); err != nil {
+				fmt.Printf("recovery error: %v\n", err)
+			}
+
+		case "keystore":
+			if len(args) == 0 || args[0] != "export" {
+				fmt.Println("usage: keystore export [--out <file>] [--pbkdf2]")
+				continue
+			}
+			if err := exportKeystore(reader, cur, args[1:]); err != nil {
+				fmt.Printf("keystore error: %v\n", err)
+			}
+
+		case "vaults":
+			for _, info := range reg.List() {
+				marker := " "
+				if info.Name == cur.name {
+					marker = "*"
+				}
+				fmt.Printf("%s %s | %s | profile %s | %s\n", marker, info.Name, orNone(info.Address), orDefault(info.Profile), syncStatus(info))
+				if info.DerivationPath != "" {
+					fmt.Printf("    key derived along %s\n", info.DerivationPath)
+				}
+			}
+
+		case "vault":
+			if len(args) < 2 {
+				fmt.Println("usage: vault create <name> [--profile p] [--min-length n] [--require-upper] [--require-lower] [--require-digit] [--require-symbol]")
+				fmt.Println("       vault use <name>")
+				continue
+			}
+			switch args[0] {
+			case "create":
+				opts, err := vaultOptions(cfg, args[2:])
+				if err != nil {
+					fmt.Printf("vault create error: %v\n", err)
+					continue
+				}
+				info, err := reg.Create(args[1], opts)
+				if err != nil {
+					fmt.Printf("vault create error: %v\n", err)
+					continue
+				}
+				fmt.Printf("Vault %s created (profile %s, min password length %d). Open it with: vault use %s\n",
+					info.Name, orDefault(info.Profile), info.PasswordPolicy.MinLength, info.Name)
+
+			case "use":
+				if args[1] == cur.name {
+					fmt.Printf("Vault %s is already open.\n", cur.name)
+					continue
+				}
+				next, err := op.open(args[1], "")
+				if err != nil {
+					fmt.Printf("open vault error: %v\n", err)
+					continue
+				}
+				cur.close()
+				cur = next
+				if err := reg.SetActive(cur.name); err != nil {
+					fmt.Printf("save active vault error: %v\n", err)
+				}
+
+			default:
+				fmt.Println("usage: vault create <name> | vault use <name>")
+			}
 
 		case "exit", "quit":
+			cur.close()
 			fmt.Println("Bye.")
 			return
 
@@ -185,6 +470,931 @@ func main() {
 	}
 }
 
+// lockedCommands run without unlocking the current vault.
+var lockedCommands = map[string]bool{
+	"lock": true, "unlock": true, "vaults": true, "vault": true, "profiles": true, "exit": true, "quit": true,
+}
+
+// walletKeyCommands unwrap or export with the wallet key, so -signer
+// refuses them.
+var walletKeyCommands = map[string]bool{
+	"collection": true, "emergency": true, "recovery": true, "keystore": true,
+}
+
+// opener unlocks vaults from the registry and connects them to their
+// network profile.
+type opener struct {
+	reader   *bufio.Reader
+	cfg      *config.Config
+	reg      *keymanager.Registry
+	prices   pricing.Source
+	currency string
+
+	signer        string // -signer: "", keystore:<dir> or clef:<endpoint>
+	signerAccount string
+
+	session      session.Config // -idle-timeout and -max-session
+	syncInterval time.Duration  // -sync-interval, 0 disables the background sync
+
+	headless bool // agent mode: events go out as JSON, there is no prompt
+}
+
+// vaultSession is an open vault connected to its network. Its keys and
+// decrypted entries only exist while sess is unlocked.
+type vaultSession struct {
+	name    string
+	profile string
+	km      *keymanager.KeyManager
+	svc     *blockchain.BlockchainServiceImpl
+	sess    *session.Session
+	signer  blockchain.Signer
+	vault   *vault.LocalVault
+	vm      *vaultmanager.VaultManager
+	syncer  *syncer.Syncer // nil with -sync-interval 0
+	locked  chan struct{}  // closed when the unlocked period ends
+
+	collections map[string]*vault.SharedCollection // opened shared collections by ID
+}
+
+// profile resolves the network profile of vault name: the flag, then the
+// vault's own profile, then the config file's default.
+func (o *opener) profile(name, profileName string) (string, *config.Profile, error) {
+	info, err := o.reg.Get(name)
+	if err != nil {
+		return "", nil, err
+	}
+	if profileName == "" {
+		profileName = info.Profile
+	}
+	if profileName == "" {
+		profileName = o.cfg.DefaultProfile
+	}
+	profile, err := o.cfg.Profile(profileName)
+	if err != nil {
+		return "", nil, fmt.Errorf("select profile: %w", err)
+	}
+	return profileName, profile, nil
+}
+
+// open unlocks vault name with its own key file and connects it to
+// profileName, or to the vault's profile when that is empty.
+func (o *opener) open(name, profileName string) (*vaultSession, error) {
+	profileName, profile, err := o.profile(name, profileName)
+	if err != nil {
+		return nil, err
+	}
+
+	var sess *session.Session
+	cfg := o.session
+	cfg.OnAutoLock = func(reason error) {
+		if o.headless {
+			fmt.Fprintf(os.Stderr, "Vault %s locked (%s).\n", name, autoLockReason(sess.Config(), reason))
+			return
+		}
+		fmt.Printf("\nVault %s locked (%s). Type unlock to continue.\n> ", name, autoLockReason(sess.Config(), reason))
+	}
+	sess = session.New(cfg)
+
+	// the key manager's own timeout is a backstop: the session locks first
+	km, err := o.reg.KeyManager(name, sess.Config().MaxLifetime)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &vaultSession{
+		name:    name,
+		profile: profileName,
+		km:      km,
+		svc:     blockchain.NewBlockchainService(profile.BlockchainConfig()),
+		sess:    sess,
+		vault:   vault.NewLocalVault(),
+
+		collections: make(map[string]*vault.SharedCollection),
+	}
+	if err := o.unlock(s, profile); err != nil {
+		s.close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// unlock asks for the master password, loads or creates the vault's keys,
+// starts the chain session and syncs. Everything it sets up is torn down
+// by the session's lock hooks.
+func (o *opener) unlock(s *vaultSession, profile *config.Profile) error {
+	km := s.km
+	fmt.Printf("Enter master password for vault %s: ", s.name)
+	masterPassword, err := readLine(o.reader)
+	if err != nil {
+		return fmt.Errorf("read master password: %w", err)
+	}
+
+	if o.signer != "" {
+		address, err := o.vaultAddress(km)
+		if err != nil {
+			return err
+		}
+		if err := km.UnlockVaultKey(address, masterPassword); err != nil {
+			return fmt.Errorf("vault key: %w", err)
+		}
+		fmt.Println("Vault key derived; the wallet key stays locked (-signer).")
+	} else if km.HasStoredKeys() {
+		if err := km.LoadFromStorage(masterPassword); err != nil {
+			return fmt.Errorf("load keys: %w", err)
+		}
+		fmt.Println("Keys loaded from storage.")
+	} else {
+		if err := o.initKeys(s.name, km, masterPassword); err != nil {
+			return fmt.Errorf("init keys: %w", err)
+		}
+		fmt.Println("Keys initialized and stored.")
+		offerRecovery(o.reader, km, masterPassword)
+	}
+
+	err = o.startSession(s, profile, masterPassword)
+	if err != nil {
+		s.dropEntries()
+		s.dropKeys()
+		return err
+	}
+	// hooks run last to first, so the background sync stops before the
+	// entries and keys it reads are wiped
+	ctx, stop := context.WithCancel(context.Background())
+	s.locked = make(chan struct{})
+	locked := s.locked
+	s.sess.Unlock(s.dropKeys, s.dropEntries, func() {
+		stop()
+		close(locked)
+	})
+	s.syncer = o.follow(ctx, s)
+
+	cfg := s.sess.Config()
+	fmt.Printf("Vault %s synced. Entries: %d, LastSync: %s\n", s.name, s.vault.Len(), s.vault.LastSync().Format("2006-01-02 15:04:05"))
+	fmt.Printf("Locks after %s idle and at %s at the latest.\n", cfg.IdleTimeout, time.Now().Add(cfg.MaxLifetime).Format("15:04"))
+	return nil
+}
+
+// startSession hands the unlocked keys to the signer, the vault manager
+// and the chain session, then syncs the vault.
+func (o *opener) startSession(s *vaultSession, profile *config.Profile, masterPassword string) error {
+	km := s.km
+	address, err := km.GetAddress()
+	if err != nil {
+		return err
+	}
+	if err := o.reg.SetAddress(s.name, address); err != nil {
+		fmt.Printf("save vault address error: %v\n", err)
+	}
+	vaultKey, err := km.VaultKey()
+	if err != nil {
+		return fmt.Errorf("vault key: %w", err)
+	}
+
+	s.vm = vaultmanager.NewVaultManager(s.svc, vaultKey)
+	if o.prices != nil {
+		s.vm.SetPriceSource(o.prices, o.currency)
+	}
+	if o.signer != "" {
+		if s.signer, err = o.txSigner(address, nil); err != nil {
+			return fmt.Errorf("signer: %w", err)
+		}
+	} else {
+		err = km.WithPrivateKey(func(key *ecdsa.PrivateKey) error {
+			if s.signer, err = o.txSigner(address, key); err != nil {
+				return fmt.Errorf("signer: %w", err)
+			}
+			return s.vm.SetIdentity(key)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if !s.svc.IsConnected() {
+		if err := s.svc.Connect(); err != nil {
+			return fmt.Errorf("blockchain connect: %w", err)
+		}
+		fmt.Printf("Connected to %s (chain %d).\n", s.profile, profile.ChainID)
+	}
+	if _, err := s.svc.StartSession(s.signer, vaultKey); err != nil {
+		return fmt.Errorf("start session: %w", err)
+	}
+
+	// entries sealed under older random salts can only have their keys
+	// derived while the password is at hand, so do it before dropping it
+	added, err := s.vm.DeriveOlderKeys(context.Background(), address, masterPassword)
+	if err != nil {
+		return fmt.Errorf("derive vault keys: %w", err)
+	}
+	// the recovery and emergency kits must open the same envelopes the
+	// session can; both need the wallet key, so -signer leaves them be
+	if added > 0 && o.signer == "" {
+		if km.HasRecovery() {
+			if err := km.RefreshRecovery(); err != nil {
+				fmt.Printf("recovery kit update error: %v\n", err)
+			}
+		}
+		refreshEmergencyContacts(s.vm, km)
+	}
+	if err := s.sync(o.reg); err != nil {
+		return fmt.Errorf("sync vault: %w", err)
+	}
+	return nil
+}
+
+// autoLockReason describes why cfg's session locked by itself.
+func autoLockReason(cfg session.Config, reason error) string {
+	if errors.Is(reason, session.ErrExpired) {
+		return fmt.Sprintf("session lifetime of %s reached", cfg.MaxLifetime)
+	}
+	return fmt.Sprintf("idle for %s", cfg.IdleTimeout)
+}
+
+// vaultAddress returns the account the vault key of km's vault is bound
+// to without unlocking keys.json: the address stored in it in the clear,
+// or for a vault without keys the -signer account.
+func (o *opener) vaultAddress(km *keymanager.KeyManager) (string, error) {
+	if km.HasStoredKeys() {
+		return km.StoredAddress()
+	}
+	if !common.IsHexAddress(o.signerAccount) {
+		return "", errors.New("a vault without keys needs -signer-account with -signer")
+	}
+	return common.HexToAddress(o.signerAccount).Hex(), nil
+}
+
+// txSigner returns what signs the vault's transactions: key, the vault's
+// wallet key at address, or with -signer an account held in a geth
+// keystore or by clef, so the key used on chain stays out of this
+// process; key is nil then.
+func (o *opener) txSigner(address string, key *ecdsa.PrivateKey) (blockchain.Signer, error) {
+	account := o.signerAccount
+	if account == "" {
+		account = address
+	}
+
+	kind, target, _ := strings.Cut(o.signer, ":")
+	switch kind {
+	case "":
+		signer, err := blockchain.NewKeySigner(key)
+		if err != nil {
+			return nil, err
+		}
+		return signer, nil
+	case "keystore":
+		return blockchain.NewKeystoreSigner(target, account, prompt(o.reader, "Keystore password", false))
+	case "clef":
+		fmt.Println("Approve requests in clef when asked.")
+		return blockchain.NewExternalSigner(target, account)
+	default:
+		return nil, fmt.Errorf("unknown signer %q, want keystore:<dir> or clef:<endpoint>", o.signer)
+	}
+}
+
+// sync reloads the vault from the chain and records the outcome in the
+// registry for the vaults listing.
+func (s *vaultSession) sync(reg *keymanager.Registry) error {
+	err := s.svc.SyncVault(s.vault)
+	if recErr := reg.RecordSync(s.name, s.vault.Len(), err); recErr != nil {
+		fmt.Printf("save sync status error: %v\n", recErr)
+	}
+	return err
+}
+
+// collection returns the shared collection with the given ID, opening it
+// on first use.
+func (s *vaultSession) collection(ctx context.Context, id string) (*vault.SharedCollection, error) {
+	if c, ok := s.collections[id]; ok {
+		return c, nil
+	}
+	n, ok := new(big.Int).SetString(id, 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid collection id %q", id)
+	}
+	c, err := s.vm.OpenCollection(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	s.collections[id] = c
+	return c, nil
+}
+
+// dropKeys is the lock hook that wipes every key of the session.
+func (s *vaultSession) dropKeys() {
+	s.svc.EndSession()
+	if ks, ok := s.signer.(*blockchain.KeySigner); ok {
+		ks.Destroy()
+	}
+	s.signer = nil
+	if s.vm != nil {
+		s.vm.ClearIdentity()
+	}
+	s.km.ClearSession()
+}
+
+// dropEntries is the lock hook that wipes the decrypted entries of the
+// vault and of every opened collection.
+func (s *vaultSession) dropEntries() {
+	s.vault.Wipe()
+	for id, c := range s.collections {
+		c.Wipe()
+		delete(s.collections, id)
+	}
+}
+
+// close locks the vault and disconnects.
+func (s *vaultSession) close() {
+	s.sess.Lock()
+	s.svc.Disconnect()
+}
+
+// handleCollection runs the "collection" subcommands on the shared
+// collections of the open vault.
+func handleCollection(ctx context.Context, reader *bufio.Reader, s *vaultSession, args []string) {
+	switch args[0] {
+	case "publish-key":
+		if err := s.vm.PublishPublicKey(ctx); err != nil {
+			fmt.Printf("publish key error: %v\n", err)
+			return
+		}
+		fmt.Println("Public key published; collection owners can now add this address.")
+		return
+
+	case "create":
+		c, err := s.vm.CreateCollection(ctx)
+		if err != nil {
+			fmt.Printf("create collection error: %v\n", err)
+			return
+		}
+		s.collections[c.ID.String()] = c
+		fmt.Printf("Collection %s created.\n", c.ID)
+		return
+	}
+
+	if len(args) < 2 {
+		fmt.Printf("usage: collection %s <id>\n", args[0])
+		return
+	}
+	c, err := s.collection(ctx, args[1])
+	if err != nil {
+		fmt.Printf("open collection error: %v\n", err)
+		return
+	}
+
+	switch args[0] {
+	case "open":
+		if err := s.vm.SyncCollection(ctx, c); err != nil {
+			fmt.Printf("sync collection error: %v\n", err)
+			return
+		}
+		fmt.Printf("Collection %s | owner %s | %d members | key epoch %s\n", c.ID, c.Owner, len(c.Members), c.KeyEpoch)
+		for id, e := range c.Entries {
+			fmt.Printf("- ID: %s | Title: %s | Username: %s\n", id, e.Title, e.Username)
+		}
+
+	case "add":
+		entry := vault.NewPasswordEntry(prompt(reader, "Title", false), prompt(reader, "Username", false), prompt(reader, "Password", false))
+		entry.URL = prompt(reader, "URL (optional)", true)
+		if err := s.vm.AddCollectionEntries(ctx, c, []*vault.PasswordEntry{entry}); err != nil {
+			fmt.Printf("add entry error: %v\n", err)
+			return
+		}
+		fmt.Println("Entry added to collection.")
+
+	case "add-member", "remove-member":
+		if len(args) < 3 || !common.IsHexAddress(args[2]) {
+			fmt.Printf("usage: collection %s <id> <address>\n", args[0])
+			return
+		}
+		if args[0] == "add-member" {
+			err = s.vm.AddCollectionMember(ctx, c, args[2])
+		} else {
+			// removal re-seals every entry, one more transaction batch
+			err = s.vm.RemoveCollectionMember(ctx, c, args[2])
+		}
+		if err != nil {
+			fmt.Printf("%s error: %v\n", args[0], err)
+			return
+		}
+		fmt.Printf("Members: %s\n", strings.Join(c.Members, ", "))
+
+	default:
+		fmt.Println("Unknown collection command.")
+	}
+}
+
+// initKeys stores the wallet key of a vault without one: a raw key or
+// keystore file the user brings, or the next BIP-44 account of a new or
+// existing mnemonic.
+func (o *opener) initKeys(name string, km *keymanager.KeyManager, masterPassword string) error {
+	fmt.Println("This vault has no wallet key yet:")
+	fmt.Println("  1) enter a private key")
+	fmt.Println("  2) generate a new mnemonic phrase")
+	fmt.Println("  3) restore from a mnemonic phrase")
+	fmt.Println("  4) import a keystore file (geth, MetaMask)")
+	choice := prompt(o.reader, "Choice [1]", true)
+
+	switch choice {
+	case "", "1":
+		privHex := prompt(o.reader, "Enter private key (64 hex chars)", false)
+		if len(privHex) != 64 {
+			return fmt.Errorf("invalid private key length")
+		}
+		if _, err := hex.DecodeString(privHex); err != nil {
+			return fmt.Errorf("invalid private key hex: %w", err)
+		}
+		return km.InitializeFirstTime(privHex, masterPassword)
+
+	case "4":
+		keyJSON, err := os.ReadFile(prompt(o.reader, "Keystore file", false))
+		if err != nil {
+			return err
+		}
+		return km.InitializeFromKeystore(keyJSON, prompt(o.reader, "Keystore password", false), masterPassword)
+
+	case "2", "3":
+	default:
+		return fmt.Errorf("unknown choice %q", choice)
+	}
+
+	var mnemonic string
+	if choice == "2" {
+		var err error
+		if mnemonic, err = hdwallet.NewMnemonic(hdwallet.DefaultWordCount); err != nil {
+			return err
+		}
+		words := strings.Fields(mnemonic)
+		fmt.Println("Write these words down in order and keep them offline. Anyone who has them controls the wallet:")
+		for i, w := range words {
+			fmt.Printf("%2d. %s\n", i+1, w)
+		}
+		check := len(words)/2 + 1
+		if prompt(o.reader, fmt.Sprintf("Type word #%d to confirm", check), false) != words[check-1] {
+			return errors.New("confirmation word does not match")
+		}
+	} else {
+		mnemonic = prompt(o.reader, "Mnemonic phrase", false)
+		if err := hdwallet.ValidateMnemonic(mnemonic); err != nil {
+			return err
+		}
+	}
+	passphrase := prompt(o.reader, "BIP-39 passphrase (optional)", true)
+
+	account := o.reg.NextAccount()
+	if v := prompt(o.reader, fmt.Sprintf("Account index (default %d)", account), true); v != "" {
+		n, err := strconv.ParseUint(v, 10, 31)
+		if err != nil {
+			return fmt.Errorf("invalid account index %q", v)
+		}
+		account = uint32(n)
+	}
+
+	if err := km.InitializeFromMnemonic(mnemonic, passphrase, account, masterPassword); err != nil {
+		return err
+	}
+	path := hdwallet.AccountPath(account)
+	if err := o.reg.SetDerivationPath(name, path); err != nil {
+		fmt.Printf("save derivation path error: %v\n", err)
+	}
+	fmt.Printf("Wallet key derived along %s.\n", path)
+	return nil
+}
+
+// exportKeystore writes the vault's wallet key as a V3 keystore file,
+// readable only by the owner, for use in geth or MetaMask.
+func exportKeystore(reader *bufio.Reader, s *vaultSession, args []string) error {
+	masterPassword := prompt(reader, "Enter master password", false)
+	password := prompt(reader, "New keystore password", false)
+	if prompt(reader, "Repeat keystore password", false) != password {
+		return errors.New("passwords do not match")
+	}
+
+	kdf := keymanager.KDFScrypt
+	if hasFlag(args, "--pbkdf2") {
+		kdf = keymanager.KDFPBKDF2
+	}
+	keyJSON, err := s.km.ExportKeystore(masterPassword, password, kdf)
+	if err != nil {
+		return err
+	}
+
+	path := flagValue(args, "--out")
+	if path == "" {
+		address, err := s.km.GetAddress()
+		if err != nil {
+			return err
+		}
+		path = keymanager.KeystoreFileName(address, time.Now())
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(keyJSON); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Printf("Keystore written to %s\n", path)
+	return nil
+}
+
+// Recovery kits default to 3 of 5 shares.
+const (
+	defaultRecoveryShares    = 5
+	defaultRecoveryThreshold = 3
+)
+
+// recoveryShares parses the optional "<shares> <threshold>" of the
+// recovery command; shamir.Split checks their range.
+func recoveryShares(args []string) (int, int, error) {
+	switch len(args) {
+	case 0:
+		return defaultRecoveryShares, defaultRecoveryThreshold, nil
+	case 2:
+	default:
+		return 0, 0, fmt.Errorf("expected both shares and threshold")
+	}
+	n, err := strconv.Atoi(args[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid shares %q", args[0])
+	}
+	k, err := strconv.Atoi(args[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid threshold %q", args[1])
+	}
+	return n, k, nil
+}
+
+// offerRecovery asks whether to create a recovery kit right after the
+// vault keys are first stored.
+func offerRecovery(reader *bufio.Reader, km *keymanager.KeyManager, masterPassword string) {
+	fmt.Println("Without the master password this vault cannot be opened. A recovery kit splits a")
+	fmt.Println("recovery key into shares for people or places you trust; enough of them reset the password.")
+	if !strings.EqualFold(prompt(reader, "Create a recovery kit now? [y/N]", true), "y") {
+		fmt.Println("Skipped. Run \"recovery\" later to create one.")
+		return
+	}
+
+	n, k := defaultRecoveryShares, defaultRecoveryThreshold
+	if v, err := strconv.Atoi(prompt(reader, fmt.Sprintf("Number of shares (default %d)", n), true)); err == nil {
+		n = v
+	}
+	if v, err := strconv.Atoi(prompt(reader, fmt.Sprintf("Shares needed to recover (default %d)", k), true)); err == nil {
+		k = v
+	}
+	if err := printRecoveryShares(km, masterPassword, n, k); err != nil {
+		fmt.Printf("recovery error: %v\n", err)
+	}
+}
+
+func printRecoveryShares(km *keymanager.KeyManager, masterPassword string, n, k int) error {
+	shares, err := km.SetupRecovery(masterPassword, n, k)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Recovery kit: any %d of these %d shares reset the master password.\n", k, n)
+	fmt.Println("Store each one separately; earlier shares of this vault no longer work.")
+	for i, share := range shares {
+		fmt.Printf("%d. %s\n", i+1, share)
+	}
+	return nil
+}
+
+// recover resets the master password of vault name from recovery shares.
+// The vault's chain data is re-sealed under the new password before
+// keys.json changes, so if a transaction fails the old password still
+// opens everything and recover can be run again.
+func (o *opener) recover(name, profileName string) error {
+	profileName, profile, err := o.profile(name, profileName)
+	if err != nil {
+		return err
+	}
+	km, err := o.reg.KeyManager(name, 0)
+	if err != nil {
+		return err
+	}
+	if !km.HasRecovery() {
+		return keymanager.ErrNoRecovery
+	}
+
+	fmt.Println("Enter recovery shares, one per line, then an empty line.")
+	var shares []string
+	for {
+		line, err := readLine(o.reader)
+		if err != nil {
+			return fmt.Errorf("read share: %w", err)
+		}
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+		shares = append(shares, line)
+	}
+	recovery, err := km.Recover(shares)
+	if err != nil {
+		return err
+	}
+	defer recovery.Destroy()
+
+	newPassword := prompt(o.reader, "New master password", false)
+	if err := km.CheckPassword(newPassword); err != nil {
+		return err
+	}
+	if prompt(o.reader, "Repeat new master password", false) != newPassword {
+		return errors.New("passwords do not match")
+	}
+
+	defer km.ClearSession()
+	newKey, err := codec.NewVaultKey(newPassword, recovery.Address, codec.FromVaultConfig(vault.DefaultVaultConfig()))
+	if err != nil {
+		return err
+	}
+	defer newKey.Destroy()
+
+	var signer blockchain.Signer
+	err = recovery.WithPrivateKey(func(key *ecdsa.PrivateKey) error {
+		signer, err = o.txSigner(recovery.Address, key)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("signer: %w", err)
+	}
+	if ks, ok := signer.(*blockchain.KeySigner); ok {
+		defer ks.Destroy()
+	}
+
+	svc := blockchain.NewBlockchainService(profile.BlockchainConfig())
+	if err := svc.Connect(); err != nil {
+		return fmt.Errorf("blockchain connect: %w", err)
+	}
+	defer svc.Disconnect()
+	fmt.Printf("Connected to %s (chain %d). Re-encrypting vault data...\n", profileName, profile.ChainID)
+	if _, err := svc.StartSession(signer, newKey); err != nil {
+		return fmt.Errorf("start session: %w", err)
+	}
+
+	// the kit holds the vault keys, older envelopes' included, so the
+	// forgotten password is not needed to open the data
+	vm := vaultmanager.NewVaultManager(svc, recovery.VaultKey)
+	if err := vm.ChangeMasterPassword(context.Background(), recovery.Address, newKey); err != nil {
+		return fmt.Errorf("re-encrypt vault (run recover again to resume): %w", err)
+	}
+	if err := km.ResetMasterPassword(recovery, newPassword); err != nil {
+		return err
+	}
+
+	// kits left for emergency contacts still hold the old keys
+	if err := recovery.WithPrivateKey(vm.SetIdentity); err != nil {
+		return err
+	}
+	refreshEmergencyContacts(vm, km)
+	return nil
+}
+
+// refreshEmergencyContacts re-wraps the emergency contacts' kits with the
+// session's vault keys. Contracts without emergency access are skipped
+// silently.
+func refreshEmergencyContacts(vm *vaultmanager.VaultManager, km *keymanager.KeyManager) {
+	n, err := vm.RefreshEmergencyContacts(context.Background(), km)
+	if n > 0 {
+		fmt.Printf("Updated the vault key left for %d emergency contact(s).\n", n)
+	}
+	if err != nil && !errors.Is(err, blockchain.ErrUnsupportedOperation) {
+		fmt.Printf("emergency contact update error: %v\n", err)
+	}
+}
+
+// defaultEmergencyWait is how long a contact waits for the owner to
+// react to a request unless "emergency add" sets --wait.
+const defaultEmergencyWait = 72 * time.Hour
+
+// handleEmergency runs the "emergency" subcommands: the owner side
+// (contacts, add, remove, approve, reject) and the contact side (request,
+// open).
+func handleEmergency(ctx context.Context, s *vaultSession, args []string) {
+	if args[0] == "contacts" {
+		contacts, err := s.vm.EmergencyContacts(ctx)
+		if err != nil {
+			fmt.Printf("emergency contacts error: %v\n", err)
+			return
+		}
+		if len(contacts) == 0 {
+			fmt.Println("No emergency contacts.")
+		}
+		for _, c := range contacts {
+			status := "no request"
+			switch {
+			case c.Approved:
+				status = "access approved"
+			case c.Requested():
+				status = "requested, opens " + c.AvailableAt().Format("2006-01-02 15:04")
+			}
+			fmt.Printf("- %s | wait %s | %s\n", c.Contact, c.WaitPeriod, status)
+		}
+		return
+	}
+
+	if len(args) < 2 || !common.IsHexAddress(args[1]) {
+		fmt.Printf("usage: emergency %s <address>\n", args[0])
+		return
+	}
+	address := args[1]
+
+	var err error
+	switch args[0] {
+	case "add":
+		wait := defaultEmergencyWait
+		if v := flagValue(args[2:], "--wait"); v != "" {
+			if wait, err = time.ParseDuration(v); err != nil || wait < 0 {
+				fmt.Printf("invalid --wait %q\n", v)
+				return
+			}
+		}
+		if err = s.vm.AddEmergencyContact(ctx, s.km, address, wait); err == nil {
+			fmt.Printf("%s can request access; it opens after %s unless you reject it, even if you are not around.\n", address, wait)
+			fmt.Println("The delay is enforced by the contract and clients, not by encryption: only add contacts you fully trust.")
+		}
+
+	case "remove":
+		if err = s.vm.RemoveEmergencyContact(ctx, address); err == nil {
+			fmt.Println("Emergency contact removed. They may already have read their copy of the vault key,")
+			fmt.Println("which opens the vault until the master password changes.")
+		}
+
+	case "approve":
+		if err = s.vm.ApproveEmergencyAccess(ctx, address); err == nil {
+			fmt.Println("Access approved.")
+		}
+
+	case "reject":
+		if err = s.vm.RejectEmergencyAccess(ctx, address); err == nil {
+			fmt.Println("Request rejected.")
+		}
+
+	case "request":
+		var access *blockchain.EmergencyAccess
+		if access, err = s.vm.RequestEmergencyAccess(ctx, address); err == nil {
+			fmt.Printf("Access requested; available %s unless the owner rejects it.\n", access.AvailableAt().Format("2006-01-02 15:04"))
+		}
+
+	case "open":
+		var v *vault.LocalVault
+		if v, err = s.vm.OpenEmergencyVault(ctx, s.km, address); err == nil {
+			fmt.Printf("Vault of %s (read-only):\n", address)
+			for id, e := range v.Entries {
+				fmt.Printf("- ID: %s | Title: %s | Username: %s | Password: %s | URL: %s\n", id, e.Title, e.Username, e.Password, e.URL)
+			}
+		}
+
+	default:
+		fmt.Println("Unknown emergency command.")
+		return
+	}
+	if err != nil {
+		fmt.Printf("emergency %s error: %v\n", args[0], err)
+	}
+}
+
+// vaultOptions parses the flags of "vault create".
+func vaultOptions(cfg *config.Config, args []string) (keymanager.VaultOptions, error) {
+	opts := keymanager.VaultOptions{
+		Profile:        flagValue(args, "--profile"),
+		PasswordPolicy: keymanager.DefaultPasswordPolicy(),
+	}
+	if opts.Profile != "" {
+		if _, err := cfg.Profile(opts.Profile); err != nil {
+			return opts, err
+		}
+	}
+	if v := flagValue(args, "--min-length"); v != "" {
+		n, err := strconv.Atoi(v)
+		// the codec itself refuses master passwords under 8 characters
+		if err != nil || n < keymanager.DefaultPasswordPolicy().MinLength {
+			return opts, fmt.Errorf("invalid --min-length %q (min %d)", v, keymanager.DefaultPasswordPolicy().MinLength)
+		}
+		opts.PasswordPolicy.MinLength = n
+	}
+	opts.PasswordPolicy.RequireUpper = hasFlag(args, "--require-upper")
+	opts.PasswordPolicy.RequireLower = hasFlag(args, "--require-lower")
+	opts.PasswordPolicy.RequireDigit = hasFlag(args, "--require-digit")
+	opts.PasswordPolicy.RequireSymbol = hasFlag(args, "--require-symbol")
+	return opts, nil
+}
+
+func syncStatus(info keymanager.VaultInfo) string {
+	switch {
+	case info.LastSync.IsZero():
+		return "never synced"
+	case info.LastSyncError != "":
+		return fmt.Sprintf("sync failed %s: %s", info.LastSync.Format("2006-01-02 15:04:05"), info.LastSyncError)
+	default:
+		return fmt.Sprintf("synced %s, %d entries", info.LastSync.Format("2006-01-02 15:04:05"), info.Entries)
+	}
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "no key yet"
+	}
+	return s
+}
+
+func orDefault(s string) string {
+	if s == "" {
+		return "(default)"
+	}
+	return s
+}
+
+// costPolicy holds the fee thresholds above which a write needs explicit
+// confirmation. Zero disables a threshold.
+type costPolicy struct {
+	maxNative float64
+	maxFiat   float64
+}
+
+func (p costPolicy) exceeded(e *blockchain.CostEstimate) bool {
+	if p.maxNative > 0 && e.FeeNative() > p.maxNative {
+		return true
+	}
+	return p.maxFiat > 0 && e.FiatCurrency != "" && e.FiatValue > p.maxFiat
+}
+
+// printOTP shows the current code of an entry's OTP secret.
+func printOTP(secret string) {
+	key, err := otp.Parse(secret)
+	if err != nil {
+		fmt.Printf("OTP: %v\n", err)
+		return
+	}
+	code, left := key.Code(time.Now())
+	fmt.Printf("OTP: %s (%ds left)\n", code, int(left/time.Second))
+}
+
+// confirmCost prints the estimate of a pending write and reports whether to
+// send it. A failed estimate means the write would fail too.
+func confirmCost(r *bufio.Reader, policy costPolicy, estimate *blockchain.CostEstimate, err error) bool {
+	if err != nil {
+		fmt.Printf("cost estimate error: %v\n", err)
+		return false
+	}
+
+	fmt.Printf("Estimated cost: %s\n", estimate)
+	if !policy.exceeded(estimate) {
+		return true
+	}
+	answer := prompt(r, "Cost is above the confirmation threshold. Send? [y/N]", true)
+	return strings.EqualFold(answer, "y")
+}
+
+// saveContract points profile at the contract a vault was migrated to, so
+// the next run talks to it as well.
+func saveContract(dir string, cfg *config.Config, profile, address string) error {
+	p, err := cfg.Profile(profile)
+	if err != nil {
+		return err
+	}
+	previous := p.ContractAddress
+	p.ContractAddress = address
+	if err := config.Save(dir, cfg); err != nil {
+		p.ContractAddress = previous
+		return err
+	}
+	return nil
+}
+
+// profileVaults returns the vaults other than except that connect to
+// profile when no -profile flag is given.
+func profileVaults(reg *keymanager.Registry, cfg *config.Config, profile, except string) []string {
+	var names []string
+	for _, info := range reg.List() {
+		p := info.Profile
+		if p == "" {
+			p = cfg.DefaultProfile
+		}
+		if p == profile && info.Name != except {
+			names = append(names, info.Name)
+		}
+	}
+	return names
+}
+
+// loadConfig reads config.json from dir, writing the built-in profiles
+// there on first run so they can be edited.
+func loadConfig(dir string) (*config.Config, error) {
+	if _, err := os.Stat(config.Path(dir)); errors.Is(err, os.ErrNotExist) {
+		cfg := config.Default()
+		if err := config.Save(dir, cfg); err != nil {
+			return nil, err
+		}
+		fmt.Printf("Wrote default network profiles to %s\n", config.Path(dir))
+		return cfg, nil
+	}
+	return config.Load(dir)
+}
+
 func readLine(r *bufio.Reader) (string, error) {
 	text, err := r.ReadString('\n')
 	if err != nil {
@@ -193,6 +1403,56 @@ func readLine(r *bufio.Reader) (string, error) {
 	return strings.TrimSpace(text), nil
 }
 
+// readImportFile reads a JSON array of {title, username, password, url}
+// objects and turns each into a new entry.
+func readImportFile(path string) ([]*vault.PasswordEntry, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []struct {
+		Title    string `json:"title"`
+		Username string `json:"username"`
+		Password string `json:"password"`
+		URL      string `json:"url"`
+	}
+	if err := json.Unmarshal(raw, &items); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	entries := make([]*vault.PasswordEntry, 0, len(items))
+	for _, item := range items {
+		entry := vault.NewPasswordEntry(item.Title, item.Username, item.Password)
+		entry.URL = item.URL
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// flagValue returns the value following name in args, accepting both
+// "--name value" and "--name=value".
+func flagValue(args []string, name string) string {
+	for i, arg := range args {
+		if arg == name && i+1 < len(args) {
+			return args[i+1]
+		}
+		if v, ok := strings.CutPrefix(arg, name+"="); ok {
+			return v
+		}
+	}
+	return ""
+}
+
+func hasFlag(args []string, name string) bool {
+	for _, arg := range args {
+		if arg == name {
+			return true
+		}
+	}
+	return false
+}
+
 func prompt(r *bufio.Reader, label string, allowEmpty bool) string {
 	for {
 		fmt.Printf("%s: ", label)
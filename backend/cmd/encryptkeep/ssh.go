@@ -0,0 +1,111 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"encryptkeep-backend/internal/agent"
+	"encryptkeep-backend/internal/askpass"
+	"encryptkeep-backend/internal/vault"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// handleSSH runs "ssh list" and "ssh add". Added keys are entries like any
+// other, encrypted and synced with the vault; the agent serves them.
+func handleSSH(ctx context.Context, reader *bufio.Reader, s *vaultSession, policy costPolicy, args []string) {
+	switch args[0] {
+	case "list":
+		n := 0
+		for _, e := range s.vault.List() {
+			if e.SSHKey == "" {
+				continue
+			}
+			n++
+			signer, err := ssh.ParsePrivateKey([]byte(e.SSHKey))
+			if err != nil {
+				fmt.Printf("- ID: %s | Title: %s | invalid key: %v\n", e.ID, e.Title, err)
+				continue
+			}
+			fmt.Printf("- ID: %s | Title: %s | %s %s%s\n", e.ID, e.Title,
+				signer.PublicKey().Type(), ssh.FingerprintSHA256(signer.PublicKey()), sshConstraints(e))
+		}
+		if n == 0 {
+			fmt.Println("No SSH keys.")
+		}
+
+	case "add":
+		path := flagValue(args[1:], "--key")
+		if len(args) < 2 || path == "" || args[1] == "--key" {
+			fmt.Println("usage: ssh add <title> --key <file> [--confirm] [--lifetime 1h]")
+			return
+		}
+		var lifetime time.Duration
+		if v := flagValue(args[2:], "--lifetime"); v != "" {
+			var err error
+			if lifetime, err = time.ParseDuration(v); err != nil || lifetime < time.Second {
+				fmt.Printf("invalid --lifetime %q\n", v)
+				return
+			}
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			fmt.Printf("ssh add error: %v\n", err)
+			return
+		}
+		title := args[1]
+		key, err := agent.ImportSSHKey(data, nil, title)
+		var missing *ssh.PassphraseMissingError
+		if errors.As(err, &missing) {
+			passphrase := prompt(reader, "Key passphrase", false)
+			key, err = agent.ImportSSHKey(data, []byte(passphrase), title)
+		}
+		if err != nil {
+			fmt.Printf("ssh add error: %v\n", err)
+			return
+		}
+
+		entry := vault.NewPasswordEntry(title, "", "")
+		entry.SSHKey = key
+		entry.SSHConfirm = hasFlag(args[2:], "--confirm")
+		entry.SSHLifetime = int(lifetime / time.Second)
+
+		estimate, err := s.vm.PreviewAddEntry(ctx, entry)
+		if !confirmCost(reader, policy, estimate, err) {
+			return
+		}
+		if err := s.vm.AddEntry(ctx, s.vault, entry); err != nil {
+			fmt.Printf("ssh add error: %v\n", err)
+			return
+		}
+		fmt.Printf("SSH key added as entry %s. You may now delete %s.\n", entry.ID, path)
+
+	default:
+		fmt.Println("Unknown ssh command.")
+	}
+}
+
+func sshConstraints(e *vault.PasswordEntry) string {
+	var s string
+	if e.SSHConfirm {
+		s += " | confirm"
+	}
+	if e.SSHLifetime > 0 {
+		s += " | lifetime " + (time.Duration(e.SSHLifetime) * time.Second).String()
+	}
+	return s
+}
+
+// askpassConfirm asks through the askpass program, as ssh-agent -c does,
+// whether the key titled title may sign.
+func askpassConfirm(title string) bool {
+	if askpass.Program() == "" {
+		fmt.Fprintf(os.Stderr, "refused to sign with %q: neither ENCRYPTKEEP_ASKPASS nor SSH_ASKPASS is set\n", title)
+		return false
+	}
+	return askpass.Confirm(fmt.Sprintf("Allow use of SSH key %q from the EncryptKeep vault?", title))
+}
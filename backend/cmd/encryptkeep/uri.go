@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"encryptkeep-backend/internal/urlmatch"
+	"encryptkeep-backend/internal/vault"
+)
+
+const uriUsage = "usage: uri list <id> | add <id> <address> [--match rule] | remove <id> <address> | match <id> <rule>\n" +
+	"       rules: domain (default), host, starts_with, regex, never; addresses may be androidapp://<app id>"
+
+// handleURI shows and edits the addresses of an entry and how pages are
+// matched against them.
+func handleURI(ctx context.Context, reader *bufio.Reader, s *vaultSession, policy costPolicy, args []string) {
+	if len(args) < 2 {
+		fmt.Println(uriUsage)
+		return
+	}
+	// Get returns a copy, so a declined or failed write leaves the vault as it was
+	entry, ok := s.vault.Get(args[1])
+	if !ok {
+		fmt.Println("entry not found")
+		return
+	}
+
+	switch args[0] {
+	case "list":
+		if entry.URL != "" {
+			fmt.Printf("- %s | %s\n", entry.URL, orDefault(entry.URLMatch))
+		}
+		for _, u := range entry.URIs {
+			fmt.Printf("- %s | %s\n", u.URI, orDefault(u.Match))
+		}
+		if entry.URL == "" && len(entry.URIs) == 0 {
+			fmt.Println("No addresses.")
+		}
+		return
+
+	case "add":
+		if len(args) < 3 {
+			fmt.Println(uriUsage)
+			return
+		}
+		u := vault.URI{URI: args[2], Match: flagValue(args[3:], "--match")}
+		if err := urlmatch.Validate(u.URI, u.Match); err != nil {
+			fmt.Printf("uri error: %v\n", err)
+			return
+		}
+		entry.URIs = append(entry.URIs, u)
+
+	case "remove":
+		if len(args) < 3 {
+			fmt.Println(uriUsage)
+			return
+		}
+		n := len(entry.URIs)
+		entry.URIs = slices.DeleteFunc(entry.URIs, func(u vault.URI) bool { return u.URI == args[2] })
+		if len(entry.URIs) == n {
+			fmt.Println("The entry has no such address. The URL itself is changed with update.")
+			return
+		}
+
+	case "match":
+		if len(args) < 3 {
+			fmt.Println(uriUsage)
+			return
+		}
+		if err := urlmatch.Validate(entry.URL, args[2]); err != nil {
+			fmt.Printf("uri error: %v\n", err)
+			return
+		}
+		entry.URLMatch = args[2]
+		if entry.URLMatch == urlmatch.Domain.String() {
+			entry.URLMatch = ""
+		}
+
+	default:
+		fmt.Println(uriUsage)
+		return
+	}
+
+	entry.UpdatedAt = time.Now()
+	estimate, err := s.vm.PreviewUpdateEntry(ctx, s.vault, entry)
+	if !confirmCost(reader, policy, estimate, err) {
+		return
+	}
+	if err := s.vm.UpdateEntry(ctx, s.vault, entry); err != nil {
+		fmt.Printf("uri error: %v\n", err)
+		return
+	}
+	fmt.Println("Entry updated and synced.")
+}
+
+// findEntries lists the entries for a page URL or androidapp:// app ID,
+// best match first, as autofill would offer them.
+func findEntries(s *vaultSession, args []string) {
+	if len(args) == 0 {
+		fmt.Println("usage: find <url or androidapp://app-id> [--match rule]")
+		return
+	}
+	rule, err := urlmatch.ParseRule(flagValue(args[1:], "--match"))
+	if err != nil {
+		fmt.Printf("find error: %v\n", err)
+		return
+	}
+	entries := urlmatch.Filter(s.vault.List(), args[0], rule)
+	if len(entries) == 0 {
+		fmt.Println("No matching entries.")
+		return
+	}
+	for _, e := range entries {
+		fmt.Printf("- ID: %s | Title: %s | Username: %s | URL: %s\n", e.ID, e.Title, e.Username, e.URL)
+	}
+}
@@ -0,0 +1,91 @@
+package agent
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"sync"
+)
+
+// Client calls a running agent. It is safe for concurrent use; calls are
+// sent one at a time.
+type Client struct {
+	mu      sync.Mutex
+	conn    net.Conn
+	scanner *bufio.Scanner
+	next    int
+}
+
+// Dial connects to the agent listening at path. It refuses a socket whose
+// listener runs as another user, who could otherwise collect the secrets
+// sent to it.
+func Dial(path string) (*Client, error) {
+	conn, err := net.DialUnix("unix", nil, &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	if uid, err := peerUID(conn); err != nil || uid != os.Getuid() {
+		conn.Close()
+		return nil, ErrForeignAgent
+	}
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessage)
+	return &Client{conn: conn, scanner: scanner}, nil
+}
+
+// Call runs method with params, nil for none, and decodes the result into
+// result unless it is nil. Errors from the agent are *Error.
+func (c *Client) Call(method string, params, result any) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.next++
+	id := json.RawMessage(strconv.Itoa(c.next))
+	req := request{JSONRPC: "2.0", ID: id, Method: method}
+	if params != nil {
+		raw, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		req.Params = raw
+	}
+	if err := json.NewEncoder(c.conn).Encode(req); err != nil {
+		return err
+	}
+
+	for c.scanner.Scan() {
+		var resp struct {
+			ID     json.RawMessage `json:"id"`
+			Result json.RawMessage `json:"result"`
+			Error  *Error          `json:"error"`
+		}
+		if err := json.Unmarshal(c.scanner.Bytes(), &resp); err != nil {
+			return fmt.Errorf("agent: bad response: %w", err)
+		}
+		// notifications and refusals of the connection carry no ID
+		if string(resp.ID) != string(id) {
+			if resp.Error != nil && string(resp.ID) == "null" {
+				return resp.Error
+			}
+			continue
+		}
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if result == nil {
+			return nil
+		}
+		return json.Unmarshal(resp.Result, result)
+	}
+	if err := c.scanner.Err(); err != nil {
+		return err
+	}
+	return fmt.Errorf("agent: connection closed")
+}
+
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,14 @@
+//go:build !(linux || darwin || freebsd)
+
+package agent
+
+import (
+	"errors"
+	"net"
+)
+
+// peerUID cannot tell who connected where the socket has no peer
+// credentials (e.g. Windows); the agent refuses every connection there.
+func peerUID(conn *net.UnixConn) (int, error) {
+	return -1, errors.New("peer credentials are not supported on this platform")
+}
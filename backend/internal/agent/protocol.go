@@ -0,0 +1,106 @@
+package agent
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// The agent speaks JSON-RPC 2.0, one message per line. Besides answering
+// calls it sends "changed" notifications, carrying a syncer.Event, to
+// connections that called "subscribe".
+
+const (
+	CodeParse          = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternal       = -32603
+
+	CodeLocked       = -32001 // the vault locked; the agent is about to exit
+	CodeNotFound     = -32002 // no entry with that ID
+	CodeRejected     = -32003 // the write's fee is above the agent's limit
+	CodeUnauthorized = -32004 // the peer runs as another user
+)
+
+// Error is a JSON-RPC error object; Client.Call returns it as is.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("agent: %s (code %d)", e.Message, e.Code)
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+type notification struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+}
+
+// Status is the result of "status".
+type Status struct {
+	Vault    string    `json:"vault"`
+	Entries  int       `json:"entries"`
+	LastSync time.Time `json:"last_sync"`
+	LocksAt  time.Time `json:"locks_at"`
+	Sync     bool      `json:"sync"` // whether "subscribe" is available
+}
+
+// Summary is an entry as "list" and "search" return it, without secrets.
+type Summary struct {
+	ID         string    `json:"id"`
+	Title      string    `json:"title"`
+	Username   string    `json:"username"`
+	URL        string    `json:"url,omitempty"`
+	IsFavorite bool      `json:"is_favorite"`
+	HasOTP     bool      `json:"has_otp"`
+	HasSSHKey  bool      `json:"has_ssh_key"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// IDParams are the params of "get", "delete" and "otp".
+type IDParams struct {
+	ID string `json:"id"`
+}
+
+// SearchParams are the params of "search". URL, a page URL or an
+// androidapp:// app ID, keeps only the entries matching it; Match is the
+// rule for addresses stored without one, domain by default.
+type SearchParams struct {
+	Query string `json:"query"`
+	URL   string `json:"url,omitempty"`
+	Match string `json:"match,omitempty"`
+}
+
+// OTPResult is the result of "otp".
+type OTPResult struct {
+	Code      string `json:"code"`
+	ExpiresIn int    `json:"expires_in"` // seconds
+}
+
+// ResolveParams are the params of "resolve": a secret reference,
+// ek://vault/entry/field, into the agent's vault.
+type ResolveParams struct {
+	Ref string `json:"ref"`
+}
+
+// ResolveResult is the result of "resolve".
+type ResolveResult struct {
+	Value string `json:"value"`
+}
@@ -0,0 +1,513 @@
+// Package agent serves an unlocked vault to other local programs over a
+// Unix socket, so the GUI, scripts and editor plugins share one running
+// agent instead of each deriving keys and syncing from the chain. Only
+// processes of the user running the agent may connect. SSHAgent serves
+// the SSH keys kept in the vault's entries over the ssh-agent protocol.
+package agent
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"encryptkeep-backend/internal/blockchain"
+	"encryptkeep-backend/internal/otp"
+	"encryptkeep-backend/internal/secretref"
+	"encryptkeep-backend/internal/session"
+	"encryptkeep-backend/internal/syncer"
+	"encryptkeep-backend/internal/urlmatch"
+	"encryptkeep-backend/internal/vault"
+	"encryptkeep-backend/internal/vaultmanager"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// maxMessage bounds one request line.
+const maxMessage = 1 << 20
+
+var (
+	ErrAgentRunning = errors.New("an agent is already listening on the socket")
+	ErrForeignAgent = errors.New("the socket belongs to another user")
+	errNotFound     = errors.New("entry not found")
+)
+
+// DefaultSocketPath is $ENCRYPTKEEP_AGENT_SOCK, else agent.sock in a
+// per-user runtime directory.
+func DefaultSocketPath() string {
+	if v := os.Getenv("ENCRYPTKEEP_AGENT_SOCK"); v != "" {
+		return v
+	}
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "encryptkeep", "agent.sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("encryptkeep-%d", os.Getuid()), "agent.sock")
+}
+
+// Listen creates the socket at path in a directory only the current user
+// can enter: the directory must be owned by the current user with mode
+// 0700, not a symlink, or Listen fails. A socket left behind by an agent
+// that died is replaced; a live agent is not, and Listen fails with
+// ErrAgentRunning.
+func Listen(path string) (*net.UnixListener, error) {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create socket directory: %w", err)
+	}
+	if err := checkSocketDir(dir); err != nil {
+		return nil, fmt.Errorf("unsafe socket directory: %w", err)
+	}
+	if conn, err := net.Dial("unix", path); err == nil {
+		conn.Close()
+		return nil, ErrAgentRunning
+	}
+	os.Remove(path)
+
+	ln, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0o600); err != nil {
+		ln.Close()
+		return nil, err
+	}
+	return ln, nil
+}
+
+// Server answers calls on the entries of one vault. Each call runs inside
+// the vault's session and counts as activity.
+type Server struct {
+	name  string
+	vm    *vaultmanager.VaultManager
+	vault *vault.LocalVault
+	sess  *session.Session
+	sync  *syncer.Syncer // nil when background sync is off
+
+	// Approve vets the fee of every write; nil sends them all. Nobody is
+	// at a prompt to confirm, so a refusal fails the call with
+	// CodeRejected.
+	Approve func(*blockchain.CostEstimate) error
+
+	uid   int
+	mu    sync.Mutex // guards conns
+	conns map[*conn]struct{}
+}
+
+func NewServer(name string, vm *vaultmanager.VaultManager, v *vault.LocalVault, sess *session.Session, sy *syncer.Syncer) *Server {
+	return &Server{
+		name:  name,
+		vm:    vm,
+		vault: v,
+		sess:  sess,
+		sync:  sy,
+		uid:   os.Getuid(),
+		conns: make(map[*conn]struct{}),
+	}
+}
+
+// Serve accepts connections on ln until ctx is done, then closes ln and
+// every open connection.
+func (s *Server) Serve(ctx context.Context, ln *net.UnixListener) error {
+	stop := context.AfterFunc(ctx, func() {
+		ln.Close()
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for c := range s.conns {
+			c.Close()
+		}
+	})
+	defer stop()
+
+	for {
+		uc, err := ln.AcceptUnix()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go s.serveConn(ctx, uc)
+	}
+}
+
+// conn is one client; responses and notifications share its writer.
+type conn struct {
+	*net.UnixConn
+	mu          sync.Mutex
+	enc         *json.Encoder
+	unsubscribe func()
+}
+
+func (c *conn) write(msg any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.enc.Encode(msg)
+}
+
+func (s *Server) serveConn(ctx context.Context, uc *net.UnixConn) {
+	c := &conn{UnixConn: uc, enc: json.NewEncoder(uc)}
+	defer c.Close()
+
+	if uid, err := peerUID(uc); err != nil || uid != s.uid {
+		c.write(response{JSONRPC: "2.0", ID: json.RawMessage("null"),
+			Error: &Error{Code: CodeUnauthorized, Message: "connection from another user refused"}})
+		return
+	}
+
+	s.mu.Lock()
+	if ctx.Err() != nil {
+		s.mu.Unlock()
+		return
+	}
+	s.conns[c] = struct{}{}
+	s.mu.Unlock()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, c)
+		s.mu.Unlock()
+		if c.unsubscribe != nil {
+			c.unsubscribe()
+		}
+	}()
+
+	scanner := bufio.NewScanner(uc)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxMessage)
+	for scanner.Scan() {
+		if resp := s.handle(ctx, c, scanner.Bytes()); resp != nil {
+			c.write(resp)
+		}
+	}
+}
+
+// handle answers one message; calls without an ID get no response.
+func (s *Server) handle(ctx context.Context, c *conn, line []byte) *response {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		return &response{JSONRPC: "2.0", ID: json.RawMessage("null"), Error: &Error{Code: CodeParse, Message: err.Error()}}
+	}
+	reply := func(result any, err error) *response {
+		if len(req.ID) == 0 {
+			return nil
+		}
+		if err != nil {
+			return &response{JSONRPC: "2.0", ID: req.ID, Error: toError(err)}
+		}
+		return &response{JSONRPC: "2.0", ID: req.ID, Result: result}
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return reply(nil, &Error{Code: CodeInvalidRequest, Message: "not a JSON-RPC 2.0 request"})
+	}
+
+	method, ok := s.methods()[req.Method]
+	if req.Method == "subscribe" {
+		method, ok = func(context.Context, json.RawMessage) (any, error) { return s.subscribe(c) }, true
+	}
+	if !ok {
+		return reply(nil, &Error{Code: CodeMethodNotFound, Message: "unknown method " + req.Method})
+	}
+
+	var result any
+	err := s.sess.Do(func() error {
+		var err error
+		result, err = method(ctx, req.Params)
+		return err
+	})
+	return reply(result, err)
+}
+
+type method func(ctx context.Context, params json.RawMessage) (any, error)
+
+func (s *Server) methods() map[string]method {
+	return map[string]method{
+		"status":  s.status,
+		"list":    s.list,
+		"search":  s.search,
+		"get":     s.get,
+		"add":     s.add,
+		"update":  s.update,
+		"delete":  s.delete,
+		"otp":     s.otp,
+		"resolve": s.resolve,
+	}
+}
+
+func toError(err error) *Error {
+	var rpcErr *Error
+	switch {
+	case errors.As(err, &rpcErr):
+		return rpcErr
+	case errors.Is(err, session.ErrLocked):
+		return &Error{Code: CodeLocked, Message: err.Error()}
+	case errors.Is(err, errNotFound), errors.Is(err, secretref.ErrNotFound):
+		return &Error{Code: CodeNotFound, Message: err.Error()}
+	case errors.Is(err, secretref.ErrInvalid), errors.Is(err, secretref.ErrAmbiguous):
+		return &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	return &Error{Code: CodeInternal, Message: err.Error()}
+}
+
+func decode(params json.RawMessage, v any) error {
+	if len(params) == 0 {
+		return &Error{Code: CodeInvalidParams, Message: "missing params"}
+	}
+	if err := json.Unmarshal(params, v); err != nil {
+		return &Error{Code: CodeInvalidParams, Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) status(ctx context.Context, params json.RawMessage) (any, error) {
+	return &Status{
+		Vault:    s.name,
+		Entries:  s.vault.Len(),
+		LastSync: s.vault.LastSync(),
+		LocksAt:  s.sess.Deadline(),
+		Sync:     s.sync != nil,
+	}, nil
+}
+
+func (s *Server) list(ctx context.Context, params json.RawMessage) (any, error) {
+	return summaries(s.vault.List(), ""), nil
+}
+
+// search matches the query against titles, usernames and addresses,
+// ignoring case. With a URL it only returns the entries for that page or
+// app, best match first.
+func (s *Server) search(ctx context.Context, params json.RawMessage) (any, error) {
+	var p SearchParams
+	if err := decode(params, &p); err != nil {
+		return nil, err
+	}
+	entries := s.vault.List()
+	if p.URL != "" {
+		rule, err := urlmatch.ParseRule(p.Match)
+		if err != nil {
+			return nil, &Error{Code: CodeInvalidParams, Message: err.Error()}
+		}
+		entries = urlmatch.Filter(entries, p.URL, rule)
+	}
+	return summaries(entries, strings.ToLower(p.Query)), nil
+}
+
+// contains reports whether e's title, username or an address contains
+// query, which is lowercase.
+func contains(e *vault.PasswordEntry, query string) bool {
+	fields := []string{e.Title, e.Username, e.URL}
+	for _, u := range e.URIs {
+		fields = append(fields, u.URI)
+	}
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), query) {
+			return true
+		}
+	}
+	return false
+}
+
+func summaries(entries []*vault.PasswordEntry, query string) []Summary {
+	out := make([]Summary, 0, len(entries))
+	for _, e := range entries {
+		if query != "" && !contains(e, query) {
+			continue
+		}
+		out = append(out, Summary{
+			ID:         e.ID,
+			Title:      e.Title,
+			Username:   e.Username,
+			URL:        e.URL,
+			IsFavorite: e.IsFavorite,
+			HasOTP:     e.OTP != "",
+			HasSSHKey:  e.SSHKey != "",
+			UpdatedAt:  e.UpdatedAt,
+		})
+	}
+	return out
+}
+
+func (s *Server) entry(params json.RawMessage) (*vault.PasswordEntry, error) {
+	var p IDParams
+	if err := decode(params, &p); err != nil {
+		return nil, err
+	}
+	entry, ok := s.vault.Get(p.ID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errNotFound, p.ID)
+	}
+	return entry, nil
+}
+
+func (s *Server) get(ctx context.Context, params json.RawMessage) (any, error) {
+	return s.entry(params)
+}
+
+// add stores a new entry from the title, username, password, url,
+// is_favorite, otp, url_match, uris and ssh_* fields of params and
+// returns it with its ID.
+func (s *Server) add(ctx context.Context, params json.RawMessage) (any, error) {
+	var p vault.PasswordEntry
+	if err := decode(params, &p); err != nil {
+		return nil, err
+	}
+	if err := validate(&p); err != nil {
+		return nil, err
+	}
+
+	entry := vault.NewPasswordEntry(p.Title, p.Username, p.Password)
+	entry.URL, entry.IsFavorite, entry.OTP = p.URL, p.IsFavorite, p.OTP
+	entry.SSHKey, entry.SSHConfirm, entry.SSHLifetime = p.SSHKey, p.SSHConfirm, p.SSHLifetime
+	entry.URLMatch, entry.URIs = p.URLMatch, p.URIs
+	estimate, err := s.vm.PreviewAddEntries(ctx, []*vault.PasswordEntry{entry})
+	if err := s.approve(estimate, err); err != nil {
+		return nil, err
+	}
+	if err := s.vm.AddEntries(ctx, s.vault, []*vault.PasswordEntry{entry}); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// update replaces the fields of entry params.id with those of params, as
+// "get" returned them.
+func (s *Server) update(ctx context.Context, params json.RawMessage) (any, error) {
+	var p vault.PasswordEntry
+	if err := decode(params, &p); err != nil {
+		return nil, err
+	}
+	entry, ok := s.vault.Get(p.ID)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errNotFound, p.ID)
+	}
+	if err := validate(&p); err != nil {
+		return nil, err
+	}
+
+	entry.Title, entry.Username, entry.Password = p.Title, p.Username, p.Password
+	entry.URL, entry.IsFavorite, entry.OTP = p.URL, p.IsFavorite, p.OTP
+	entry.SSHKey, entry.SSHConfirm, entry.SSHLifetime = p.SSHKey, p.SSHConfirm, p.SSHLifetime
+	entry.URLMatch, entry.URIs = p.URLMatch, p.URIs
+	entry.UpdatedAt = time.Now()
+	estimate, err := s.vm.PreviewUpdateEntries(ctx, s.vault, []*vault.PasswordEntry{entry})
+	if err := s.approve(estimate, err); err != nil {
+		return nil, err
+	}
+	if err := s.vm.UpdateEntries(ctx, s.vault, []*vault.PasswordEntry{entry}); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *Server) delete(ctx context.Context, params json.RawMessage) (any, error) {
+	entry, err := s.entry(params)
+	if err != nil {
+		return nil, err
+	}
+	estimate, err := s.vm.PreviewDeleteEntries(ctx, s.vault, []string{entry.ID})
+	if err := s.approve(estimate, err); err != nil {
+		return nil, err
+	}
+	if err := s.vm.DeleteEntries(ctx, s.vault, []string{entry.ID}); err != nil {
+		return nil, err
+	}
+	return true, nil
+}
+
+func (s *Server) otp(ctx context.Context, params json.RawMessage) (any, error) {
+	entry, err := s.entry(params)
+	if err != nil {
+		return nil, err
+	}
+	if entry.OTP == "" {
+		return nil, &Error{Code: CodeInvalidParams, Message: "entry has no OTP secret"}
+	}
+	key, err := otp.Parse(entry.OTP)
+	if err != nil {
+		return nil, err
+	}
+	code, left := key.Code(time.Now())
+	return &OTPResult{Code: code, ExpiresIn: int(left / time.Second)}, nil
+}
+
+// resolve returns the value a secret reference into this vault names.
+func (s *Server) resolve(ctx context.Context, params json.RawMessage) (any, error) {
+	var p ResolveParams
+	if err := decode(params, &p); err != nil {
+		return nil, err
+	}
+	ref, err := secretref.Parse(p.Ref)
+	if err != nil {
+		return nil, err
+	}
+	value, err := secretref.Resolve(s.name, s.vault, ref)
+	if err != nil {
+		return nil, err
+	}
+	return &ResolveResult{Value: value}, nil
+}
+
+// subscribe starts "changed" notifications on c.
+func (s *Server) subscribe(c *conn) (any, error) {
+	if s.sync == nil {
+		return nil, &Error{Code: CodeInvalidRequest, Message: "background sync is off"}
+	}
+	if c.unsubscribe != nil {
+		return true, nil
+	}
+	events, unsubscribe := s.sync.Subscribe(16)
+	c.unsubscribe = unsubscribe
+	go func() {
+		for ev := range events {
+			c.write(notification{JSONRPC: "2.0", Method: "changed", Params: ev})
+		}
+	}()
+	return true, nil
+}
+
+func validate(e *vault.PasswordEntry) error {
+	if e.Title == "" {
+		return &Error{Code: CodeInvalidParams, Message: "title is required"}
+	}
+	if e.OTP != "" {
+		if _, err := otp.Parse(e.OTP); err != nil {
+			return &Error{Code: CodeInvalidParams, Message: err.Error()}
+		}
+	}
+	if err := urlmatch.Validate(e.URL, e.URLMatch); err != nil {
+		return &Error{Code: CodeInvalidParams, Message: "url: " + err.Error()}
+	}
+	for _, u := range e.URIs {
+		err := urlmatch.Validate(u.URI, u.Match)
+		if err == nil && strings.TrimSpace(u.URI) == "" {
+			err = errors.New("empty address")
+		}
+		if err != nil {
+			return &Error{Code: CodeInvalidParams, Message: "uri " + u.URI + ": " + err.Error()}
+		}
+	}
+	if e.SSHKey != "" {
+		if _, err := ssh.ParsePrivateKey([]byte(e.SSHKey)); err != nil {
+			return &Error{Code: CodeInvalidParams, Message: "ssh key: " + err.Error()}
+		}
+	}
+	return nil
+}
+
+// approve passes a write whose fee could be estimated and that Approve
+// lets through. A failed estimate means the write would fail too.
+func (s *Server) approve(estimate *blockchain.CostEstimate, err error) error {
+	if err != nil {
+		return err
+	}
+	if s.Approve != nil {
+		if err := s.Approve(estimate); err != nil {
+			return &Error{Code: CodeRejected, Message: err.Error()}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,11 @@
+//go:build !unix
+
+package agent
+
+import "errors"
+
+// checkSocketDir cannot tell who owns the directory where there are no
+// Unix file owners; the agent refuses to listen there.
+func checkSocketDir(dir string) error {
+	return errors.New("socket directory ownership cannot be checked on this platform")
+}
@@ -0,0 +1,31 @@
+//go:build unix
+
+package agent
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// checkSocketDir refuses dir unless it is a real directory owned by the
+// current user that nobody else can enter. MkdirAll keeps a directory that
+// already exists as it is, and under a shared temporary directory another
+// user can create it first.
+func checkSocketDir(dir string) error {
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	st, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || int(st.Uid) != os.Getuid() {
+		return fmt.Errorf("%s is not owned by the current user", dir)
+	}
+	if info.Mode().Perm() != 0o700 {
+		return fmt.Errorf("%s has mode %o, want 700", dir, info.Mode().Perm())
+	}
+	return nil
+}
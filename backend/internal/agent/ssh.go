@@ -0,0 +1,226 @@
+package agent
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"encryptkeep-backend/internal/session"
+	"encryptkeep-backend/internal/vault"
+
+	"golang.org/x/crypto/ssh"
+	sshagent "golang.org/x/crypto/ssh/agent"
+)
+
+var (
+	errReadOnly    = errors.New("keys are managed in the vault, not with ssh-add")
+	errKeyNotFound = errors.New("no such key in the vault")
+	errNotApproved = errors.New("signature not approved")
+)
+
+// DefaultSSHSocketPath is ssh-agent.sock next to the agent's own socket.
+func DefaultSSHSocketPath() string {
+	return filepath.Join(filepath.Dir(DefaultSocketPath()), "ssh-agent.sock")
+}
+
+// ImportSSHKey turns a private key file into what PasswordEntry.SSHKey
+// holds: an unencrypted OpenSSH key. An encrypted key is opened with
+// passphrase once here; from then on the vault's encryption protects it.
+func ImportSSHKey(data, passphrase []byte, comment string) (string, error) {
+	var raw any
+	var err error
+	if len(passphrase) > 0 {
+		raw, err = ssh.ParseRawPrivateKeyWithPassphrase(data, passphrase)
+	} else {
+		raw, err = ssh.ParseRawPrivateKey(data)
+	}
+	if err != nil {
+		return "", err
+	}
+	block, err := ssh.MarshalPrivateKey(raw, comment)
+	if err != nil {
+		return "", err
+	}
+	return string(pem.EncodeToMemory(block)), nil
+}
+
+// SSHAgent serves the SSH keys stored in a vault's entries to ssh, git and
+// other ssh-agent clients. The key list follows the vault: entries added
+// or synced from another device show up without a restart. Keys cannot
+// be added or removed through the protocol.
+type SSHAgent struct {
+	vault *vault.LocalVault
+	sess  *session.Session
+
+	// Confirm asks the user whether the key of entry title may sign.
+	// With Confirm nil, keys stored with SSHConfirm never sign.
+	Confirm func(title string) bool
+
+	uid     int
+	mu      sync.Mutex           // guards offered
+	offered map[string]time.Time // entry ID -> when the key was first listed or used
+}
+
+func NewSSHAgent(v *vault.LocalVault, sess *session.Session) *SSHAgent {
+	return &SSHAgent{
+		vault:   v,
+		sess:    sess,
+		uid:     os.Getuid(),
+		offered: make(map[string]time.Time),
+	}
+}
+
+// Serve answers ssh-agent requests on ln until ctx is done. Like the
+// agent socket, it only talks to processes of the same user.
+func (a *SSHAgent) Serve(ctx context.Context, ln *net.UnixListener) error {
+	var mu sync.Mutex
+	conns := make(map[*net.UnixConn]struct{})
+	stop := context.AfterFunc(ctx, func() {
+		ln.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for c := range conns {
+			c.Close()
+		}
+	})
+	defer stop()
+
+	for {
+		uc, err := ln.AcceptUnix()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		go func() {
+			defer uc.Close()
+			if uid, err := peerUID(uc); err != nil || uid != a.uid {
+				return
+			}
+			mu.Lock()
+			conns[uc] = struct{}{}
+			mu.Unlock()
+			sshagent.ServeAgent(a, uc)
+			mu.Lock()
+			delete(conns, uc)
+			mu.Unlock()
+		}()
+	}
+}
+
+// sshKey is a vault entry's key, parsed for one request.
+type sshKey struct {
+	entry  *vault.PasswordEntry
+	signer ssh.Signer
+}
+
+// keys parses the keys of the vault's entries, leaving out those whose
+// lifetime is over and those that fail to parse.
+func (a *SSHAgent) keys() []sshKey {
+	now := time.Now()
+	var keys []sshKey
+	for _, e := range a.vault.List() {
+		if e.SSHKey == "" {
+			continue
+		}
+		signer, err := ssh.ParsePrivateKey([]byte(e.SSHKey))
+		if err != nil {
+			continue
+		}
+
+		a.mu.Lock()
+		first, seen := a.offered[e.ID]
+		if !seen {
+			first = now
+			a.offered[e.ID] = now
+		}
+		a.mu.Unlock()
+		if e.SSHLifetime > 0 && now.Sub(first) >= time.Duration(e.SSHLifetime)*time.Second {
+			continue
+		}
+		keys = append(keys, sshKey{entry: e, signer: signer})
+	}
+	return keys
+}
+
+func (a *SSHAgent) List() ([]*sshagent.Key, error) {
+	var list []*sshagent.Key
+	err := a.sess.Do(func() error {
+		for _, k := range a.keys() {
+			pub := k.signer.PublicKey()
+			list = append(list, &sshagent.Key{Format: pub.Type(), Blob: pub.Marshal(), Comment: k.entry.Title})
+		}
+		return nil
+	})
+	return list, err
+}
+
+func (a *SSHAgent) Sign(key ssh.PublicKey, data []byte) (*ssh.Signature, error) {
+	return a.SignWithFlags(key, data, 0)
+}
+
+// SignWithFlags signs with the vault key matching key, asking first when
+// the entry wants confirmation. The flags pick the RSA hash as ssh asks.
+func (a *SSHAgent) SignWithFlags(key ssh.PublicKey, data []byte, flags sshagent.SignatureFlags) (*ssh.Signature, error) {
+	var sig *ssh.Signature
+	err := a.sess.Do(func() error {
+		want := key.Marshal()
+		for _, k := range a.keys() {
+			if !bytes.Equal(k.signer.PublicKey().Marshal(), want) {
+				continue
+			}
+			if k.entry.SSHConfirm && (a.Confirm == nil || !a.Confirm(k.entry.Title)) {
+				return fmt.Errorf("%w: %s", errNotApproved, k.entry.Title)
+			}
+
+			var err error
+			algo := ""
+			switch {
+			case flags&sshagent.SignatureFlagRsaSha256 != 0:
+				algo = ssh.KeyAlgoRSASHA256
+			case flags&sshagent.SignatureFlagRsaSha512 != 0:
+				algo = ssh.KeyAlgoRSASHA512
+			}
+			if as, ok := k.signer.(ssh.AlgorithmSigner); ok && algo != "" {
+				sig, err = as.SignWithAlgorithm(rand.Reader, data, algo)
+			} else {
+				sig, err = k.signer.Sign(rand.Reader, data)
+			}
+			return err
+		}
+		return errKeyNotFound
+	})
+	return sig, err
+}
+
+// Signers returns the keys for in-process use; confirmation does not
+// apply, so callers must not hand them to anything the user did not ask
+// for.
+func (a *SSHAgent) Signers() ([]ssh.Signer, error) {
+	var signers []ssh.Signer
+	err := a.sess.Do(func() error {
+		for _, k := range a.keys() {
+			signers = append(signers, k.signer)
+		}
+		return nil
+	})
+	return signers, err
+}
+
+func (a *SSHAgent) Add(key sshagent.AddedKey) error { return errReadOnly }
+func (a *SSHAgent) Remove(key ssh.PublicKey) error  { return errReadOnly }
+func (a *SSHAgent) RemoveAll() error                { return errReadOnly }
+func (a *SSHAgent) Lock(passphrase []byte) error    { return errReadOnly }
+func (a *SSHAgent) Unlock(passphrase []byte) error  { return errReadOnly }
+func (a *SSHAgent) Extension(string, []byte) ([]byte, error) {
+	return nil, sshagent.ErrExtensionUnsupported
+}
@@ -2,23 +2,36 @@ package appservice
 
 import (
 	"encryptkeep-backend/internal/blockchain"
+	"encryptkeep-backend/internal/config"
+	"encryptkeep-backend/internal/keymanager"
 )
 
 type AppService struct {
 	blockchainService blockchain.BlockchainService
 	// vault *vault.LocalVault
+
+	// Profile names the network profile in the config file, empty for its default.
+	Profile string
+	// ConfigDir holds config.json, keymanager.DefaultConfigDir() when empty.
+	ConfigDir string
 }
 
 func (as *AppService) Initialize() error {
-	config := &blockchain.BlockchainConfig{
-		RPCEndpoint:     "https://sepolia.infura.io/v3/",
-		ContractAddress: "0x123",
-		ChainID:         11155111,
-		GasLimit:        10000000,
-		GasPrice:        nil,
+	dir := as.ConfigDir
+	if dir == "" {
+		dir = keymanager.DefaultConfigDir()
+	}
+
+	cfg, err := config.Load(dir)
+	if err != nil {
+		return err
+	}
+	profile, err := cfg.Profile(as.Profile)
+	if err != nil {
+		return err
 	}
 
-	as.blockchainService = blockchain.NewBlockchainService(config)
+	as.blockchainService = blockchain.NewBlockchainService(profile.BlockchainConfig())
 
 	return as.blockchainService.Connect()
 }
@@ -0,0 +1,31 @@
+// Package askpass asks the user yes-or-no questions through a graphical
+// askpass program, for parts of EncryptKeep that run without a terminal.
+package askpass
+
+import (
+	"os"
+	"os/exec"
+)
+
+// Program returns the askpass program: $ENCRYPTKEEP_ASKPASS, else
+// $SSH_ASKPASS, which desktops commonly set. Empty means none.
+func Program() string {
+	if p := os.Getenv("ENCRYPTKEEP_ASKPASS"); p != "" {
+		return p
+	}
+	return os.Getenv("SSH_ASKPASS")
+}
+
+// Confirm shows prompt and reports whether the user agreed. It asks the
+// way ssh-agent -c does, with SSH_ASKPASS_PROMPT=confirm, so the program
+// shows yes and no buttons and exits 0 for yes. Without a program there
+// is nobody to ask and the answer is no.
+func Confirm(prompt string) bool {
+	program := Program()
+	if program == "" {
+		return false
+	}
+	cmd := exec.Command(program, prompt)
+	cmd.Env = append(os.Environ(), "SSH_ASKPASS_PROMPT=confirm")
+	return cmd.Run() == nil
+}
@@ -0,0 +1,220 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	maxBatchEntries = 25
+	maxBatchBytes   = 64 * 1024 // keeps each transaction well below the 128KB tx size limit
+)
+
+// batchRange is the half-open range of request items covered by one transaction.
+type batchRange struct {
+	start, end int
+}
+
+// splitBatches groups items into ranges bounded by maxBatchEntries and
+// maxBatchBytes. sizes holds the payload size of every item.
+func splitBatches(sizes []int) []batchRange {
+	var ranges []batchRange
+
+	start, total := 0, 0
+	for i, size := range sizes {
+		if i > start && (i-start == maxBatchEntries || total+size > maxBatchBytes) {
+			ranges = append(ranges, batchRange{start, i})
+			start, total = i, 0
+		}
+		total += size
+	}
+	if start < len(sizes) {
+		ranges = append(ranges, batchRange{start, len(sizes)})
+	}
+
+	return ranges
+}
+
+// singleRanges gives every item its own transaction.
+func singleRanges(n int) []batchRange {
+	ranges := make([]batchRange, n)
+	for i := range ranges {
+		ranges[i] = batchRange{i, i + 1}
+	}
+	return ranges
+}
+
+func payloadSizes(data [][]byte) []int {
+	sizes := make([]int, len(data))
+	for i, d := range data {
+		sizes[i] = len(d)
+	}
+	return sizes
+}
+
+// idSizes gives the calldata size of n uint256 IDs.
+func idSizes(n int) []int {
+	sizes := make([]int, n)
+	for i := range sizes {
+		sizes[i] = 32
+	}
+	return sizes
+}
+
+// pipeline sends one transaction per range with consecutive nonces starting
+// at auth.Nonce, without waiting in between, then waits for every receipt.
+// The returned result has IDs and Revisions aligned with the request items
+// and written reports which items were mined successfully; on failure the
+// partial result is returned alongside the error.
+func (k *KeeperContract) pipeline(ctx context.Context, auth *bind.TransactOpts, items int, ranges []batchRange, send func(opts *bind.TransactOpts, r batchRange) (*types.Transaction, error)) (*TransactionResult, []bool, error) {
+	result := &TransactionResult{
+		IDs:       make([]*big.Int, items),
+		Revisions: make([]*big.Int, items),
+	}
+
+	nonce := new(big.Int)
+	if auth.Nonce != nil {
+		nonce.Set(auth.Nonce)
+	}
+
+	var firstErr error
+	txs := make([]*types.Transaction, 0, len(ranges))
+	for i, r := range ranges {
+		opts := *auth
+		opts.Nonce = new(big.Int).Add(nonce, big.NewInt(int64(i)))
+
+		tx, err := send(&opts, r)
+		if err != nil {
+			firstErr = ParseContractError(err)
+			break
+		}
+		txs = append(txs, tx)
+	}
+
+	written := make([]bool, items)
+	count := 0
+	for i, tx := range txs {
+		receipt, err := k.waitReceipt(ctx, tx)
+		if receipt != nil {
+			result.GasUsed += receipt.GasUsed
+			result.BlockNumber = receipt.BlockNumber.Uint64()
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		result.TxHash = tx.Hash().Hex()
+		r := ranges[i]
+		ids, revisions := k.parseEvents(receipt)
+		if len(ids) == r.end-r.start {
+			copy(result.IDs[r.start:r.end], ids)
+			copy(result.Revisions[r.start:r.end], revisions)
+		}
+		for j := r.start; j < r.end; j++ {
+			written[j] = true
+		}
+		count += r.end - r.start
+	}
+
+	result.Success = firstErr == nil
+	result.Timestamp = time.Now()
+
+	if firstErr != nil {
+		return result, written, fmt.Errorf("%w: %d of %d items written: %w", ErrTransactionFailed, count, items, firstErr)
+	}
+	return result, written, nil
+}
+
+// StoreDataBatch stores every blob in data. v2 deployments receive
+// storeDataBatch calls of bounded size; v1 deployments one storeData
+// transaction per blob. Either way the transactions are pipelined.
+func (k *KeeperContract) StoreDataBatch(ctx context.Context, auth *bind.TransactOpts, data [][]byte) (*TransactionResult, error) {
+	if len(data) == 0 {
+		return nil, ErrInvalidDataLength
+	}
+
+	if k.contract != nil {
+		result, _, err := k.pipeline(ctx, auth, len(data), splitBatches(payloadSizes(data)), func(opts *bind.TransactOpts, r batchRange) (*types.Transaction, error) {
+			return k.contract.StoreDataBatch(opts, data[r.start:r.end])
+		})
+		return result, err
+	}
+
+	next, err := k.binding.NextDataId(&bind.CallOpts{Context: ctx}, auth.From)
+	if err != nil {
+		return nil, ParseContractError(err)
+	}
+
+	result, written, err := k.pipeline(ctx, auth, len(data), singleRanges(len(data)), func(opts *bind.TransactOpts, r batchRange) (*types.Transaction, error) {
+		return k.binding.StoreData(opts, data[r.start])
+	})
+	// v1 emits no events. IDs come from nextDataId at execution time, in
+	// nonce order, and reverted transactions do not consume one.
+	id := new(big.Int).Set(next)
+	for i, ok := range written {
+		if ok {
+			result.IDs[i] = new(big.Int).Set(id)
+			id.Add(id, big.NewInt(1))
+		}
+	}
+	return result, err
+}
+
+func (k *KeeperContract) ChangeDataBatch(ctx context.Context, auth *bind.TransactOpts, dataIDs []*big.Int, data [][]byte) (*TransactionResult, error) {
+	if len(dataIDs) != len(data) {
+		return nil, ErrBatchLengthMismatch
+	}
+	if len(data) == 0 {
+		return nil, ErrInvalidDataLength
+	}
+
+	if k.contract != nil {
+		result, _, err := k.pipeline(ctx, auth, len(data), splitBatches(payloadSizes(data)), func(opts *bind.TransactOpts, r batchRange) (*types.Transaction, error) {
+			return k.contract.ChangeDataBatch(opts, dataIDs[r.start:r.end], data[r.start:r.end])
+		})
+		return result, err
+	}
+
+	result, written, err := k.pipeline(ctx, auth, len(data), singleRanges(len(data)), func(opts *bind.TransactOpts, r batchRange) (*types.Transaction, error) {
+		return k.binding.ChangeData(opts, dataIDs[r.start], data[r.start])
+	})
+	markLegacyIDs(result, written, dataIDs)
+	return result, err
+}
+
+func (k *KeeperContract) RemoveDataBatch(ctx context.Context, auth *bind.TransactOpts, dataIDs []*big.Int) (*TransactionResult, error) {
+	if len(dataIDs) == 0 {
+		return nil, ErrInvalidDataLength
+	}
+
+	if k.contract != nil {
+		result, _, err := k.pipeline(ctx, auth, len(dataIDs), splitBatches(idSizes(len(dataIDs))), func(opts *bind.TransactOpts, r batchRange) (*types.Transaction, error) {
+			return k.contract.RemoveDataBatch(opts, dataIDs[r.start:r.end])
+		})
+		return result, err
+	}
+
+	result, written, err := k.pipeline(ctx, auth, len(dataIDs), singleRanges(len(dataIDs)), func(opts *bind.TransactOpts, r batchRange) (*types.Transaction, error) {
+		return k.binding.RemoveData(opts, dataIDs[r.start])
+	})
+	markLegacyIDs(result, written, dataIDs)
+	return result, err
+}
+
+// markLegacyIDs sets the IDs of the written items of a v1 change/remove
+// pipeline, which has no events to report them.
+func markLegacyIDs(result *TransactionResult, written []bool, dataIDs []*big.Int) {
+	for i, ok := range written {
+		if ok {
+			result.IDs[i] = dataIDs[i]
+		}
+	}
+}
@@ -2,25 +2,33 @@ package blockchain
 
 import (
 	"context"
-	"crypto/ecdsa"
 	"fmt"
 	"math/big"
+	"sync"
 	"time"
 
+	"encryptkeep-backend/internal/codec"
+
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-
-	// "github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// healthCheckTimeout bounds the endpoint probe done when connecting.
+const healthCheckTimeout = 5 * time.Second
+
 type Client struct {
 	config   *BlockchainConfig
-	client   *ethclient.Client
+	client   *RPCPool
 	contract *KeeperContract
 	chainID  *big.Int
-	session  *Session
+
+	mu      sync.RWMutex // guards contract and session
+	session *Session
+
+	sendMu  sync.Mutex
+	sending map[common.Address]*sync.Mutex // per account, see createAuth
 }
 
 // type GasConfig struct {
@@ -37,15 +45,33 @@ type Client struct {
 // 	ChainID int64
 // }
 
+// NewClient connects to the endpoints of config, preferring the ones that
+// pass an initial health check, and binds the Keeper contract.
 func NewClient(config *BlockchainConfig) (*Client, error) {
-	client, err := ethclient.Dial(config.RPCEndpoint)
+	policy := DefaultRetryPolicy()
+	if config.Retry != nil {
+		policy = *config.Retry
+	}
+
+	client, err := NewRPCPool(config.Endpoints(), policy)
 	if err != nil {
-		return nil, ErrConnectionFailed
+		return nil, err
 	}
+	if config.ChainID != 0 {
+		client.ExpectChainID(config.ChainID)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+	client.HealthCheck(ctx)
 
-	contract, err := NewKeeperContract(client, config.ContractAddress)
+	contract, err := NewKeeperContract(context.Background(), client, config.ContractAddress)
 	if err != nil {
-		return nil, ErrContractNotFound
+		client.Close()
+		if IsTransient(err) {
+			return nil, fmt.Errorf("%w: %w", ErrConnectionFailed, err)
+		}
+		return nil, err
 	}
 
 	return &Client{
@@ -57,189 +83,231 @@ func NewClient(config *BlockchainConfig) (*Client, error) {
 }
 
 func (c *Client) EstimateGas(ctx context.Context, method string, args ...interface{}) (uint64, error) {
-	if method == "storeMetadata" {
-		data := args[0].([]byte)
-		tx, err := c.contract.contract.StoreMetaData(&bind.TransactOpts{
-			Context: ctx,
-			NoSend:  true,
-		}, data)
-		if err != nil {
-			return 0, err
-		}
-
-		gas, err := c.client.EstimateGas(ctx, ethereum.CallMsg{
-			To:   &c.contract.address,
-			Data: tx.Data(),
-		})
-		if err != nil {
-			return 0, err
-		}
+	parsed, err := KeeperMetaData.GetAbi()
+	if err != nil {
+		return 0, err
+	}
 
-		return gas, nil
+	if _, ok := parsed.Methods[method]; !ok {
+		return 0, fmt.Errorf("unknown method")
 	}
 
-	if method == "storeData" {
-		data := args[0].([]byte)
-		tx, err := c.contract.contract.StoreData(&bind.TransactOpts{
-			Context: ctx,
-			NoSend:  true,
-		}, data)
-		if err != nil {
-			return 0, err
-		}
+	data, err := parsed.Pack(method, args...)
+	if err != nil {
+		return 0, err
+	}
 
-		gas, err := c.client.EstimateGas(ctx, ethereum.CallMsg{
-			To:   &c.contract.address,
-			Data: tx.Data(),
-		})
-		if err != nil {
-			return 0, err
-		}
+	msg := ethereum.CallMsg{
+		To:   &c.keeper().address,
+		Data: data,
+	}
+	if session := c.currentSession(); session != nil {
+		msg.From = common.HexToAddress(session.Address)
+	}
 
-		return gas, nil
+	gas, err := c.client.EstimateGas(ctx, msg)
+	if err != nil {
+		return 0, ParseContractError(err)
 	}
 
-	if method == "changeData" {
-		dataID := args[0].(*big.Int)
-		data := args[1].([]byte)
-		tx, err := c.contract.contract.ChangeData(&bind.TransactOpts{
-			Context: ctx,
-			NoSend:  true,
-		}, dataID, data)
-		if err != nil {
-			return 0, err
-		}
+	return gas, nil
+}
 
-		gas, err := c.client.EstimateGas(ctx, ethereum.CallMsg{
-			To:   &c.contract.address,
-			Data: tx.Data(),
-		})
-		if err != nil {
-			return 0, err
-		}
+// func (c *Client) GetOptimalGasPrice(ctx context.Context) (*big.Int, *big.Int, error) {
+// 	return c.contract
+// }
 
-		return gas, nil
-	}
+// Endpoints reports the health of every configured RPC endpoint.
+func (c *Client) Endpoints() []EndpointStatus {
+	return c.client.Status()
+}
 
-	if method == "removeData" {
-		dataID := args[0].(*big.Int)
-		tx, err := c.contract.contract.RemoveData(&bind.TransactOpts{
-			Context: ctx,
-			NoSend:  true,
-		}, dataID)
-		if err != nil {
-			return 0, err
-		}
+func (c *Client) ContractAddress() string {
+	return c.keeper().Address()
+}
 
-		gas, err := c.client.EstimateGas(ctx, ethereum.CallMsg{
-			To:   &c.contract.address,
-			Data: tx.Data(),
-		})
-		if err != nil {
-			return 0, err
-		}
+func (c *Client) ContractVersion() int {
+	return c.keeper().Version()
+}
 
-		return gas, nil
+// UseContract points the client at another Keeper deployment, e.g. after a
+// successful migration.
+func (c *Client) UseContract(ctx context.Context, contractAddress string) error {
+	contract, err := NewKeeperContract(ctx, c.client, contractAddress)
+	if err != nil {
+		return err
 	}
 
-	return 0, fmt.Errorf("unknown method")
+	c.mu.Lock()
+	c.contract = contract
+	c.config.ContractAddress = contract.Address()
+	c.mu.Unlock()
+	return nil
 }
 
-// func (c *Client) GetOptimalGasPrice(ctx context.Context) (*big.Int, *big.Int, error) {
-// 	return c.contract
-// }
-
 func (c *Client) GetUserMetadata(ctx context.Context, userAddress string) ([]byte, error) {
-	return c.contract.GetUserMetadata(ctx, userAddress)
+	return c.keeper().GetUserMetadata(ctx, userAddress)
 }
 
 func (c *Client) GetUserData(ctx context.Context, userAddress string, dataID *big.Int) ([]byte, error) {
-	return c.contract.GetUserData(ctx, userAddress, dataID)
+	return c.keeper().GetUserData(ctx, userAddress, dataID)
 }
 
 func (c *Client) GetActiveIds(ctx context.Context, userAddress string) ([]*big.Int, error) {
-	return c.contract.GetActiveIds(ctx, userAddress)
+	return c.keeper().GetActiveIds(ctx, userAddress)
+}
+
+func (c *Client) GetDataRevision(ctx context.Context, userAddress string, dataID *big.Int) (*big.Int, error) {
+	return c.keeper().GetDataRevision(ctx, userAddress, dataID)
 }
 
 func (c *Client) StoreMetadata(ctx context.Context, data []byte) (*TransactionResult, error) {
-	if c.session == nil {
+	session := c.currentSession()
+	if session == nil {
 		return nil, ErrInvalidPrivateKey
 	}
 
-	auth, err := c.createAuth(c.session.PrivateKey)
+	auth, release, err := c.createAuth(session.Signer)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
-	return c.contract.StoreMetadata(ctx, auth, data)
+	return c.keeper().StoreMetadata(ctx, auth, data)
 }
 
 func (c *Client) StoreData(ctx context.Context, data []byte) (*TransactionResult, error) {
-	if c.session == nil {
+	session := c.currentSession()
+	if session == nil {
 		return nil, ErrInvalidPrivateKey
 	}
 
-	auth, err := c.createAuth(c.session.PrivateKey)
+	auth, release, err := c.createAuth(session.Signer)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
-	return c.contract.StoreData(ctx, auth, data)
+	return c.keeper().StoreData(ctx, auth, data)
 }
 
 func (c *Client) ChangeData(ctx context.Context, dataID *big.Int, data []byte) (*TransactionResult, error) {
-	if c.session == nil {
+	session := c.currentSession()
+	if session == nil {
 		return nil, ErrInvalidPrivateKey
 	}
 
-	auth, err := c.createAuth(c.session.PrivateKey)
+	auth, release, err := c.createAuth(session.Signer)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
-	return c.contract.ChangeData(ctx, auth, dataID, data)
+	return c.keeper().ChangeData(ctx, auth, dataID, data)
 }
 
 func (c *Client) RemoveData(ctx context.Context, dataID *big.Int) (*TransactionResult, error) {
-	if c.session == nil {
+	session := c.currentSession()
+	if session == nil {
 		return nil, ErrInvalidPrivateKey
 	}
 
-	auth, err := c.createAuth(c.session.PrivateKey)
+	auth, release, err := c.createAuth(session.Signer)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
 
-	return c.contract.RemoveData(ctx, auth, dataID)
+	return c.keeper().RemoveData(ctx, auth, dataID)
 }
 
-func (c *Client) createAuth(privateKeyHex string) (*bind.TransactOpts, error) {
-	privateKey, err := crypto.HexToECDSA(privateKeyHex)
-	if err != nil {
+func (c *Client) StoreDataBatch(ctx context.Context, data [][]byte) (*TransactionResult, error) {
+	session := c.currentSession()
+	if session == nil {
 		return nil, ErrInvalidPrivateKey
 	}
 
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return nil, ErrInvalidPrivateKey
+	auth, release, err := c.createAuth(session.Signer)
+	if err != nil {
+		return nil, err
 	}
+	defer release()
+	auth.GasLimit = 0 // batches outgrow the fixed limit, let the binding estimate
 
-	fromAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
+	return c.keeper().StoreDataBatch(ctx, auth, data)
+}
 
-	nonce, err := c.client.PendingNonceAt(context.Background(), fromAddress)
+func (c *Client) ChangeDataBatch(ctx context.Context, dataIDs []*big.Int, data [][]byte) (*TransactionResult, error) {
+	session := c.currentSession()
+	if session == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	auth, release, err := c.createAuth(session.Signer)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+	auth.GasLimit = 0 // batches outgrow the fixed limit, let the binding estimate
+
+	return c.keeper().ChangeDataBatch(ctx, auth, dataIDs, data)
+}
 
-	gasPrice, err := c.client.SuggestGasPrice(context.Background())
+func (c *Client) RemoveDataBatch(ctx context.Context, dataIDs []*big.Int) (*TransactionResult, error) {
+	session := c.currentSession()
+	if session == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	auth, release, err := c.createAuth(session.Signer)
 	if err != nil {
 		return nil, err
 	}
+	defer release()
+	auth.GasLimit = 0 // batches outgrow the fixed limit, let the binding estimate
 
-	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, c.chainID)
+	return c.keeper().RemoveDataBatch(ctx, auth, dataIDs)
+}
+
+// createAuth builds transact options that sign through signer, so the
+// client never handles the key itself. It locks the signer's account
+// before reading the pending nonce and the caller calls release once its
+// transactions are sent and mined, so two writes from one account never
+// pick the same nonce.
+func (c *Client) createAuth(signer Signer) (auth *bind.TransactOpts, release func(), err error) {
+	if signer == nil {
+		return nil, nil, ErrInvalidPrivateKey
+	}
+
+	fromAddress := signer.Address()
+
+	lock := c.sendLock(fromAddress)
+	lock.Lock()
+	defer func() {
+		if err != nil {
+			lock.Unlock()
+		}
+	}()
+
+	nonce, err := c.client.PendingNonceAt(context.Background(), fromAddress)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	gasPrice, err := c.gasPrice(context.Background())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	auth = &bind.TransactOpts{
+		From: fromAddress,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != fromAddress {
+				return nil, bind.ErrNotAuthorized
+			}
+			return signer.SignTx(tx, c.chainID)
+		},
+		Context: context.Background(),
 	}
 
 	auth.Nonce = big.NewInt(int64(nonce))
@@ -247,7 +315,40 @@ func (c *Client) createAuth(privateKeyHex string) (*bind.TransactOpts, error) {
 	auth.GasLimit = c.config.GasLimit
 	auth.GasPrice = gasPrice
 
-	return auth, nil
+	return auth, lock.Unlock, nil
+}
+
+// sendLock returns the lock serializing transactions from account.
+func (c *Client) sendLock(account common.Address) *sync.Mutex {
+	c.sendMu.Lock()
+	defer c.sendMu.Unlock()
+	if c.sending == nil {
+		c.sending = make(map[common.Address]*sync.Mutex)
+	}
+	lock, ok := c.sending[account]
+	if !ok {
+		lock = new(sync.Mutex)
+		c.sending[account] = lock
+	}
+	return lock
+}
+
+// gasPrice returns the configured gas price, or the node's suggestion when
+// none is set, refusing prices above config.MaxGasPrice.
+func (c *Client) gasPrice(ctx context.Context) (*big.Int, error) {
+	price := c.config.GasPrice
+	if price == nil {
+		suggested, err := c.client.SuggestGasPrice(ctx)
+		if err != nil {
+			return nil, err
+		}
+		price = suggested
+	}
+
+	if c.config.MaxGasPrice != nil && price.Cmp(c.config.MaxGasPrice) > 0 {
+		return nil, fmt.Errorf("%w: %s wei > %s wei", ErrGasPriceTooHigh, price, c.config.MaxGasPrice)
+	}
+	return new(big.Int).Set(price), nil
 }
 
 func (c *Client) Close() error {
@@ -264,46 +365,67 @@ func (c *Client) GetSyncStatus(ctx context.Context) (*SyncStatus, error) {
 	return &SyncStatus{
 		IsOnline:     isOnline,
 		LastSyncTime: time.Now(),
+		Endpoints:    c.client.Status(),
 	}, nil
 }
 
-func (c *Client) CreateSession(privateKeyHex string, masterPassword string) (*Session, error) {
-	privateKey, err := crypto.HexToECDSA(privateKeyHex)
-	if err != nil {
-		return nil, ErrInvalidPrivateKey
-	}
-
-	publicKey := privateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
+// CreateSession sends the session's writes from signer's account and
+// reads the vault with key. Both stay owned by the caller.
+func (c *Client) CreateSession(signer Signer, key *codec.VaultKey) (*Session, error) {
+	if signer == nil {
 		return nil, ErrInvalidPrivateKey
 	}
 
-	address := crypto.PubkeyToAddress(*publicKeyECDSA)
-
 	session := &Session{
-		Address:        address.Hex(),
-		PrivateKey:     privateKeyHex,
-		MasterPassword: masterPassword,
-		CreatedAt:      time.Now(),
-		LastUsed:       time.Now(),
+		Address:   signer.Address().Hex(),
+		Signer:    signer,
+		VaultKey:  key,
+		CreatedAt: time.Now(),
+		LastUsed:  time.Now(),
 	}
 
+	c.mu.Lock()
 	c.session = session
-	return session, nil
+	c.mu.Unlock()
+
+	copied := *session
+	return &copied, nil
 }
 
+// GetSession marks the session as used and returns a copy of it, or nil
+// when there is none.
 func (c *Client) GetSession() *Session {
-	if c.session != nil {
-		c.session.LastUsed = time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.session == nil {
+		return nil
 	}
-	return c.session
+	c.session.LastUsed = time.Now()
+	copied := *c.session
+	return &copied
 }
 
+// ClearSession drops the session. Calls already holding its signer finish
+// with it; later writes fail with ErrInvalidPrivateKey.
 func (c *Client) ClearSession() {
-	if c.session != nil {
-		c.session.PrivateKey = ""
-		c.session.MasterPassword = ""
-		c.session = nil
-	}
+	c.mu.Lock()
+	c.session = nil
+	c.mu.Unlock()
+}
+
+// currentSession returns the session without touching LastUsed. Its
+// fields are never written after CreateSession, so callers may read them
+// without the lock.
+func (c *Client) currentSession() *Session {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.session
+}
+
+// keeper returns the contract calls go to; UseContract may swap it.
+func (c *Client) keeper() *KeeperContract {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.contract
 }
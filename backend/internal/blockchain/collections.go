@@ -0,0 +1,345 @@
+package blockchain
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// CollectionInfo is the on-chain state of a shared collection. Members
+// includes the owner.
+type CollectionInfo struct {
+	ID       *big.Int `json:"id"`
+	Owner    string   `json:"owner"`
+	Members  []string `json:"members"`
+	KeyEpoch *big.Int `json:"key_epoch"` // bumped on every key rotation
+}
+
+func (k *KeeperContract) requireCollections() error {
+	if !k.SupportsCollections() {
+		return ErrUnsupportedOperation
+	}
+	return nil
+}
+
+// GetPublicKey returns the 64-byte public key account registered, or
+// ErrPublicKeyNotRegistered.
+func (k *KeeperContract) GetPublicKey(ctx context.Context, account string) ([]byte, error) {
+	if err := k.requireCollections(); err != nil {
+		return nil, err
+	}
+
+	key, err := k.contract.PublicKeys(&bind.CallOpts{Context: ctx}, common.HexToAddress(account))
+	if err != nil {
+		return nil, ParseContractError(err)
+	}
+	if len(key) == 0 {
+		return nil, ErrPublicKeyNotRegistered
+	}
+	return key, nil
+}
+
+func (k *KeeperContract) GetCollection(ctx context.Context, collectionID *big.Int) (*CollectionInfo, error) {
+	if err := k.requireCollections(); err != nil {
+		return nil, err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+
+	owner, err := k.contract.CollectionOwner(opts, collectionID)
+	if err != nil {
+		return nil, ParseContractError(err)
+	}
+	if owner == (common.Address{}) {
+		return nil, ErrUnknownCollection
+	}
+
+	members, err := k.contract.GetCollectionMembers(opts, collectionID)
+	if err != nil {
+		return nil, ParseContractError(err)
+	}
+	epoch, err := k.contract.CollectionKeyEpoch(opts, collectionID)
+	if err != nil {
+		return nil, ParseContractError(err)
+	}
+
+	info := &CollectionInfo{
+		ID:       new(big.Int).Set(collectionID),
+		Owner:    owner.Hex(),
+		Members:  make([]string, len(members)),
+		KeyEpoch: epoch,
+	}
+	for i, member := range members {
+		info.Members[i] = member.Hex()
+	}
+	return info, nil
+}
+
+// GetCollectionKey returns the collection key wrapped to member, or
+// ErrNotCollectionMember.
+func (k *KeeperContract) GetCollectionKey(ctx context.Context, collectionID *big.Int, member string) ([]byte, error) {
+	if err := k.requireCollections(); err != nil {
+		return nil, err
+	}
+
+	wrapped, err := k.contract.WrappedKeys(&bind.CallOpts{Context: ctx}, collectionID, common.HexToAddress(member))
+	if err != nil {
+		return nil, ParseContractError(err)
+	}
+	if len(wrapped) == 0 {
+		return nil, ErrNotCollectionMember
+	}
+	return wrapped, nil
+}
+
+func (k *KeeperContract) GetCollectionIds(ctx context.Context, collectionID *big.Int) ([]*big.Int, error) {
+	if err := k.requireCollections(); err != nil {
+		return nil, err
+	}
+
+	ids, err := k.contract.GetCollectionIds(&bind.CallOpts{Context: ctx}, collectionID)
+	if err != nil {
+		return nil, ParseContractError(err)
+	}
+	return ids, nil
+}
+
+func (k *KeeperContract) GetCollectionData(ctx context.Context, collectionID, dataID *big.Int) ([]byte, error) {
+	if err := k.requireCollections(); err != nil {
+		return nil, err
+	}
+
+	data, err := k.contract.CollectionData(&bind.CallOpts{Context: ctx}, collectionID, dataID)
+	if err != nil {
+		return nil, ParseContractError(err)
+	}
+	return data, nil
+}
+
+// transact sends one collection or emergency access transaction and waits
+// for it.
+func (k *KeeperContract) transact(ctx context.Context, send func() (*types.Transaction, error)) (*TransactionResult, error) {
+	if err := k.requireCollections(); err != nil {
+		return nil, err
+	}
+
+	tx, err := send()
+	if err != nil {
+		return nil, ParseContractError(err)
+	}
+	return k.waitTransaction(ctx, tx)
+}
+
+func (k *KeeperContract) RegisterPublicKey(ctx context.Context, auth *bind.TransactOpts, publicKey []byte) (*TransactionResult, error) {
+	return k.transact(ctx, func() (*types.Transaction, error) {
+		return k.contract.RegisterPublicKey(auth, publicKey)
+	})
+}
+
+// CreateCollection creates a collection owned by auth.From; the result's
+// IDs hold the new collection ID.
+func (k *KeeperContract) CreateCollection(ctx context.Context, auth *bind.TransactOpts, wrappedKey []byte) (*TransactionResult, error) {
+	return k.transact(ctx, func() (*types.Transaction, error) {
+		return k.contract.CreateCollection(auth, wrappedKey)
+	})
+}
+
+func (k *KeeperContract) AddCollectionMember(ctx context.Context, auth *bind.TransactOpts, collectionID *big.Int, member string, wrappedKey []byte) (*TransactionResult, error) {
+	return k.transact(ctx, func() (*types.Transaction, error) {
+		return k.contract.AddMember(auth, collectionID, common.HexToAddress(member), wrappedKey)
+	})
+}
+
+func (k *KeeperContract) RemoveCollectionMember(ctx context.Context, auth *bind.TransactOpts, collectionID *big.Int, member string) (*TransactionResult, error) {
+	return k.transact(ctx, func() (*types.Transaction, error) {
+		return k.contract.RemoveMember(auth, collectionID, common.HexToAddress(member))
+	})
+}
+
+func (k *KeeperContract) RotateCollectionKey(ctx context.Context, auth *bind.TransactOpts, collectionID *big.Int, members []string, wrappedKeys [][]byte) (*TransactionResult, error) {
+	if len(members) != len(wrappedKeys) {
+		return nil, ErrBatchLengthMismatch
+	}
+
+	addresses := make([]common.Address, len(members))
+	for i, member := range members {
+		addresses[i] = common.HexToAddress(member)
+	}
+	return k.transact(ctx, func() (*types.Transaction, error) {
+		return k.contract.RotateCollectionKey(auth, collectionID, addresses, wrappedKeys)
+	})
+}
+
+// StoreCollectionData stores data in a collection, pipelining bounded
+// batches the same way StoreDataBatch does.
+func (k *KeeperContract) StoreCollectionData(ctx context.Context, auth *bind.TransactOpts, collectionID *big.Int, data [][]byte) (*TransactionResult, error) {
+	if err := k.requireCollections(); err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrInvalidDataLength
+	}
+
+	result, _, err := k.pipeline(ctx, auth, len(data), splitBatches(payloadSizes(data)), func(opts *bind.TransactOpts, r batchRange) (*types.Transaction, error) {
+		return k.contract.StoreCollectionData(opts, collectionID, data[r.start:r.end])
+	})
+	return result, err
+}
+
+func (k *KeeperContract) ChangeCollectionData(ctx context.Context, auth *bind.TransactOpts, collectionID *big.Int, dataIDs []*big.Int, data [][]byte) (*TransactionResult, error) {
+	if err := k.requireCollections(); err != nil {
+		return nil, err
+	}
+	if len(dataIDs) != len(data) {
+		return nil, ErrBatchLengthMismatch
+	}
+	if len(data) == 0 {
+		return nil, ErrInvalidDataLength
+	}
+
+	result, _, err := k.pipeline(ctx, auth, len(data), splitBatches(payloadSizes(data)), func(opts *bind.TransactOpts, r batchRange) (*types.Transaction, error) {
+		return k.contract.ChangeCollectionData(opts, collectionID, dataIDs[r.start:r.end], data[r.start:r.end])
+	})
+	return result, err
+}
+
+func (k *KeeperContract) RemoveCollectionData(ctx context.Context, auth *bind.TransactOpts, collectionID *big.Int, dataIDs []*big.Int) (*TransactionResult, error) {
+	if err := k.requireCollections(); err != nil {
+		return nil, err
+	}
+	if len(dataIDs) == 0 {
+		return nil, ErrInvalidDataLength
+	}
+
+	result, _, err := k.pipeline(ctx, auth, len(dataIDs), splitBatches(idSizes(len(dataIDs))), func(opts *bind.TransactOpts, r batchRange) (*types.Transaction, error) {
+		return k.contract.RemoveCollectionData(opts, collectionID, dataIDs[r.start:r.end])
+	})
+	return result, err
+}
+
+// sessionAuth builds transact options for the session signer, as
+// createAuth. Collection calls vary too much in size for the fixed gas
+// limit, so the binding estimates each one.
+func (c *Client) sessionAuth() (*bind.TransactOpts, func(), error) {
+	session := c.currentSession()
+	if session == nil {
+		return nil, nil, ErrInvalidPrivateKey
+	}
+
+	auth, release, err := c.createAuth(session.Signer)
+	if err != nil {
+		return nil, nil, err
+	}
+	auth.GasLimit = 0
+	return auth, release, nil
+}
+
+// RegisterPublicKey publishes the session signer's public key so others can
+// share collections with this account.
+func (c *Client) RegisterPublicKey(ctx context.Context) (*TransactionResult, error) {
+	session := c.currentSession()
+	if session == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+	pub, err := session.Signer.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	auth, release, err := c.createAuth(session.Signer)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	auth.GasLimit = 0
+	// uncompressed point without the 0x04 prefix, as the contract hashes it
+	publicKey := crypto.FromECDSAPub(pub)[1:]
+
+	return c.keeper().RegisterPublicKey(ctx, auth, publicKey)
+}
+
+func (c *Client) GetPublicKey(ctx context.Context, account string) ([]byte, error) {
+	return c.keeper().GetPublicKey(ctx, account)
+}
+
+func (c *Client) GetCollection(ctx context.Context, collectionID *big.Int) (*CollectionInfo, error) {
+	return c.keeper().GetCollection(ctx, collectionID)
+}
+
+func (c *Client) GetCollectionKey(ctx context.Context, collectionID *big.Int, member string) ([]byte, error) {
+	return c.keeper().GetCollectionKey(ctx, collectionID, member)
+}
+
+func (c *Client) GetCollectionIds(ctx context.Context, collectionID *big.Int) ([]*big.Int, error) {
+	return c.keeper().GetCollectionIds(ctx, collectionID)
+}
+
+func (c *Client) GetCollectionData(ctx context.Context, collectionID, dataID *big.Int) ([]byte, error) {
+	return c.keeper().GetCollectionData(ctx, collectionID, dataID)
+}
+
+func (c *Client) CreateCollection(ctx context.Context, wrappedKey []byte) (*TransactionResult, error) {
+	auth, release, err := c.sessionAuth()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.keeper().CreateCollection(ctx, auth, wrappedKey)
+}
+
+func (c *Client) AddCollectionMember(ctx context.Context, collectionID *big.Int, member string, wrappedKey []byte) (*TransactionResult, error) {
+	auth, release, err := c.sessionAuth()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.keeper().AddCollectionMember(ctx, auth, collectionID, member, wrappedKey)
+}
+
+func (c *Client) RemoveCollectionMember(ctx context.Context, collectionID *big.Int, member string) (*TransactionResult, error) {
+	auth, release, err := c.sessionAuth()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.keeper().RemoveCollectionMember(ctx, auth, collectionID, member)
+}
+
+func (c *Client) RotateCollectionKey(ctx context.Context, collectionID *big.Int, members []string, wrappedKeys [][]byte) (*TransactionResult, error) {
+	auth, release, err := c.sessionAuth()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.keeper().RotateCollectionKey(ctx, auth, collectionID, members, wrappedKeys)
+}
+
+func (c *Client) StoreCollectionData(ctx context.Context, collectionID *big.Int, data [][]byte) (*TransactionResult, error) {
+	auth, release, err := c.sessionAuth()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.keeper().StoreCollectionData(ctx, auth, collectionID, data)
+}
+
+func (c *Client) ChangeCollectionData(ctx context.Context, collectionID *big.Int, dataIDs []*big.Int, data [][]byte) (*TransactionResult, error) {
+	auth, release, err := c.sessionAuth()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.keeper().ChangeCollectionData(ctx, auth, collectionID, dataIDs, data)
+}
+
+func (c *Client) RemoveCollectionData(ctx context.Context, collectionID *big.Int, dataIDs []*big.Int) (*TransactionResult, error) {
+	auth, release, err := c.sessionAuth()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.keeper().RemoveCollectionData(ctx, auth, collectionID, dataIDs)
+}
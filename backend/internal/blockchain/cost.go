@@ -0,0 +1,198 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+)
+
+var weiPerEther = big.NewFloat(1e18)
+
+// CostEstimate is the expected price of a write, summed over every
+// transaction it would send.
+type CostEstimate struct {
+	Transactions int      `json:"transactions"`
+	Gas          uint64   `json:"gas"`
+	GasPrice     *big.Int `json:"gas_price"` // wei per gas
+	Fee          *big.Int `json:"fee"`       // wei
+	Symbol       string   `json:"symbol"`    // native token of the chain
+	FiatValue    float64  `json:"fiat_value,omitempty"`
+	FiatCurrency string   `json:"fiat_currency,omitempty"` // empty when no price was available
+}
+
+// FeeNative returns the fee in whole native tokens.
+func (e *CostEstimate) FeeNative() float64 {
+	if e.Fee == nil {
+		return 0
+	}
+	fee, _ := new(big.Float).Quo(new(big.Float).SetInt(e.Fee), weiPerEther).Float64()
+	return fee
+}
+
+func (e *CostEstimate) String() string {
+	gwei := 0.0
+	if e.GasPrice != nil {
+		gwei, _ = new(big.Float).Quo(new(big.Float).SetInt(e.GasPrice), big.NewFloat(1e9)).Float64()
+	}
+
+	s := fmt.Sprintf("%d tx, %d gas at %.4g gwei: %.8f %s", e.Transactions, e.Gas, gwei, e.FeeNative(), e.Symbol)
+	if e.FiatCurrency != "" {
+		s += fmt.Sprintf(" (~%.2f %s)", e.FiatValue, e.FiatCurrency)
+	}
+	return s
+}
+
+// contractCall is one transaction a write would send.
+type contractCall struct {
+	method string
+	args   []interface{}
+}
+
+// The plan* methods return the calls StoreDataBatch, ChangeDataBatch and
+// RemoveDataBatch would send for the same input, so estimates follow the
+// same chunking.
+
+func (k *KeeperContract) planStoreData(data [][]byte) ([]contractCall, error) {
+	if len(data) == 0 {
+		return nil, ErrInvalidDataLength
+	}
+
+	if k.contract == nil {
+		calls := make([]contractCall, len(data))
+		for i, d := range data {
+			calls[i] = contractCall{"storeData", []interface{}{d}}
+		}
+		return calls, nil
+	}
+
+	var calls []contractCall
+	for _, r := range splitBatches(payloadSizes(data)) {
+		calls = append(calls, contractCall{"storeDataBatch", []interface{}{data[r.start:r.end]}})
+	}
+	return calls, nil
+}
+
+func (k *KeeperContract) planChangeData(dataIDs []*big.Int, data [][]byte) ([]contractCall, error) {
+	if len(dataIDs) != len(data) {
+		return nil, ErrBatchLengthMismatch
+	}
+	if len(data) == 0 {
+		return nil, ErrInvalidDataLength
+	}
+
+	if k.contract == nil {
+		calls := make([]contractCall, len(data))
+		for i := range data {
+			calls[i] = contractCall{"changeData", []interface{}{dataIDs[i], data[i]}}
+		}
+		return calls, nil
+	}
+
+	var calls []contractCall
+	for _, r := range splitBatches(payloadSizes(data)) {
+		calls = append(calls, contractCall{"changeDataBatch", []interface{}{dataIDs[r.start:r.end], data[r.start:r.end]}})
+	}
+	return calls, nil
+}
+
+func (k *KeeperContract) planRemoveData(dataIDs []*big.Int) ([]contractCall, error) {
+	if len(dataIDs) == 0 {
+		return nil, ErrInvalidDataLength
+	}
+
+	if k.contract == nil {
+		calls := make([]contractCall, len(dataIDs))
+		for i, id := range dataIDs {
+			calls[i] = contractCall{"removeData", []interface{}{id}}
+		}
+		return calls, nil
+	}
+
+	var calls []contractCall
+	for _, r := range splitBatches(idSizes(len(dataIDs))) {
+		calls = append(calls, contractCall{"removeDataBatch", []interface{}{dataIDs[r.start:r.end]}})
+	}
+	return calls, nil
+}
+
+func (c *Client) EstimateStoreMetadata(ctx context.Context, data []byte) (*CostEstimate, error) {
+	if len(data) == 0 {
+		return nil, ErrInvalidDataLength
+	}
+	return c.estimateCost(ctx, []contractCall{{"storeMetaData", []interface{}{data}}})
+}
+
+// EstimateStoreData, EstimateChangeData and EstimateRemoveData price the
+// single-entry calls StoreData, ChangeData and RemoveData send; the Batch
+// variants follow the chunking of their batch counterparts.
+
+func (c *Client) EstimateStoreData(ctx context.Context, data []byte) (*CostEstimate, error) {
+	if len(data) == 0 {
+		return nil, ErrInvalidDataLength
+	}
+	return c.estimateCost(ctx, []contractCall{{"storeData", []interface{}{data}}})
+}
+
+func (c *Client) EstimateChangeData(ctx context.Context, dataID *big.Int, data []byte) (*CostEstimate, error) {
+	if len(data) == 0 {
+		return nil, ErrInvalidDataLength
+	}
+	return c.estimateCost(ctx, []contractCall{{"changeData", []interface{}{dataID, data}}})
+}
+
+func (c *Client) EstimateRemoveData(ctx context.Context, dataID *big.Int) (*CostEstimate, error) {
+	return c.estimateCost(ctx, []contractCall{{"removeData", []interface{}{dataID}}})
+}
+
+func (c *Client) EstimateStoreDataBatch(ctx context.Context, data [][]byte) (*CostEstimate, error) {
+	calls, err := c.keeper().planStoreData(data)
+	if err != nil {
+		return nil, err
+	}
+	return c.estimateCost(ctx, calls)
+}
+
+func (c *Client) EstimateChangeDataBatch(ctx context.Context, dataIDs []*big.Int, data [][]byte) (*CostEstimate, error) {
+	calls, err := c.keeper().planChangeData(dataIDs, data)
+	if err != nil {
+		return nil, err
+	}
+	return c.estimateCost(ctx, calls)
+}
+
+func (c *Client) EstimateRemoveDataBatch(ctx context.Context, dataIDs []*big.Int) (*CostEstimate, error) {
+	calls, err := c.keeper().planRemoveData(dataIDs)
+	if err != nil {
+		return nil, err
+	}
+	return c.estimateCost(ctx, calls)
+}
+
+// estimateCost prices calls as sent from the session address. A call that
+// would revert fails the whole estimate with the parsed contract error.
+func (c *Client) estimateCost(ctx context.Context, calls []contractCall) (*CostEstimate, error) {
+	if c.currentSession() == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	gasPrice, err := c.gasPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate := &CostEstimate{
+		Transactions: len(calls),
+		GasPrice:     gasPrice,
+		Symbol:       c.config.NativeSymbol,
+	}
+	for _, call := range calls {
+		gas, err := c.EstimateGas(ctx, call.method, call.args...)
+		if err != nil {
+			return nil, err
+		}
+		estimate.Gas += gas
+	}
+	estimate.Fee = new(big.Int).Mul(new(big.Int).SetUint64(estimate.Gas), gasPrice)
+
+	return estimate, nil
+}
@@ -0,0 +1,213 @@
+package blockchain
+
+import (
+	"context"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// EmergencyAccess is the on-chain state of one owner/contact pair. The
+// contract releases the wrapped key once the owner approves a request or
+// the wait period passes without a rejection.
+type EmergencyAccess struct {
+	Owner       string        `json:"owner"`
+	Contact     string        `json:"contact"`
+	WaitPeriod  time.Duration `json:"wait_period"`
+	RequestedAt time.Time     `json:"requested_at"` // zero while nothing is requested
+	Approved    bool          `json:"approved"`
+}
+
+// Requested reports whether the contact has an open request.
+func (a *EmergencyAccess) Requested() bool {
+	return !a.RequestedAt.IsZero()
+}
+
+// AvailableAt is when an open request is granted without approval; zero
+// if nothing is requested.
+func (a *EmergencyAccess) AvailableAt() time.Time {
+	if !a.Requested() {
+		return time.Time{}
+	}
+	return a.RequestedAt.Add(a.WaitPeriod)
+}
+
+// Granted reports whether the contract would release the key at now.
+func (a *EmergencyAccess) Granted(now time.Time) bool {
+	return a.Requested() && (a.Approved || !now.Before(a.AvailableAt()))
+}
+
+func (k *KeeperContract) SupportsEmergencyAccess() bool {
+	return k.version >= KeeperVersion3
+}
+
+func (k *KeeperContract) requireEmergencyAccess() error {
+	if !k.SupportsEmergencyAccess() {
+		return ErrUnsupportedOperation
+	}
+	return nil
+}
+
+func (k *KeeperContract) GetEmergencyContacts(ctx context.Context, owner string) ([]string, error) {
+	if err := k.requireEmergencyAccess(); err != nil {
+		return nil, err
+	}
+
+	contacts, err := k.contract.GetEmergencyContacts(&bind.CallOpts{Context: ctx}, common.HexToAddress(owner))
+	if err != nil {
+		return nil, ParseContractError(err)
+	}
+	result := make([]string, len(contacts))
+	for i, contact := range contacts {
+		result[i] = contact.Hex()
+	}
+	return result, nil
+}
+
+// GetEmergencyAccess returns the state of the pair, or ErrNotEmergencyContact.
+func (k *KeeperContract) GetEmergencyAccess(ctx context.Context, owner, contact string) (*EmergencyAccess, error) {
+	if err := k.requireEmergencyAccess(); err != nil {
+		return nil, err
+	}
+
+	ownerAddr, contactAddr := common.HexToAddress(owner), common.HexToAddress(contact)
+	state, err := k.contract.GetEmergencyAccess(&bind.CallOpts{Context: ctx}, ownerAddr, contactAddr)
+	if err != nil {
+		return nil, ParseContractError(err)
+	}
+
+	access := &EmergencyAccess{
+		Owner:      ownerAddr.Hex(),
+		Contact:    contactAddr.Hex(),
+		WaitPeriod: time.Duration(state.WaitPeriod.Int64()) * time.Second,
+		Approved:   state.Approved,
+	}
+	if state.RequestedAt.Sign() > 0 {
+		access.RequestedAt = time.Unix(state.RequestedAt.Int64(), 0)
+	}
+	return access, nil
+}
+
+// GetEmergencyKey returns the key the owner wrapped to contact. The call
+// reverts with ErrEmergencyAccessNotGranted until access is granted.
+func (k *KeeperContract) GetEmergencyKey(ctx context.Context, owner, contact string) ([]byte, error) {
+	if err := k.requireEmergencyAccess(); err != nil {
+		return nil, err
+	}
+
+	key, err := k.contract.GetEmergencyKey(&bind.CallOpts{Context: ctx}, common.HexToAddress(owner), common.HexToAddress(contact))
+	if err != nil {
+		return nil, ParseContractError(err)
+	}
+	return key, nil
+}
+
+func (k *KeeperContract) SetEmergencyContact(ctx context.Context, auth *bind.TransactOpts, contact string, waitPeriod time.Duration, wrappedKey []byte) (*TransactionResult, error) {
+	if err := k.requireEmergencyAccess(); err != nil {
+		return nil, err
+	}
+	seconds := big.NewInt(int64(waitPeriod / time.Second))
+	return k.transact(ctx, func() (*types.Transaction, error) {
+		return k.contract.SetEmergencyContact(auth, common.HexToAddress(contact), seconds, wrappedKey)
+	})
+}
+
+func (k *KeeperContract) RemoveEmergencyContact(ctx context.Context, auth *bind.TransactOpts, contact string) (*TransactionResult, error) {
+	if err := k.requireEmergencyAccess(); err != nil {
+		return nil, err
+	}
+	return k.transact(ctx, func() (*types.Transaction, error) {
+		return k.contract.RemoveEmergencyContact(auth, common.HexToAddress(contact))
+	})
+}
+
+func (k *KeeperContract) RequestEmergencyAccess(ctx context.Context, auth *bind.TransactOpts, owner string) (*TransactionResult, error) {
+	if err := k.requireEmergencyAccess(); err != nil {
+		return nil, err
+	}
+	return k.transact(ctx, func() (*types.Transaction, error) {
+		return k.contract.RequestEmergencyAccess(auth, common.HexToAddress(owner))
+	})
+}
+
+func (k *KeeperContract) ApproveEmergencyAccess(ctx context.Context, auth *bind.TransactOpts, contact string) (*TransactionResult, error) {
+	if err := k.requireEmergencyAccess(); err != nil {
+		return nil, err
+	}
+	return k.transact(ctx, func() (*types.Transaction, error) {
+		return k.contract.ApproveEmergencyAccess(auth, common.HexToAddress(contact))
+	})
+}
+
+func (k *KeeperContract) RejectEmergencyAccess(ctx context.Context, auth *bind.TransactOpts, contact string) (*TransactionResult, error) {
+	if err := k.requireEmergencyAccess(); err != nil {
+		return nil, err
+	}
+	return k.transact(ctx, func() (*types.Transaction, error) {
+		return k.contract.RejectEmergencyAccess(auth, common.HexToAddress(contact))
+	})
+}
+
+func (c *Client) GetEmergencyContacts(ctx context.Context, owner string) ([]string, error) {
+	return c.keeper().GetEmergencyContacts(ctx, owner)
+}
+
+func (c *Client) GetEmergencyAccess(ctx context.Context, owner, contact string) (*EmergencyAccess, error) {
+	return c.keeper().GetEmergencyAccess(ctx, owner, contact)
+}
+
+func (c *Client) GetEmergencyKey(ctx context.Context, owner, contact string) ([]byte, error) {
+	return c.keeper().GetEmergencyKey(ctx, owner, contact)
+}
+
+// SetEmergencyContact adds contact or replaces their wrapped key and wait
+// period. An open request stays open.
+func (c *Client) SetEmergencyContact(ctx context.Context, contact string, waitPeriod time.Duration, wrappedKey []byte) (*TransactionResult, error) {
+	auth, release, err := c.sessionAuth()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.keeper().SetEmergencyContact(ctx, auth, contact, waitPeriod, wrappedKey)
+}
+
+func (c *Client) RemoveEmergencyContact(ctx context.Context, contact string) (*TransactionResult, error) {
+	auth, release, err := c.sessionAuth()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.keeper().RemoveEmergencyContact(ctx, auth, contact)
+}
+
+// RequestEmergencyAccess starts the owner's wait period for the session
+// account, which must be one of the owner's contacts.
+func (c *Client) RequestEmergencyAccess(ctx context.Context, owner string) (*TransactionResult, error) {
+	auth, release, err := c.sessionAuth()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.keeper().RequestEmergencyAccess(ctx, auth, owner)
+}
+
+func (c *Client) ApproveEmergencyAccess(ctx context.Context, contact string) (*TransactionResult, error) {
+	auth, release, err := c.sessionAuth()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.keeper().ApproveEmergencyAccess(ctx, auth, contact)
+}
+
+func (c *Client) RejectEmergencyAccess(ctx context.Context, contact string) (*TransactionResult, error) {
+	auth, release, err := c.sessionAuth()
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+	return c.keeper().RejectEmergencyAccess(ctx, auth, contact)
+}
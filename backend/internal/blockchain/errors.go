@@ -1,9 +1,16 @@
 package blockchain
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
+	"net"
+	"net/http"
 	"strings"
+	"syscall"
+
+	"github.com/ethereum/go-ethereum/rpc"
 )
 
 var (
@@ -24,23 +31,55 @@ var (
 	ErrNonceTooLow             = errors.New("nonce too low")
 	ErrInsufficientFunds       = errors.New("insufficient funds")
 	ErrNotConnected            = errors.New("not connected to blockchain")
+	ErrUnsupportedContract     = errors.New("unsupported Keeper contract version")
+	ErrUnsupportedOperation    = errors.New("operation not supported by this Keeper version")
+	ErrMigrationFailed         = errors.New("contract migration failed")
+	ErrGasPriceTooHigh         = errors.New("gas price above configured maximum")
 
 	ErrInvalidDataLength           = errors.New("invalid data length")
 	ErrCannotStoreExistingData     = errors.New("cannot store existing data")
 	ErrCannotChangeNonExistentData = errors.New("cannot change non-existent data")
 	ErrCannotRemoveNonExistentData = errors.New("cannot remove non-existent data")
+	ErrBatchLengthMismatch         = errors.New("batch ids and data length mismatch")
+	ErrInvalidPublicKey            = errors.New("public key does not match the sender")
+	ErrUnknownCollection           = errors.New("collection does not exist")
+	ErrNotCollectionOwner          = errors.New("not the collection owner")
+	ErrNotCollectionMember         = errors.New("not a collection member")
+	ErrAlreadyCollectionMember     = errors.New("already a collection member")
+	ErrCannotRemoveCollectionOwner = errors.New("cannot remove the collection owner")
+	ErrPublicKeyNotRegistered      = errors.New("no public key registered for account")
+	ErrNotEmergencyContact         = errors.New("not an emergency contact")
+	ErrEmergencyRequestPending     = errors.New("emergency access already requested")
+	ErrNoEmergencyRequest          = errors.New("no emergency access request")
+	ErrEmergencyAccessNotGranted   = errors.New("emergency access not granted yet")
 )
 
 type BlockchainError struct {
 	Type    string `json:"type"`
 	Message string `json:"message"`
 	Code    int    `json:"code"`
+
+	sentinel error // matching Err* value, if any
+	cause    error // underlying error, a *ContractError for decoded reverts
 }
 
 func (e *BlockchainError) Error() string {
 	return e.Message
 }
 
+// Unwrap exposes both the sentinel and the cause, so errors.Is works with
+// the Err* values and errors.As reaches a decoded *ContractError.
+func (e *BlockchainError) Unwrap() []error {
+	var errs []error
+	if e.sentinel != nil {
+		errs = append(errs, e.sentinel)
+	}
+	if e.cause != nil {
+		errs = append(errs, e.cause)
+	}
+	return errs
+}
+
 func NewBlockchainError(errorType, message string, code int) *BlockchainError {
 	return &BlockchainError{
 		Type:    errorType,
@@ -49,57 +88,99 @@ func NewBlockchainError(errorType, message string, code int) *BlockchainError {
 	}
 }
 
+func classified(errorType, message string, code int, sentinel, cause error) *BlockchainError {
+	return &BlockchainError{
+		Type:     errorType,
+		Message:  message,
+		Code:     code,
+		sentinel: sentinel,
+		cause:    cause,
+	}
+}
+
+// ParseContractError classifies err. Reverts are decoded from the revert
+// data through the Keeper ABI; only nodes that strip the data fall back to
+// matching the error name in the message.
 func ParseContractError(err error) *BlockchainError {
 	if err == nil {
 		return nil
 	}
+	if parsed, ok := err.(*BlockchainError); ok {
+		return parsed
+	}
+
+	if decoded := DecodeRevertError(err); decoded != nil {
+		if known, ok := lookupKeeperError(decoded.Name); ok {
+			message := known.message
+			if decoded.ID != nil {
+				message = fmt.Sprintf("%s (id %s)", message, decoded.ID)
+			}
+			return classified(known.errorType, message, known.code, nil, decoded)
+		}
+		return classified("CONTRACT_REVERTED", "Contract execution reverted: "+decoded.Reason, 1001, nil, decoded)
+	}
 
 	errorStr := err.Error()
 
 	if strings.Contains(errorStr, "execution reverted") {
-		if strings.Contains(errorStr, "InvalidDataLength()") {
-			return NewBlockchainError("INVALID_DATA_LENGTH", "Data length must be greater than 0", 2001)
-		}
-		if strings.Contains(errorStr, "CannotStoreExistingData") {
-			return NewBlockchainError("CANNOT_STORE_EXISTING_DATA", "Cannot store data at existing ID", 2002)
-		}
-		if strings.Contains(errorStr, "CannotChangeNonExistentData") {
-			return NewBlockchainError("CANNOT_CHANGE_NON_EXISTENT_DATA", "Cannot change non-existent data", 2003)
-		}
-		if strings.Contains(errorStr, "CannotRemoveNonExistentData") {
-			return NewBlockchainError("CANNOT_REMOVE_NON_EXISTENT_DATA", "Cannot remove non-existent data", 2004)
+		for _, known := range keeperErrors {
+			if strings.Contains(errorStr, known.name) {
+				return classified(known.errorType, known.message, known.code, known.sentinel, err)
+			}
 		}
 
-		return NewBlockchainError("CONTRACT_REVERTED", "Contract execution reverted", 1001)
+		return classified("CONTRACT_REVERTED", "Contract execution reverted", 1001, ErrTransactionReverted, err)
 	}
 
 	if strings.Contains(errorStr, "gas required exceeds allowance") {
-		return NewBlockchainError("GAS_ESTIMATION_FAILED", "Gas required exceeds allowance", 1002)
+		return classified("GAS_ESTIMATION_FAILED", "Gas required exceeds allowance", 1002, ErrGasEstimationFailed, err)
 	}
 
 	if strings.Contains(errorStr, "nonce too low") {
-		return NewBlockchainError("NONCE_TOO_LOW", "Transaction nonce too low", 1003)
+		return classified("NONCE_TOO_LOW", "Transaction nonce too low", 1003, ErrNonceTooLow, err)
 	}
 
 	if strings.Contains(errorStr, "insufficient funds") {
-		return NewBlockchainError("INSUFFICIENT_FUNDS", "Insufficient funds for transaction", 1004)
+		return classified("INSUFFICIENT_FUNDS", "Insufficient funds for transaction", 1004, ErrInsufficientFunds, err)
+	}
+
+	var httpErr rpc.HTTPError
+	if errors.As(err, &httpErr) {
+		switch httpErr.StatusCode {
+		case http.StatusTooManyRequests:
+			return classified("RATE_LIMITED", "Rate limited by RPC endpoint", 1007, ErrNetworkUnavailable, err)
+		case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return classified("ENDPOINT_UNAVAILABLE", "RPC endpoint unavailable", 1008, ErrNetworkUnavailable, err)
+		}
+	}
+
+	if strings.Contains(errorStr, "too many requests") || strings.Contains(errorStr, "rate limit") {
+		return classified("RATE_LIMITED", "Rate limited by RPC endpoint", 1007, ErrNetworkUnavailable, err)
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) || strings.Contains(errorStr, "connection refused") {
+		return classified("CONNECTION_REFUSED", "Connection to blockchain refused", 1005, ErrConnectionFailed, err)
 	}
 
-	if strings.Contains(errorStr, "connection refused") {
-		return NewBlockchainError("CONNECTION_REFUSED", "Connection to blockchain refused", 1005)
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) || strings.Contains(errorStr, "timeout") {
+		return classified("TIMEOUT", "Request timeout", 1006, ErrNetworkUnavailable, err)
 	}
 
-	if strings.Contains(errorStr, "timeout") {
-		return NewBlockchainError("TIMEOUT", "Request timeout", 1006)
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) || strings.Contains(errorStr, "connection reset") {
+		return classified("ENDPOINT_UNAVAILABLE", "RPC endpoint unavailable", 1008, ErrNetworkUnavailable, err)
 	}
 
-	return NewBlockchainError("UNKNOWN_ERROR", errorStr, 9999)
+	return classified("UNKNOWN_ERROR", errorStr, 9999, nil, err)
 }
 
 func IsContractError(err error) bool {
 	if err == nil {
 		return false
 	}
+	if DecodeRevertError(err) != nil {
+		return true
+	}
 
 	errorStr := err.Error()
 	return strings.Contains(errorStr, "execution reverted") ||
@@ -108,15 +189,32 @@ func IsContractError(err error) bool {
 		strings.Contains(errorStr, "insufficient funds")
 }
 
+// IsTransient reports whether err is worth retrying, possibly on another
+// endpoint: the node could not be reached, timed out, was overloaded or
+// rate limited us. Reverts and rejected transactions are not transient.
+func IsTransient(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	switch ParseContractError(err).Code {
+	case 1005, 1006, 1007, 1008:
+		return true
+	}
+	return false
+}
+
 func GetErrorCode(err error) int {
-	if blockchainErr, ok := err.(*BlockchainError); ok {
+	var blockchainErr *BlockchainError
+	if errors.As(err, &blockchainErr) {
 		return blockchainErr.Code
 	}
 	return 0
 }
 
 func GetErrorType(err error) string {
-	if blockchainErr, ok := err.(*BlockchainError); ok {
+	var blockchainErr *BlockchainError
+	if errors.As(err, &blockchainErr) {
 		return blockchainErr.Type
 	}
 	return "UNKNOWN"
@@ -127,5 +225,6 @@ func WrapError(err error, errorType, message string, code int) *BlockchainError
 		Type:    errorType,
 		Message: fmt.Sprintf("%s: %s", message, err.Error()),
 		Code:    code,
+		cause:   err,
 	}
 }
@@ -6,34 +6,89 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
-	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// keeperBinding is the part of the contract surface shared by every Keeper
+// version, satisfied by both the Keeper and KeeperV1 bindings.
+type keeperBinding interface {
+	UserData(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) ([]byte, error)
+	UserMetaData(opts *bind.CallOpts, arg0 common.Address) ([]byte, error)
+	ActiveIdsForUser(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) (*big.Int, error)
+	NextDataId(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error)
+	StoreMetaData(opts *bind.TransactOpts, _data []byte) (*types.Transaction, error)
+	StoreData(opts *bind.TransactOpts, _data []byte) (*types.Transaction, error)
+	ChangeData(opts *bind.TransactOpts, _id *big.Int, _newData []byte) (*types.Transaction, error)
+	RemoveData(opts *bind.TransactOpts, _id *big.Int) (*types.Transaction, error)
+}
+
 type KeeperContract struct {
-	client   *ethclient.Client
-	contract *Keeper // Go binding
+	client   Backend
+	binding  keeperBinding
+	contract *Keeper // Go binding, nil for v1 deployments
 	address  common.Address
+	version  int
 }
 
-func NewKeeperContract(client *ethclient.Client, contractAddress string) (*KeeperContract, error) {
+func NewKeeperContract(ctx context.Context, client Backend, contractAddress string) (*KeeperContract, error) {
+	if !common.IsHexAddress(contractAddress) {
+		return nil, ErrInvalidAddress
+	}
 	address := common.HexToAddress(contractAddress)
 
-	contract, err := NewKeeper(address, client)
+	version, err := DetectKeeperVersion(ctx, client, address)
 	if err != nil {
 		return nil, err
 	}
 
-	return &KeeperContract{
-		client:   client,
-		contract: contract,
-		address:  address,
-	}, nil
+	k := &KeeperContract{
+		client:  client,
+		address: address,
+		version: version,
+	}
+
+	switch version {
+	case KeeperVersion1:
+		legacy, err := NewKeeperV1(address, client)
+		if err != nil {
+			return nil, err
+		}
+		k.binding = legacy
+	case KeeperVersion2, KeeperVersion3:
+		contract, err := NewKeeper(address, client)
+		if err != nil {
+			return nil, err
+		}
+		k.binding = contract
+		k.contract = contract
+	default:
+		return nil, ErrUnsupportedContract
+	}
+
+	return k, nil
+}
+
+func (k *KeeperContract) Address() string {
+	return k.address.Hex()
+}
+
+func (k *KeeperContract) Version() int {
+	return k.version
+}
+
+func (k *KeeperContract) SupportsBatch() bool {
+	return k.contract != nil
+}
+
+func (k *KeeperContract) SupportsCollections() bool {
+	return k.version >= KeeperVersion3
 }
 
 func (k *KeeperContract) GetUserMetadata(ctx context.Context, userAddress string) ([]byte, error) {
-	data, err := k.contract.UserMetaData(&bind.CallOpts{Context: ctx}, common.HexToAddress(userAddress))
+	data, err := k.binding.UserMetaData(&bind.CallOpts{Context: ctx}, common.HexToAddress(userAddress))
 	if err != nil {
 		return nil, ParseContractError(err)
 	}
@@ -41,7 +96,7 @@ func (k *KeeperContract) GetUserMetadata(ctx context.Context, userAddress string
 }
 
 func (k *KeeperContract) GetUserData(ctx context.Context, userAddress string, dataID *big.Int) ([]byte, error) {
-	data, err := k.contract.UserData(&bind.CallOpts{Context: ctx}, common.HexToAddress(userAddress), dataID)
+	data, err := k.binding.UserData(&bind.CallOpts{Context: ctx}, common.HexToAddress(userAddress), dataID)
 	if err != nil {
 		return nil, ParseContractError(err)
 	}
@@ -49,17 +104,32 @@ func (k *KeeperContract) GetUserData(ctx context.Context, userAddress string, da
 }
 
 func (k *KeeperContract) GetActiveIds(ctx context.Context, userAddress string) ([]*big.Int, error) {
+	if k.contract == nil {
+		return k.getActiveIdsLegacy(ctx, userAddress)
+	}
+
+	ids, err := k.contract.GetActiveIds(&bind.CallOpts{Context: ctx}, common.HexToAddress(userAddress))
+	if err != nil {
+		return nil, ParseContractError(err)
+	}
+	return ids, nil
+}
+
+// getActiveIdsLegacy walks the public activeIdsForUser array of a v1
+// deployment, which has no view returning the whole list. The walk ends on
+// the out-of-bounds panic; any other failure is returned.
+func (k *KeeperContract) getActiveIdsLegacy(ctx context.Context, userAddress string) ([]*big.Int, error) {
 	addr := common.HexToAddress(userAddress)
 	var ids []*big.Int
 
 	for i := int64(0); ; i++ {
-		result, err := k.contract.ActiveIdsForUser(&bind.CallOpts{Context: ctx}, addr, big.NewInt(i))
+		result, err := k.binding.ActiveIdsForUser(&bind.CallOpts{Context: ctx}, addr, big.NewInt(i))
 		if err != nil {
-			if IsContractError(err) {
+			if isArrayOutOfBounds(err) {
 				break
 			}
 
-			return nil, err
+			return nil, ParseContractError(err)
 		}
 		ids = append(ids, result)
 	}
@@ -67,73 +137,152 @@ func (k *KeeperContract) GetActiveIds(ctx context.Context, userAddress string) (
 	return ids, nil
 }
 
-func (k *KeeperContract) StoreMetadata(ctx context.Context, auth *bind.TransactOpts, data []byte) (*TransactionResult, error) {
-	tx, err := k.contract.StoreMetaData(auth, data)
+func (k *KeeperContract) GetDataRevision(ctx context.Context, userAddress string, dataID *big.Int) (*big.Int, error) {
+	if k.contract == nil {
+		return nil, ErrUnsupportedOperation
+	}
+
+	revision, err := k.contract.DataRevision(&bind.CallOpts{Context: ctx}, common.HexToAddress(userAddress), dataID)
 	if err != nil {
 		return nil, ParseContractError(err)
 	}
+	return revision, nil
+}
 
-	receipt, err := bind.WaitMined(ctx, k.client, tx)
+func (k *KeeperContract) StoreMetadata(ctx context.Context, auth *bind.TransactOpts, data []byte) (*TransactionResult, error) {
+	tx, err := k.binding.StoreMetaData(auth, data)
 	if err != nil {
-		return nil, err
-	}
-	if receipt.Status == 0 {
-		return nil, ParseContractError(fmt.Errorf("transaction failed"))
+		return nil, ParseContractError(err)
 	}
-
-	return &TransactionResult{
-		Success:   true,
-		Timestamp: time.Now(),
-	}, nil
+	return k.waitTransaction(ctx, tx)
 }
 
 func (k *KeeperContract) StoreData(ctx context.Context, auth *bind.TransactOpts, data []byte) (*TransactionResult, error) {
-	_, err := k.contract.StoreData(auth, data)
+	tx, err := k.binding.StoreData(auth, data)
 	if err != nil {
 		return nil, ParseContractError(err)
 	}
-	return &TransactionResult{
-		Success:   true,
-		Timestamp: time.Now(),
-	}, nil
+	return k.waitTransaction(ctx, tx)
 }
 
 func (k *KeeperContract) ChangeData(ctx context.Context, auth *bind.TransactOpts, dataID *big.Int, data []byte) (*TransactionResult, error) {
-	tx, err := k.contract.ChangeData(auth, dataID, data)
+	tx, err := k.binding.ChangeData(auth, dataID, data)
 	if err != nil {
 		return nil, ParseContractError(err)
 	}
+	return k.waitTransaction(ctx, tx)
+}
 
-	receipt, err := bind.WaitMined(ctx, k.client, tx)
+func (k *KeeperContract) RemoveData(ctx context.Context, auth *bind.TransactOpts, dataID *big.Int) (*TransactionResult, error) {
+	tx, err := k.binding.RemoveData(auth, dataID)
 	if err != nil {
-		return nil, err
+		return nil, ParseContractError(err)
 	}
-	if receipt.Status == 0 {
-		return nil, ParseContractError(fmt.Errorf("transaction failed"))
+	return k.waitTransaction(ctx, tx)
+}
+
+// waitTransaction blocks until tx is mined and collects the IDs and
+// revisions reported by the Keeper events in its receipt.
+func (k *KeeperContract) waitTransaction(ctx context.Context, tx *types.Transaction) (*TransactionResult, error) {
+	receipt, err := k.waitReceipt(ctx, tx)
+	if err != nil {
+		return nil, err
 	}
 
+	ids, revisions := k.parseEvents(receipt)
 	return &TransactionResult{
-		Success:   true,
-		Timestamp: time.Now(),
+		Success:     true,
+		TxHash:      tx.Hash().Hex(),
+		BlockNumber: receipt.BlockNumber.Uint64(),
+		GasUsed:     receipt.GasUsed,
+		IDs:         ids,
+		Revisions:   revisions,
+		Timestamp:   time.Now(),
 	}, nil
 }
 
-func (k *KeeperContract) RemoveData(ctx context.Context, auth *bind.TransactOpts, dataID *big.Int) (*TransactionResult, error) {
-	tx, err := k.contract.RemoveData(auth, dataID)
+func (k *KeeperContract) waitReceipt(ctx context.Context, tx *types.Transaction) (*types.Receipt, error) {
+	receipt, err := bind.WaitMined(ctx, k.client, tx)
 	if err != nil {
-		return nil, ParseContractError(err)
+		return nil, err
 	}
+	if receipt.Status == types.ReceiptStatusFailed {
+		return receipt, ParseContractError(k.replayFailure(ctx, tx, receipt))
+	}
+	return receipt, nil
+}
 
-	receipt, err := bind.WaitMined(ctx, k.client, tx)
+// replayFailure re-executes a failed transaction as a call at its block to
+// recover the revert data, which receipts do not carry.
+func (k *KeeperContract) replayFailure(ctx context.Context, tx *types.Transaction, receipt *types.Receipt) error {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("transaction failed")
+	}
+
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	if _, err := k.client.CallContract(ctx, msg, receipt.BlockNumber); err != nil {
+		return err
 	}
-	if receipt.Status == 0 {
-		return nil, ParseContractError(fmt.Errorf("transaction failed"))
+	// the replay can pass when the failure depended on earlier transactions
+	// in the same block
+	return fmt.Errorf("transaction failed")
+}
+
+// parseEvents returns the data IDs and revisions of the Keeper events in
+// receipt, in emission order; CollectionCreated reports the collection ID
+// with a nil revision. v1 deployments emit no events.
+func (k *KeeperContract) parseEvents(receipt *types.Receipt) ([]*big.Int, []*big.Int) {
+	if k.contract == nil {
+		return nil, nil
 	}
 
-	return &TransactionResult{
-		Success:   true,
-		Timestamp: time.Now(),
-	}, nil
+	var ids, revisions []*big.Int
+	for _, log := range receipt.Logs {
+		if log.Address != k.address {
+			continue
+		}
+		if stored, err := k.contract.ParseDataStored(*log); err == nil {
+			ids = append(ids, stored.Id)
+			revisions = append(revisions, stored.Revision)
+			continue
+		}
+		if changed, err := k.contract.ParseDataChanged(*log); err == nil {
+			ids = append(ids, changed.Id)
+			revisions = append(revisions, changed.Revision)
+			continue
+		}
+		if removed, err := k.contract.ParseDataRemoved(*log); err == nil {
+			ids = append(ids, removed.Id)
+			revisions = append(revisions, removed.Revision)
+			continue
+		}
+		if stored, err := k.contract.ParseCollectionDataStored(*log); err == nil {
+			ids = append(ids, stored.Id)
+			revisions = append(revisions, stored.Revision)
+			continue
+		}
+		if changed, err := k.contract.ParseCollectionDataChanged(*log); err == nil {
+			ids = append(ids, changed.Id)
+			revisions = append(revisions, changed.Revision)
+			continue
+		}
+		if removed, err := k.contract.ParseCollectionDataRemoved(*log); err == nil {
+			ids = append(ids, removed.Id)
+			revisions = append(revisions, removed.Revision)
+			continue
+		}
+		if created, err := k.contract.ParseCollectionCreated(*log); err == nil {
+			ids = append(ids, created.Collection)
+			revisions = append(revisions, nil)
+		}
+	}
+
+	return ids, revisions
 }
@@ -31,7 +31,7 @@ var (
 
 // KeeperMetaData contains all meta data concerning the Keeper contract.
 var KeeperMetaData = &bind.MetaData{
-	ABI: "[{\"type\":\"function\",\"name\":\"activeIdsForUser\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"changeData\",\"inputs\":[{\"name\":\"_id\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_newData\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"nextDataId\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"removeData\",\"inputs\":[{\"name\":\"_id\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"storeData\",\"inputs\":[{\"name\":\"_data\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"storeMetaData\",\"inputs\":[{\"name\":\"_data\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"userData\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"userMetaData\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"stateMutability\":\"view\"},{\"type\":\"error\",\"name\":\"CannotChangeNonExistentData\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"CannotRemoveNonExistentData\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"CannotStoreExistingData\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"InvalidDataLength\",\"inputs\":[]}]",
+	ABI: "[{\"type\":\"function\",\"name\":\"VERSION\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"activeIdsForUser\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"addMember\",\"inputs\":[{\"name\":\"_collection\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_member\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_wrappedKey\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"approveEmergencyAccess\",\"inputs\":[{\"name\":\"_contact\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"changeCollectionData\",\"inputs\":[{\"name\":\"_collection\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_ids\",\"type\":\"uint256[]\",\"internalType\":\"uint256[]\"},{\"name\":\"_newData\",\"type\":\"bytes[]\",\"internalType\":\"bytes[]\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"changeData\",\"inputs\":[{\"name\":\"_id\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_newData\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"changeDataBatch\",\"inputs\":[{\"name\":\"_ids\",\"type\":\"uint256[]\",\"internalType\":\"uint256[]\"},{\"name\":\"_newData\",\"type\":\"bytes[]\",\"internalType\":\"bytes[]\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"collectionData\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"collectionDataRevision\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"collectionKeyEpoch\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"collectionOwner\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"createCollection\",\"inputs\":[{\"name\":\"_wrappedKey\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[{\"name\":\"collection\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"dataRevision\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getActiveIds\",\"inputs\":[{\"name\":\"_account\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256[]\",\"internalType\":\"uint256[]\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getCollectionIds\",\"inputs\":[{\"name\":\"_collection\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256[]\",\"internalType\":\"uint256[]\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getCollectionMembers\",\"inputs\":[{\"name\":\"_collection\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"address[]\",\"internalType\":\"address[]\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getEmergencyAccess\",\"inputs\":[{\"name\":\"_owner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_contact\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"waitPeriod\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"requestedAt\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"approved\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getEmergencyContacts\",\"inputs\":[{\"name\":\"_owner\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"address[]\",\"internalType\":\"address[]\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"getEmergencyKey\",\"inputs\":[{\"name\":\"_owner\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_contact\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"memberKeyEpoch\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"nextCollectionDataId\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"nextCollectionId\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"nextDataId\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"publicKeys\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"registerPublicKey\",\"inputs\":[{\"name\":\"_publicKey\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"rejectEmergencyAccess\",\"inputs\":[{\"name\":\"_contact\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"removeCollectionData\",\"inputs\":[{\"name\":\"_collection\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_ids\",\"type\":\"uint256[]\",\"internalType\":\"uint256[]\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"removeData\",\"inputs\":[{\"name\":\"_id\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"removeDataBatch\",\"inputs\":[{\"name\":\"_ids\",\"type\":\"uint256[]\",\"internalType\":\"uint256[]\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"removeEmergencyContact\",\"inputs\":[{\"name\":\"_contact\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"removeMember\",\"inputs\":[{\"name\":\"_collection\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_member\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"requestEmergencyAccess\",\"inputs\":[{\"name\":\"_owner\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"rotateCollectionKey\",\"inputs\":[{\"name\":\"_collection\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_members\",\"type\":\"address[]\",\"internalType\":\"address[]\"},{\"name\":\"_wrappedKeys\",\"type\":\"bytes[]\",\"internalType\":\"bytes[]\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"setEmergencyContact\",\"inputs\":[{\"name\":\"_contact\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"_waitPeriod\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_wrappedKey\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"storeCollectionData\",\"inputs\":[{\"name\":\"_collection\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_data\",\"type\":\"bytes[]\",\"internalType\":\"bytes[]\"}],\"outputs\":[{\"name\":\"ids\",\"type\":\"uint256[]\",\"internalType\":\"uint256[]\"}],\"stateMutability\":\"nonpayable\"},{\"type\":\"function\",\"name\":\"storeData\",\"inputs\":[{\"name\":\"_data\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"storeDataBatch\",\"inputs\":[{\"name\":\"_data\",\"type\":\"bytes[]\",\"internalType\":\"bytes[]\"}],\"outputs\":[{\"name\":\"ids\",\"type\":\"uint256[]\",\"internalType\":\"uint256[]\"}],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"storeMetaData\",\"inputs\":[{\"name\":\"_data\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"supportsInterface\",\"inputs\":[{\"name\":\"_interfaceId\",\"type\":\"bytes4\",\"internalType\":\"bytes4\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bool\",\"internalType\":\"bool\"}],\"stateMutability\":\"pure\"},{\"type\":\"function\",\"name\":\"userData\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"userMetaData\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"version\",\"inputs\":[],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"pure\"},{\"type\":\"function\",\"name\":\"wrappedKeys\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"stateMutability\":\"view\"},{\"type\":\"event\",\"name\":\"CollectionCreated\",\"inputs\":[{\"name\":\"collection\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"owner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"CollectionDataChanged\",\"inputs\":[{\"name\":\"collection\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"id\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"revision\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"CollectionDataRemoved\",\"inputs\":[{\"name\":\"collection\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"id\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"revision\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"CollectionDataStored\",\"inputs\":[{\"name\":\"collection\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"id\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"revision\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"CollectionKeyRotated\",\"inputs\":[{\"name\":\"collection\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"epoch\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"DataChanged\",\"inputs\":[{\"name\":\"account\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"id\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"revision\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"DataRemoved\",\"inputs\":[{\"name\":\"account\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"id\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"revision\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"DataStored\",\"inputs\":[{\"name\":\"account\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"id\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"revision\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"EmergencyAccessApproved\",\"inputs\":[{\"name\":\"owner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"contact\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"EmergencyAccessRejected\",\"inputs\":[{\"name\":\"owner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"contact\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"EmergencyAccessRequested\",\"inputs\":[{\"name\":\"owner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"contact\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"availableAt\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"EmergencyContactRemoved\",\"inputs\":[{\"name\":\"owner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"contact\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"EmergencyContactSet\",\"inputs\":[{\"name\":\"owner\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"contact\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"},{\"name\":\"waitPeriod\",\"type\":\"uint256\",\"indexed\":false,\"internalType\":\"uint256\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"MemberAdded\",\"inputs\":[{\"name\":\"collection\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"member\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"MemberRemoved\",\"inputs\":[{\"name\":\"collection\",\"type\":\"uint256\",\"indexed\":true,\"internalType\":\"uint256\"},{\"name\":\"member\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"MetaDataStored\",\"inputs\":[{\"name\":\"account\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"event\",\"name\":\"PublicKeyRegistered\",\"inputs\":[{\"name\":\"account\",\"type\":\"address\",\"indexed\":true,\"internalType\":\"address\"}],\"anonymous\":false},{\"type\":\"error\",\"name\":\"AlreadyCollectionMember\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}]},{\"type\":\"error\",\"name\":\"BatchLengthMismatch\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"CannotChangeNonExistentData\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"CannotRemoveCollectionOwner\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"CannotRemoveNonExistentData\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"CannotStoreExistingData\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"EmergencyAccessNotGranted\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"EmergencyRequestPending\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}]},{\"type\":\"error\",\"name\":\"InvalidDataLength\",\"inputs\":[]},{\"type\":\"error\",\"name\":\"InvalidPublicKey\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}]},{\"type\":\"error\",\"name\":\"NoEmergencyRequest\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}]},{\"type\":\"error\",\"name\":\"NotCollectionMember\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}]},{\"type\":\"error\",\"name\":\"NotCollectionOwner\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}]},{\"type\":\"error\",\"name\":\"NotEmergencyContact\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}]},{\"type\":\"error\",\"name\":\"UnknownCollection\",\"inputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]}]",
 }
 
 // KeeperABI is the input ABI used to generate the binding from.
@@ -180,6 +180,37 @@ func (_Keeper *KeeperTransactorRaw) Transact(opts *bind.TransactOpts, method str
 	return _Keeper.Contract.contract.Transact(opts, method, params...)
 }
 
+// VERSION is a free data retrieval call binding the contract method 0xffa1ad74.
+//
+// Solidity: function VERSION() view returns(uint256)
+func (_Keeper *KeeperCaller) VERSION(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "VERSION")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// VERSION is a free data retrieval call binding the contract method 0xffa1ad74.
+//
+// Solidity: function VERSION() view returns(uint256)
+func (_Keeper *KeeperSession) VERSION() (*big.Int, error) {
+	return _Keeper.Contract.VERSION(&_Keeper.CallOpts)
+}
+
+// VERSION is a free data retrieval call binding the contract method 0xffa1ad74.
+//
+// Solidity: function VERSION() view returns(uint256)
+func (_Keeper *KeeperCallerSession) VERSION() (*big.Int, error) {
+	return _Keeper.Contract.VERSION(&_Keeper.CallOpts)
+}
+
 // ActiveIdsForUser is a free data retrieval call binding the contract method 0x0592f5d5.
 //
 // Solidity: function activeIdsForUser(address , uint256 ) view returns(uint256)
@@ -211,12 +242,43 @@ func (_Keeper *KeeperCallerSession) ActiveIdsForUser(arg0 common.Address, arg1 *
 	return _Keeper.Contract.ActiveIdsForUser(&_Keeper.CallOpts, arg0, arg1)
 }
 
-// NextDataId is a free data retrieval call binding the contract method 0x63ee461d.
+// CollectionData is a free data retrieval call binding the contract method 0xdbb77d14.
 //
-// Solidity: function nextDataId(address ) view returns(uint256)
-func (_Keeper *KeeperCaller) NextDataId(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+// Solidity: function collectionData(uint256 , uint256 ) view returns(bytes)
+func (_Keeper *KeeperCaller) CollectionData(opts *bind.CallOpts, arg0 *big.Int, arg1 *big.Int) ([]byte, error) {
 	var out []interface{}
-	err := _Keeper.contract.Call(opts, &out, "nextDataId", arg0)
+	err := _Keeper.contract.Call(opts, &out, "collectionData", arg0, arg1)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// CollectionData is a free data retrieval call binding the contract method 0xdbb77d14.
+//
+// Solidity: function collectionData(uint256 , uint256 ) view returns(bytes)
+func (_Keeper *KeeperSession) CollectionData(arg0 *big.Int, arg1 *big.Int) ([]byte, error) {
+	return _Keeper.Contract.CollectionData(&_Keeper.CallOpts, arg0, arg1)
+}
+
+// CollectionData is a free data retrieval call binding the contract method 0xdbb77d14.
+//
+// Solidity: function collectionData(uint256 , uint256 ) view returns(bytes)
+func (_Keeper *KeeperCallerSession) CollectionData(arg0 *big.Int, arg1 *big.Int) ([]byte, error) {
+	return _Keeper.Contract.CollectionData(&_Keeper.CallOpts, arg0, arg1)
+}
+
+// CollectionDataRevision is a free data retrieval call binding the contract method 0x61da1c42.
+//
+// Solidity: function collectionDataRevision(uint256 , uint256 ) view returns(uint256)
+func (_Keeper *KeeperCaller) CollectionDataRevision(opts *bind.CallOpts, arg0 *big.Int, arg1 *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "collectionDataRevision", arg0, arg1)
 
 	if err != nil {
 		return *new(*big.Int), err
@@ -228,162 +290,3627 @@ func (_Keeper *KeeperCaller) NextDataId(opts *bind.CallOpts, arg0 common.Address
 
 }
 
-// NextDataId is a free data retrieval call binding the contract method 0x63ee461d.
+// CollectionDataRevision is a free data retrieval call binding the contract method 0x61da1c42.
 //
-// Solidity: function nextDataId(address ) view returns(uint256)
-func (_Keeper *KeeperSession) NextDataId(arg0 common.Address) (*big.Int, error) {
-	return _Keeper.Contract.NextDataId(&_Keeper.CallOpts, arg0)
+// Solidity: function collectionDataRevision(uint256 , uint256 ) view returns(uint256)
+func (_Keeper *KeeperSession) CollectionDataRevision(arg0 *big.Int, arg1 *big.Int) (*big.Int, error) {
+	return _Keeper.Contract.CollectionDataRevision(&_Keeper.CallOpts, arg0, arg1)
 }
 
-// NextDataId is a free data retrieval call binding the contract method 0x63ee461d.
+// CollectionDataRevision is a free data retrieval call binding the contract method 0x61da1c42.
 //
-// Solidity: function nextDataId(address ) view returns(uint256)
-func (_Keeper *KeeperCallerSession) NextDataId(arg0 common.Address) (*big.Int, error) {
-	return _Keeper.Contract.NextDataId(&_Keeper.CallOpts, arg0)
+// Solidity: function collectionDataRevision(uint256 , uint256 ) view returns(uint256)
+func (_Keeper *KeeperCallerSession) CollectionDataRevision(arg0 *big.Int, arg1 *big.Int) (*big.Int, error) {
+	return _Keeper.Contract.CollectionDataRevision(&_Keeper.CallOpts, arg0, arg1)
 }
 
-// UserData is a free data retrieval call binding the contract method 0x3c05eca1.
+// CollectionKeyEpoch is a free data retrieval call binding the contract method 0x4b30aad7.
 //
-// Solidity: function userData(address , uint256 ) view returns(bytes)
-func (_Keeper *KeeperCaller) UserData(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) ([]byte, error) {
+// Solidity: function collectionKeyEpoch(uint256 ) view returns(uint256)
+func (_Keeper *KeeperCaller) CollectionKeyEpoch(opts *bind.CallOpts, arg0 *big.Int) (*big.Int, error) {
 	var out []interface{}
-	err := _Keeper.contract.Call(opts, &out, "userData", arg0, arg1)
+	err := _Keeper.contract.Call(opts, &out, "collectionKeyEpoch", arg0)
 
 	if err != nil {
-		return *new([]byte), err
+		return *new(*big.Int), err
 	}
 
-	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
 
 	return out0, err
 
 }
 
-// UserData is a free data retrieval call binding the contract method 0x3c05eca1.
+// CollectionKeyEpoch is a free data retrieval call binding the contract method 0x4b30aad7.
 //
-// Solidity: function userData(address , uint256 ) view returns(bytes)
-func (_Keeper *KeeperSession) UserData(arg0 common.Address, arg1 *big.Int) ([]byte, error) {
-	return _Keeper.Contract.UserData(&_Keeper.CallOpts, arg0, arg1)
+// Solidity: function collectionKeyEpoch(uint256 ) view returns(uint256)
+func (_Keeper *KeeperSession) CollectionKeyEpoch(arg0 *big.Int) (*big.Int, error) {
+	return _Keeper.Contract.CollectionKeyEpoch(&_Keeper.CallOpts, arg0)
 }
 
-// UserData is a free data retrieval call binding the contract method 0x3c05eca1.
+// CollectionKeyEpoch is a free data retrieval call binding the contract method 0x4b30aad7.
 //
-// Solidity: function userData(address , uint256 ) view returns(bytes)
-func (_Keeper *KeeperCallerSession) UserData(arg0 common.Address, arg1 *big.Int) ([]byte, error) {
-	return _Keeper.Contract.UserData(&_Keeper.CallOpts, arg0, arg1)
+// Solidity: function collectionKeyEpoch(uint256 ) view returns(uint256)
+func (_Keeper *KeeperCallerSession) CollectionKeyEpoch(arg0 *big.Int) (*big.Int, error) {
+	return _Keeper.Contract.CollectionKeyEpoch(&_Keeper.CallOpts, arg0)
 }
 
-// UserMetaData is a free data retrieval call binding the contract method 0x6192b6b0.
+// CollectionOwner is a free data retrieval call binding the contract method 0x04430837.
 //
-// Solidity: function userMetaData(address ) view returns(bytes)
-func (_Keeper *KeeperCaller) UserMetaData(opts *bind.CallOpts, arg0 common.Address) ([]byte, error) {
+// Solidity: function collectionOwner(uint256 ) view returns(address)
+func (_Keeper *KeeperCaller) CollectionOwner(opts *bind.CallOpts, arg0 *big.Int) (common.Address, error) {
 	var out []interface{}
-	err := _Keeper.contract.Call(opts, &out, "userMetaData", arg0)
+	err := _Keeper.contract.Call(opts, &out, "collectionOwner", arg0)
 
 	if err != nil {
-		return *new([]byte), err
+		return *new(common.Address), err
 	}
 
-	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
 
 	return out0, err
 
 }
 
-// UserMetaData is a free data retrieval call binding the contract method 0x6192b6b0.
+// CollectionOwner is a free data retrieval call binding the contract method 0x04430837.
 //
-// Solidity: function userMetaData(address ) view returns(bytes)
-func (_Keeper *KeeperSession) UserMetaData(arg0 common.Address) ([]byte, error) {
-	return _Keeper.Contract.UserMetaData(&_Keeper.CallOpts, arg0)
+// Solidity: function collectionOwner(uint256 ) view returns(address)
+func (_Keeper *KeeperSession) CollectionOwner(arg0 *big.Int) (common.Address, error) {
+	return _Keeper.Contract.CollectionOwner(&_Keeper.CallOpts, arg0)
 }
 
-// UserMetaData is a free data retrieval call binding the contract method 0x6192b6b0.
+// CollectionOwner is a free data retrieval call binding the contract method 0x04430837.
 //
-// Solidity: function userMetaData(address ) view returns(bytes)
-func (_Keeper *KeeperCallerSession) UserMetaData(arg0 common.Address) ([]byte, error) {
-	return _Keeper.Contract.UserMetaData(&_Keeper.CallOpts, arg0)
+// Solidity: function collectionOwner(uint256 ) view returns(address)
+func (_Keeper *KeeperCallerSession) CollectionOwner(arg0 *big.Int) (common.Address, error) {
+	return _Keeper.Contract.CollectionOwner(&_Keeper.CallOpts, arg0)
 }
 
-// ChangeData is a paid mutator transaction binding the contract method 0xf2836502.
+// DataRevision is a free data retrieval call binding the contract method 0xaa9ba08b.
 //
-// Solidity: function changeData(uint256 _id, bytes _newData) payable returns()
-func (_Keeper *KeeperTransactor) ChangeData(opts *bind.TransactOpts, _id *big.Int, _newData []byte) (*types.Transaction, error) {
-	return _Keeper.contract.Transact(opts, "changeData", _id, _newData)
+// Solidity: function dataRevision(address , uint256 ) view returns(uint256)
+func (_Keeper *KeeperCaller) DataRevision(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "dataRevision", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
 }
 
-// ChangeData is a paid mutator transaction binding the contract method 0xf2836502.
+// DataRevision is a free data retrieval call binding the contract method 0xaa9ba08b.
 //
-// Solidity: function changeData(uint256 _id, bytes _newData) payable returns()
-func (_Keeper *KeeperSession) ChangeData(_id *big.Int, _newData []byte) (*types.Transaction, error) {
-	return _Keeper.Contract.ChangeData(&_Keeper.TransactOpts, _id, _newData)
+// Solidity: function dataRevision(address , uint256 ) view returns(uint256)
+func (_Keeper *KeeperSession) DataRevision(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _Keeper.Contract.DataRevision(&_Keeper.CallOpts, arg0, arg1)
 }
 
-// ChangeData is a paid mutator transaction binding the contract method 0xf2836502.
+// DataRevision is a free data retrieval call binding the contract method 0xaa9ba08b.
 //
-// Solidity: function changeData(uint256 _id, bytes _newData) payable returns()
-func (_Keeper *KeeperTransactorSession) ChangeData(_id *big.Int, _newData []byte) (*types.Transaction, error) {
-	return _Keeper.Contract.ChangeData(&_Keeper.TransactOpts, _id, _newData)
+// Solidity: function dataRevision(address , uint256 ) view returns(uint256)
+func (_Keeper *KeeperCallerSession) DataRevision(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _Keeper.Contract.DataRevision(&_Keeper.CallOpts, arg0, arg1)
 }
 
-// RemoveData is a paid mutator transaction binding the contract method 0xa94840bb.
+// GetActiveIds is a free data retrieval call binding the contract method 0x68b2be42.
 //
-// Solidity: function removeData(uint256 _id) payable returns()
-func (_Keeper *KeeperTransactor) RemoveData(opts *bind.TransactOpts, _id *big.Int) (*types.Transaction, error) {
-	return _Keeper.contract.Transact(opts, "removeData", _id)
+// Solidity: function getActiveIds(address _account) view returns(uint256[])
+func (_Keeper *KeeperCaller) GetActiveIds(opts *bind.CallOpts, _account common.Address) ([]*big.Int, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "getActiveIds", _account)
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
 }
 
-// RemoveData is a paid mutator transaction binding the contract method 0xa94840bb.
+// GetActiveIds is a free data retrieval call binding the contract method 0x68b2be42.
 //
-// Solidity: function removeData(uint256 _id) payable returns()
-func (_Keeper *KeeperSession) RemoveData(_id *big.Int) (*types.Transaction, error) {
-	return _Keeper.Contract.RemoveData(&_Keeper.TransactOpts, _id)
+// Solidity: function getActiveIds(address _account) view returns(uint256[])
+func (_Keeper *KeeperSession) GetActiveIds(_account common.Address) ([]*big.Int, error) {
+	return _Keeper.Contract.GetActiveIds(&_Keeper.CallOpts, _account)
 }
 
-// RemoveData is a paid mutator transaction binding the contract method 0xa94840bb.
+// GetActiveIds is a free data retrieval call binding the contract method 0x68b2be42.
 //
-// Solidity: function removeData(uint256 _id) payable returns()
-func (_Keeper *KeeperTransactorSession) RemoveData(_id *big.Int) (*types.Transaction, error) {
-	return _Keeper.Contract.RemoveData(&_Keeper.TransactOpts, _id)
+// Solidity: function getActiveIds(address _account) view returns(uint256[])
+func (_Keeper *KeeperCallerSession) GetActiveIds(_account common.Address) ([]*big.Int, error) {
+	return _Keeper.Contract.GetActiveIds(&_Keeper.CallOpts, _account)
 }
 
-// StoreData is a paid mutator transaction binding the contract method 0xac5c8535.
+// GetCollectionIds is a free data retrieval call binding the contract method 0xc2982f00.
 //
-// Solidity: function storeData(bytes _data) payable returns()
-func (_Keeper *KeeperTransactor) StoreData(opts *bind.TransactOpts, _data []byte) (*types.Transaction, error) {
-	return _Keeper.contract.Transact(opts, "storeData", _data)
+// Solidity: function getCollectionIds(uint256 _collection) view returns(uint256[])
+func (_Keeper *KeeperCaller) GetCollectionIds(opts *bind.CallOpts, _collection *big.Int) ([]*big.Int, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "getCollectionIds", _collection)
+
+	if err != nil {
+		return *new([]*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]*big.Int)).(*[]*big.Int)
+
+	return out0, err
+
 }
 
-// StoreData is a paid mutator transaction binding the contract method 0xac5c8535.
+// GetCollectionIds is a free data retrieval call binding the contract method 0xc2982f00.
 //
-// Solidity: function storeData(bytes _data) payable returns()
-func (_Keeper *KeeperSession) StoreData(_data []byte) (*types.Transaction, error) {
-	return _Keeper.Contract.StoreData(&_Keeper.TransactOpts, _data)
+// Solidity: function getCollectionIds(uint256 _collection) view returns(uint256[])
+func (_Keeper *KeeperSession) GetCollectionIds(_collection *big.Int) ([]*big.Int, error) {
+	return _Keeper.Contract.GetCollectionIds(&_Keeper.CallOpts, _collection)
 }
 
-// StoreData is a paid mutator transaction binding the contract method 0xac5c8535.
+// GetCollectionIds is a free data retrieval call binding the contract method 0xc2982f00.
 //
-// Solidity: function storeData(bytes _data) payable returns()
-func (_Keeper *KeeperTransactorSession) StoreData(_data []byte) (*types.Transaction, error) {
-	return _Keeper.Contract.StoreData(&_Keeper.TransactOpts, _data)
+// Solidity: function getCollectionIds(uint256 _collection) view returns(uint256[])
+func (_Keeper *KeeperCallerSession) GetCollectionIds(_collection *big.Int) ([]*big.Int, error) {
+	return _Keeper.Contract.GetCollectionIds(&_Keeper.CallOpts, _collection)
 }
 
-// StoreMetaData is a paid mutator transaction binding the contract method 0xd33e9b27.
+// GetCollectionMembers is a free data retrieval call binding the contract method 0x9d4bb144.
 //
-// Solidity: function storeMetaData(bytes _data) payable returns()
-func (_Keeper *KeeperTransactor) StoreMetaData(opts *bind.TransactOpts, _data []byte) (*types.Transaction, error) {
-	return _Keeper.contract.Transact(opts, "storeMetaData", _data)
+// Solidity: function getCollectionMembers(uint256 _collection) view returns(address[])
+func (_Keeper *KeeperCaller) GetCollectionMembers(opts *bind.CallOpts, _collection *big.Int) ([]common.Address, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "getCollectionMembers", _collection)
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
 }
 
-// StoreMetaData is a paid mutator transaction binding the contract method 0xd33e9b27.
+// GetCollectionMembers is a free data retrieval call binding the contract method 0x9d4bb144.
 //
-// Solidity: function storeMetaData(bytes _data) payable returns()
-func (_Keeper *KeeperSession) StoreMetaData(_data []byte) (*types.Transaction, error) {
-	return _Keeper.Contract.StoreMetaData(&_Keeper.TransactOpts, _data)
+// Solidity: function getCollectionMembers(uint256 _collection) view returns(address[])
+func (_Keeper *KeeperSession) GetCollectionMembers(_collection *big.Int) ([]common.Address, error) {
+	return _Keeper.Contract.GetCollectionMembers(&_Keeper.CallOpts, _collection)
 }
 
-// StoreMetaData is a paid mutator transaction binding the contract method 0xd33e9b27.
+// GetCollectionMembers is a free data retrieval call binding the contract method 0x9d4bb144.
 //
-// Solidity: function storeMetaData(bytes _data) payable returns()
-func (_Keeper *KeeperTransactorSession) StoreMetaData(_data []byte) (*types.Transaction, error) {
-	return _Keeper.Contract.StoreMetaData(&_Keeper.TransactOpts, _data)
+// Solidity: function getCollectionMembers(uint256 _collection) view returns(address[])
+func (_Keeper *KeeperCallerSession) GetCollectionMembers(_collection *big.Int) ([]common.Address, error) {
+	return _Keeper.Contract.GetCollectionMembers(&_Keeper.CallOpts, _collection)
+}
+
+// GetEmergencyAccess is a free data retrieval call binding the contract method 0x979b1e0b.
+//
+// Solidity: function getEmergencyAccess(address _owner, address _contact) view returns(uint256 waitPeriod, uint256 requestedAt, bool approved)
+func (_Keeper *KeeperCaller) GetEmergencyAccess(opts *bind.CallOpts, _owner common.Address, _contact common.Address) (struct {
+	WaitPeriod  *big.Int
+	RequestedAt *big.Int
+	Approved    bool
+}, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "getEmergencyAccess", _owner, _contact)
+
+	outstruct := new(struct {
+		WaitPeriod  *big.Int
+		RequestedAt *big.Int
+		Approved    bool
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.WaitPeriod = *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+	outstruct.RequestedAt = *abi.ConvertType(out[1], new(*big.Int)).(**big.Int)
+	outstruct.Approved = *abi.ConvertType(out[2], new(bool)).(*bool)
+
+	return *outstruct, err
+
+}
+
+// GetEmergencyAccess is a free data retrieval call binding the contract method 0x979b1e0b.
+//
+// Solidity: function getEmergencyAccess(address _owner, address _contact) view returns(uint256 waitPeriod, uint256 requestedAt, bool approved)
+func (_Keeper *KeeperSession) GetEmergencyAccess(_owner common.Address, _contact common.Address) (struct {
+	WaitPeriod  *big.Int
+	RequestedAt *big.Int
+	Approved    bool
+}, error) {
+	return _Keeper.Contract.GetEmergencyAccess(&_Keeper.CallOpts, _owner, _contact)
+}
+
+// GetEmergencyAccess is a free data retrieval call binding the contract method 0x979b1e0b.
+//
+// Solidity: function getEmergencyAccess(address _owner, address _contact) view returns(uint256 waitPeriod, uint256 requestedAt, bool approved)
+func (_Keeper *KeeperCallerSession) GetEmergencyAccess(_owner common.Address, _contact common.Address) (struct {
+	WaitPeriod  *big.Int
+	RequestedAt *big.Int
+	Approved    bool
+}, error) {
+	return _Keeper.Contract.GetEmergencyAccess(&_Keeper.CallOpts, _owner, _contact)
+}
+
+// GetEmergencyContacts is a free data retrieval call binding the contract method 0xae221e4a.
+//
+// Solidity: function getEmergencyContacts(address _owner) view returns(address[])
+func (_Keeper *KeeperCaller) GetEmergencyContacts(opts *bind.CallOpts, _owner common.Address) ([]common.Address, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "getEmergencyContacts", _owner)
+
+	if err != nil {
+		return *new([]common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]common.Address)).(*[]common.Address)
+
+	return out0, err
+
+}
+
+// GetEmergencyContacts is a free data retrieval call binding the contract method 0xae221e4a.
+//
+// Solidity: function getEmergencyContacts(address _owner) view returns(address[])
+func (_Keeper *KeeperSession) GetEmergencyContacts(_owner common.Address) ([]common.Address, error) {
+	return _Keeper.Contract.GetEmergencyContacts(&_Keeper.CallOpts, _owner)
+}
+
+// GetEmergencyContacts is a free data retrieval call binding the contract method 0xae221e4a.
+//
+// Solidity: function getEmergencyContacts(address _owner) view returns(address[])
+func (_Keeper *KeeperCallerSession) GetEmergencyContacts(_owner common.Address) ([]common.Address, error) {
+	return _Keeper.Contract.GetEmergencyContacts(&_Keeper.CallOpts, _owner)
+}
+
+// GetEmergencyKey is a free data retrieval call binding the contract method 0xdd22ba01.
+//
+// Solidity: function getEmergencyKey(address _owner, address _contact) view returns(bytes)
+func (_Keeper *KeeperCaller) GetEmergencyKey(opts *bind.CallOpts, _owner common.Address, _contact common.Address) ([]byte, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "getEmergencyKey", _owner, _contact)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// GetEmergencyKey is a free data retrieval call binding the contract method 0xdd22ba01.
+//
+// Solidity: function getEmergencyKey(address _owner, address _contact) view returns(bytes)
+func (_Keeper *KeeperSession) GetEmergencyKey(_owner common.Address, _contact common.Address) ([]byte, error) {
+	return _Keeper.Contract.GetEmergencyKey(&_Keeper.CallOpts, _owner, _contact)
+}
+
+// GetEmergencyKey is a free data retrieval call binding the contract method 0xdd22ba01.
+//
+// Solidity: function getEmergencyKey(address _owner, address _contact) view returns(bytes)
+func (_Keeper *KeeperCallerSession) GetEmergencyKey(_owner common.Address, _contact common.Address) ([]byte, error) {
+	return _Keeper.Contract.GetEmergencyKey(&_Keeper.CallOpts, _owner, _contact)
+}
+
+// MemberKeyEpoch is a free data retrieval call binding the contract method 0xe4b9f148.
+//
+// Solidity: function memberKeyEpoch(uint256 , address ) view returns(uint256)
+func (_Keeper *KeeperCaller) MemberKeyEpoch(opts *bind.CallOpts, arg0 *big.Int, arg1 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "memberKeyEpoch", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// MemberKeyEpoch is a free data retrieval call binding the contract method 0xe4b9f148.
+//
+// Solidity: function memberKeyEpoch(uint256 , address ) view returns(uint256)
+func (_Keeper *KeeperSession) MemberKeyEpoch(arg0 *big.Int, arg1 common.Address) (*big.Int, error) {
+	return _Keeper.Contract.MemberKeyEpoch(&_Keeper.CallOpts, arg0, arg1)
+}
+
+// MemberKeyEpoch is a free data retrieval call binding the contract method 0xe4b9f148.
+//
+// Solidity: function memberKeyEpoch(uint256 , address ) view returns(uint256)
+func (_Keeper *KeeperCallerSession) MemberKeyEpoch(arg0 *big.Int, arg1 common.Address) (*big.Int, error) {
+	return _Keeper.Contract.MemberKeyEpoch(&_Keeper.CallOpts, arg0, arg1)
+}
+
+// NextCollectionDataId is a free data retrieval call binding the contract method 0xb474fbfa.
+//
+// Solidity: function nextCollectionDataId(uint256 ) view returns(uint256)
+func (_Keeper *KeeperCaller) NextCollectionDataId(opts *bind.CallOpts, arg0 *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "nextCollectionDataId", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// NextCollectionDataId is a free data retrieval call binding the contract method 0xb474fbfa.
+//
+// Solidity: function nextCollectionDataId(uint256 ) view returns(uint256)
+func (_Keeper *KeeperSession) NextCollectionDataId(arg0 *big.Int) (*big.Int, error) {
+	return _Keeper.Contract.NextCollectionDataId(&_Keeper.CallOpts, arg0)
+}
+
+// NextCollectionDataId is a free data retrieval call binding the contract method 0xb474fbfa.
+//
+// Solidity: function nextCollectionDataId(uint256 ) view returns(uint256)
+func (_Keeper *KeeperCallerSession) NextCollectionDataId(arg0 *big.Int) (*big.Int, error) {
+	return _Keeper.Contract.NextCollectionDataId(&_Keeper.CallOpts, arg0)
+}
+
+// NextCollectionId is a free data retrieval call binding the contract method 0xe77d6f7c.
+//
+// Solidity: function nextCollectionId() view returns(uint256)
+func (_Keeper *KeeperCaller) NextCollectionId(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "nextCollectionId")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// NextCollectionId is a free data retrieval call binding the contract method 0xe77d6f7c.
+//
+// Solidity: function nextCollectionId() view returns(uint256)
+func (_Keeper *KeeperSession) NextCollectionId() (*big.Int, error) {
+	return _Keeper.Contract.NextCollectionId(&_Keeper.CallOpts)
+}
+
+// NextCollectionId is a free data retrieval call binding the contract method 0xe77d6f7c.
+//
+// Solidity: function nextCollectionId() view returns(uint256)
+func (_Keeper *KeeperCallerSession) NextCollectionId() (*big.Int, error) {
+	return _Keeper.Contract.NextCollectionId(&_Keeper.CallOpts)
+}
+
+// NextDataId is a free data retrieval call binding the contract method 0x63ee461d.
+//
+// Solidity: function nextDataId(address ) view returns(uint256)
+func (_Keeper *KeeperCaller) NextDataId(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "nextDataId", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// NextDataId is a free data retrieval call binding the contract method 0x63ee461d.
+//
+// Solidity: function nextDataId(address ) view returns(uint256)
+func (_Keeper *KeeperSession) NextDataId(arg0 common.Address) (*big.Int, error) {
+	return _Keeper.Contract.NextDataId(&_Keeper.CallOpts, arg0)
+}
+
+// NextDataId is a free data retrieval call binding the contract method 0x63ee461d.
+//
+// Solidity: function nextDataId(address ) view returns(uint256)
+func (_Keeper *KeeperCallerSession) NextDataId(arg0 common.Address) (*big.Int, error) {
+	return _Keeper.Contract.NextDataId(&_Keeper.CallOpts, arg0)
+}
+
+// PublicKeys is a free data retrieval call binding the contract method 0xa3d6f9a9.
+//
+// Solidity: function publicKeys(address ) view returns(bytes)
+func (_Keeper *KeeperCaller) PublicKeys(opts *bind.CallOpts, arg0 common.Address) ([]byte, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "publicKeys", arg0)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// PublicKeys is a free data retrieval call binding the contract method 0xa3d6f9a9.
+//
+// Solidity: function publicKeys(address ) view returns(bytes)
+func (_Keeper *KeeperSession) PublicKeys(arg0 common.Address) ([]byte, error) {
+	return _Keeper.Contract.PublicKeys(&_Keeper.CallOpts, arg0)
+}
+
+// PublicKeys is a free data retrieval call binding the contract method 0xa3d6f9a9.
+//
+// Solidity: function publicKeys(address ) view returns(bytes)
+func (_Keeper *KeeperCallerSession) PublicKeys(arg0 common.Address) ([]byte, error) {
+	return _Keeper.Contract.PublicKeys(&_Keeper.CallOpts, arg0)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 _interfaceId) pure returns(bool)
+func (_Keeper *KeeperCaller) SupportsInterface(opts *bind.CallOpts, _interfaceId [4]byte) (bool, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "supportsInterface", _interfaceId)
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 _interfaceId) pure returns(bool)
+func (_Keeper *KeeperSession) SupportsInterface(_interfaceId [4]byte) (bool, error) {
+	return _Keeper.Contract.SupportsInterface(&_Keeper.CallOpts, _interfaceId)
+}
+
+// SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
+//
+// Solidity: function supportsInterface(bytes4 _interfaceId) pure returns(bool)
+func (_Keeper *KeeperCallerSession) SupportsInterface(_interfaceId [4]byte) (bool, error) {
+	return _Keeper.Contract.SupportsInterface(&_Keeper.CallOpts, _interfaceId)
+}
+
+// UserData is a free data retrieval call binding the contract method 0x3c05eca1.
+//
+// Solidity: function userData(address , uint256 ) view returns(bytes)
+func (_Keeper *KeeperCaller) UserData(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) ([]byte, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "userData", arg0, arg1)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// UserData is a free data retrieval call binding the contract method 0x3c05eca1.
+//
+// Solidity: function userData(address , uint256 ) view returns(bytes)
+func (_Keeper *KeeperSession) UserData(arg0 common.Address, arg1 *big.Int) ([]byte, error) {
+	return _Keeper.Contract.UserData(&_Keeper.CallOpts, arg0, arg1)
+}
+
+// UserData is a free data retrieval call binding the contract method 0x3c05eca1.
+//
+// Solidity: function userData(address , uint256 ) view returns(bytes)
+func (_Keeper *KeeperCallerSession) UserData(arg0 common.Address, arg1 *big.Int) ([]byte, error) {
+	return _Keeper.Contract.UserData(&_Keeper.CallOpts, arg0, arg1)
+}
+
+// UserMetaData is a free data retrieval call binding the contract method 0x6192b6b0.
+//
+// Solidity: function userMetaData(address ) view returns(bytes)
+func (_Keeper *KeeperCaller) UserMetaData(opts *bind.CallOpts, arg0 common.Address) ([]byte, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "userMetaData", arg0)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// UserMetaData is a free data retrieval call binding the contract method 0x6192b6b0.
+//
+// Solidity: function userMetaData(address ) view returns(bytes)
+func (_Keeper *KeeperSession) UserMetaData(arg0 common.Address) ([]byte, error) {
+	return _Keeper.Contract.UserMetaData(&_Keeper.CallOpts, arg0)
+}
+
+// UserMetaData is a free data retrieval call binding the contract method 0x6192b6b0.
+//
+// Solidity: function userMetaData(address ) view returns(bytes)
+func (_Keeper *KeeperCallerSession) UserMetaData(arg0 common.Address) ([]byte, error) {
+	return _Keeper.Contract.UserMetaData(&_Keeper.CallOpts, arg0)
+}
+
+// Version is a free data retrieval call binding the contract method 0x54fd4d50.
+//
+// Solidity: function version() pure returns(uint256)
+func (_Keeper *KeeperCaller) Version(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "version")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// Version is a free data retrieval call binding the contract method 0x54fd4d50.
+//
+// Solidity: function version() pure returns(uint256)
+func (_Keeper *KeeperSession) Version() (*big.Int, error) {
+	return _Keeper.Contract.Version(&_Keeper.CallOpts)
+}
+
+// Version is a free data retrieval call binding the contract method 0x54fd4d50.
+//
+// Solidity: function version() pure returns(uint256)
+func (_Keeper *KeeperCallerSession) Version() (*big.Int, error) {
+	return _Keeper.Contract.Version(&_Keeper.CallOpts)
+}
+
+// WrappedKeys is a free data retrieval call binding the contract method 0xfa4defd7.
+//
+// Solidity: function wrappedKeys(uint256 , address ) view returns(bytes)
+func (_Keeper *KeeperCaller) WrappedKeys(opts *bind.CallOpts, arg0 *big.Int, arg1 common.Address) ([]byte, error) {
+	var out []interface{}
+	err := _Keeper.contract.Call(opts, &out, "wrappedKeys", arg0, arg1)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// WrappedKeys is a free data retrieval call binding the contract method 0xfa4defd7.
+//
+// Solidity: function wrappedKeys(uint256 , address ) view returns(bytes)
+func (_Keeper *KeeperSession) WrappedKeys(arg0 *big.Int, arg1 common.Address) ([]byte, error) {
+	return _Keeper.Contract.WrappedKeys(&_Keeper.CallOpts, arg0, arg1)
+}
+
+// WrappedKeys is a free data retrieval call binding the contract method 0xfa4defd7.
+//
+// Solidity: function wrappedKeys(uint256 , address ) view returns(bytes)
+func (_Keeper *KeeperCallerSession) WrappedKeys(arg0 *big.Int, arg1 common.Address) ([]byte, error) {
+	return _Keeper.Contract.WrappedKeys(&_Keeper.CallOpts, arg0, arg1)
+}
+
+// AddMember is a paid mutator transaction binding the contract method 0x4d8aece0.
+//
+// Solidity: function addMember(uint256 _collection, address _member, bytes _wrappedKey) returns()
+func (_Keeper *KeeperTransactor) AddMember(opts *bind.TransactOpts, _collection *big.Int, _member common.Address, _wrappedKey []byte) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "addMember", _collection, _member, _wrappedKey)
+}
+
+// AddMember is a paid mutator transaction binding the contract method 0x4d8aece0.
+//
+// Solidity: function addMember(uint256 _collection, address _member, bytes _wrappedKey) returns()
+func (_Keeper *KeeperSession) AddMember(_collection *big.Int, _member common.Address, _wrappedKey []byte) (*types.Transaction, error) {
+	return _Keeper.Contract.AddMember(&_Keeper.TransactOpts, _collection, _member, _wrappedKey)
+}
+
+// AddMember is a paid mutator transaction binding the contract method 0x4d8aece0.
+//
+// Solidity: function addMember(uint256 _collection, address _member, bytes _wrappedKey) returns()
+func (_Keeper *KeeperTransactorSession) AddMember(_collection *big.Int, _member common.Address, _wrappedKey []byte) (*types.Transaction, error) {
+	return _Keeper.Contract.AddMember(&_Keeper.TransactOpts, _collection, _member, _wrappedKey)
+}
+
+// ApproveEmergencyAccess is a paid mutator transaction binding the contract method 0x08912b92.
+//
+// Solidity: function approveEmergencyAccess(address _contact) returns()
+func (_Keeper *KeeperTransactor) ApproveEmergencyAccess(opts *bind.TransactOpts, _contact common.Address) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "approveEmergencyAccess", _contact)
+}
+
+// ApproveEmergencyAccess is a paid mutator transaction binding the contract method 0x08912b92.
+//
+// Solidity: function approveEmergencyAccess(address _contact) returns()
+func (_Keeper *KeeperSession) ApproveEmergencyAccess(_contact common.Address) (*types.Transaction, error) {
+	return _Keeper.Contract.ApproveEmergencyAccess(&_Keeper.TransactOpts, _contact)
+}
+
+// ApproveEmergencyAccess is a paid mutator transaction binding the contract method 0x08912b92.
+//
+// Solidity: function approveEmergencyAccess(address _contact) returns()
+func (_Keeper *KeeperTransactorSession) ApproveEmergencyAccess(_contact common.Address) (*types.Transaction, error) {
+	return _Keeper.Contract.ApproveEmergencyAccess(&_Keeper.TransactOpts, _contact)
+}
+
+// ChangeCollectionData is a paid mutator transaction binding the contract method 0xc9f5dbf9.
+//
+// Solidity: function changeCollectionData(uint256 _collection, uint256[] _ids, bytes[] _newData) returns()
+func (_Keeper *KeeperTransactor) ChangeCollectionData(opts *bind.TransactOpts, _collection *big.Int, _ids []*big.Int, _newData [][]byte) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "changeCollectionData", _collection, _ids, _newData)
+}
+
+// ChangeCollectionData is a paid mutator transaction binding the contract method 0xc9f5dbf9.
+//
+// Solidity: function changeCollectionData(uint256 _collection, uint256[] _ids, bytes[] _newData) returns()
+func (_Keeper *KeeperSession) ChangeCollectionData(_collection *big.Int, _ids []*big.Int, _newData [][]byte) (*types.Transaction, error) {
+	return _Keeper.Contract.ChangeCollectionData(&_Keeper.TransactOpts, _collection, _ids, _newData)
+}
+
+// ChangeCollectionData is a paid mutator transaction binding the contract method 0xc9f5dbf9.
+//
+// Solidity: function changeCollectionData(uint256 _collection, uint256[] _ids, bytes[] _newData) returns()
+func (_Keeper *KeeperTransactorSession) ChangeCollectionData(_collection *big.Int, _ids []*big.Int, _newData [][]byte) (*types.Transaction, error) {
+	return _Keeper.Contract.ChangeCollectionData(&_Keeper.TransactOpts, _collection, _ids, _newData)
+}
+
+// ChangeData is a paid mutator transaction binding the contract method 0xf2836502.
+//
+// Solidity: function changeData(uint256 _id, bytes _newData) payable returns()
+func (_Keeper *KeeperTransactor) ChangeData(opts *bind.TransactOpts, _id *big.Int, _newData []byte) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "changeData", _id, _newData)
+}
+
+// ChangeData is a paid mutator transaction binding the contract method 0xf2836502.
+//
+// Solidity: function changeData(uint256 _id, bytes _newData) payable returns()
+func (_Keeper *KeeperSession) ChangeData(_id *big.Int, _newData []byte) (*types.Transaction, error) {
+	return _Keeper.Contract.ChangeData(&_Keeper.TransactOpts, _id, _newData)
+}
+
+// ChangeData is a paid mutator transaction binding the contract method 0xf2836502.
+//
+// Solidity: function changeData(uint256 _id, bytes _newData) payable returns()
+func (_Keeper *KeeperTransactorSession) ChangeData(_id *big.Int, _newData []byte) (*types.Transaction, error) {
+	return _Keeper.Contract.ChangeData(&_Keeper.TransactOpts, _id, _newData)
+}
+
+// ChangeDataBatch is a paid mutator transaction binding the contract method 0x49fba8de.
+//
+// Solidity: function changeDataBatch(uint256[] _ids, bytes[] _newData) payable returns()
+func (_Keeper *KeeperTransactor) ChangeDataBatch(opts *bind.TransactOpts, _ids []*big.Int, _newData [][]byte) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "changeDataBatch", _ids, _newData)
+}
+
+// ChangeDataBatch is a paid mutator transaction binding the contract method 0x49fba8de.
+//
+// Solidity: function changeDataBatch(uint256[] _ids, bytes[] _newData) payable returns()
+func (_Keeper *KeeperSession) ChangeDataBatch(_ids []*big.Int, _newData [][]byte) (*types.Transaction, error) {
+	return _Keeper.Contract.ChangeDataBatch(&_Keeper.TransactOpts, _ids, _newData)
+}
+
+// ChangeDataBatch is a paid mutator transaction binding the contract method 0x49fba8de.
+//
+// Solidity: function changeDataBatch(uint256[] _ids, bytes[] _newData) payable returns()
+func (_Keeper *KeeperTransactorSession) ChangeDataBatch(_ids []*big.Int, _newData [][]byte) (*types.Transaction, error) {
+	return _Keeper.Contract.ChangeDataBatch(&_Keeper.TransactOpts, _ids, _newData)
+}
+
+// CreateCollection is a paid mutator transaction binding the contract method 0xc934e07d.
+//
+// Solidity: function createCollection(bytes _wrappedKey) returns(uint256 collection)
+func (_Keeper *KeeperTransactor) CreateCollection(opts *bind.TransactOpts, _wrappedKey []byte) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "createCollection", _wrappedKey)
+}
+
+// CreateCollection is a paid mutator transaction binding the contract method 0xc934e07d.
+//
+// Solidity: function createCollection(bytes _wrappedKey) returns(uint256 collection)
+func (_Keeper *KeeperSession) CreateCollection(_wrappedKey []byte) (*types.Transaction, error) {
+	return _Keeper.Contract.CreateCollection(&_Keeper.TransactOpts, _wrappedKey)
+}
+
+// CreateCollection is a paid mutator transaction binding the contract method 0xc934e07d.
+//
+// Solidity: function createCollection(bytes _wrappedKey) returns(uint256 collection)
+func (_Keeper *KeeperTransactorSession) CreateCollection(_wrappedKey []byte) (*types.Transaction, error) {
+	return _Keeper.Contract.CreateCollection(&_Keeper.TransactOpts, _wrappedKey)
+}
+
+// RegisterPublicKey is a paid mutator transaction binding the contract method 0x85623594.
+//
+// Solidity: function registerPublicKey(bytes _publicKey) returns()
+func (_Keeper *KeeperTransactor) RegisterPublicKey(opts *bind.TransactOpts, _publicKey []byte) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "registerPublicKey", _publicKey)
+}
+
+// RegisterPublicKey is a paid mutator transaction binding the contract method 0x85623594.
+//
+// Solidity: function registerPublicKey(bytes _publicKey) returns()
+func (_Keeper *KeeperSession) RegisterPublicKey(_publicKey []byte) (*types.Transaction, error) {
+	return _Keeper.Contract.RegisterPublicKey(&_Keeper.TransactOpts, _publicKey)
+}
+
+// RegisterPublicKey is a paid mutator transaction binding the contract method 0x85623594.
+//
+// Solidity: function registerPublicKey(bytes _publicKey) returns()
+func (_Keeper *KeeperTransactorSession) RegisterPublicKey(_publicKey []byte) (*types.Transaction, error) {
+	return _Keeper.Contract.RegisterPublicKey(&_Keeper.TransactOpts, _publicKey)
+}
+
+// RejectEmergencyAccess is a paid mutator transaction binding the contract method 0x26ca3ba9.
+//
+// Solidity: function rejectEmergencyAccess(address _contact) returns()
+func (_Keeper *KeeperTransactor) RejectEmergencyAccess(opts *bind.TransactOpts, _contact common.Address) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "rejectEmergencyAccess", _contact)
+}
+
+// RejectEmergencyAccess is a paid mutator transaction binding the contract method 0x26ca3ba9.
+//
+// Solidity: function rejectEmergencyAccess(address _contact) returns()
+func (_Keeper *KeeperSession) RejectEmergencyAccess(_contact common.Address) (*types.Transaction, error) {
+	return _Keeper.Contract.RejectEmergencyAccess(&_Keeper.TransactOpts, _contact)
+}
+
+// RejectEmergencyAccess is a paid mutator transaction binding the contract method 0x26ca3ba9.
+//
+// Solidity: function rejectEmergencyAccess(address _contact) returns()
+func (_Keeper *KeeperTransactorSession) RejectEmergencyAccess(_contact common.Address) (*types.Transaction, error) {
+	return _Keeper.Contract.RejectEmergencyAccess(&_Keeper.TransactOpts, _contact)
+}
+
+// RemoveCollectionData is a paid mutator transaction binding the contract method 0xf723458b.
+//
+// Solidity: function removeCollectionData(uint256 _collection, uint256[] _ids) returns()
+func (_Keeper *KeeperTransactor) RemoveCollectionData(opts *bind.TransactOpts, _collection *big.Int, _ids []*big.Int) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "removeCollectionData", _collection, _ids)
+}
+
+// RemoveCollectionData is a paid mutator transaction binding the contract method 0xf723458b.
+//
+// Solidity: function removeCollectionData(uint256 _collection, uint256[] _ids) returns()
+func (_Keeper *KeeperSession) RemoveCollectionData(_collection *big.Int, _ids []*big.Int) (*types.Transaction, error) {
+	return _Keeper.Contract.RemoveCollectionData(&_Keeper.TransactOpts, _collection, _ids)
+}
+
+// RemoveCollectionData is a paid mutator transaction binding the contract method 0xf723458b.
+//
+// Solidity: function removeCollectionData(uint256 _collection, uint256[] _ids) returns()
+func (_Keeper *KeeperTransactorSession) RemoveCollectionData(_collection *big.Int, _ids []*big.Int) (*types.Transaction, error) {
+	return _Keeper.Contract.RemoveCollectionData(&_Keeper.TransactOpts, _collection, _ids)
+}
+
+// RemoveData is a paid mutator transaction binding the contract method 0xa94840bb.
+//
+// Solidity: function removeData(uint256 _id) payable returns()
+func (_Keeper *KeeperTransactor) RemoveData(opts *bind.TransactOpts, _id *big.Int) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "removeData", _id)
+}
+
+// RemoveData is a paid mutator transaction binding the contract method 0xa94840bb.
+//
+// Solidity: function removeData(uint256 _id) payable returns()
+func (_Keeper *KeeperSession) RemoveData(_id *big.Int) (*types.Transaction, error) {
+	return _Keeper.Contract.RemoveData(&_Keeper.TransactOpts, _id)
+}
+
+// RemoveData is a paid mutator transaction binding the contract method 0xa94840bb.
+//
+// Solidity: function removeData(uint256 _id) payable returns()
+func (_Keeper *KeeperTransactorSession) RemoveData(_id *big.Int) (*types.Transaction, error) {
+	return _Keeper.Contract.RemoveData(&_Keeper.TransactOpts, _id)
+}
+
+// RemoveDataBatch is a paid mutator transaction binding the contract method 0x70a294a1.
+//
+// Solidity: function removeDataBatch(uint256[] _ids) payable returns()
+func (_Keeper *KeeperTransactor) RemoveDataBatch(opts *bind.TransactOpts, _ids []*big.Int) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "removeDataBatch", _ids)
+}
+
+// RemoveDataBatch is a paid mutator transaction binding the contract method 0x70a294a1.
+//
+// Solidity: function removeDataBatch(uint256[] _ids) payable returns()
+func (_Keeper *KeeperSession) RemoveDataBatch(_ids []*big.Int) (*types.Transaction, error) {
+	return _Keeper.Contract.RemoveDataBatch(&_Keeper.TransactOpts, _ids)
+}
+
+// RemoveDataBatch is a paid mutator transaction binding the contract method 0x70a294a1.
+//
+// Solidity: function removeDataBatch(uint256[] _ids) payable returns()
+func (_Keeper *KeeperTransactorSession) RemoveDataBatch(_ids []*big.Int) (*types.Transaction, error) {
+	return _Keeper.Contract.RemoveDataBatch(&_Keeper.TransactOpts, _ids)
+}
+
+// RemoveEmergencyContact is a paid mutator transaction binding the contract method 0xcdbef3ed.
+//
+// Solidity: function removeEmergencyContact(address _contact) returns()
+func (_Keeper *KeeperTransactor) RemoveEmergencyContact(opts *bind.TransactOpts, _contact common.Address) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "removeEmergencyContact", _contact)
+}
+
+// RemoveEmergencyContact is a paid mutator transaction binding the contract method 0xcdbef3ed.
+//
+// Solidity: function removeEmergencyContact(address _contact) returns()
+func (_Keeper *KeeperSession) RemoveEmergencyContact(_contact common.Address) (*types.Transaction, error) {
+	return _Keeper.Contract.RemoveEmergencyContact(&_Keeper.TransactOpts, _contact)
+}
+
+// RemoveEmergencyContact is a paid mutator transaction binding the contract method 0xcdbef3ed.
+//
+// Solidity: function removeEmergencyContact(address _contact) returns()
+func (_Keeper *KeeperTransactorSession) RemoveEmergencyContact(_contact common.Address) (*types.Transaction, error) {
+	return _Keeper.Contract.RemoveEmergencyContact(&_Keeper.TransactOpts, _contact)
+}
+
+// RemoveMember is a paid mutator transaction binding the contract method 0x6be7658b.
+//
+// Solidity: function removeMember(uint256 _collection, address _member) returns()
+func (_Keeper *KeeperTransactor) RemoveMember(opts *bind.TransactOpts, _collection *big.Int, _member common.Address) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "removeMember", _collection, _member)
+}
+
+// RemoveMember is a paid mutator transaction binding the contract method 0x6be7658b.
+//
+// Solidity: function removeMember(uint256 _collection, address _member) returns()
+func (_Keeper *KeeperSession) RemoveMember(_collection *big.Int, _member common.Address) (*types.Transaction, error) {
+	return _Keeper.Contract.RemoveMember(&_Keeper.TransactOpts, _collection, _member)
+}
+
+// RemoveMember is a paid mutator transaction binding the contract method 0x6be7658b.
+//
+// Solidity: function removeMember(uint256 _collection, address _member) returns()
+func (_Keeper *KeeperTransactorSession) RemoveMember(_collection *big.Int, _member common.Address) (*types.Transaction, error) {
+	return _Keeper.Contract.RemoveMember(&_Keeper.TransactOpts, _collection, _member)
+}
+
+// RequestEmergencyAccess is a paid mutator transaction binding the contract method 0x53a43eac.
+//
+// Solidity: function requestEmergencyAccess(address _owner) returns()
+func (_Keeper *KeeperTransactor) RequestEmergencyAccess(opts *bind.TransactOpts, _owner common.Address) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "requestEmergencyAccess", _owner)
+}
+
+// RequestEmergencyAccess is a paid mutator transaction binding the contract method 0x53a43eac.
+//
+// Solidity: function requestEmergencyAccess(address _owner) returns()
+func (_Keeper *KeeperSession) RequestEmergencyAccess(_owner common.Address) (*types.Transaction, error) {
+	return _Keeper.Contract.RequestEmergencyAccess(&_Keeper.TransactOpts, _owner)
+}
+
+// RequestEmergencyAccess is a paid mutator transaction binding the contract method 0x53a43eac.
+//
+// Solidity: function requestEmergencyAccess(address _owner) returns()
+func (_Keeper *KeeperTransactorSession) RequestEmergencyAccess(_owner common.Address) (*types.Transaction, error) {
+	return _Keeper.Contract.RequestEmergencyAccess(&_Keeper.TransactOpts, _owner)
+}
+
+// RotateCollectionKey is a paid mutator transaction binding the contract method 0xbad190b3.
+//
+// Solidity: function rotateCollectionKey(uint256 _collection, address[] _members, bytes[] _wrappedKeys) returns()
+func (_Keeper *KeeperTransactor) RotateCollectionKey(opts *bind.TransactOpts, _collection *big.Int, _members []common.Address, _wrappedKeys [][]byte) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "rotateCollectionKey", _collection, _members, _wrappedKeys)
+}
+
+// RotateCollectionKey is a paid mutator transaction binding the contract method 0xbad190b3.
+//
+// Solidity: function rotateCollectionKey(uint256 _collection, address[] _members, bytes[] _wrappedKeys) returns()
+func (_Keeper *KeeperSession) RotateCollectionKey(_collection *big.Int, _members []common.Address, _wrappedKeys [][]byte) (*types.Transaction, error) {
+	return _Keeper.Contract.RotateCollectionKey(&_Keeper.TransactOpts, _collection, _members, _wrappedKeys)
+}
+
+// RotateCollectionKey is a paid mutator transaction binding the contract method 0xbad190b3.
+//
+// Solidity: function rotateCollectionKey(uint256 _collection, address[] _members, bytes[] _wrappedKeys) returns()
+func (_Keeper *KeeperTransactorSession) RotateCollectionKey(_collection *big.Int, _members []common.Address, _wrappedKeys [][]byte) (*types.Transaction, error) {
+	return _Keeper.Contract.RotateCollectionKey(&_Keeper.TransactOpts, _collection, _members, _wrappedKeys)
+}
+
+// SetEmergencyContact is a paid mutator transaction binding the contract method 0xfc66f42d.
+//
+// Solidity: function setEmergencyContact(address _contact, uint256 _waitPeriod, bytes _wrappedKey) returns()
+func (_Keeper *KeeperTransactor) SetEmergencyContact(opts *bind.TransactOpts, _contact common.Address, _waitPeriod *big.Int, _wrappedKey []byte) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "setEmergencyContact", _contact, _waitPeriod, _wrappedKey)
+}
+
+// SetEmergencyContact is a paid mutator transaction binding the contract method 0xfc66f42d.
+//
+// Solidity: function setEmergencyContact(address _contact, uint256 _waitPeriod, bytes _wrappedKey) returns()
+func (_Keeper *KeeperSession) SetEmergencyContact(_contact common.Address, _waitPeriod *big.Int, _wrappedKey []byte) (*types.Transaction, error) {
+	return _Keeper.Contract.SetEmergencyContact(&_Keeper.TransactOpts, _contact, _waitPeriod, _wrappedKey)
+}
+
+// SetEmergencyContact is a paid mutator transaction binding the contract method 0xfc66f42d.
+//
+// Solidity: function setEmergencyContact(address _contact, uint256 _waitPeriod, bytes _wrappedKey) returns()
+func (_Keeper *KeeperTransactorSession) SetEmergencyContact(_contact common.Address, _waitPeriod *big.Int, _wrappedKey []byte) (*types.Transaction, error) {
+	return _Keeper.Contract.SetEmergencyContact(&_Keeper.TransactOpts, _contact, _waitPeriod, _wrappedKey)
+}
+
+// StoreCollectionData is a paid mutator transaction binding the contract method 0xb8a4bcb4.
+//
+// Solidity: function storeCollectionData(uint256 _collection, bytes[] _data) returns(uint256[] ids)
+func (_Keeper *KeeperTransactor) StoreCollectionData(opts *bind.TransactOpts, _collection *big.Int, _data [][]byte) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "storeCollectionData", _collection, _data)
+}
+
+// StoreCollectionData is a paid mutator transaction binding the contract method 0xb8a4bcb4.
+//
+// Solidity: function storeCollectionData(uint256 _collection, bytes[] _data) returns(uint256[] ids)
+func (_Keeper *KeeperSession) StoreCollectionData(_collection *big.Int, _data [][]byte) (*types.Transaction, error) {
+	return _Keeper.Contract.StoreCollectionData(&_Keeper.TransactOpts, _collection, _data)
+}
+
+// StoreCollectionData is a paid mutator transaction binding the contract method 0xb8a4bcb4.
+//
+// Solidity: function storeCollectionData(uint256 _collection, bytes[] _data) returns(uint256[] ids)
+func (_Keeper *KeeperTransactorSession) StoreCollectionData(_collection *big.Int, _data [][]byte) (*types.Transaction, error) {
+	return _Keeper.Contract.StoreCollectionData(&_Keeper.TransactOpts, _collection, _data)
+}
+
+// StoreData is a paid mutator transaction binding the contract method 0xac5c8535.
+//
+// Solidity: function storeData(bytes _data) payable returns(uint256)
+func (_Keeper *KeeperTransactor) StoreData(opts *bind.TransactOpts, _data []byte) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "storeData", _data)
+}
+
+// StoreData is a paid mutator transaction binding the contract method 0xac5c8535.
+//
+// Solidity: function storeData(bytes _data) payable returns(uint256)
+func (_Keeper *KeeperSession) StoreData(_data []byte) (*types.Transaction, error) {
+	return _Keeper.Contract.StoreData(&_Keeper.TransactOpts, _data)
+}
+
+// StoreData is a paid mutator transaction binding the contract method 0xac5c8535.
+//
+// Solidity: function storeData(bytes _data) payable returns(uint256)
+func (_Keeper *KeeperTransactorSession) StoreData(_data []byte) (*types.Transaction, error) {
+	return _Keeper.Contract.StoreData(&_Keeper.TransactOpts, _data)
+}
+
+// StoreDataBatch is a paid mutator transaction binding the contract method 0x1e3e588d.
+//
+// Solidity: function storeDataBatch(bytes[] _data) payable returns(uint256[] ids)
+func (_Keeper *KeeperTransactor) StoreDataBatch(opts *bind.TransactOpts, _data [][]byte) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "storeDataBatch", _data)
+}
+
+// StoreDataBatch is a paid mutator transaction binding the contract method 0x1e3e588d.
+//
+// Solidity: function storeDataBatch(bytes[] _data) payable returns(uint256[] ids)
+func (_Keeper *KeeperSession) StoreDataBatch(_data [][]byte) (*types.Transaction, error) {
+	return _Keeper.Contract.StoreDataBatch(&_Keeper.TransactOpts, _data)
+}
+
+// StoreDataBatch is a paid mutator transaction binding the contract method 0x1e3e588d.
+//
+// Solidity: function storeDataBatch(bytes[] _data) payable returns(uint256[] ids)
+func (_Keeper *KeeperTransactorSession) StoreDataBatch(_data [][]byte) (*types.Transaction, error) {
+	return _Keeper.Contract.StoreDataBatch(&_Keeper.TransactOpts, _data)
+}
+
+// StoreMetaData is a paid mutator transaction binding the contract method 0xd33e9b27.
+//
+// Solidity: function storeMetaData(bytes _data) payable returns()
+func (_Keeper *KeeperTransactor) StoreMetaData(opts *bind.TransactOpts, _data []byte) (*types.Transaction, error) {
+	return _Keeper.contract.Transact(opts, "storeMetaData", _data)
+}
+
+// StoreMetaData is a paid mutator transaction binding the contract method 0xd33e9b27.
+//
+// Solidity: function storeMetaData(bytes _data) payable returns()
+func (_Keeper *KeeperSession) StoreMetaData(_data []byte) (*types.Transaction, error) {
+	return _Keeper.Contract.StoreMetaData(&_Keeper.TransactOpts, _data)
+}
+
+// StoreMetaData is a paid mutator transaction binding the contract method 0xd33e9b27.
+//
+// Solidity: function storeMetaData(bytes _data) payable returns()
+func (_Keeper *KeeperTransactorSession) StoreMetaData(_data []byte) (*types.Transaction, error) {
+	return _Keeper.Contract.StoreMetaData(&_Keeper.TransactOpts, _data)
+}
+
+// KeeperCollectionCreatedIterator is returned from FilterCollectionCreated and is used to iterate over the raw logs and unpacked data for CollectionCreated events raised by the Keeper contract.
+type KeeperCollectionCreatedIterator struct {
+	Event *KeeperCollectionCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperCollectionCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperCollectionCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperCollectionCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperCollectionCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperCollectionCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperCollectionCreated represents a CollectionCreated event raised by the Keeper contract.
+type KeeperCollectionCreated struct {
+	Collection *big.Int
+	Owner      common.Address
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterCollectionCreated is a free log retrieval operation binding the contract event 0x18c1b1c8856053698af995ce4384c0f661562c52a65d6dd29b910849d5e1bfca.
+//
+// Solidity: event CollectionCreated(uint256 indexed collection, address indexed owner)
+func (_Keeper *KeeperFilterer) FilterCollectionCreated(opts *bind.FilterOpts, collection []*big.Int, owner []common.Address) (*KeeperCollectionCreatedIterator, error) {
+
+	var collectionRule []interface{}
+	for _, collectionItem := range collection {
+		collectionRule = append(collectionRule, collectionItem)
+	}
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "CollectionCreated", collectionRule, ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperCollectionCreatedIterator{contract: _Keeper.contract, event: "CollectionCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchCollectionCreated is a free log subscription operation binding the contract event 0x18c1b1c8856053698af995ce4384c0f661562c52a65d6dd29b910849d5e1bfca.
+//
+// Solidity: event CollectionCreated(uint256 indexed collection, address indexed owner)
+func (_Keeper *KeeperFilterer) WatchCollectionCreated(opts *bind.WatchOpts, sink chan<- *KeeperCollectionCreated, collection []*big.Int, owner []common.Address) (event.Subscription, error) {
+
+	var collectionRule []interface{}
+	for _, collectionItem := range collection {
+		collectionRule = append(collectionRule, collectionItem)
+	}
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "CollectionCreated", collectionRule, ownerRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperCollectionCreated)
+				if err := _Keeper.contract.UnpackLog(event, "CollectionCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCollectionCreated is a log parse operation binding the contract event 0x18c1b1c8856053698af995ce4384c0f661562c52a65d6dd29b910849d5e1bfca.
+//
+// Solidity: event CollectionCreated(uint256 indexed collection, address indexed owner)
+func (_Keeper *KeeperFilterer) ParseCollectionCreated(log types.Log) (*KeeperCollectionCreated, error) {
+	event := new(KeeperCollectionCreated)
+	if err := _Keeper.contract.UnpackLog(event, "CollectionCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// KeeperCollectionDataChangedIterator is returned from FilterCollectionDataChanged and is used to iterate over the raw logs and unpacked data for CollectionDataChanged events raised by the Keeper contract.
+type KeeperCollectionDataChangedIterator struct {
+	Event *KeeperCollectionDataChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperCollectionDataChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperCollectionDataChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperCollectionDataChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperCollectionDataChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperCollectionDataChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperCollectionDataChanged represents a CollectionDataChanged event raised by the Keeper contract.
+type KeeperCollectionDataChanged struct {
+	Collection *big.Int
+	Id         *big.Int
+	Revision   *big.Int
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterCollectionDataChanged is a free log retrieval operation binding the contract event 0x6b43c7285f7011659ce579dd69924b366d82a184a7c17af834f1817cf026ef65.
+//
+// Solidity: event CollectionDataChanged(uint256 indexed collection, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) FilterCollectionDataChanged(opts *bind.FilterOpts, collection []*big.Int, id []*big.Int) (*KeeperCollectionDataChangedIterator, error) {
+
+	var collectionRule []interface{}
+	for _, collectionItem := range collection {
+		collectionRule = append(collectionRule, collectionItem)
+	}
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "CollectionDataChanged", collectionRule, idRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperCollectionDataChangedIterator{contract: _Keeper.contract, event: "CollectionDataChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchCollectionDataChanged is a free log subscription operation binding the contract event 0x6b43c7285f7011659ce579dd69924b366d82a184a7c17af834f1817cf026ef65.
+//
+// Solidity: event CollectionDataChanged(uint256 indexed collection, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) WatchCollectionDataChanged(opts *bind.WatchOpts, sink chan<- *KeeperCollectionDataChanged, collection []*big.Int, id []*big.Int) (event.Subscription, error) {
+
+	var collectionRule []interface{}
+	for _, collectionItem := range collection {
+		collectionRule = append(collectionRule, collectionItem)
+	}
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "CollectionDataChanged", collectionRule, idRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperCollectionDataChanged)
+				if err := _Keeper.contract.UnpackLog(event, "CollectionDataChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCollectionDataChanged is a log parse operation binding the contract event 0x6b43c7285f7011659ce579dd69924b366d82a184a7c17af834f1817cf026ef65.
+//
+// Solidity: event CollectionDataChanged(uint256 indexed collection, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) ParseCollectionDataChanged(log types.Log) (*KeeperCollectionDataChanged, error) {
+	event := new(KeeperCollectionDataChanged)
+	if err := _Keeper.contract.UnpackLog(event, "CollectionDataChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// KeeperCollectionDataRemovedIterator is returned from FilterCollectionDataRemoved and is used to iterate over the raw logs and unpacked data for CollectionDataRemoved events raised by the Keeper contract.
+type KeeperCollectionDataRemovedIterator struct {
+	Event *KeeperCollectionDataRemoved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperCollectionDataRemovedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperCollectionDataRemoved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperCollectionDataRemoved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperCollectionDataRemovedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperCollectionDataRemovedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperCollectionDataRemoved represents a CollectionDataRemoved event raised by the Keeper contract.
+type KeeperCollectionDataRemoved struct {
+	Collection *big.Int
+	Id         *big.Int
+	Revision   *big.Int
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterCollectionDataRemoved is a free log retrieval operation binding the contract event 0x7137820915838342b857ccf38987bdb49b382edc8c1ca4b9d206deb76f9e919f.
+//
+// Solidity: event CollectionDataRemoved(uint256 indexed collection, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) FilterCollectionDataRemoved(opts *bind.FilterOpts, collection []*big.Int, id []*big.Int) (*KeeperCollectionDataRemovedIterator, error) {
+
+	var collectionRule []interface{}
+	for _, collectionItem := range collection {
+		collectionRule = append(collectionRule, collectionItem)
+	}
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "CollectionDataRemoved", collectionRule, idRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperCollectionDataRemovedIterator{contract: _Keeper.contract, event: "CollectionDataRemoved", logs: logs, sub: sub}, nil
+}
+
+// WatchCollectionDataRemoved is a free log subscription operation binding the contract event 0x7137820915838342b857ccf38987bdb49b382edc8c1ca4b9d206deb76f9e919f.
+//
+// Solidity: event CollectionDataRemoved(uint256 indexed collection, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) WatchCollectionDataRemoved(opts *bind.WatchOpts, sink chan<- *KeeperCollectionDataRemoved, collection []*big.Int, id []*big.Int) (event.Subscription, error) {
+
+	var collectionRule []interface{}
+	for _, collectionItem := range collection {
+		collectionRule = append(collectionRule, collectionItem)
+	}
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "CollectionDataRemoved", collectionRule, idRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperCollectionDataRemoved)
+				if err := _Keeper.contract.UnpackLog(event, "CollectionDataRemoved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCollectionDataRemoved is a log parse operation binding the contract event 0x7137820915838342b857ccf38987bdb49b382edc8c1ca4b9d206deb76f9e919f.
+//
+// Solidity: event CollectionDataRemoved(uint256 indexed collection, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) ParseCollectionDataRemoved(log types.Log) (*KeeperCollectionDataRemoved, error) {
+	event := new(KeeperCollectionDataRemoved)
+	if err := _Keeper.contract.UnpackLog(event, "CollectionDataRemoved", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// KeeperCollectionDataStoredIterator is returned from FilterCollectionDataStored and is used to iterate over the raw logs and unpacked data for CollectionDataStored events raised by the Keeper contract.
+type KeeperCollectionDataStoredIterator struct {
+	Event *KeeperCollectionDataStored // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperCollectionDataStoredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperCollectionDataStored)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperCollectionDataStored)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperCollectionDataStoredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperCollectionDataStoredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperCollectionDataStored represents a CollectionDataStored event raised by the Keeper contract.
+type KeeperCollectionDataStored struct {
+	Collection *big.Int
+	Id         *big.Int
+	Revision   *big.Int
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterCollectionDataStored is a free log retrieval operation binding the contract event 0x2d2b46bde804105f6c640d5ba1d35b64449657a3031f43e08dc5bd216a4742f7.
+//
+// Solidity: event CollectionDataStored(uint256 indexed collection, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) FilterCollectionDataStored(opts *bind.FilterOpts, collection []*big.Int, id []*big.Int) (*KeeperCollectionDataStoredIterator, error) {
+
+	var collectionRule []interface{}
+	for _, collectionItem := range collection {
+		collectionRule = append(collectionRule, collectionItem)
+	}
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "CollectionDataStored", collectionRule, idRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperCollectionDataStoredIterator{contract: _Keeper.contract, event: "CollectionDataStored", logs: logs, sub: sub}, nil
+}
+
+// WatchCollectionDataStored is a free log subscription operation binding the contract event 0x2d2b46bde804105f6c640d5ba1d35b64449657a3031f43e08dc5bd216a4742f7.
+//
+// Solidity: event CollectionDataStored(uint256 indexed collection, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) WatchCollectionDataStored(opts *bind.WatchOpts, sink chan<- *KeeperCollectionDataStored, collection []*big.Int, id []*big.Int) (event.Subscription, error) {
+
+	var collectionRule []interface{}
+	for _, collectionItem := range collection {
+		collectionRule = append(collectionRule, collectionItem)
+	}
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "CollectionDataStored", collectionRule, idRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperCollectionDataStored)
+				if err := _Keeper.contract.UnpackLog(event, "CollectionDataStored", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCollectionDataStored is a log parse operation binding the contract event 0x2d2b46bde804105f6c640d5ba1d35b64449657a3031f43e08dc5bd216a4742f7.
+//
+// Solidity: event CollectionDataStored(uint256 indexed collection, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) ParseCollectionDataStored(log types.Log) (*KeeperCollectionDataStored, error) {
+	event := new(KeeperCollectionDataStored)
+	if err := _Keeper.contract.UnpackLog(event, "CollectionDataStored", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// KeeperCollectionKeyRotatedIterator is returned from FilterCollectionKeyRotated and is used to iterate over the raw logs and unpacked data for CollectionKeyRotated events raised by the Keeper contract.
+type KeeperCollectionKeyRotatedIterator struct {
+	Event *KeeperCollectionKeyRotated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperCollectionKeyRotatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperCollectionKeyRotated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperCollectionKeyRotated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperCollectionKeyRotatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperCollectionKeyRotatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperCollectionKeyRotated represents a CollectionKeyRotated event raised by the Keeper contract.
+type KeeperCollectionKeyRotated struct {
+	Collection *big.Int
+	Epoch      *big.Int
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterCollectionKeyRotated is a free log retrieval operation binding the contract event 0x8a8c869f2dde9a13b67914faef43e4ab6c390d34584dc6819e6d4435ce75da61.
+//
+// Solidity: event CollectionKeyRotated(uint256 indexed collection, uint256 epoch)
+func (_Keeper *KeeperFilterer) FilterCollectionKeyRotated(opts *bind.FilterOpts, collection []*big.Int) (*KeeperCollectionKeyRotatedIterator, error) {
+
+	var collectionRule []interface{}
+	for _, collectionItem := range collection {
+		collectionRule = append(collectionRule, collectionItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "CollectionKeyRotated", collectionRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperCollectionKeyRotatedIterator{contract: _Keeper.contract, event: "CollectionKeyRotated", logs: logs, sub: sub}, nil
+}
+
+// WatchCollectionKeyRotated is a free log subscription operation binding the contract event 0x8a8c869f2dde9a13b67914faef43e4ab6c390d34584dc6819e6d4435ce75da61.
+//
+// Solidity: event CollectionKeyRotated(uint256 indexed collection, uint256 epoch)
+func (_Keeper *KeeperFilterer) WatchCollectionKeyRotated(opts *bind.WatchOpts, sink chan<- *KeeperCollectionKeyRotated, collection []*big.Int) (event.Subscription, error) {
+
+	var collectionRule []interface{}
+	for _, collectionItem := range collection {
+		collectionRule = append(collectionRule, collectionItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "CollectionKeyRotated", collectionRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperCollectionKeyRotated)
+				if err := _Keeper.contract.UnpackLog(event, "CollectionKeyRotated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseCollectionKeyRotated is a log parse operation binding the contract event 0x8a8c869f2dde9a13b67914faef43e4ab6c390d34584dc6819e6d4435ce75da61.
+//
+// Solidity: event CollectionKeyRotated(uint256 indexed collection, uint256 epoch)
+func (_Keeper *KeeperFilterer) ParseCollectionKeyRotated(log types.Log) (*KeeperCollectionKeyRotated, error) {
+	event := new(KeeperCollectionKeyRotated)
+	if err := _Keeper.contract.UnpackLog(event, "CollectionKeyRotated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// KeeperDataChangedIterator is returned from FilterDataChanged and is used to iterate over the raw logs and unpacked data for DataChanged events raised by the Keeper contract.
+type KeeperDataChangedIterator struct {
+	Event *KeeperDataChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperDataChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperDataChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperDataChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperDataChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperDataChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperDataChanged represents a DataChanged event raised by the Keeper contract.
+type KeeperDataChanged struct {
+	Account  common.Address
+	Id       *big.Int
+	Revision *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterDataChanged is a free log retrieval operation binding the contract event 0xc1254941e18e5f2a133a76f74a84603ad3cbdee983b9ae046b72bfe3dcabfedb.
+//
+// Solidity: event DataChanged(address indexed account, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) FilterDataChanged(opts *bind.FilterOpts, account []common.Address, id []*big.Int) (*KeeperDataChangedIterator, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "DataChanged", accountRule, idRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperDataChangedIterator{contract: _Keeper.contract, event: "DataChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchDataChanged is a free log subscription operation binding the contract event 0xc1254941e18e5f2a133a76f74a84603ad3cbdee983b9ae046b72bfe3dcabfedb.
+//
+// Solidity: event DataChanged(address indexed account, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) WatchDataChanged(opts *bind.WatchOpts, sink chan<- *KeeperDataChanged, account []common.Address, id []*big.Int) (event.Subscription, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "DataChanged", accountRule, idRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperDataChanged)
+				if err := _Keeper.contract.UnpackLog(event, "DataChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDataChanged is a log parse operation binding the contract event 0xc1254941e18e5f2a133a76f74a84603ad3cbdee983b9ae046b72bfe3dcabfedb.
+//
+// Solidity: event DataChanged(address indexed account, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) ParseDataChanged(log types.Log) (*KeeperDataChanged, error) {
+	event := new(KeeperDataChanged)
+	if err := _Keeper.contract.UnpackLog(event, "DataChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// KeeperDataRemovedIterator is returned from FilterDataRemoved and is used to iterate over the raw logs and unpacked data for DataRemoved events raised by the Keeper contract.
+type KeeperDataRemovedIterator struct {
+	Event *KeeperDataRemoved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperDataRemovedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperDataRemoved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperDataRemoved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperDataRemovedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperDataRemovedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperDataRemoved represents a DataRemoved event raised by the Keeper contract.
+type KeeperDataRemoved struct {
+	Account  common.Address
+	Id       *big.Int
+	Revision *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterDataRemoved is a free log retrieval operation binding the contract event 0xad8e51014faa42615a03d18f2643fa16fe64b081206f9c996b9606d655513d9a.
+//
+// Solidity: event DataRemoved(address indexed account, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) FilterDataRemoved(opts *bind.FilterOpts, account []common.Address, id []*big.Int) (*KeeperDataRemovedIterator, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "DataRemoved", accountRule, idRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperDataRemovedIterator{contract: _Keeper.contract, event: "DataRemoved", logs: logs, sub: sub}, nil
+}
+
+// WatchDataRemoved is a free log subscription operation binding the contract event 0xad8e51014faa42615a03d18f2643fa16fe64b081206f9c996b9606d655513d9a.
+//
+// Solidity: event DataRemoved(address indexed account, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) WatchDataRemoved(opts *bind.WatchOpts, sink chan<- *KeeperDataRemoved, account []common.Address, id []*big.Int) (event.Subscription, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "DataRemoved", accountRule, idRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperDataRemoved)
+				if err := _Keeper.contract.UnpackLog(event, "DataRemoved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDataRemoved is a log parse operation binding the contract event 0xad8e51014faa42615a03d18f2643fa16fe64b081206f9c996b9606d655513d9a.
+//
+// Solidity: event DataRemoved(address indexed account, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) ParseDataRemoved(log types.Log) (*KeeperDataRemoved, error) {
+	event := new(KeeperDataRemoved)
+	if err := _Keeper.contract.UnpackLog(event, "DataRemoved", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// KeeperDataStoredIterator is returned from FilterDataStored and is used to iterate over the raw logs and unpacked data for DataStored events raised by the Keeper contract.
+type KeeperDataStoredIterator struct {
+	Event *KeeperDataStored // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperDataStoredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperDataStored)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperDataStored)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperDataStoredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperDataStoredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperDataStored represents a DataStored event raised by the Keeper contract.
+type KeeperDataStored struct {
+	Account  common.Address
+	Id       *big.Int
+	Revision *big.Int
+	Raw      types.Log // Blockchain specific contextual infos
+}
+
+// FilterDataStored is a free log retrieval operation binding the contract event 0x196d776bd3df5dc8f3c877beec22a7b888b8ca883038df0da6b0f3fc9de3816d.
+//
+// Solidity: event DataStored(address indexed account, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) FilterDataStored(opts *bind.FilterOpts, account []common.Address, id []*big.Int) (*KeeperDataStoredIterator, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "DataStored", accountRule, idRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperDataStoredIterator{contract: _Keeper.contract, event: "DataStored", logs: logs, sub: sub}, nil
+}
+
+// WatchDataStored is a free log subscription operation binding the contract event 0x196d776bd3df5dc8f3c877beec22a7b888b8ca883038df0da6b0f3fc9de3816d.
+//
+// Solidity: event DataStored(address indexed account, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) WatchDataStored(opts *bind.WatchOpts, sink chan<- *KeeperDataStored, account []common.Address, id []*big.Int) (event.Subscription, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+	var idRule []interface{}
+	for _, idItem := range id {
+		idRule = append(idRule, idItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "DataStored", accountRule, idRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperDataStored)
+				if err := _Keeper.contract.UnpackLog(event, "DataStored", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseDataStored is a log parse operation binding the contract event 0x196d776bd3df5dc8f3c877beec22a7b888b8ca883038df0da6b0f3fc9de3816d.
+//
+// Solidity: event DataStored(address indexed account, uint256 indexed id, uint256 revision)
+func (_Keeper *KeeperFilterer) ParseDataStored(log types.Log) (*KeeperDataStored, error) {
+	event := new(KeeperDataStored)
+	if err := _Keeper.contract.UnpackLog(event, "DataStored", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// KeeperEmergencyAccessApprovedIterator is returned from FilterEmergencyAccessApproved and is used to iterate over the raw logs and unpacked data for EmergencyAccessApproved events raised by the Keeper contract.
+type KeeperEmergencyAccessApprovedIterator struct {
+	Event *KeeperEmergencyAccessApproved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperEmergencyAccessApprovedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperEmergencyAccessApproved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperEmergencyAccessApproved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperEmergencyAccessApprovedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperEmergencyAccessApprovedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperEmergencyAccessApproved represents a EmergencyAccessApproved event raised by the Keeper contract.
+type KeeperEmergencyAccessApproved struct {
+	Owner   common.Address
+	Contact common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterEmergencyAccessApproved is a free log retrieval operation binding the contract event 0xd1d84093e3c91b668931c0c64f8936d132de78589acace3463ebb098e607cc92.
+//
+// Solidity: event EmergencyAccessApproved(address indexed owner, address indexed contact)
+func (_Keeper *KeeperFilterer) FilterEmergencyAccessApproved(opts *bind.FilterOpts, owner []common.Address, contact []common.Address) (*KeeperEmergencyAccessApprovedIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var contactRule []interface{}
+	for _, contactItem := range contact {
+		contactRule = append(contactRule, contactItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "EmergencyAccessApproved", ownerRule, contactRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperEmergencyAccessApprovedIterator{contract: _Keeper.contract, event: "EmergencyAccessApproved", logs: logs, sub: sub}, nil
+}
+
+// WatchEmergencyAccessApproved is a free log subscription operation binding the contract event 0xd1d84093e3c91b668931c0c64f8936d132de78589acace3463ebb098e607cc92.
+//
+// Solidity: event EmergencyAccessApproved(address indexed owner, address indexed contact)
+func (_Keeper *KeeperFilterer) WatchEmergencyAccessApproved(opts *bind.WatchOpts, sink chan<- *KeeperEmergencyAccessApproved, owner []common.Address, contact []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var contactRule []interface{}
+	for _, contactItem := range contact {
+		contactRule = append(contactRule, contactItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "EmergencyAccessApproved", ownerRule, contactRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperEmergencyAccessApproved)
+				if err := _Keeper.contract.UnpackLog(event, "EmergencyAccessApproved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEmergencyAccessApproved is a log parse operation binding the contract event 0xd1d84093e3c91b668931c0c64f8936d132de78589acace3463ebb098e607cc92.
+//
+// Solidity: event EmergencyAccessApproved(address indexed owner, address indexed contact)
+func (_Keeper *KeeperFilterer) ParseEmergencyAccessApproved(log types.Log) (*KeeperEmergencyAccessApproved, error) {
+	event := new(KeeperEmergencyAccessApproved)
+	if err := _Keeper.contract.UnpackLog(event, "EmergencyAccessApproved", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// KeeperEmergencyAccessRejectedIterator is returned from FilterEmergencyAccessRejected and is used to iterate over the raw logs and unpacked data for EmergencyAccessRejected events raised by the Keeper contract.
+type KeeperEmergencyAccessRejectedIterator struct {
+	Event *KeeperEmergencyAccessRejected // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperEmergencyAccessRejectedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperEmergencyAccessRejected)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperEmergencyAccessRejected)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperEmergencyAccessRejectedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperEmergencyAccessRejectedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperEmergencyAccessRejected represents a EmergencyAccessRejected event raised by the Keeper contract.
+type KeeperEmergencyAccessRejected struct {
+	Owner   common.Address
+	Contact common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterEmergencyAccessRejected is a free log retrieval operation binding the contract event 0x4d8348c050304211c10e920c37959cb2053e19f7be4fdafd8a8093737398d0da.
+//
+// Solidity: event EmergencyAccessRejected(address indexed owner, address indexed contact)
+func (_Keeper *KeeperFilterer) FilterEmergencyAccessRejected(opts *bind.FilterOpts, owner []common.Address, contact []common.Address) (*KeeperEmergencyAccessRejectedIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var contactRule []interface{}
+	for _, contactItem := range contact {
+		contactRule = append(contactRule, contactItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "EmergencyAccessRejected", ownerRule, contactRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperEmergencyAccessRejectedIterator{contract: _Keeper.contract, event: "EmergencyAccessRejected", logs: logs, sub: sub}, nil
+}
+
+// WatchEmergencyAccessRejected is a free log subscription operation binding the contract event 0x4d8348c050304211c10e920c37959cb2053e19f7be4fdafd8a8093737398d0da.
+//
+// Solidity: event EmergencyAccessRejected(address indexed owner, address indexed contact)
+func (_Keeper *KeeperFilterer) WatchEmergencyAccessRejected(opts *bind.WatchOpts, sink chan<- *KeeperEmergencyAccessRejected, owner []common.Address, contact []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var contactRule []interface{}
+	for _, contactItem := range contact {
+		contactRule = append(contactRule, contactItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "EmergencyAccessRejected", ownerRule, contactRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperEmergencyAccessRejected)
+				if err := _Keeper.contract.UnpackLog(event, "EmergencyAccessRejected", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEmergencyAccessRejected is a log parse operation binding the contract event 0x4d8348c050304211c10e920c37959cb2053e19f7be4fdafd8a8093737398d0da.
+//
+// Solidity: event EmergencyAccessRejected(address indexed owner, address indexed contact)
+func (_Keeper *KeeperFilterer) ParseEmergencyAccessRejected(log types.Log) (*KeeperEmergencyAccessRejected, error) {
+	event := new(KeeperEmergencyAccessRejected)
+	if err := _Keeper.contract.UnpackLog(event, "EmergencyAccessRejected", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// KeeperEmergencyAccessRequestedIterator is returned from FilterEmergencyAccessRequested and is used to iterate over the raw logs and unpacked data for EmergencyAccessRequested events raised by the Keeper contract.
+type KeeperEmergencyAccessRequestedIterator struct {
+	Event *KeeperEmergencyAccessRequested // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperEmergencyAccessRequestedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperEmergencyAccessRequested)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperEmergencyAccessRequested)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperEmergencyAccessRequestedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperEmergencyAccessRequestedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperEmergencyAccessRequested represents a EmergencyAccessRequested event raised by the Keeper contract.
+type KeeperEmergencyAccessRequested struct {
+	Owner       common.Address
+	Contact     common.Address
+	AvailableAt *big.Int
+	Raw         types.Log // Blockchain specific contextual infos
+}
+
+// FilterEmergencyAccessRequested is a free log retrieval operation binding the contract event 0x7fc8fd4257367d38aa0b65ebdd0f10a9513f68bc496e04de54c9b8dc480509e3.
+//
+// Solidity: event EmergencyAccessRequested(address indexed owner, address indexed contact, uint256 availableAt)
+func (_Keeper *KeeperFilterer) FilterEmergencyAccessRequested(opts *bind.FilterOpts, owner []common.Address, contact []common.Address) (*KeeperEmergencyAccessRequestedIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var contactRule []interface{}
+	for _, contactItem := range contact {
+		contactRule = append(contactRule, contactItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "EmergencyAccessRequested", ownerRule, contactRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperEmergencyAccessRequestedIterator{contract: _Keeper.contract, event: "EmergencyAccessRequested", logs: logs, sub: sub}, nil
+}
+
+// WatchEmergencyAccessRequested is a free log subscription operation binding the contract event 0x7fc8fd4257367d38aa0b65ebdd0f10a9513f68bc496e04de54c9b8dc480509e3.
+//
+// Solidity: event EmergencyAccessRequested(address indexed owner, address indexed contact, uint256 availableAt)
+func (_Keeper *KeeperFilterer) WatchEmergencyAccessRequested(opts *bind.WatchOpts, sink chan<- *KeeperEmergencyAccessRequested, owner []common.Address, contact []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var contactRule []interface{}
+	for _, contactItem := range contact {
+		contactRule = append(contactRule, contactItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "EmergencyAccessRequested", ownerRule, contactRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperEmergencyAccessRequested)
+				if err := _Keeper.contract.UnpackLog(event, "EmergencyAccessRequested", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEmergencyAccessRequested is a log parse operation binding the contract event 0x7fc8fd4257367d38aa0b65ebdd0f10a9513f68bc496e04de54c9b8dc480509e3.
+//
+// Solidity: event EmergencyAccessRequested(address indexed owner, address indexed contact, uint256 availableAt)
+func (_Keeper *KeeperFilterer) ParseEmergencyAccessRequested(log types.Log) (*KeeperEmergencyAccessRequested, error) {
+	event := new(KeeperEmergencyAccessRequested)
+	if err := _Keeper.contract.UnpackLog(event, "EmergencyAccessRequested", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// KeeperEmergencyContactRemovedIterator is returned from FilterEmergencyContactRemoved and is used to iterate over the raw logs and unpacked data for EmergencyContactRemoved events raised by the Keeper contract.
+type KeeperEmergencyContactRemovedIterator struct {
+	Event *KeeperEmergencyContactRemoved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperEmergencyContactRemovedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperEmergencyContactRemoved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperEmergencyContactRemoved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperEmergencyContactRemovedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperEmergencyContactRemovedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperEmergencyContactRemoved represents a EmergencyContactRemoved event raised by the Keeper contract.
+type KeeperEmergencyContactRemoved struct {
+	Owner   common.Address
+	Contact common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterEmergencyContactRemoved is a free log retrieval operation binding the contract event 0xa7a48872c026fdb2f9027d354e59e51feda5fab5fe39990493fbf10f95c170e9.
+//
+// Solidity: event EmergencyContactRemoved(address indexed owner, address indexed contact)
+func (_Keeper *KeeperFilterer) FilterEmergencyContactRemoved(opts *bind.FilterOpts, owner []common.Address, contact []common.Address) (*KeeperEmergencyContactRemovedIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var contactRule []interface{}
+	for _, contactItem := range contact {
+		contactRule = append(contactRule, contactItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "EmergencyContactRemoved", ownerRule, contactRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperEmergencyContactRemovedIterator{contract: _Keeper.contract, event: "EmergencyContactRemoved", logs: logs, sub: sub}, nil
+}
+
+// WatchEmergencyContactRemoved is a free log subscription operation binding the contract event 0xa7a48872c026fdb2f9027d354e59e51feda5fab5fe39990493fbf10f95c170e9.
+//
+// Solidity: event EmergencyContactRemoved(address indexed owner, address indexed contact)
+func (_Keeper *KeeperFilterer) WatchEmergencyContactRemoved(opts *bind.WatchOpts, sink chan<- *KeeperEmergencyContactRemoved, owner []common.Address, contact []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var contactRule []interface{}
+	for _, contactItem := range contact {
+		contactRule = append(contactRule, contactItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "EmergencyContactRemoved", ownerRule, contactRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperEmergencyContactRemoved)
+				if err := _Keeper.contract.UnpackLog(event, "EmergencyContactRemoved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEmergencyContactRemoved is a log parse operation binding the contract event 0xa7a48872c026fdb2f9027d354e59e51feda5fab5fe39990493fbf10f95c170e9.
+//
+// Solidity: event EmergencyContactRemoved(address indexed owner, address indexed contact)
+func (_Keeper *KeeperFilterer) ParseEmergencyContactRemoved(log types.Log) (*KeeperEmergencyContactRemoved, error) {
+	event := new(KeeperEmergencyContactRemoved)
+	if err := _Keeper.contract.UnpackLog(event, "EmergencyContactRemoved", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// KeeperEmergencyContactSetIterator is returned from FilterEmergencyContactSet and is used to iterate over the raw logs and unpacked data for EmergencyContactSet events raised by the Keeper contract.
+type KeeperEmergencyContactSetIterator struct {
+	Event *KeeperEmergencyContactSet // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperEmergencyContactSetIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperEmergencyContactSet)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperEmergencyContactSet)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperEmergencyContactSetIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperEmergencyContactSetIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperEmergencyContactSet represents a EmergencyContactSet event raised by the Keeper contract.
+type KeeperEmergencyContactSet struct {
+	Owner      common.Address
+	Contact    common.Address
+	WaitPeriod *big.Int
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterEmergencyContactSet is a free log retrieval operation binding the contract event 0x101072ded1cc728adf60778ffd997c9edb79e3841b6e0d57206afdccdc1e7794.
+//
+// Solidity: event EmergencyContactSet(address indexed owner, address indexed contact, uint256 waitPeriod)
+func (_Keeper *KeeperFilterer) FilterEmergencyContactSet(opts *bind.FilterOpts, owner []common.Address, contact []common.Address) (*KeeperEmergencyContactSetIterator, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var contactRule []interface{}
+	for _, contactItem := range contact {
+		contactRule = append(contactRule, contactItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "EmergencyContactSet", ownerRule, contactRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperEmergencyContactSetIterator{contract: _Keeper.contract, event: "EmergencyContactSet", logs: logs, sub: sub}, nil
+}
+
+// WatchEmergencyContactSet is a free log subscription operation binding the contract event 0x101072ded1cc728adf60778ffd997c9edb79e3841b6e0d57206afdccdc1e7794.
+//
+// Solidity: event EmergencyContactSet(address indexed owner, address indexed contact, uint256 waitPeriod)
+func (_Keeper *KeeperFilterer) WatchEmergencyContactSet(opts *bind.WatchOpts, sink chan<- *KeeperEmergencyContactSet, owner []common.Address, contact []common.Address) (event.Subscription, error) {
+
+	var ownerRule []interface{}
+	for _, ownerItem := range owner {
+		ownerRule = append(ownerRule, ownerItem)
+	}
+	var contactRule []interface{}
+	for _, contactItem := range contact {
+		contactRule = append(contactRule, contactItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "EmergencyContactSet", ownerRule, contactRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperEmergencyContactSet)
+				if err := _Keeper.contract.UnpackLog(event, "EmergencyContactSet", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseEmergencyContactSet is a log parse operation binding the contract event 0x101072ded1cc728adf60778ffd997c9edb79e3841b6e0d57206afdccdc1e7794.
+//
+// Solidity: event EmergencyContactSet(address indexed owner, address indexed contact, uint256 waitPeriod)
+func (_Keeper *KeeperFilterer) ParseEmergencyContactSet(log types.Log) (*KeeperEmergencyContactSet, error) {
+	event := new(KeeperEmergencyContactSet)
+	if err := _Keeper.contract.UnpackLog(event, "EmergencyContactSet", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// KeeperMemberAddedIterator is returned from FilterMemberAdded and is used to iterate over the raw logs and unpacked data for MemberAdded events raised by the Keeper contract.
+type KeeperMemberAddedIterator struct {
+	Event *KeeperMemberAdded // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperMemberAddedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperMemberAdded)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperMemberAdded)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperMemberAddedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperMemberAddedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperMemberAdded represents a MemberAdded event raised by the Keeper contract.
+type KeeperMemberAdded struct {
+	Collection *big.Int
+	Member     common.Address
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterMemberAdded is a free log retrieval operation binding the contract event 0xf328ac0f8bcae00933fe87ba0aa2d0d505c1df94bc9c1aa05b8441c28b74032c.
+//
+// Solidity: event MemberAdded(uint256 indexed collection, address indexed member)
+func (_Keeper *KeeperFilterer) FilterMemberAdded(opts *bind.FilterOpts, collection []*big.Int, member []common.Address) (*KeeperMemberAddedIterator, error) {
+
+	var collectionRule []interface{}
+	for _, collectionItem := range collection {
+		collectionRule = append(collectionRule, collectionItem)
+	}
+	var memberRule []interface{}
+	for _, memberItem := range member {
+		memberRule = append(memberRule, memberItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "MemberAdded", collectionRule, memberRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperMemberAddedIterator{contract: _Keeper.contract, event: "MemberAdded", logs: logs, sub: sub}, nil
+}
+
+// WatchMemberAdded is a free log subscription operation binding the contract event 0xf328ac0f8bcae00933fe87ba0aa2d0d505c1df94bc9c1aa05b8441c28b74032c.
+//
+// Solidity: event MemberAdded(uint256 indexed collection, address indexed member)
+func (_Keeper *KeeperFilterer) WatchMemberAdded(opts *bind.WatchOpts, sink chan<- *KeeperMemberAdded, collection []*big.Int, member []common.Address) (event.Subscription, error) {
+
+	var collectionRule []interface{}
+	for _, collectionItem := range collection {
+		collectionRule = append(collectionRule, collectionItem)
+	}
+	var memberRule []interface{}
+	for _, memberItem := range member {
+		memberRule = append(memberRule, memberItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "MemberAdded", collectionRule, memberRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperMemberAdded)
+				if err := _Keeper.contract.UnpackLog(event, "MemberAdded", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMemberAdded is a log parse operation binding the contract event 0xf328ac0f8bcae00933fe87ba0aa2d0d505c1df94bc9c1aa05b8441c28b74032c.
+//
+// Solidity: event MemberAdded(uint256 indexed collection, address indexed member)
+func (_Keeper *KeeperFilterer) ParseMemberAdded(log types.Log) (*KeeperMemberAdded, error) {
+	event := new(KeeperMemberAdded)
+	if err := _Keeper.contract.UnpackLog(event, "MemberAdded", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// KeeperMemberRemovedIterator is returned from FilterMemberRemoved and is used to iterate over the raw logs and unpacked data for MemberRemoved events raised by the Keeper contract.
+type KeeperMemberRemovedIterator struct {
+	Event *KeeperMemberRemoved // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperMemberRemovedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperMemberRemoved)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperMemberRemoved)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperMemberRemovedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperMemberRemovedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperMemberRemoved represents a MemberRemoved event raised by the Keeper contract.
+type KeeperMemberRemoved struct {
+	Collection *big.Int
+	Member     common.Address
+	Raw        types.Log // Blockchain specific contextual infos
+}
+
+// FilterMemberRemoved is a free log retrieval operation binding the contract event 0x1c4c9d2e56d0635d11bc47c997c6909a0d7061f55cbb8f4b27386db37553191c.
+//
+// Solidity: event MemberRemoved(uint256 indexed collection, address indexed member)
+func (_Keeper *KeeperFilterer) FilterMemberRemoved(opts *bind.FilterOpts, collection []*big.Int, member []common.Address) (*KeeperMemberRemovedIterator, error) {
+
+	var collectionRule []interface{}
+	for _, collectionItem := range collection {
+		collectionRule = append(collectionRule, collectionItem)
+	}
+	var memberRule []interface{}
+	for _, memberItem := range member {
+		memberRule = append(memberRule, memberItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "MemberRemoved", collectionRule, memberRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperMemberRemovedIterator{contract: _Keeper.contract, event: "MemberRemoved", logs: logs, sub: sub}, nil
+}
+
+// WatchMemberRemoved is a free log subscription operation binding the contract event 0x1c4c9d2e56d0635d11bc47c997c6909a0d7061f55cbb8f4b27386db37553191c.
+//
+// Solidity: event MemberRemoved(uint256 indexed collection, address indexed member)
+func (_Keeper *KeeperFilterer) WatchMemberRemoved(opts *bind.WatchOpts, sink chan<- *KeeperMemberRemoved, collection []*big.Int, member []common.Address) (event.Subscription, error) {
+
+	var collectionRule []interface{}
+	for _, collectionItem := range collection {
+		collectionRule = append(collectionRule, collectionItem)
+	}
+	var memberRule []interface{}
+	for _, memberItem := range member {
+		memberRule = append(memberRule, memberItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "MemberRemoved", collectionRule, memberRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperMemberRemoved)
+				if err := _Keeper.contract.UnpackLog(event, "MemberRemoved", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMemberRemoved is a log parse operation binding the contract event 0x1c4c9d2e56d0635d11bc47c997c6909a0d7061f55cbb8f4b27386db37553191c.
+//
+// Solidity: event MemberRemoved(uint256 indexed collection, address indexed member)
+func (_Keeper *KeeperFilterer) ParseMemberRemoved(log types.Log) (*KeeperMemberRemoved, error) {
+	event := new(KeeperMemberRemoved)
+	if err := _Keeper.contract.UnpackLog(event, "MemberRemoved", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// KeeperMetaDataStoredIterator is returned from FilterMetaDataStored and is used to iterate over the raw logs and unpacked data for MetaDataStored events raised by the Keeper contract.
+type KeeperMetaDataStoredIterator struct {
+	Event *KeeperMetaDataStored // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperMetaDataStoredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperMetaDataStored)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperMetaDataStored)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperMetaDataStoredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperMetaDataStoredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperMetaDataStored represents a MetaDataStored event raised by the Keeper contract.
+type KeeperMetaDataStored struct {
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterMetaDataStored is a free log retrieval operation binding the contract event 0x1c9e0430f876dbfdbf27fee31d685c2404fb5c2fef6e72576b7442057c2c89c0.
+//
+// Solidity: event MetaDataStored(address indexed account)
+func (_Keeper *KeeperFilterer) FilterMetaDataStored(opts *bind.FilterOpts, account []common.Address) (*KeeperMetaDataStoredIterator, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "MetaDataStored", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperMetaDataStoredIterator{contract: _Keeper.contract, event: "MetaDataStored", logs: logs, sub: sub}, nil
+}
+
+// WatchMetaDataStored is a free log subscription operation binding the contract event 0x1c9e0430f876dbfdbf27fee31d685c2404fb5c2fef6e72576b7442057c2c89c0.
+//
+// Solidity: event MetaDataStored(address indexed account)
+func (_Keeper *KeeperFilterer) WatchMetaDataStored(opts *bind.WatchOpts, sink chan<- *KeeperMetaDataStored, account []common.Address) (event.Subscription, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "MetaDataStored", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperMetaDataStored)
+				if err := _Keeper.contract.UnpackLog(event, "MetaDataStored", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMetaDataStored is a log parse operation binding the contract event 0x1c9e0430f876dbfdbf27fee31d685c2404fb5c2fef6e72576b7442057c2c89c0.
+//
+// Solidity: event MetaDataStored(address indexed account)
+func (_Keeper *KeeperFilterer) ParseMetaDataStored(log types.Log) (*KeeperMetaDataStored, error) {
+	event := new(KeeperMetaDataStored)
+	if err := _Keeper.contract.UnpackLog(event, "MetaDataStored", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// KeeperPublicKeyRegisteredIterator is returned from FilterPublicKeyRegistered and is used to iterate over the raw logs and unpacked data for PublicKeyRegistered events raised by the Keeper contract.
+type KeeperPublicKeyRegisteredIterator struct {
+	Event *KeeperPublicKeyRegistered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *KeeperPublicKeyRegisteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(KeeperPublicKeyRegistered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(KeeperPublicKeyRegistered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *KeeperPublicKeyRegisteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *KeeperPublicKeyRegisteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// KeeperPublicKeyRegistered represents a PublicKeyRegistered event raised by the Keeper contract.
+type KeeperPublicKeyRegistered struct {
+	Account common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterPublicKeyRegistered is a free log retrieval operation binding the contract event 0xfa3256bc03b03ee751ee91c53b7318d6e4321ab4d09757c08a50a3f65f678300.
+//
+// Solidity: event PublicKeyRegistered(address indexed account)
+func (_Keeper *KeeperFilterer) FilterPublicKeyRegistered(opts *bind.FilterOpts, account []common.Address) (*KeeperPublicKeyRegisteredIterator, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _Keeper.contract.FilterLogs(opts, "PublicKeyRegistered", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperPublicKeyRegisteredIterator{contract: _Keeper.contract, event: "PublicKeyRegistered", logs: logs, sub: sub}, nil
+}
+
+// WatchPublicKeyRegistered is a free log subscription operation binding the contract event 0xfa3256bc03b03ee751ee91c53b7318d6e4321ab4d09757c08a50a3f65f678300.
+//
+// Solidity: event PublicKeyRegistered(address indexed account)
+func (_Keeper *KeeperFilterer) WatchPublicKeyRegistered(opts *bind.WatchOpts, sink chan<- *KeeperPublicKeyRegistered, account []common.Address) (event.Subscription, error) {
+
+	var accountRule []interface{}
+	for _, accountItem := range account {
+		accountRule = append(accountRule, accountItem)
+	}
+
+	logs, sub, err := _Keeper.contract.WatchLogs(opts, "PublicKeyRegistered", accountRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(KeeperPublicKeyRegistered)
+				if err := _Keeper.contract.UnpackLog(event, "PublicKeyRegistered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParsePublicKeyRegistered is a log parse operation binding the contract event 0xfa3256bc03b03ee751ee91c53b7318d6e4321ab4d09757c08a50a3f65f678300.
+//
+// Solidity: event PublicKeyRegistered(address indexed account)
+func (_Keeper *KeeperFilterer) ParsePublicKeyRegistered(log types.Log) (*KeeperPublicKeyRegistered, error) {
+	event := new(KeeperPublicKeyRegistered)
+	if err := _Keeper.contract.UnpackLog(event, "PublicKeyRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
 }
@@ -0,0 +1,389 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package blockchain
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// KeeperV1MetaData contains all meta data concerning the KeeperV1 contract.
+var KeeperV1MetaData = &bind.MetaData{
+	ABI: "[{\"type\":\"function\",\"name\":\"activeIdsForUser\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"changeData\",\"inputs\":[{\"name\":\"_id\",\"type\":\"uint256\",\"internalType\":\"uint256\"},{\"name\":\"_newData\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"nextDataId\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"removeData\",\"inputs\":[{\"name\":\"_id\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"storeData\",\"inputs\":[{\"name\":\"_data\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"storeMetaData\",\"inputs\":[{\"name\":\"_data\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"outputs\":[],\"stateMutability\":\"payable\"},{\"type\":\"function\",\"name\":\"userData\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"stateMutability\":\"view\"},{\"type\":\"function\",\"name\":\"userMetaData\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"}],\"outputs\":[{\"name\":\"\",\"type\":\"bytes\",\"internalType\":\"bytes\"}],\"stateMutability\":\"view\"},{\"type\":\"error\",\"name\":\"CannotChangeNonExistentData\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"CannotRemoveNonExistentData\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"CannotStoreExistingData\",\"inputs\":[{\"name\":\"\",\"type\":\"address\",\"internalType\":\"address\"},{\"name\":\"\",\"type\":\"uint256\",\"internalType\":\"uint256\"}]},{\"type\":\"error\",\"name\":\"InvalidDataLength\",\"inputs\":[]}]",
+}
+
+// KeeperV1ABI is the input ABI used to generate the binding from.
+// Deprecated: Use KeeperV1MetaData.ABI instead.
+var KeeperV1ABI = KeeperV1MetaData.ABI
+
+// KeeperV1 is an auto generated Go binding around an Ethereum contract.
+type KeeperV1 struct {
+	KeeperV1Caller     // Read-only binding to the contract
+	KeeperV1Transactor // Write-only binding to the contract
+	KeeperV1Filterer   // Log filterer for contract events
+}
+
+// KeeperV1Caller is an auto generated read-only Go binding around an Ethereum contract.
+type KeeperV1Caller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// KeeperV1Transactor is an auto generated write-only Go binding around an Ethereum contract.
+type KeeperV1Transactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// KeeperV1Filterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type KeeperV1Filterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// KeeperV1Session is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type KeeperV1Session struct {
+	Contract     *KeeperV1         // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// KeeperV1CallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type KeeperV1CallerSession struct {
+	Contract *KeeperV1Caller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts   // Call options to use throughout this session
+}
+
+// KeeperV1TransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type KeeperV1TransactorSession struct {
+	Contract     *KeeperV1Transactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts   // Transaction auth options to use throughout this session
+}
+
+// KeeperV1Raw is an auto generated low-level Go binding around an Ethereum contract.
+type KeeperV1Raw struct {
+	Contract *KeeperV1 // Generic contract binding to access the raw methods on
+}
+
+// KeeperV1CallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type KeeperV1CallerRaw struct {
+	Contract *KeeperV1Caller // Generic read-only contract binding to access the raw methods on
+}
+
+// KeeperV1TransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type KeeperV1TransactorRaw struct {
+	Contract *KeeperV1Transactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewKeeperV1 creates a new instance of KeeperV1, bound to a specific deployed contract.
+func NewKeeperV1(address common.Address, backend bind.ContractBackend) (*KeeperV1, error) {
+	contract, err := bindKeeperV1(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperV1{KeeperV1Caller: KeeperV1Caller{contract: contract}, KeeperV1Transactor: KeeperV1Transactor{contract: contract}, KeeperV1Filterer: KeeperV1Filterer{contract: contract}}, nil
+}
+
+// NewKeeperV1Caller creates a new read-only instance of KeeperV1, bound to a specific deployed contract.
+func NewKeeperV1Caller(address common.Address, caller bind.ContractCaller) (*KeeperV1Caller, error) {
+	contract, err := bindKeeperV1(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperV1Caller{contract: contract}, nil
+}
+
+// NewKeeperV1Transactor creates a new write-only instance of KeeperV1, bound to a specific deployed contract.
+func NewKeeperV1Transactor(address common.Address, transactor bind.ContractTransactor) (*KeeperV1Transactor, error) {
+	contract, err := bindKeeperV1(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperV1Transactor{contract: contract}, nil
+}
+
+// NewKeeperV1Filterer creates a new log filterer instance of KeeperV1, bound to a specific deployed contract.
+func NewKeeperV1Filterer(address common.Address, filterer bind.ContractFilterer) (*KeeperV1Filterer, error) {
+	contract, err := bindKeeperV1(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &KeeperV1Filterer{contract: contract}, nil
+}
+
+// bindKeeperV1 binds a generic wrapper to an already deployed contract.
+func bindKeeperV1(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := KeeperV1MetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_KeeperV1 *KeeperV1Raw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _KeeperV1.Contract.KeeperV1Caller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_KeeperV1 *KeeperV1Raw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _KeeperV1.Contract.KeeperV1Transactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_KeeperV1 *KeeperV1Raw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _KeeperV1.Contract.KeeperV1Transactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_KeeperV1 *KeeperV1CallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _KeeperV1.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_KeeperV1 *KeeperV1TransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _KeeperV1.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_KeeperV1 *KeeperV1TransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _KeeperV1.Contract.contract.Transact(opts, method, params...)
+}
+
+// ActiveIdsForUser is a free data retrieval call binding the contract method 0x0592f5d5.
+//
+// Solidity: function activeIdsForUser(address , uint256 ) view returns(uint256)
+func (_KeeperV1 *KeeperV1Caller) ActiveIdsForUser(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _KeeperV1.contract.Call(opts, &out, "activeIdsForUser", arg0, arg1)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// ActiveIdsForUser is a free data retrieval call binding the contract method 0x0592f5d5.
+//
+// Solidity: function activeIdsForUser(address , uint256 ) view returns(uint256)
+func (_KeeperV1 *KeeperV1Session) ActiveIdsForUser(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _KeeperV1.Contract.ActiveIdsForUser(&_KeeperV1.CallOpts, arg0, arg1)
+}
+
+// ActiveIdsForUser is a free data retrieval call binding the contract method 0x0592f5d5.
+//
+// Solidity: function activeIdsForUser(address , uint256 ) view returns(uint256)
+func (_KeeperV1 *KeeperV1CallerSession) ActiveIdsForUser(arg0 common.Address, arg1 *big.Int) (*big.Int, error) {
+	return _KeeperV1.Contract.ActiveIdsForUser(&_KeeperV1.CallOpts, arg0, arg1)
+}
+
+// NextDataId is a free data retrieval call binding the contract method 0x63ee461d.
+//
+// Solidity: function nextDataId(address ) view returns(uint256)
+func (_KeeperV1 *KeeperV1Caller) NextDataId(opts *bind.CallOpts, arg0 common.Address) (*big.Int, error) {
+	var out []interface{}
+	err := _KeeperV1.contract.Call(opts, &out, "nextDataId", arg0)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// NextDataId is a free data retrieval call binding the contract method 0x63ee461d.
+//
+// Solidity: function nextDataId(address ) view returns(uint256)
+func (_KeeperV1 *KeeperV1Session) NextDataId(arg0 common.Address) (*big.Int, error) {
+	return _KeeperV1.Contract.NextDataId(&_KeeperV1.CallOpts, arg0)
+}
+
+// NextDataId is a free data retrieval call binding the contract method 0x63ee461d.
+//
+// Solidity: function nextDataId(address ) view returns(uint256)
+func (_KeeperV1 *KeeperV1CallerSession) NextDataId(arg0 common.Address) (*big.Int, error) {
+	return _KeeperV1.Contract.NextDataId(&_KeeperV1.CallOpts, arg0)
+}
+
+// UserData is a free data retrieval call binding the contract method 0x3c05eca1.
+//
+// Solidity: function userData(address , uint256 ) view returns(bytes)
+func (_KeeperV1 *KeeperV1Caller) UserData(opts *bind.CallOpts, arg0 common.Address, arg1 *big.Int) ([]byte, error) {
+	var out []interface{}
+	err := _KeeperV1.contract.Call(opts, &out, "userData", arg0, arg1)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// UserData is a free data retrieval call binding the contract method 0x3c05eca1.
+//
+// Solidity: function userData(address , uint256 ) view returns(bytes)
+func (_KeeperV1 *KeeperV1Session) UserData(arg0 common.Address, arg1 *big.Int) ([]byte, error) {
+	return _KeeperV1.Contract.UserData(&_KeeperV1.CallOpts, arg0, arg1)
+}
+
+// UserData is a free data retrieval call binding the contract method 0x3c05eca1.
+//
+// Solidity: function userData(address , uint256 ) view returns(bytes)
+func (_KeeperV1 *KeeperV1CallerSession) UserData(arg0 common.Address, arg1 *big.Int) ([]byte, error) {
+	return _KeeperV1.Contract.UserData(&_KeeperV1.CallOpts, arg0, arg1)
+}
+
+// UserMetaData is a free data retrieval call binding the contract method 0x6192b6b0.
+//
+// Solidity: function userMetaData(address ) view returns(bytes)
+func (_KeeperV1 *KeeperV1Caller) UserMetaData(opts *bind.CallOpts, arg0 common.Address) ([]byte, error) {
+	var out []interface{}
+	err := _KeeperV1.contract.Call(opts, &out, "userMetaData", arg0)
+
+	if err != nil {
+		return *new([]byte), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]byte)).(*[]byte)
+
+	return out0, err
+
+}
+
+// UserMetaData is a free data retrieval call binding the contract method 0x6192b6b0.
+//
+// Solidity: function userMetaData(address ) view returns(bytes)
+func (_KeeperV1 *KeeperV1Session) UserMetaData(arg0 common.Address) ([]byte, error) {
+	return _KeeperV1.Contract.UserMetaData(&_KeeperV1.CallOpts, arg0)
+}
+
+// UserMetaData is a free data retrieval call binding the contract method 0x6192b6b0.
+//
+// Solidity: function userMetaData(address ) view returns(bytes)
+func (_KeeperV1 *KeeperV1CallerSession) UserMetaData(arg0 common.Address) ([]byte, error) {
+	return _KeeperV1.Contract.UserMetaData(&_KeeperV1.CallOpts, arg0)
+}
+
+// ChangeData is a paid mutator transaction binding the contract method 0xf2836502.
+//
+// Solidity: function changeData(uint256 _id, bytes _newData) payable returns()
+func (_KeeperV1 *KeeperV1Transactor) ChangeData(opts *bind.TransactOpts, _id *big.Int, _newData []byte) (*types.Transaction, error) {
+	return _KeeperV1.contract.Transact(opts, "changeData", _id, _newData)
+}
+
+// ChangeData is a paid mutator transaction binding the contract method 0xf2836502.
+//
+// Solidity: function changeData(uint256 _id, bytes _newData) payable returns()
+func (_KeeperV1 *KeeperV1Session) ChangeData(_id *big.Int, _newData []byte) (*types.Transaction, error) {
+	return _KeeperV1.Contract.ChangeData(&_KeeperV1.TransactOpts, _id, _newData)
+}
+
+// ChangeData is a paid mutator transaction binding the contract method 0xf2836502.
+//
+// Solidity: function changeData(uint256 _id, bytes _newData) payable returns()
+func (_KeeperV1 *KeeperV1TransactorSession) ChangeData(_id *big.Int, _newData []byte) (*types.Transaction, error) {
+	return _KeeperV1.Contract.ChangeData(&_KeeperV1.TransactOpts, _id, _newData)
+}
+
+// RemoveData is a paid mutator transaction binding the contract method 0xa94840bb.
+//
+// Solidity: function removeData(uint256 _id) payable returns()
+func (_KeeperV1 *KeeperV1Transactor) RemoveData(opts *bind.TransactOpts, _id *big.Int) (*types.Transaction, error) {
+	return _KeeperV1.contract.Transact(opts, "removeData", _id)
+}
+
+// RemoveData is a paid mutator transaction binding the contract method 0xa94840bb.
+//
+// Solidity: function removeData(uint256 _id) payable returns()
+func (_KeeperV1 *KeeperV1Session) RemoveData(_id *big.Int) (*types.Transaction, error) {
+	return _KeeperV1.Contract.RemoveData(&_KeeperV1.TransactOpts, _id)
+}
+
+// RemoveData is a paid mutator transaction binding the contract method 0xa94840bb.
+//
+// Solidity: function removeData(uint256 _id) payable returns()
+func (_KeeperV1 *KeeperV1TransactorSession) RemoveData(_id *big.Int) (*types.Transaction, error) {
+	return _KeeperV1.Contract.RemoveData(&_KeeperV1.TransactOpts, _id)
+}
+
+// StoreData is a paid mutator transaction binding the contract method 0xac5c8535.
+//
+// Solidity: function storeData(bytes _data) payable returns()
+func (_KeeperV1 *KeeperV1Transactor) StoreData(opts *bind.TransactOpts, _data []byte) (*types.Transaction, error) {
+	return _KeeperV1.contract.Transact(opts, "storeData", _data)
+}
+
+// StoreData is a paid mutator transaction binding the contract method 0xac5c8535.
+//
+// Solidity: function storeData(bytes _data) payable returns()
+func (_KeeperV1 *KeeperV1Session) StoreData(_data []byte) (*types.Transaction, error) {
+	return _KeeperV1.Contract.StoreData(&_KeeperV1.TransactOpts, _data)
+}
+
+// StoreData is a paid mutator transaction binding the contract method 0xac5c8535.
+//
+// Solidity: function storeData(bytes _data) payable returns()
+func (_KeeperV1 *KeeperV1TransactorSession) StoreData(_data []byte) (*types.Transaction, error) {
+	return _KeeperV1.Contract.StoreData(&_KeeperV1.TransactOpts, _data)
+}
+
+// StoreMetaData is a paid mutator transaction binding the contract method 0xd33e9b27.
+//
+// Solidity: function storeMetaData(bytes _data) payable returns()
+func (_KeeperV1 *KeeperV1Transactor) StoreMetaData(opts *bind.TransactOpts, _data []byte) (*types.Transaction, error) {
+	return _KeeperV1.contract.Transact(opts, "storeMetaData", _data)
+}
+
+// StoreMetaData is a paid mutator transaction binding the contract method 0xd33e9b27.
+//
+// Solidity: function storeMetaData(bytes _data) payable returns()
+func (_KeeperV1 *KeeperV1Session) StoreMetaData(_data []byte) (*types.Transaction, error) {
+	return _KeeperV1.Contract.StoreMetaData(&_KeeperV1.TransactOpts, _data)
+}
+
+// StoreMetaData is a paid mutator transaction binding the contract method 0xd33e9b27.
+//
+// Solidity: function storeMetaData(bytes _data) payable returns()
+func (_KeeperV1 *KeeperV1TransactorSession) StoreMetaData(_data []byte) (*types.Transaction, error) {
+	return _KeeperV1.Contract.StoreMetaData(&_KeeperV1.TransactOpts, _data)
+}
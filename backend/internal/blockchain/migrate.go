@@ -0,0 +1,209 @@
+package blockchain
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const migrationBatchSize = 20
+
+type MigrationProgress struct {
+	Stage string `json:"stage"` // "read", "metadata", "entries" or "verify"
+	Done  int    `json:"done"`
+	Total int    `json:"total"`
+}
+
+type MigrationReport struct {
+	From           string `json:"from"`
+	To             string `json:"to"`
+	FromVersion    int    `json:"from_version"`
+	ToVersion      int    `json:"to_version"`
+	Entries        int    `json:"entries"`
+	Copied         int    `json:"copied"`
+	Skipped        int    `json:"skipped"` // already present on the target, e.g. after an interrupted run
+	MetadataCopied bool   `json:"metadata_copied"`
+	Verified       bool   `json:"verified"`
+	Transactions   int    `json:"transactions"`
+	GasUsed        uint64 `json:"gas_used"`
+}
+
+func (r *MigrationReport) record(result *TransactionResult) {
+	r.Transactions++
+	r.GasUsed += result.GasUsed
+}
+
+// MigrateTo copies the session user's metadata and active entries from the
+// current deployment to the Keeper at toAddress. Blobs are copied as-is
+// (they are already encrypted), entries already present on the target are
+// skipped so an interrupted migration can simply be rerun, and the target
+// is read back afterwards to verify every blob arrived.
+func (c *Client) MigrateTo(ctx context.Context, toAddress string, progress func(MigrationProgress)) (*MigrationReport, error) {
+	session := c.currentSession()
+	if session == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+	from := c.keeper()
+
+	target, err := NewKeeperContract(ctx, c.client, toAddress)
+	if err != nil {
+		return nil, err
+	}
+	if target.address == from.address {
+		return nil, fmt.Errorf("%w: source and target are the same contract", ErrMigrationFailed)
+	}
+
+	notify := func(stage string, done, total int) {
+		if progress != nil {
+			progress(MigrationProgress{Stage: stage, Done: done, Total: total})
+		}
+	}
+
+	user := session.Address
+	report := &MigrationReport{
+		From:        from.Address(),
+		To:          target.Address(),
+		FromVersion: from.Version(),
+		ToVersion:   target.Version(),
+	}
+
+	metadata, err := from.GetUserMetadata(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := from.GetActiveIds(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	source := make([][]byte, 0, len(ids))
+	for i, id := range ids {
+		data, err := from.GetUserData(ctx, user, id)
+		if err != nil {
+			return nil, err
+		}
+		source = append(source, data)
+		notify("read", i+1, len(ids))
+	}
+	report.Entries = len(source)
+
+	present, err := collectDataHashes(ctx, target, user)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending [][]byte
+	for _, data := range source {
+		hash := crypto.Keccak256Hash(data)
+		if present[hash] > 0 {
+			present[hash]--
+			report.Skipped++
+			continue
+		}
+		pending = append(pending, data)
+	}
+
+	if len(metadata) > 0 {
+		targetMetadata, err := target.GetUserMetadata(ctx, user)
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(metadata, targetMetadata) {
+			auth, release, err := c.createAuth(session.Signer)
+			if err != nil {
+				return nil, err
+			}
+			result, err := target.StoreMetadata(ctx, auth, metadata)
+			release()
+			if err != nil {
+				return nil, err
+			}
+			report.record(result)
+			report.MetadataCopied = true
+		}
+	}
+	notify("metadata", 1, 1)
+
+	for start := 0; start < len(pending); {
+		auth, release, err := c.createAuth(session.Signer)
+		if err != nil {
+			return report, err
+		}
+
+		var result *TransactionResult
+		n := 1
+		if target.SupportsBatch() {
+			n = min(migrationBatchSize, len(pending)-start)
+			auth.GasLimit = 0
+			result, err = target.StoreDataBatch(ctx, auth, pending[start:start+n])
+		} else {
+			result, err = target.StoreData(ctx, auth, pending[start])
+		}
+		release()
+		if err != nil {
+			return report, err
+		}
+
+		report.record(result)
+		report.Copied += n
+		start += n
+		notify("entries", start, len(pending))
+	}
+
+	if err := verifyMigration(ctx, target, user, metadata, source); err != nil {
+		return report, err
+	}
+	report.Verified = true
+	notify("verify", 1, 1)
+
+	return report, nil
+}
+
+func collectDataHashes(ctx context.Context, k *KeeperContract, user string) (map[common.Hash]int, error) {
+	ids, err := k.GetActiveIds(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make(map[common.Hash]int, len(ids))
+	for _, id := range ids {
+		data, err := k.GetUserData(ctx, user, id)
+		if err != nil {
+			return nil, err
+		}
+		hashes[crypto.Keccak256Hash(data)]++
+	}
+
+	return hashes, nil
+}
+
+func verifyMigration(ctx context.Context, target *KeeperContract, user string, metadata []byte, source [][]byte) error {
+	if len(metadata) > 0 {
+		targetMetadata, err := target.GetUserMetadata(ctx, user)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(metadata, targetMetadata) {
+			return fmt.Errorf("%w: metadata mismatch on target", ErrMigrationFailed)
+		}
+	}
+
+	present, err := collectDataHashes(ctx, target, user)
+	if err != nil {
+		return err
+	}
+
+	for _, data := range source {
+		hash := crypto.Keccak256Hash(data)
+		if present[hash] == 0 {
+			return fmt.Errorf("%w: entry %s missing on target", ErrMigrationFailed, hash.Hex())
+		}
+		present[hash]--
+	}
+
+	return nil
+}
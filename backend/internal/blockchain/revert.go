@@ -0,0 +1,195 @@
+package blockchain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	revertSelector = crypto.Keccak256([]byte("Error(string)"))[:4]
+	panicSelector  = crypto.Keccak256([]byte("Panic(uint256)"))[:4]
+)
+
+// panicArrayOutOfBounds is the Solidity panic code for an out-of-bounds
+// array index.
+const panicArrayOutOfBounds = 0x32
+
+// keeperError describes how a Keeper custom error is reported.
+type keeperError struct {
+	name      string
+	errorType string
+	message   string
+	code      int
+	sentinel  error
+}
+
+var keeperErrors = []keeperError{
+	{"InvalidDataLength", "INVALID_DATA_LENGTH", "Data length must be greater than 0", 2001, ErrInvalidDataLength},
+	{"CannotStoreExistingData", "CANNOT_STORE_EXISTING_DATA", "Cannot store data at existing ID", 2002, ErrCannotStoreExistingData},
+	{"CannotChangeNonExistentData", "CANNOT_CHANGE_NON_EXISTENT_DATA", "Cannot change non-existent data", 2003, ErrCannotChangeNonExistentData},
+	{"CannotRemoveNonExistentData", "CANNOT_REMOVE_NON_EXISTENT_DATA", "Cannot remove non-existent data", 2004, ErrCannotRemoveNonExistentData},
+	{"BatchLengthMismatch", "BATCH_LENGTH_MISMATCH", "Batch ids and data length mismatch", 2005, ErrBatchLengthMismatch},
+	{"InvalidPublicKey", "INVALID_PUBLIC_KEY", "Public key does not match the sender", 2006, ErrInvalidPublicKey},
+	{"UnknownCollection", "UNKNOWN_COLLECTION", "Collection does not exist", 2007, ErrUnknownCollection},
+	{"NotCollectionOwner", "NOT_COLLECTION_OWNER", "Only the collection owner can do this", 2008, ErrNotCollectionOwner},
+	{"NotCollectionMember", "NOT_COLLECTION_MEMBER", "Account is not a collection member", 2009, ErrNotCollectionMember},
+	{"AlreadyCollectionMember", "ALREADY_COLLECTION_MEMBER", "Account is already a collection member", 2010, ErrAlreadyCollectionMember},
+	{"CannotRemoveCollectionOwner", "CANNOT_REMOVE_COLLECTION_OWNER", "The collection owner cannot be removed", 2011, ErrCannotRemoveCollectionOwner},
+	{"NotEmergencyContact", "NOT_EMERGENCY_CONTACT", "Account is not an emergency contact of the owner", 2012, ErrNotEmergencyContact},
+	{"EmergencyRequestPending", "EMERGENCY_REQUEST_PENDING", "Emergency access was already requested", 2013, ErrEmergencyRequestPending},
+	{"NoEmergencyRequest", "NO_EMERGENCY_REQUEST", "The contact has not requested emergency access", 2014, ErrNoEmergencyRequest},
+	{"EmergencyAccessNotGranted", "EMERGENCY_ACCESS_NOT_GRANTED", "Emergency access is not granted yet", 2015, ErrEmergencyAccessNotGranted},
+}
+
+func lookupKeeperError(name string) (keeperError, bool) {
+	for _, e := range keeperErrors {
+		if e.name == name {
+			return e, true
+		}
+	}
+	return keeperError{}, false
+}
+
+// ContractError is a revert decoded from its ABI-encoded data: one of the
+// Keeper custom errors, Error(string) or Panic(uint256). It unwraps to the
+// matching sentinel, e.g. ErrCannotChangeNonExistentData, or to
+// ErrTransactionReverted for reverts that are not Keeper errors.
+type ContractError struct {
+	Name      string         `json:"name"`
+	Args      []interface{}  `json:"args"`
+	Account   common.Address `json:"account"`    // zero unless the error names an account
+	ID        *big.Int       `json:"id"`         // offending data or collection ID, nil unless the error names one
+	Reason    string         `json:"reason"`     // Error(string) message or panic description
+	PanicCode *big.Int       `json:"panic_code"` // set for Panic(uint256) only
+}
+
+func (e *ContractError) Error() string {
+	if e.Reason != "" {
+		return fmt.Sprintf("execution reverted: %s", e.Reason)
+	}
+
+	args := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		args[i] = fmt.Sprint(arg)
+	}
+	return fmt.Sprintf("execution reverted: %s(%s)", e.Name, strings.Join(args, ", "))
+}
+
+func (e *ContractError) Unwrap() error {
+	if known, ok := lookupKeeperError(e.Name); ok {
+		return known.sentinel
+	}
+	return ErrTransactionReverted
+}
+
+// DecodeRevertData decodes revert data returned by a node against the
+// Keeper ABI. It returns nil for data that is neither a Keeper error nor a
+// standard Error/Panic.
+func DecodeRevertData(data []byte) *ContractError {
+	if len(data) < 4 {
+		return nil
+	}
+
+	if bytes.Equal(data[:4], revertSelector) || bytes.Equal(data[:4], panicSelector) {
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			return nil
+		}
+		if bytes.Equal(data[:4], revertSelector) {
+			return &ContractError{Name: "Error", Args: []interface{}{reason}, Reason: reason}
+		}
+		return &ContractError{
+			Name:      "Panic",
+			Args:      []interface{}{new(big.Int).SetBytes(data[4:])},
+			Reason:    reason,
+			PanicCode: new(big.Int).SetBytes(data[4:]),
+		}
+	}
+
+	parsed, err := KeeperMetaData.GetAbi()
+	if err != nil {
+		return nil
+	}
+	for name, abiErr := range parsed.Errors {
+		if !bytes.Equal(data[:4], abiErr.ID[:4]) {
+			continue
+		}
+		unpacked, err := abiErr.Unpack(data)
+		if err != nil {
+			return nil
+		}
+		args, _ := unpacked.([]interface{})
+
+		decoded := &ContractError{Name: name, Args: args}
+		switch len(args) {
+		case 1:
+			decoded.ID, _ = args[0].(*big.Int)
+			decoded.Account, _ = args[0].(common.Address)
+		case 2, 3:
+			// data errors name (account, id), collection errors (collection,
+			// account), emergency errors (owner, contact[, availableAt])
+			if account, ok := args[0].(common.Address); ok {
+				decoded.Account = account
+				decoded.ID, _ = args[1].(*big.Int)
+			} else if account, ok := args[1].(common.Address); ok {
+				decoded.Account = account
+				decoded.ID, _ = args[0].(*big.Int)
+			}
+		}
+		return decoded
+	}
+
+	return nil
+}
+
+// DecodeRevertError extracts and decodes the revert data carried by an RPC
+// error, looking through wrapped errors.
+func DecodeRevertError(err error) *ContractError {
+	var decoded *ContractError
+	if errors.As(err, &decoded) {
+		return decoded
+	}
+
+	data, ok := revertData(err)
+	if !ok {
+		return nil
+	}
+	return DecodeRevertData(data)
+}
+
+// revertData returns the data field of a JSON-RPC error, which nodes fill
+// with the raw revert payload of a failed call or estimate.
+func revertData(err error) ([]byte, bool) {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return nil, false
+	}
+
+	switch data := dataErr.ErrorData().(type) {
+	case string:
+		raw, err := hexutil.Decode(data)
+		if err != nil {
+			return nil, false
+		}
+		return raw, true
+	case []byte:
+		return data, true
+	}
+	return nil, false
+}
+
+// isArrayOutOfBounds reports whether err is the panic raised by reading
+// past the end of a public array getter.
+func isArrayOutOfBounds(err error) bool {
+	decoded := DecodeRevertError(err)
+	return decoded != nil && decoded.PanicCode != nil && decoded.PanicCode.Cmp(big.NewInt(panicArrayOutOfBounds)) == 0
+}
@@ -0,0 +1,490 @@
+package blockchain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/rand/v2"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Backend is the node connection used by Client and KeeperContract. Both
+// *ethclient.Client and *RPCPool satisfy it.
+type Backend interface {
+	bind.ContractBackend
+	bind.DeployBackend
+	ChainID(ctx context.Context) (*big.Int, error)
+	NetworkID(ctx context.Context) (*big.Int, error)
+	Close()
+}
+
+// RetryPolicy controls retries of transient RPC failures and when an
+// endpoint is taken out of rotation.
+type RetryPolicy struct {
+	MaxAttempts      int           `json:"max_attempts"`
+	BaseDelay        time.Duration `json:"base_delay"`
+	MaxDelay         time.Duration `json:"max_delay"`
+	FailureThreshold int           `json:"failure_threshold"` // consecutive failures that open an endpoint's circuit
+	Cooldown         time.Duration `json:"cooldown"`          // how long an open circuit skips the endpoint
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:      5,
+		BaseDelay:        250 * time.Millisecond,
+		MaxDelay:         8 * time.Second,
+		FailureThreshold: 3,
+		Cooldown:         time.Minute,
+	}
+}
+
+// backoff returns the wait before retry number attempt (1-based):
+// exponential in attempt, capped at MaxDelay, with jitter over the upper half.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	half := delay / 2
+	return half + rand.N(half+1)
+}
+
+// EndpointStatus is the health of one RPC endpoint as seen by the pool.
+type EndpointStatus struct {
+	URL       string        `json:"url"`
+	Healthy   bool          `json:"healthy"`
+	Failures  int           `json:"failures"`
+	OpenUntil time.Time     `json:"open_until"`
+	LastError string        `json:"last_error,omitempty"`
+	Latency   time.Duration `json:"latency"`
+	CheckedAt time.Time     `json:"checked_at"`
+}
+
+type endpoint struct {
+	url    string
+	client *ethclient.Client
+
+	verified bool  // chain ID checked against the pool's expectation
+	disabled error // set for good when the endpoint serves another chain
+
+	failures  int
+	openUntil time.Time
+	lastError error
+	latency   time.Duration
+	checkedAt time.Time
+}
+
+func (e *endpoint) open(now time.Time) bool {
+	return now.Before(e.openUntil)
+}
+
+func (e *endpoint) usable() bool {
+	return e.client != nil && e.disabled == nil
+}
+
+// RPCPool is a Backend spread over several endpoints. Calls go to the
+// current endpoint; transient failures (see IsTransient) move to the next
+// one and are retried with backoff, and an endpoint that keeps failing is
+// skipped until its cooldown ends. Reverts and other definite answers are
+// returned at once.
+type RPCPool struct {
+	mu        sync.Mutex
+	endpoints []*endpoint
+	current   int
+	policy    RetryPolicy
+	chainID   *big.Int // expected chain, nil accepts any
+}
+
+func NewRPCPool(urls []string, policy RetryPolicy) (*RPCPool, error) {
+	if policy.MaxAttempts <= 0 {
+		policy = DefaultRetryPolicy()
+	}
+
+	pool := &RPCPool{policy: policy}
+	for _, url := range urls {
+		ep := &endpoint{url: url}
+		client, err := ethclient.Dial(url)
+		if err != nil {
+			ep.lastError = err
+			ep.failures = policy.FailureThreshold
+			ep.openUntil = time.Now().Add(policy.Cooldown)
+		}
+		ep.client = client
+		pool.endpoints = append(pool.endpoints, ep)
+	}
+
+	if pool.usable() == 0 {
+		pool.Close()
+		return nil, ErrConnectionFailed
+	}
+	return pool, nil
+}
+
+func (p *RPCPool) usable() int {
+	n := 0
+	for _, ep := range p.endpoints {
+		if ep.usable() {
+			n++
+		}
+	}
+	return n
+}
+
+// ExpectChainID makes the pool use only endpoints reporting chainID.
+// Each endpoint is checked before its first call and by every health
+// check; one serving another chain is disabled for good.
+func (p *RPCPool) ExpectChainID(chainID int64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.chainID = big.NewInt(chainID)
+	for _, ep := range p.endpoints {
+		ep.verified = false
+		ep.disabled = nil
+	}
+}
+
+// verify checks the chain ID of ep if the pool expects one and ep has not
+// been checked yet.
+func (p *RPCPool) verify(ctx context.Context, ep *endpoint) error {
+	p.mu.Lock()
+	expected, verified := p.chainID, ep.verified
+	p.mu.Unlock()
+	if expected == nil || verified {
+		return nil
+	}
+
+	chainID, err := ep.client.ChainID(ctx)
+	if err != nil {
+		return err
+	}
+	return p.checkChainID(ep, chainID)
+}
+
+func (p *RPCPool) checkChainID(ep *endpoint, chainID *big.Int) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.chainID == nil {
+		return nil
+	}
+	if chainID.Cmp(p.chainID) != 0 {
+		ep.disabled = fmt.Errorf("%w: %s serves chain %s, expected %s", ErrInvalidChainID, ep.url, chainID, p.chainID)
+		ep.lastError = ep.disabled
+		return ep.disabled
+	}
+	ep.verified = true
+	return nil
+}
+
+// unavailable explains why no endpoint can be picked.
+func (p *RPCPool) unavailable() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, ep := range p.endpoints {
+		if ep.disabled != nil {
+			return ep.disabled
+		}
+	}
+	return ErrConnectionFailed
+}
+
+// pick returns the first endpoint from current on whose circuit is closed.
+// If every circuit is open the one reopening soonest is tried anyway.
+func (p *RPCPool) pick() *endpoint {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var soonest *endpoint
+	for i := range p.endpoints {
+		idx := (p.current + i) % len(p.endpoints)
+		ep := p.endpoints[idx]
+		if !ep.usable() {
+			continue
+		}
+		if !ep.open(now) {
+			p.current = idx
+			return ep
+		}
+		if soonest == nil || ep.openUntil.Before(soonest.openUntil) {
+			soonest = ep
+		}
+	}
+	return soonest
+}
+
+func (p *RPCPool) succeeded(ep *endpoint) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ep.failures = 0
+	ep.openUntil = time.Time{}
+	ep.lastError = nil
+}
+
+func (p *RPCPool) failed(ep *endpoint, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ep.failures++
+	ep.lastError = err
+	if ep.failures >= p.policy.FailureThreshold {
+		ep.openUntil = time.Now().Add(p.policy.Cooldown)
+	}
+	// fail over right away, the next attempt goes to another endpoint
+	if p.endpoints[p.current] == ep {
+		p.current = (p.current + 1) % len(p.endpoints)
+	}
+}
+
+// do runs call against the pool, retrying transient failures.
+func (p *RPCPool) do(ctx context.Context, call func(*ethclient.Client) error) error {
+	var lastErr error
+	for attempt := 0; attempt < p.policy.MaxAttempts; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(p.policy.backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return lastErr
+			case <-timer.C:
+			}
+		}
+
+		ep := p.pick()
+		if ep == nil {
+			return p.unavailable()
+		}
+
+		err := p.verify(ctx, ep)
+		if errors.Is(err, ErrInvalidChainID) {
+			attempt-- // a wrong chain costs no attempt, pick skips the endpoint from now on
+			continue
+		}
+		if err == nil {
+			err = call(ep.client)
+		}
+		if err == nil || !IsTransient(err) {
+			// a revert or rejection is still an answer from a healthy node
+			p.succeeded(ep)
+			return err
+		}
+		p.failed(ep, err)
+		lastErr = err
+
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return lastErr
+}
+
+// HealthCheck probes every endpoint with eth_chainId, updating the
+// circuits and checking the chain, and returns the resulting status.
+func (p *RPCPool) HealthCheck(ctx context.Context) []EndpointStatus {
+	var wg sync.WaitGroup
+	for _, ep := range p.endpoints {
+		if !ep.usable() {
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			start := time.Now()
+			chainID, err := ep.client.ChainID(ctx)
+			if err == nil {
+				if p.checkChainID(ep, chainID) != nil {
+					return
+				}
+			}
+
+			p.mu.Lock()
+			ep.latency = time.Since(start)
+			ep.checkedAt = time.Now()
+			p.mu.Unlock()
+
+			if err != nil {
+				p.failed(ep, err)
+				return
+			}
+			p.succeeded(ep)
+		}()
+	}
+	wg.Wait()
+
+	return p.Status()
+}
+
+// RunHealthChecks probes the endpoints every interval until ctx is done.
+func (p *RPCPool) RunHealthChecks(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.HealthCheck(ctx)
+		}
+	}
+}
+
+func (p *RPCPool) Status() []EndpointStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	status := make([]EndpointStatus, len(p.endpoints))
+	for i, ep := range p.endpoints {
+		status[i] = EndpointStatus{
+			URL:       ep.url,
+			Healthy:   ep.usable() && ep.failures == 0 && !ep.open(now),
+			Failures:  ep.failures,
+			OpenUntil: ep.openUntil,
+			Latency:   ep.latency,
+			CheckedAt: ep.checkedAt,
+		}
+		if ep.lastError != nil {
+			status[i].LastError = ep.lastError.Error()
+		}
+	}
+	return status
+}
+
+func (p *RPCPool) Close() {
+	for _, ep := range p.endpoints {
+		if ep.client != nil {
+			ep.client.Close()
+		}
+	}
+}
+
+func (p *RPCPool) ChainID(ctx context.Context) (id *big.Int, err error) {
+	err = p.do(ctx, func(c *ethclient.Client) error {
+		id, err = c.ChainID(ctx)
+		return err
+	})
+	return id, err
+}
+
+func (p *RPCPool) NetworkID(ctx context.Context) (id *big.Int, err error) {
+	err = p.do(ctx, func(c *ethclient.Client) error {
+		id, err = c.NetworkID(ctx)
+		return err
+	})
+	return id, err
+}
+
+func (p *RPCPool) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) (code []byte, err error) {
+	err = p.do(ctx, func(c *ethclient.Client) error {
+		code, err = c.CodeAt(ctx, account, blockNumber)
+		return err
+	})
+	return code, err
+}
+
+func (p *RPCPool) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) (out []byte, err error) {
+	err = p.do(ctx, func(c *ethclient.Client) error {
+		out, err = c.CallContract(ctx, call, blockNumber)
+		return err
+	})
+	return out, err
+}
+
+func (p *RPCPool) HeaderByNumber(ctx context.Context, number *big.Int) (header *types.Header, err error) {
+	err = p.do(ctx, func(c *ethclient.Client) error {
+		header, err = c.HeaderByNumber(ctx, number)
+		return err
+	})
+	return header, err
+}
+
+func (p *RPCPool) PendingCodeAt(ctx context.Context, account common.Address) (code []byte, err error) {
+	err = p.do(ctx, func(c *ethclient.Client) error {
+		code, err = c.PendingCodeAt(ctx, account)
+		return err
+	})
+	return code, err
+}
+
+func (p *RPCPool) PendingNonceAt(ctx context.Context, account common.Address) (nonce uint64, err error) {
+	err = p.do(ctx, func(c *ethclient.Client) error {
+		nonce, err = c.PendingNonceAt(ctx, account)
+		return err
+	})
+	return nonce, err
+}
+
+func (p *RPCPool) SuggestGasPrice(ctx context.Context) (price *big.Int, err error) {
+	err = p.do(ctx, func(c *ethclient.Client) error {
+		price, err = c.SuggestGasPrice(ctx)
+		return err
+	})
+	return price, err
+}
+
+func (p *RPCPool) SuggestGasTipCap(ctx context.Context) (tip *big.Int, err error) {
+	err = p.do(ctx, func(c *ethclient.Client) error {
+		tip, err = c.SuggestGasTipCap(ctx)
+		return err
+	})
+	return tip, err
+}
+
+func (p *RPCPool) EstimateGas(ctx context.Context, call ethereum.CallMsg) (gas uint64, err error) {
+	err = p.do(ctx, func(c *ethclient.Client) error {
+		gas, err = c.EstimateGas(ctx, call)
+		return err
+	})
+	return gas, err
+}
+
+// SendTransaction resends the same signed transaction on retries. A node
+// reporting it as already known means an earlier attempt got through.
+func (p *RPCPool) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	attempt := 0
+	return p.do(ctx, func(c *ethclient.Client) error {
+		err := c.SendTransaction(ctx, tx)
+		if err != nil && attempt > 0 && strings.Contains(err.Error(), "already known") {
+			err = nil
+		}
+		attempt++
+		return err
+	})
+}
+
+func (p *RPCPool) TransactionReceipt(ctx context.Context, txHash common.Hash) (receipt *types.Receipt, err error) {
+	err = p.do(ctx, func(c *ethclient.Client) error {
+		receipt, err = c.TransactionReceipt(ctx, txHash)
+		return err
+	})
+	return receipt, err
+}
+
+func (p *RPCPool) FilterLogs(ctx context.Context, query ethereum.FilterQuery) (logs []types.Log, err error) {
+	err = p.do(ctx, func(c *ethclient.Client) error {
+		logs, err = c.FilterLogs(ctx, query)
+		return err
+	})
+	return logs, err
+}
+
+func (p *RPCPool) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (sub ethereum.Subscription, err error) {
+	err = p.do(ctx, func(c *ethclient.Client) error {
+		sub, err = c.SubscribeFilterLogs(ctx, query, ch)
+		return err
+	})
+	return sub, err
+}
@@ -3,8 +3,12 @@ package blockchain
 import (
 	"context"
 	"math/big"
+	"time"
 
+	"encryptkeep-backend/internal/codec"
 	"encryptkeep-backend/internal/vault"
+
+	"github.com/ethereum/go-ethereum"
 )
 
 type BlockchainService interface {
@@ -13,7 +17,8 @@ type BlockchainService interface {
 	GetStatus() (*SyncStatus, error)
 	IsConnected() bool
 
-	StartSession(privateKeyHex string, masterPassword string) (*Session, error)
+	StartSession(signer Signer, key *codec.VaultKey) (*Session, error)
+	EndSession()
 
 	StoreMetadata(ctx context.Context, data []byte) (*TransactionResult, error)
 	GetUserMetadata(ctx context.Context, userAddress string) ([]byte, error)
@@ -23,6 +28,48 @@ type BlockchainService interface {
 	RemoveData(ctx context.Context, dataID *big.Int) (*TransactionResult, error)
 	GetUserData(ctx context.Context, userAddress string, dataID *big.Int) ([]byte, error)
 	GetActiveIds(ctx context.Context, userAddress string) ([]*big.Int, error)
+	GetDataRevision(ctx context.Context, userAddress string, dataID *big.Int) (*big.Int, error)
+
+	StoreDataBatch(ctx context.Context, data [][]byte) (*TransactionResult, error)
+	ChangeDataBatch(ctx context.Context, dataIDs []*big.Int, data [][]byte) (*TransactionResult, error)
+	RemoveDataBatch(ctx context.Context, dataIDs []*big.Int) (*TransactionResult, error)
+
+	EstimateStoreMetadata(ctx context.Context, data []byte) (*CostEstimate, error)
+	EstimateStoreData(ctx context.Context, data []byte) (*CostEstimate, error)
+	EstimateChangeData(ctx context.Context, dataID *big.Int, data []byte) (*CostEstimate, error)
+	EstimateRemoveData(ctx context.Context, dataID *big.Int) (*CostEstimate, error)
+	EstimateStoreDataBatch(ctx context.Context, data [][]byte) (*CostEstimate, error)
+	EstimateChangeDataBatch(ctx context.Context, dataIDs []*big.Int, data [][]byte) (*CostEstimate, error)
+	EstimateRemoveDataBatch(ctx context.Context, dataIDs []*big.Int) (*CostEstimate, error)
+
+	RegisterPublicKey(ctx context.Context) (*TransactionResult, error)
+	GetPublicKey(ctx context.Context, account string) ([]byte, error)
+	CreateCollection(ctx context.Context, wrappedKey []byte) (*TransactionResult, error)
+	GetCollection(ctx context.Context, collectionID *big.Int) (*CollectionInfo, error)
+	GetCollectionKey(ctx context.Context, collectionID *big.Int, member string) ([]byte, error)
+	AddCollectionMember(ctx context.Context, collectionID *big.Int, member string, wrappedKey []byte) (*TransactionResult, error)
+	RemoveCollectionMember(ctx context.Context, collectionID *big.Int, member string) (*TransactionResult, error)
+	RotateCollectionKey(ctx context.Context, collectionID *big.Int, members []string, wrappedKeys [][]byte) (*TransactionResult, error)
+	StoreCollectionData(ctx context.Context, collectionID *big.Int, data [][]byte) (*TransactionResult, error)
+	ChangeCollectionData(ctx context.Context, collectionID *big.Int, dataIDs []*big.Int, data [][]byte) (*TransactionResult, error)
+	RemoveCollectionData(ctx context.Context, collectionID *big.Int, dataIDs []*big.Int) (*TransactionResult, error)
+	GetCollectionIds(ctx context.Context, collectionID *big.Int) ([]*big.Int, error)
+	GetCollectionData(ctx context.Context, collectionID, dataID *big.Int) ([]byte, error)
+
+	SetEmergencyContact(ctx context.Context, contact string, waitPeriod time.Duration, wrappedKey []byte) (*TransactionResult, error)
+	RemoveEmergencyContact(ctx context.Context, contact string) (*TransactionResult, error)
+	GetEmergencyContacts(ctx context.Context, owner string) ([]string, error)
+	GetEmergencyAccess(ctx context.Context, owner, contact string) (*EmergencyAccess, error)
+	RequestEmergencyAccess(ctx context.Context, owner string) (*TransactionResult, error)
+	ApproveEmergencyAccess(ctx context.Context, contact string) (*TransactionResult, error)
+	RejectEmergencyAccess(ctx context.Context, contact string) (*TransactionResult, error)
+	GetEmergencyKey(ctx context.Context, owner, contact string) ([]byte, error)
 
 	SyncVault(v *vault.LocalVault) error
+	LatestBlock(ctx context.Context) (uint64, error)
+	VaultChanges(ctx context.Context, account string, fromBlock, toBlock uint64) ([]VaultChange, error)
+	WatchVault(ctx context.Context, account string, ch chan<- VaultChange) (ethereum.Subscription, error)
+
+	MigrateTo(ctx context.Context, toAddress string, progress func(MigrationProgress)) (*MigrationReport, error)
+	UseContract(ctx context.Context, contractAddress string) error
 }
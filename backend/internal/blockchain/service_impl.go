@@ -7,6 +7,8 @@ import (
 
 	"encryptkeep-backend/internal/codec"
 	"encryptkeep-backend/internal/vault"
+
+	"github.com/ethereum/go-ethereum"
 )
 
 type BlockchainServiceImpl struct {
@@ -51,11 +53,19 @@ func (bs *BlockchainServiceImpl) IsConnected() bool {
 	return bs.client != nil
 }
 
-func (bs *BlockchainServiceImpl) StartSession(privateKeyHex string, masterPassword string) (*Session, error) {
+func (bs *BlockchainServiceImpl) StartSession(signer Signer, key *codec.VaultKey) (*Session, error) {
 	if bs.client == nil {
 		return nil, ErrNotConnected
 	}
-	return bs.client.CreateSession(privateKeyHex, masterPassword)
+	return bs.client.CreateSession(signer, key)
+}
+
+// EndSession forgets the session's signer and vault key; writes and
+// SyncVault fail until StartSession is called again.
+func (bs *BlockchainServiceImpl) EndSession() {
+	if bs.client != nil {
+		bs.client.ClearSession()
+	}
 }
 
 func (bs *BlockchainServiceImpl) StoreMetadata(ctx context.Context, data []byte) (*TransactionResult, error) {
@@ -107,13 +117,237 @@ func (bs *BlockchainServiceImpl) GetActiveIds(ctx context.Context, userAddress s
 	return bs.client.GetActiveIds(ctx, userAddress)
 }
 
+func (bs *BlockchainServiceImpl) GetDataRevision(ctx context.Context, userAddress string, dataID *big.Int) (*big.Int, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.GetDataRevision(ctx, userAddress, dataID)
+}
+
+func (bs *BlockchainServiceImpl) StoreDataBatch(ctx context.Context, data [][]byte) (*TransactionResult, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.StoreDataBatch(ctx, data)
+}
+
+func (bs *BlockchainServiceImpl) ChangeDataBatch(ctx context.Context, dataIDs []*big.Int, data [][]byte) (*TransactionResult, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.ChangeDataBatch(ctx, dataIDs, data)
+}
+
+func (bs *BlockchainServiceImpl) RemoveDataBatch(ctx context.Context, dataIDs []*big.Int) (*TransactionResult, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.RemoveDataBatch(ctx, dataIDs)
+}
+
+func (bs *BlockchainServiceImpl) EstimateStoreMetadata(ctx context.Context, data []byte) (*CostEstimate, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.EstimateStoreMetadata(ctx, data)
+}
+
+func (bs *BlockchainServiceImpl) EstimateStoreData(ctx context.Context, data []byte) (*CostEstimate, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.EstimateStoreData(ctx, data)
+}
+
+func (bs *BlockchainServiceImpl) EstimateChangeData(ctx context.Context, dataID *big.Int, data []byte) (*CostEstimate, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.EstimateChangeData(ctx, dataID, data)
+}
+
+func (bs *BlockchainServiceImpl) EstimateRemoveData(ctx context.Context, dataID *big.Int) (*CostEstimate, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.EstimateRemoveData(ctx, dataID)
+}
+
+func (bs *BlockchainServiceImpl) EstimateStoreDataBatch(ctx context.Context, data [][]byte) (*CostEstimate, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.EstimateStoreDataBatch(ctx, data)
+}
+
+func (bs *BlockchainServiceImpl) EstimateChangeDataBatch(ctx context.Context, dataIDs []*big.Int, data [][]byte) (*CostEstimate, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.EstimateChangeDataBatch(ctx, dataIDs, data)
+}
+
+func (bs *BlockchainServiceImpl) EstimateRemoveDataBatch(ctx context.Context, dataIDs []*big.Int) (*CostEstimate, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.EstimateRemoveDataBatch(ctx, dataIDs)
+}
+
+func (bs *BlockchainServiceImpl) RegisterPublicKey(ctx context.Context) (*TransactionResult, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.RegisterPublicKey(ctx)
+}
+
+func (bs *BlockchainServiceImpl) GetPublicKey(ctx context.Context, account string) ([]byte, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.GetPublicKey(ctx, account)
+}
+
+func (bs *BlockchainServiceImpl) CreateCollection(ctx context.Context, wrappedKey []byte) (*TransactionResult, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.CreateCollection(ctx, wrappedKey)
+}
+
+func (bs *BlockchainServiceImpl) GetCollection(ctx context.Context, collectionID *big.Int) (*CollectionInfo, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.GetCollection(ctx, collectionID)
+}
+
+func (bs *BlockchainServiceImpl) GetCollectionKey(ctx context.Context, collectionID *big.Int, member string) ([]byte, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.GetCollectionKey(ctx, collectionID, member)
+}
+
+func (bs *BlockchainServiceImpl) AddCollectionMember(ctx context.Context, collectionID *big.Int, member string, wrappedKey []byte) (*TransactionResult, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.AddCollectionMember(ctx, collectionID, member, wrappedKey)
+}
+
+func (bs *BlockchainServiceImpl) RemoveCollectionMember(ctx context.Context, collectionID *big.Int, member string) (*TransactionResult, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.RemoveCollectionMember(ctx, collectionID, member)
+}
+
+func (bs *BlockchainServiceImpl) RotateCollectionKey(ctx context.Context, collectionID *big.Int, members []string, wrappedKeys [][]byte) (*TransactionResult, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.RotateCollectionKey(ctx, collectionID, members, wrappedKeys)
+}
+
+func (bs *BlockchainServiceImpl) StoreCollectionData(ctx context.Context, collectionID *big.Int, data [][]byte) (*TransactionResult, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.StoreCollectionData(ctx, collectionID, data)
+}
+
+func (bs *BlockchainServiceImpl) ChangeCollectionData(ctx context.Context, collectionID *big.Int, dataIDs []*big.Int, data [][]byte) (*TransactionResult, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.ChangeCollectionData(ctx, collectionID, dataIDs, data)
+}
+
+func (bs *BlockchainServiceImpl) RemoveCollectionData(ctx context.Context, collectionID *big.Int, dataIDs []*big.Int) (*TransactionResult, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.RemoveCollectionData(ctx, collectionID, dataIDs)
+}
+
+func (bs *BlockchainServiceImpl) GetCollectionIds(ctx context.Context, collectionID *big.Int) ([]*big.Int, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.GetCollectionIds(ctx, collectionID)
+}
+
+func (bs *BlockchainServiceImpl) GetCollectionData(ctx context.Context, collectionID, dataID *big.Int) ([]byte, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.GetCollectionData(ctx, collectionID, dataID)
+}
+
+func (bs *BlockchainServiceImpl) SetEmergencyContact(ctx context.Context, contact string, waitPeriod time.Duration, wrappedKey []byte) (*TransactionResult, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.SetEmergencyContact(ctx, contact, waitPeriod, wrappedKey)
+}
+
+func (bs *BlockchainServiceImpl) RemoveEmergencyContact(ctx context.Context, contact string) (*TransactionResult, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.RemoveEmergencyContact(ctx, contact)
+}
+
+func (bs *BlockchainServiceImpl) GetEmergencyContacts(ctx context.Context, owner string) ([]string, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.GetEmergencyContacts(ctx, owner)
+}
+
+func (bs *BlockchainServiceImpl) GetEmergencyAccess(ctx context.Context, owner, contact string) (*EmergencyAccess, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.GetEmergencyAccess(ctx, owner, contact)
+}
+
+func (bs *BlockchainServiceImpl) RequestEmergencyAccess(ctx context.Context, owner string) (*TransactionResult, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.RequestEmergencyAccess(ctx, owner)
+}
+
+func (bs *BlockchainServiceImpl) ApproveEmergencyAccess(ctx context.Context, contact string) (*TransactionResult, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.ApproveEmergencyAccess(ctx, contact)
+}
+
+func (bs *BlockchainServiceImpl) RejectEmergencyAccess(ctx context.Context, contact string) (*TransactionResult, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.RejectEmergencyAccess(ctx, contact)
+}
+
+func (bs *BlockchainServiceImpl) GetEmergencyKey(ctx context.Context, owner, contact string) ([]byte, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.GetEmergencyKey(ctx, owner, contact)
+}
+
 func (bs *BlockchainServiceImpl) SyncVault(v *vault.LocalVault) error {
 	if bs.client == nil {
 		return ErrNotConnected
 	}
 
 	session := bs.client.GetSession()
-	if session == nil || session.MasterPassword == "" {
+	if session == nil || session.VaultKey == nil {
 		return ErrInvalidPrivateKey
 	}
 	ctx := context.Background()
@@ -133,7 +367,7 @@ func (bs *BlockchainServiceImpl) SyncVault(v *vault.LocalVault) error {
 		TotalEntries: 0,
 	}
 	if len(metaBytes) > 0 {
-		if decoded, err := cdc.UnpackMetadata(metaBytes, session.MasterPassword); err == nil {
+		if decoded, err := cdc.UnpackMetadataWithVaultKey(metaBytes, session.VaultKey); err == nil {
 			meta = decoded
 		} else {
 			return err
@@ -157,7 +391,7 @@ func (bs *BlockchainServiceImpl) SyncVault(v *vault.LocalVault) error {
 			return err
 		}
 
-		entry, err := cdc.UnpackEntry(dataBytes, session.MasterPassword)
+		entry, err := cdc.UnpackEntryWithVaultKey(dataBytes, session.VaultKey)
 		if err != nil {
 			return err
 		}
@@ -166,11 +400,45 @@ func (bs *BlockchainServiceImpl) SyncVault(v *vault.LocalVault) error {
 		blockchainEntries[entry.ID] = id
 	}
 
-	v.Metadata = meta
-	v.Entries = entries
-	v.BlockchainEntries = blockchainEntries
-	v.LastSyncTime = meta.UpdatedAt
-	v.IsDirty = false
+	v.Replace(meta, entries, blockchainEntries)
 
 	return nil
 }
+
+func (bs *BlockchainServiceImpl) LatestBlock(ctx context.Context) (uint64, error) {
+	if bs.client == nil {
+		return 0, ErrNotConnected
+	}
+	return bs.client.LatestBlock(ctx)
+}
+
+// VaultChanges lists the writes to account's vault in a block range. v1
+// deployments emit no events and return ErrUnsupportedOperation; callers
+// fall back to re-reading the vault.
+func (bs *BlockchainServiceImpl) VaultChanges(ctx context.Context, account string, fromBlock, toBlock uint64) ([]VaultChange, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.VaultChanges(ctx, account, fromBlock, toBlock)
+}
+
+func (bs *BlockchainServiceImpl) WatchVault(ctx context.Context, account string, ch chan<- VaultChange) (ethereum.Subscription, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.WatchVault(ctx, account, ch)
+}
+
+func (bs *BlockchainServiceImpl) MigrateTo(ctx context.Context, toAddress string, progress func(MigrationProgress)) (*MigrationReport, error) {
+	if bs.client == nil {
+		return nil, ErrNotConnected
+	}
+	return bs.client.MigrateTo(ctx, toAddress, progress)
+}
+
+func (bs *BlockchainServiceImpl) UseContract(ctx context.Context, contractAddress string) error {
+	if bs.client == nil {
+		return ErrNotConnected
+	}
+	return bs.client.UseContract(ctx, contractAddress)
+}
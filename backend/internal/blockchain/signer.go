@@ -0,0 +1,188 @@
+package blockchain
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"encryptkeep-backend/internal/secret"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/external"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrSignerAccount is returned when a keystore or external signer does not
+// manage the requested account.
+var ErrSignerAccount = errors.New("signer does not manage the account")
+
+// Signer signs the session's transactions. Only KeySigner holds the key in
+// this process; keystore and external signers keep it on disk or in
+// another process, and the client only ever sees the address.
+type Signer interface {
+	Address() common.Address
+	SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error)
+	// PublicKey is needed to register the account for shared collections.
+	PublicKey() (*ecdsa.PublicKey, error)
+}
+
+// KeySigner signs with a private key held in locked memory. The key is
+// rebuilt for each signature and wiped afterwards.
+type KeySigner struct {
+	key       *secret.Secret
+	publicKey ecdsa.PublicKey
+}
+
+// NewKeySigner copies key into locked memory; the caller may drop its
+// own copy.
+func NewKeySigner(key *ecdsa.PrivateKey) (*KeySigner, error) {
+	s, err := secret.FromBytes(crypto.FromECDSA(key))
+	if err != nil {
+		return nil, err
+	}
+	return &KeySigner{key: s, publicKey: key.PublicKey}, nil
+}
+
+func (s *KeySigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.publicKey)
+}
+
+func (s *KeySigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	var signed *types.Transaction
+	err := s.key.Use(func(b []byte) error {
+		key, err := crypto.ToECDSA(b)
+		if err != nil {
+			return ErrInvalidPrivateKey
+		}
+		defer clear(key.D.Bits())
+
+		signed, err = types.SignTx(tx, types.LatestSignerForChainID(chainID), key)
+		return err
+	})
+	return signed, err
+}
+
+func (s *KeySigner) PublicKey() (*ecdsa.PublicKey, error) {
+	if s.key.Destroyed() {
+		return nil, secret.ErrDestroyed
+	}
+	return &s.publicKey, nil
+}
+
+// Destroy wipes the key; later signatures fail.
+func (s *KeySigner) Destroy() {
+	s.key.Destroy()
+}
+
+// walletSigner signs through an accounts.Wallet (a keystore file or clef)
+// and learns the public key by recovering it from a signed message.
+type walletSigner struct {
+	wallet  accounts.Wallet
+	account accounts.Account
+
+	mu        sync.Mutex
+	publicKey *ecdsa.PublicKey
+}
+
+// publicKeyProbe is the text signed to recover an account's public key.
+// Clef shows it to the user when asking for approval.
+var publicKeyProbe = []byte("EncryptKeep: register public key")
+
+func (s *walletSigner) Address() common.Address {
+	return s.account.Address
+}
+
+func (s *walletSigner) SignTx(tx *types.Transaction, chainID *big.Int) (*types.Transaction, error) {
+	return s.wallet.SignTx(s.account, tx, chainID)
+}
+
+func (s *walletSigner) PublicKey() (*ecdsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.publicKey != nil {
+		return s.publicKey, nil
+	}
+
+	sig, err := s.wallet.SignText(s.account, publicKeyProbe)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := crypto.SigToPub(accounts.TextHash(publicKeyProbe), sig)
+	if err != nil {
+		return nil, err
+	}
+	if crypto.PubkeyToAddress(*pub) != s.account.Address {
+		return nil, fmt.Errorf("%w: signature from another key", ErrSignerAccount)
+	}
+	s.publicKey = pub
+	return pub, nil
+}
+
+// NewKeystoreSigner unlocks account in a geth keystore directory. The
+// key is decrypted by the keystore package and never handed to the
+// client. With an empty account the directory must hold exactly one key.
+func NewKeystoreSigner(dir, account, passphrase string) (Signer, error) {
+	ks := keystore.NewKeyStore(dir, keystore.StandardScryptN, keystore.StandardScryptP)
+
+	acct, err := pickAccount(ks.Accounts(), account)
+	if err != nil {
+		return nil, err
+	}
+	if err := ks.Unlock(acct, passphrase); err != nil {
+		return nil, err
+	}
+	wallet, err := ks.Find(acct)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range ks.Wallets() {
+		if w.Contains(wallet) {
+			return &walletSigner{wallet: w, account: wallet}, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", ErrSignerAccount, acct.Address.Hex())
+}
+
+// NewExternalSigner talks to clef or another external signer over its
+// JSON-RPC API (account_list, account_signTransaction, account_signData)
+// at endpoint, an http(s) URL or IPC path. Every transaction is approved
+// in the signer; the key never enters this process.
+func NewExternalSigner(endpoint, account string) (Signer, error) {
+	ext, err := external.NewExternalSigner(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrConnectionFailed, err)
+	}
+
+	acct, err := pickAccount(ext.Accounts(), account)
+	if err != nil {
+		return nil, err
+	}
+	return &walletSigner{wallet: ext, account: acct}, nil
+}
+
+// pickAccount finds account among available, or the only one when
+// account is empty.
+func pickAccount(available []accounts.Account, account string) (accounts.Account, error) {
+	if account == "" {
+		if len(available) != 1 {
+			return accounts.Account{}, fmt.Errorf("%w: %d accounts available, pick one", ErrSignerAccount, len(available))
+		}
+		return available[0], nil
+	}
+	if !common.IsHexAddress(account) {
+		return accounts.Account{}, fmt.Errorf("%w: %s", ErrInvalidAddress, account)
+	}
+
+	address := common.HexToAddress(account)
+	for _, a := range available {
+		if a.Address == address {
+			return a, nil
+		}
+	}
+	return accounts.Account{}, fmt.Errorf("%w: %s", ErrSignerAccount, address.Hex())
+}
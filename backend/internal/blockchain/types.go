@@ -3,15 +3,38 @@ package blockchain
 import (
 	"fmt"
 	"math/big"
+	"strings"
 	"time"
+
+	"encryptkeep-backend/internal/codec"
 )
 
 type BlockchainConfig struct {
-	RPCEndpoint     string   `json:"rpc_endpoint"`
-	ContractAddress string   `json:"contract_address"`
-	ChainID         int64    `json:"chain_id"`
-	GasLimit        uint64   `json:"gas_limit"`
-	GasPrice        *big.Int `json:"gas_price"`
+	RPCEndpoint     string       `json:"rpc_endpoint"`
+	RPCEndpoints    []string     `json:"rpc_endpoints"` // fallbacks tried after RPCEndpoint
+	ContractAddress string       `json:"contract_address"`
+	ChainID         int64        `json:"chain_id"`
+	GasLimit        uint64       `json:"gas_limit"`
+	GasPrice        *big.Int     `json:"gas_price"`     // fixed price, nil asks the node
+	MaxGasPrice     *big.Int     `json:"max_gas_price"` // writes are refused above this, nil means no cap
+	NativeSymbol    string       `json:"native_symbol"`
+	Retry           *RetryPolicy `json:"retry,omitempty"` // nil means DefaultRetryPolicy
+}
+
+// Endpoints returns RPCEndpoint followed by RPCEndpoints, without blanks
+// or duplicates.
+func (c *BlockchainConfig) Endpoints() []string {
+	seen := make(map[string]bool)
+	var endpoints []string
+	for _, url := range append([]string{c.RPCEndpoint}, c.RPCEndpoints...) {
+		url = strings.TrimSpace(url)
+		if url == "" || seen[url] {
+			continue
+		}
+		seen[url] = true
+		endpoints = append(endpoints, url)
+	}
+	return endpoints
 }
 
 type UserData struct {
@@ -22,30 +45,41 @@ type UserData struct {
 }
 
 type TransactionResult struct {
-	Success   bool      `json:"success"`
-	Timestamp time.Time `json:"timestamp"`
+	Success     bool       `json:"success"`
+	TxHash      string     `json:"tx_hash"`
+	BlockNumber uint64     `json:"block_number"`
+	GasUsed     uint64     `json:"gas_used"`
+	IDs         []*big.Int `json:"ids"`       // data IDs touched; for batch writes aligned with the inputs, nil where not written
+	Revisions   []*big.Int `json:"revisions"` // revision of each ID after the write, nil on v1 deployments
+	Timestamp   time.Time  `json:"timestamp"`
 }
 
 type SyncStatus struct {
-	IsOnline     bool      `json:"is_online"`
-	LastSyncTime time.Time `json:"last_sync_time"`
+	IsOnline     bool             `json:"is_online"`
+	LastSyncTime time.Time        `json:"last_sync_time"`
+	Endpoints    []EndpointStatus `json:"endpoints,omitempty"`
 }
 
+// Session is the unlocked account writes are sent from. It holds a
+// Signer rather than the key, which may live outside the process, and the
+// vault key rather than the master password.
 type Session struct {
-	Address        string    `json:"address"`
-	PrivateKey     string    `json:"private_key"`
-	MasterPassword string    `json:"master_password"`
-	CreatedAt      time.Time `json:"created_at"`
-	LastUsed       time.Time `json:"last_used"`
+	Address   string          `json:"address"`
+	Signer    Signer          `json:"-"`
+	VaultKey  *codec.VaultKey `json:"-"`
+	CreatedAt time.Time       `json:"created_at"`
+	LastUsed  time.Time       `json:"last_used"`
 }
 
 func GetDefaultConfig() *BlockchainConfig {
 	return &BlockchainConfig{
 		RPCEndpoint:     "https://sepolia.base.org",
+		RPCEndpoints:    []string{"https://base-sepolia-rpc.publicnode.com"},
 		ContractAddress: "0x02a06b3427A2D949E971Bd80606996C75ae9fEa9",
 		ChainID:         84532,
 		GasLimit:        1_000_000,
 		GasPrice:        nil,
+		NativeSymbol:    "ETH",
 	}
 }
 
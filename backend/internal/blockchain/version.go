@@ -0,0 +1,88 @@
+package blockchain
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	KeeperVersionUnknown = 0
+	KeeperVersion1       = 1 // original deployment: no events, batches or version view
+	KeeperVersion2       = 2
+	KeeperVersion3       = 3 // adds shared collections and emergency access
+)
+
+// keeperInterfaceMethods mirrors the functions declared in IKeeper.sol; their
+// selectors XOR-ed together give the ERC-165 interface ID of the v2 contract.
+var keeperInterfaceMethods = []string{
+	"storeMetaData",
+	"storeData",
+	"changeData",
+	"removeData",
+	"storeDataBatch",
+	"changeDataBatch",
+	"removeDataBatch",
+	"getActiveIds",
+	"version",
+}
+
+func KeeperInterfaceID() ([4]byte, error) {
+	var id [4]byte
+
+	parsed, err := KeeperMetaData.GetAbi()
+	if err != nil {
+		return id, err
+	}
+
+	for _, name := range keeperInterfaceMethods {
+		method, ok := parsed.Methods[name]
+		if !ok {
+			return id, ErrUnsupportedContract
+		}
+		for i := range id {
+			id[i] ^= method.ID[i]
+		}
+	}
+
+	return id, nil
+}
+
+// DetectKeeperVersion probes the code deployed at address: code presence
+// first, then the version() view, then ERC-165, and finally a v1-only getter.
+func DetectKeeperVersion(ctx context.Context, backend bind.ContractBackend, address common.Address) (int, error) {
+	code, err := backend.CodeAt(ctx, address, nil)
+	if err != nil {
+		return KeeperVersionUnknown, err
+	}
+	if len(code) == 0 {
+		return KeeperVersionUnknown, ErrContractNotFound
+	}
+
+	keeper, err := NewKeeperCaller(address, backend)
+	if err != nil {
+		return KeeperVersionUnknown, err
+	}
+	opts := &bind.CallOpts{Context: ctx}
+
+	if version, err := keeper.Version(opts); err == nil && version.IsInt64() {
+		return int(version.Int64()), nil
+	}
+
+	if interfaceID, err := KeeperInterfaceID(); err == nil {
+		if ok, err := keeper.SupportsInterface(opts, interfaceID); err == nil && ok {
+			return KeeperVersion2, nil
+		}
+	}
+
+	legacy, err := NewKeeperV1Caller(address, backend)
+	if err != nil {
+		return KeeperVersionUnknown, err
+	}
+	if _, err := legacy.NextDataId(opts, common.Address{}); err == nil {
+		return KeeperVersion1, nil
+	}
+
+	return KeeperVersionUnknown, ErrUnsupportedContract
+}
@@ -0,0 +1,161 @@
+package blockchain
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+type ChangeKind string
+
+const (
+	ChangeStored   ChangeKind = "stored"
+	ChangeChanged  ChangeKind = "changed"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeMetadata ChangeKind = "metadata"
+)
+
+// VaultChange is one write to an account's vault, read from the Keeper's
+// logs. Only v2 and later deployments emit them.
+type VaultChange struct {
+	Kind     ChangeKind `json:"kind"`
+	ID       *big.Int   `json:"id,omitempty"`       // nil for metadata
+	Revision *big.Int   `json:"revision,omitempty"` // nil for metadata
+	Block    uint64     `json:"block"`
+	// Reverted marks a log dropped by a reorg; the write it reported may
+	// no longer hold.
+	Reverted bool `json:"reverted,omitempty"`
+}
+
+// vaultEvents are the topics of the events that change an account's own
+// vault; collection and emergency events are left out.
+var vaultEvents = []string{"DataStored", "DataChanged", "DataRemoved", "MetaDataStored"}
+
+// vaultQuery filters the vault events of account between two blocks; a
+// nil to follows the chain head.
+func (k *KeeperContract) vaultQuery(account string, from, to *big.Int) (ethereum.FilterQuery, error) {
+	parsed, err := KeeperMetaData.GetAbi()
+	if err != nil {
+		return ethereum.FilterQuery{}, err
+	}
+	topics := make([]common.Hash, len(vaultEvents))
+	for i, name := range vaultEvents {
+		topics[i] = parsed.Events[name].ID
+	}
+	return ethereum.FilterQuery{
+		FromBlock: from,
+		ToBlock:   to,
+		Addresses: []common.Address{k.address},
+		Topics:    [][]common.Hash{topics, {common.BytesToHash(common.HexToAddress(account).Bytes())}},
+	}, nil
+}
+
+// parseVaultChange decodes a log matched by vaultQuery.
+func (k *KeeperContract) parseVaultChange(log types.Log) (VaultChange, bool) {
+	change := VaultChange{Block: log.BlockNumber, Reverted: log.Removed}
+	if stored, err := k.contract.ParseDataStored(log); err == nil {
+		change.Kind, change.ID, change.Revision = ChangeStored, stored.Id, stored.Revision
+		return change, true
+	}
+	if changed, err := k.contract.ParseDataChanged(log); err == nil {
+		change.Kind, change.ID, change.Revision = ChangeChanged, changed.Id, changed.Revision
+		return change, true
+	}
+	if removed, err := k.contract.ParseDataRemoved(log); err == nil {
+		change.Kind, change.ID, change.Revision = ChangeRemoved, removed.Id, removed.Revision
+		return change, true
+	}
+	if _, err := k.contract.ParseMetaDataStored(log); err == nil {
+		change.Kind = ChangeMetadata
+		return change, true
+	}
+	return change, false
+}
+
+// VaultChanges returns the writes to account's vault in blocks from
+// through to, oldest first.
+func (k *KeeperContract) VaultChanges(ctx context.Context, account string, from, to uint64) ([]VaultChange, error) {
+	if k.contract == nil {
+		return nil, ErrUnsupportedOperation
+	}
+	query, err := k.vaultQuery(account, new(big.Int).SetUint64(from), new(big.Int).SetUint64(to))
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := k.client.FilterLogs(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]VaultChange, 0, len(logs))
+	for _, log := range logs {
+		if change, ok := k.parseVaultChange(log); ok {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// WatchVault delivers the writes to account's vault on ch as they are
+// mined. It needs an endpoint that supports eth_subscribe, such as a
+// websocket one; plain HTTP endpoints fail and callers poll VaultChanges
+// instead.
+func (k *KeeperContract) WatchVault(ctx context.Context, account string, ch chan<- VaultChange) (ethereum.Subscription, error) {
+	if k.contract == nil {
+		return nil, ErrUnsupportedOperation
+	}
+	query, err := k.vaultQuery(account, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	logs := make(chan types.Log)
+	sub, err := k.client.SubscribeFilterLogs(ctx, query, logs)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				change, ok := k.parseVaultChange(log)
+				if !ok {
+					continue
+				}
+				select {
+				case ch <- change:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// LatestBlock returns the number of the chain head.
+func (c *Client) LatestBlock(ctx context.Context) (uint64, error) {
+	header, err := c.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	return header.Number.Uint64(), nil
+}
+
+func (c *Client) VaultChanges(ctx context.Context, account string, fromBlock, toBlock uint64) ([]VaultChange, error) {
+	return c.keeper().VaultChanges(ctx, account, fromBlock, toBlock)
+}
+
+func (c *Client) WatchVault(ctx context.Context, account string, ch chan<- VaultChange) (ethereum.Subscription, error) {
+	return c.keeper().WatchVault(ctx, account, ch)
+}
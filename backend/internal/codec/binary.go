@@ -0,0 +1,242 @@
+package codec
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"encryptkeep-backend/internal/vault"
+)
+
+// Compact payload: a sequence of fields, each a one-byte tag followed by a
+// uvarint length and that many bytes. Unknown tags are skipped on decode so
+// newer clients can add fields without breaking older ones.
+
+const (
+	entryTagID          = 1
+	entryTagTitle       = 2
+	entryTagUsername    = 3
+	entryTagPassword    = 4
+	entryTagURL         = 5
+	entryTagCreatedAt   = 6
+	entryTagUpdatedAt   = 7
+	entryTagIsFavorite  = 8
+	entryTagOTP         = 9
+	entryTagSSHKey      = 10
+	entryTagSSHConfirm  = 11
+	entryTagSSHLifetime = 12
+	entryTagURLMatch    = 13
+	entryTagURI         = 14 // repeated, value is a uri/match field pair
+
+	metaTagVersion      = 1
+	metaTagUpdatedAt    = 2
+	metaTagSetting      = 3 // repeated, value is a key/value field pair
+	metaTagTotalEntries = 4
+)
+
+var errTruncatedPayload = errors.New("truncated payload")
+
+type fieldWriter struct {
+	buf []byte
+}
+
+func (w *fieldWriter) bytes(tag byte, value []byte) {
+	w.buf = append(w.buf, tag)
+	w.buf = binary.AppendUvarint(w.buf, uint64(len(value)))
+	w.buf = append(w.buf, value...)
+}
+
+func (w *fieldWriter) string(tag byte, value string) {
+	if value == "" {
+		return
+	}
+	w.bytes(tag, []byte(value))
+}
+
+func (w *fieldWriter) varint(tag byte, value int64) {
+	if value == 0 {
+		return
+	}
+	w.bytes(tag, binary.AppendVarint(nil, value))
+}
+
+func (w *fieldWriter) time(tag byte, value time.Time) {
+	if value.IsZero() {
+		return
+	}
+	w.varint(tag, value.UnixNano())
+}
+
+func (w *fieldWriter) bool(tag byte, value bool) {
+	if value {
+		w.bytes(tag, []byte{1})
+	}
+}
+
+// readFields calls fn for every field in buf.
+func readFields(buf []byte, fn func(tag byte, value []byte) error) error {
+	for len(buf) > 0 {
+		tag := buf[0]
+		length, n := binary.Uvarint(buf[1:])
+		if n <= 0 || uint64(len(buf)-1-n) < length {
+			return errTruncatedPayload
+		}
+		start := 1 + n
+		if err := fn(tag, buf[start:start+int(length)]); err != nil {
+			return err
+		}
+		buf = buf[start+int(length):]
+	}
+	return nil
+}
+
+func decodeVarint(value []byte) (int64, error) {
+	v, n := binary.Varint(value)
+	if n <= 0 {
+		return 0, errTruncatedPayload
+	}
+	return v, nil
+}
+
+func decodeTime(value []byte) (time.Time, error) {
+	v, err := decodeVarint(value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, v), nil
+}
+
+func encodeEntry(entry *vault.PasswordEntry) []byte {
+	w := &fieldWriter{}
+	w.string(entryTagID, entry.ID)
+	w.string(entryTagTitle, entry.Title)
+	w.string(entryTagUsername, entry.Username)
+	w.string(entryTagPassword, entry.Password)
+	w.string(entryTagURL, entry.URL)
+	w.time(entryTagCreatedAt, entry.CreatedAt)
+	w.time(entryTagUpdatedAt, entry.UpdatedAt)
+	w.bool(entryTagIsFavorite, entry.IsFavorite)
+	w.string(entryTagOTP, entry.OTP)
+	w.string(entryTagSSHKey, entry.SSHKey)
+	w.bool(entryTagSSHConfirm, entry.SSHConfirm)
+	w.varint(entryTagSSHLifetime, int64(entry.SSHLifetime))
+	w.string(entryTagURLMatch, entry.URLMatch)
+	for _, u := range entry.URIs {
+		pair := &fieldWriter{}
+		pair.string(1, u.URI)
+		pair.string(2, u.Match)
+		w.bytes(entryTagURI, pair.buf)
+	}
+	return w.buf
+}
+
+func decodeEntry(buf []byte) (*vault.PasswordEntry, error) {
+	entry := &vault.PasswordEntry{}
+	err := readFields(buf, func(tag byte, value []byte) error {
+		var err error
+		switch tag {
+		case entryTagID:
+			entry.ID = string(value)
+		case entryTagTitle:
+			entry.Title = string(value)
+		case entryTagUsername:
+			entry.Username = string(value)
+		case entryTagPassword:
+			entry.Password = string(value)
+		case entryTagURL:
+			entry.URL = string(value)
+		case entryTagCreatedAt:
+			entry.CreatedAt, err = decodeTime(value)
+		case entryTagUpdatedAt:
+			entry.UpdatedAt, err = decodeTime(value)
+		case entryTagIsFavorite:
+			entry.IsFavorite = len(value) == 1 && value[0] == 1
+		case entryTagOTP:
+			entry.OTP = string(value)
+		case entryTagSSHKey:
+			entry.SSHKey = string(value)
+		case entryTagSSHConfirm:
+			entry.SSHConfirm = len(value) == 1 && value[0] == 1
+		case entryTagSSHLifetime:
+			var lifetime int64
+			lifetime, err = decodeVarint(value)
+			entry.SSHLifetime = int(lifetime)
+		case entryTagURLMatch:
+			entry.URLMatch = string(value)
+		case entryTagURI:
+			var u vault.URI
+			err = readFields(value, func(tag byte, value []byte) error {
+				switch tag {
+				case 1:
+					u.URI = string(value)
+				case 2:
+					u.Match = string(value)
+				}
+				return nil
+			})
+			entry.URIs = append(entry.URIs, u)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("decode entry: %w", err)
+	}
+	return entry, nil
+}
+
+func encodeMetadata(metadata *vault.BlockchainMetadata) []byte {
+	w := &fieldWriter{}
+	w.string(metaTagVersion, metadata.Version)
+	w.time(metaTagUpdatedAt, metadata.UpdatedAt)
+
+	keys := make([]string, 0, len(metadata.Settings))
+	for key := range metadata.Settings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		pair := &fieldWriter{}
+		pair.bytes(1, []byte(key))
+		pair.bytes(2, []byte(metadata.Settings[key]))
+		w.bytes(metaTagSetting, pair.buf)
+	}
+
+	w.varint(metaTagTotalEntries, int64(metadata.TotalEntries))
+	return w.buf
+}
+
+func decodeMetadata(buf []byte) (*vault.BlockchainMetadata, error) {
+	metadata := &vault.BlockchainMetadata{Settings: map[string]string{}}
+	err := readFields(buf, func(tag byte, value []byte) error {
+		var err error
+		switch tag {
+		case metaTagVersion:
+			metadata.Version = string(value)
+		case metaTagUpdatedAt:
+			metadata.UpdatedAt, err = decodeTime(value)
+		case metaTagSetting:
+			var key, val string
+			err = readFields(value, func(tag byte, value []byte) error {
+				switch tag {
+				case 1:
+					key = string(value)
+				case 2:
+					val = string(value)
+				}
+				return nil
+			})
+			metadata.Settings[key] = val
+		case metaTagTotalEntries:
+			var total int64
+			total, err = decodeVarint(value)
+			metadata.TotalEntries = int(total)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("decode metadata: %w", err)
+	}
+	return metadata, nil
+}
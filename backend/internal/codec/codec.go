@@ -11,23 +11,34 @@ import (
 
 type Codec struct {
 	argon2Config crypto.Argon2Config
+	options      EncodingOptions
 }
 
 func NewCodec() *Codec {
 	return &Codec{
 		argon2Config: FromVaultConfig(vault.DefaultVaultConfig()),
+		options:      DefaultEncodingOptions(),
 	}
 }
 
 func NewCodecWithConfig(cfg crypto.Argon2Config) *Codec {
 	return &Codec{
 		argon2Config: cfg,
+		options:      DefaultEncodingOptions(),
 	}
 }
 
 func NewCodecWithVaultConfig(vaultCfg *vault.VaultConfig) *Codec {
 	return &Codec{
 		argon2Config: FromVaultConfig(vaultCfg),
+		options:      DefaultEncodingOptions(),
+	}
+}
+
+func NewCodecWithOptions(cfg crypto.Argon2Config, options EncodingOptions) *Codec {
+	return &Codec{
+		argon2Config: cfg,
+		options:      options,
 	}
 }
 
@@ -40,28 +51,17 @@ func (c *Codec) PackEntry(passwordEntry *vault.PasswordEntry, masterPassword str
 		return nil, errors.New("master password cannot be empty")
 	}
 
-	plaintext, err := json.Marshal(passwordEntry)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal entry: %w", err)
-	}
-
-	sealed, err := crypto.Seal(masterPassword, c.argon2Config, plaintext)
-	if err != nil {
-		return nil, fmt.Errorf("failed to seal plain text: %w", err)
-	}
-
-	encryptedBlob := &vault.EncryptedEntryBlob{
-		EncryptedData: sealed.Ciphertext,
-		Salt:          sealed.Salt,
-		Nonce:         sealed.Nonce,
-	}
+	return c.seal(encodeEntry(passwordEntry), c.password(masterPassword))
+}
 
-	packedData, err := json.Marshal(encryptedBlob)
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert marshal sealed data: %w", err)
+// PackEntryWithVaultKey seals an entry like PackEntry, with a key derived
+// from the master password instead of the password.
+func (c *Codec) PackEntryWithVaultKey(passwordEntry *vault.PasswordEntry, key *VaultKey) ([]byte, error) {
+	if passwordEntry == nil {
+		return nil, errors.New("passwordEntry cannot be empty")
 	}
 
-	return packedData, nil
+	return c.seal(encodeEntry(passwordEntry), key)
 }
 
 func (c *Codec) PackMetadata(metadata *vault.UserMetadata, masterPassword string) ([]byte, error) {
@@ -73,6 +73,18 @@ func (c *Codec) PackMetadata(metadata *vault.UserMetadata, masterPassword string
 		return nil, errors.New("master password cannot be empty")
 	}
 
+	return c.packMetadata(metadata, c.password(masterPassword))
+}
+
+func (c *Codec) PackMetadataWithVaultKey(metadata *vault.UserMetadata, key *VaultKey) ([]byte, error) {
+	if metadata == nil {
+		return nil, errors.New("metadata cannot be empty")
+	}
+
+	return c.packMetadata(metadata, key)
+}
+
+func (c *Codec) packMetadata(metadata *vault.UserMetadata, s sealer) ([]byte, error) {
 	blockchainMetaData := vault.BlockchainMetadata{
 		Version:      metadata.Version,
 		UpdatedAt:    metadata.UpdatedAt,
@@ -80,40 +92,63 @@ func (c *Codec) PackMetadata(metadata *vault.UserMetadata, masterPassword string
 		TotalEntries: metadata.TotalEntries,
 	}
 
-	plaintext, err := json.Marshal(blockchainMetaData)
-	if err != nil {
-		return nil, fmt.Errorf("failed to convert marshal metadata: %w", err)
+	return c.seal(encodeMetadata(&blockchainMetaData), s)
+}
+
+func (c *Codec) UnpackEntry(encryptedData []byte, masterPassword string) (*vault.PasswordEntry, error) {
+	if len(masterPassword) < 8 {
+		return nil, errors.New("master password cannot be empty")
 	}
 
-	sealed, err := crypto.Seal(masterPassword, c.argon2Config, plaintext)
-	if err != nil {
-		return nil, fmt.Errorf("failed to seal data: %w", err)
+	return c.unpackEntry(encryptedData, c.password(masterPassword))
+}
+
+// UnpackEntryWithVaultKey opens an entry sealed with the vault's master
+// password, by PackEntry or PackEntryWithVaultKey.
+func (c *Codec) UnpackEntryWithVaultKey(encryptedData []byte, key *VaultKey) (*vault.PasswordEntry, error) {
+	return c.unpackEntry(encryptedData, key)
+}
+
+func (c *Codec) unpackEntry(encryptedData []byte, s sealer) (*vault.PasswordEntry, error) {
+	length := len(encryptedData)
+	if length == 0 {
+		return nil, errors.New("encrypted data cannot be empty")
 	}
 
-	encryptedBlob := &vault.EncryptedMetadataBlob{
-		EncryptedData: sealed.Ciphertext,
-		Salt:          sealed.Salt,
-		Nonce:         sealed.Nonce,
+	if isLegacyBlob(encryptedData) {
+		return c.unpackLegacyEntry(encryptedData, s)
 	}
 
-	packedData, err := json.Marshal(encryptedBlob)
+	payload, err := c.open(encryptedData, s)
 	if err != nil {
-		return nil, fmt.Errorf("failed to convert marshal sealed data: %w", err)
+		return nil, err
 	}
-
-	return packedData, nil
+	return decodeEntry(payload)
 }
 
-func (c *Codec) UnpackEntry(encryptedData []byte, masterPassword string) (*vault.PasswordEntry, error) {
-	if len(masterPassword) < 8 {
-		return nil, errors.New("master password cannot be empty")
+// PackEntryWithKey seals an entry with a raw 32-byte key, e.g. the key of
+// a shared collection, skipping the password KDF.
+func (c *Codec) PackEntryWithKey(passwordEntry *vault.PasswordEntry, key []byte) ([]byte, error) {
+	if passwordEntry == nil {
+		return nil, errors.New("passwordEntry cannot be empty")
 	}
 
-	length := len(encryptedData)
-	if length == 0 {
+	return c.sealWithKey(encodeEntry(passwordEntry), key)
+}
+
+func (c *Codec) UnpackEntryWithKey(encryptedData []byte, key []byte) (*vault.PasswordEntry, error) {
+	if len(encryptedData) == 0 {
 		return nil, errors.New("encrypted data cannot be empty")
 	}
 
+	payload, err := openWithKey(encryptedData, key)
+	if err != nil {
+		return nil, err
+	}
+	return decodeEntry(payload)
+}
+
+func (c *Codec) unpackLegacyEntry(encryptedData []byte, s sealer) (*vault.PasswordEntry, error) {
 	var encryptedBlob vault.EncryptedEntryBlob
 	if err := json.Unmarshal(encryptedData, &encryptedBlob); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal metadata^ %w", err)
@@ -124,7 +159,7 @@ func (c *Codec) UnpackEntry(encryptedData []byte, masterPassword string) (*vault
 		Ciphertext: encryptedBlob.EncryptedData,
 	}
 
-	plaintext, err := crypto.Open(masterPassword, c.argon2Config, sealed)
+	plaintext, err := s.open(sealed)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open plaintext: %w", err)
 	}
@@ -151,11 +186,46 @@ func (c *Codec) UnpackMetadata(encryptedData []byte, masterPassword string) (*va
 		return nil, errors.New("master password cannot be empty")
 	}
 
+	return c.unpackMetadata(encryptedData, c.password(masterPassword))
+}
+
+func (c *Codec) UnpackMetadataWithVaultKey(encryptedData []byte, key *VaultKey) (*vault.UserMetadata, error) {
+	return c.unpackMetadata(encryptedData, key)
+}
+
+func (c *Codec) unpackMetadata(encryptedData []byte, s sealer) (*vault.UserMetadata, error) {
 	length := len(encryptedData)
 	if length == 0 {
 		return nil, errors.New("encrypted data cannot be empty")
 	}
 
+	var blockchainMeta *vault.BlockchainMetadata
+	var err error
+	if isLegacyBlob(encryptedData) {
+		blockchainMeta, err = c.unpackLegacyMetadata(encryptedData, s)
+	} else {
+		var payload []byte
+		payload, err = c.open(encryptedData, s)
+		if err == nil {
+			blockchainMeta, err = decodeMetadata(payload)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	metadata := &vault.UserMetadata{
+		Version:      blockchainMeta.Version,
+		Settings:     blockchainMeta.Settings,
+		PasswordIDs:  []string{},
+		UpdatedAt:    blockchainMeta.UpdatedAt,
+		TotalEntries: blockchainMeta.TotalEntries,
+	}
+
+	return metadata, nil
+}
+
+func (c *Codec) unpackLegacyMetadata(encryptedData []byte, s sealer) (*vault.BlockchainMetadata, error) {
 	var encryptedBlob vault.EncryptedMetadataBlob
 	if err := json.Unmarshal(encryptedData, &encryptedBlob); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal metadata^ %w", err)
@@ -166,7 +236,7 @@ func (c *Codec) UnpackMetadata(encryptedData []byte, masterPassword string) (*va
 		Ciphertext: encryptedBlob.EncryptedData,
 	}
 
-	plaintext, err := crypto.Open(masterPassword, c.argon2Config, sealed)
+	plaintext, err := s.open(sealed)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open plaintext: %w", err)
 	}
@@ -176,13 +246,5 @@ func (c *Codec) UnpackMetadata(encryptedData []byte, masterPassword string) (*va
 		return nil, fmt.Errorf("failed unmarshal plaintext: %w", err)
 	}
 
-	metadata := &vault.UserMetadata{
-		Version:      blockchainMeta.Version,
-		Settings:     blockchainMeta.Settings,
-		PasswordIDs:  []string{},
-		UpdatedAt:    blockchainMeta.UpdatedAt,
-		TotalEntries: blockchainMeta.TotalEntries,
-	}
-
-	return metadata, nil
+	return &blockchainMeta, nil
 }
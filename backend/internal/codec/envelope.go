@@ -0,0 +1,257 @@
+package codec
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"encryptkeep-backend/internal/crypto"
+)
+
+// Envelope layout stored on chain:
+//
+//	version(1) | salt(16) | nonce(12) | AES-GCM ciphertext
+//
+// Entries of shared collections are sealed with the raw collection key
+// instead of a password, so they carry no salt:
+//
+//	keyedVersion(1) | nonce(12) | AES-GCM ciphertext
+//
+// The sealed plaintext is flags(1) | uvarint payload length | payload |
+// zero padding up to the next size bucket, so ciphertext length reveals
+// the bucket rather than the length of the secrets inside. Legacy blobs
+// are JSON objects and therefore start with '{'.
+const (
+	envelopeVersion = 0x01
+	envelopeHeader  = 1 + crypto.DefaultSaltLen + crypto.GCMNonceLen
+
+	keyedEnvelopeVersion = 0x02
+	keyedEnvelopeHeader  = 1 + crypto.GCMNonceLen
+
+	flagCompressed = 0x01
+
+	legacyJSONPrefix = '{'
+)
+
+// paddingBuckets are the plaintext sizes entries are padded to; larger
+// payloads are rounded up to a multiple of the last bucket.
+var paddingBuckets = []int{64, 128, 256, 512, 1024}
+
+type EncodingOptions struct {
+	Compress bool // deflate the payload when that makes it smaller
+	Pad      bool // pad the sealed plaintext to paddingBuckets
+}
+
+func DefaultEncodingOptions() EncodingOptions {
+	return EncodingOptions{
+		Compress: true,
+		Pad:      true,
+	}
+}
+
+func isLegacyBlob(data []byte) bool {
+	return len(data) > 0 && data[0] == legacyJSONPrefix
+}
+
+func paddedSize(n int) int {
+	for _, bucket := range paddingBuckets {
+		if n <= bucket {
+			return bucket
+		}
+	}
+	last := paddingBuckets[len(paddingBuckets)-1]
+	return (n + last - 1) / last * last
+}
+
+func (c *Codec) frame(payload []byte) ([]byte, error) {
+	flags := byte(0)
+	if c.options.Compress {
+		compressed, err := deflate(payload)
+		if err != nil {
+			return nil, err
+		}
+		if len(compressed) < len(payload) {
+			payload = compressed
+			flags |= flagCompressed
+		}
+	}
+
+	plaintext := []byte{flags}
+	plaintext = binary.AppendUvarint(plaintext, uint64(len(payload)))
+	plaintext = append(plaintext, payload...)
+
+	if c.options.Pad {
+		plaintext = append(plaintext, make([]byte, paddedSize(len(plaintext))-len(plaintext))...)
+	}
+	return plaintext, nil
+}
+
+func unframe(plaintext []byte) ([]byte, error) {
+	if len(plaintext) < 2 {
+		return nil, errTruncatedPayload
+	}
+	flags := plaintext[0]
+	length, n := binary.Uvarint(plaintext[1:])
+	if n <= 0 || uint64(len(plaintext)-1-n) < length {
+		return nil, errTruncatedPayload
+	}
+	payload := plaintext[1+n : 1+n+int(length)]
+
+	if flags&flagCompressed != 0 {
+		return inflate(payload)
+	}
+	return payload, nil
+}
+
+// sealer seals and opens password-based envelopes, either with the master
+// password itself or with a VaultKey derived from it.
+type sealer interface {
+	seal(plaintext []byte) (crypto.Sealed, error)
+	open(sealed crypto.Sealed) ([]byte, error)
+}
+
+// passwordSealer runs the KDF with a fresh salt for every envelope.
+type passwordSealer struct {
+	password string
+	cfg      crypto.Argon2Config
+}
+
+func (p passwordSealer) seal(plaintext []byte) (crypto.Sealed, error) {
+	return crypto.Seal(p.password, p.cfg, plaintext)
+}
+
+func (p passwordSealer) open(sealed crypto.Sealed) ([]byte, error) {
+	return crypto.Open(p.password, p.cfg, sealed)
+}
+
+func (c *Codec) password(masterPassword string) sealer {
+	return passwordSealer{password: masterPassword, cfg: c.argon2Config}
+}
+
+func (c *Codec) seal(payload []byte, s sealer) ([]byte, error) {
+	plaintext, err := c.frame(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := s.seal(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal plain text: %w", err)
+	}
+
+	out := make([]byte, 0, envelopeHeader+len(sealed.Ciphertext))
+	out = append(out, envelopeVersion)
+	out = append(out, sealed.Salt...)
+	out = append(out, sealed.Nonce...)
+	out = append(out, sealed.Ciphertext...)
+	return out, nil
+}
+
+func (c *Codec) open(data []byte, s sealer) ([]byte, error) {
+	if len(data) <= envelopeHeader {
+		return nil, errTruncatedPayload
+	}
+	if data[0] != envelopeVersion {
+		return nil, fmt.Errorf("unsupported envelope version %d", data[0])
+	}
+
+	sealed := crypto.Sealed{
+		Salt:       data[1 : 1+crypto.DefaultSaltLen],
+		Nonce:      data[1+crypto.DefaultSaltLen : envelopeHeader],
+		Ciphertext: data[envelopeHeader:],
+	}
+
+	plaintext, err := s.open(sealed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open plaintext: %w", err)
+	}
+	return unframe(plaintext)
+}
+
+// EnvelopeSalt returns the KDF salt a password-sealed envelope or legacy
+// blob was written under. It reports false for envelopes sealed with a raw
+// key and for data it cannot parse.
+func EnvelopeSalt(data []byte) ([]byte, bool) {
+	if isLegacyBlob(data) {
+		var blob struct {
+			Salt []byte `json:"salt"`
+		}
+		if err := json.Unmarshal(data, &blob); err != nil || len(blob.Salt) == 0 {
+			return nil, false
+		}
+		return blob.Salt, true
+	}
+	if len(data) <= envelopeHeader || data[0] != envelopeVersion {
+		return nil, false
+	}
+	return bytes.Clone(data[1 : 1+crypto.DefaultSaltLen]), true
+}
+
+func (c *Codec) sealWithKey(payload, key []byte) ([]byte, error) {
+	plaintext, err := c.frame(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	ciphertext, nonce, err := crypto.Encrypt(key, plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to seal plain text: %w", err)
+	}
+
+	out := make([]byte, 0, keyedEnvelopeHeader+len(ciphertext))
+	out = append(out, keyedEnvelopeVersion)
+	out = append(out, nonce...)
+	out = append(out, ciphertext...)
+	return out, nil
+}
+
+func openWithKey(data, key []byte) ([]byte, error) {
+	if len(data) <= keyedEnvelopeHeader {
+		return nil, errTruncatedPayload
+	}
+	if data[0] != keyedEnvelopeVersion {
+		return nil, fmt.Errorf("unsupported envelope version %d", data[0])
+	}
+
+	plaintext, err := crypto.Decrypt(key, data[keyedEnvelopeHeader:], data[1:keyedEnvelopeHeader])
+	if err != nil {
+		return nil, fmt.Errorf("failed to open plaintext: %w", err)
+	}
+	return unframe(plaintext)
+}
+
+func deflate(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// maxInflatedSize guards against decompression bombs in foreign blobs.
+const maxInflatedSize = 1 << 20
+
+func inflate(data []byte) ([]byte, error) {
+	r := flate.NewReader(bytes.NewReader(data))
+	defer r.Close()
+
+	out, err := io.ReadAll(io.LimitReader(r, maxInflatedSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("inflate payload: %w", err)
+	}
+	if len(out) > maxInflatedSize {
+		return nil, errors.New("inflated payload too large")
+	}
+	return out, nil
+}
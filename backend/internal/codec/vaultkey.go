@@ -0,0 +1,255 @@
+package codec
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"encryptkeep-backend/internal/crypto"
+	"encryptkeep-backend/internal/secret"
+)
+
+// ErrUnknownVaultKey is returned for envelopes sealed under a salt whose
+// key has not been derived, e.g. written by an older client after unlock.
+var ErrUnknownVaultKey = errors.New("envelope sealed under a key not derived yet, unlock the vault again")
+
+var errInvalidExportedKey = errors.New("invalid exported vault key")
+
+// VaultKey seals and opens a vault's envelopes with keys derived from the
+// master password, so the password itself need not be kept. New envelopes
+// use a salt derived from the account: every device derives the same key,
+// and the envelope format is unchanged, so the password still opens them.
+// Keys of older envelopes, each sealed under a random salt, are derived
+// up front with Derive. All keys share one buffer of locked memory until
+// Destroy, so a vault with many older salts still fits RLIMIT_MEMLOCK.
+type VaultKey struct {
+	cfg  crypto.Argon2Config
+	salt []byte
+
+	mu        sync.RWMutex
+	keys      *secret.Secret // every key back to back, nil when there are none
+	index     map[string]int // slot in keys by hex salt
+	destroyed bool
+}
+
+// VaultSalt is the salt account's new envelopes are sealed under.
+func VaultSalt(account string) []byte {
+	sum := sha256.Sum256([]byte("encryptkeep/vault-salt/v1:" + strings.ToLower(account)))
+	return sum[:crypto.DefaultSaltLen]
+}
+
+// NewVaultKey derives account's vault key from masterPassword.
+func NewVaultKey(masterPassword, account string, cfg crypto.Argon2Config) (*VaultKey, error) {
+	k := &VaultKey{
+		cfg:   cfg,
+		salt:  VaultSalt(account),
+		index: make(map[string]int),
+	}
+	if _, err := k.Derive(masterPassword, k.salt); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// ImportVaultKey rebuilds account's vault key from keys as Export returns
+// them, moving them into locked memory and wiping the originals. The
+// result opens envelopes under those salts only, unless Derive is given
+// the password.
+func ImportVaultKey(account string, keys map[string][]byte, cfg crypto.Argon2Config) (*VaultKey, error) {
+	defer wipeKeys(keys)
+
+	for salt, key := range keys {
+		if _, err := hex.DecodeString(salt); err != nil || len(key) != crypto.AESKeyLen {
+			return nil, errInvalidExportedKey
+		}
+	}
+	k := &VaultKey{
+		cfg:   cfg,
+		salt:  VaultSalt(account),
+		index: make(map[string]int, len(keys)),
+	}
+	if err := k.add(keys); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Derive derives from masterPassword the keys of envelopes sealed under
+// salts, such as those older clients wrote, so they open later without
+// the password. Salts with a known key are skipped. It returns how many
+// keys it added.
+func (k *VaultKey) Derive(masterPassword string, salts ...[]byte) (int, error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.destroyed {
+		return 0, secret.ErrDestroyed
+	}
+
+	derived := make(map[string][]byte)
+	defer wipeKeys(derived)
+	for _, salt := range salts {
+		id := hex.EncodeToString(salt)
+		if _, ok := k.index[id]; ok {
+			continue
+		}
+		if _, ok := derived[id]; ok {
+			continue
+		}
+		dk, err := crypto.DeriveKey(masterPassword, salt, k.cfg)
+		if err != nil {
+			return 0, err
+		}
+		derived[id] = dk.Key
+		if len(dk.Key) != crypto.AESKeyLen {
+			return 0, fmt.Errorf("invalid key length: got %d, want %d", len(dk.Key), crypto.AESKeyLen)
+		}
+	}
+	if err := k.add(derived); err != nil {
+		return 0, err
+	}
+	return len(derived), nil
+}
+
+// Export returns a copy of every derived key by hex salt, for a recovery
+// or emergency kit. The caller wipes the copies.
+func (k *VaultKey) Export() (map[string][]byte, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if k.destroyed {
+		return nil, secret.ErrDestroyed
+	}
+
+	keys := make(map[string][]byte, len(k.index))
+	if k.keys == nil {
+		return keys, nil
+	}
+	err := k.keys.Use(func(b []byte) error {
+		for salt, slot := range k.index {
+			keys[salt] = bytes.Clone(slotKey(b, slot))
+		}
+		return nil
+	})
+	if err != nil {
+		wipeKeys(keys)
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Destroy wipes every derived key. Later use fails with
+// secret.ErrDestroyed.
+func (k *VaultKey) Destroy() {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.keys != nil {
+		k.keys.Destroy()
+	}
+	k.keys = nil
+	k.index = nil
+	k.destroyed = true
+}
+
+// Destroyed reports whether Destroy has been called.
+func (k *VaultKey) Destroyed() bool {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	return k.destroyed
+}
+
+func (k *VaultKey) seal(plaintext []byte) (crypto.Sealed, error) {
+	var sealed crypto.Sealed
+	err := k.use(k.salt, func(key []byte) error {
+		ciphertext, nonce, err := crypto.Encrypt(key, plaintext)
+		if err != nil {
+			return err
+		}
+		sealed = crypto.Sealed{Salt: k.salt, Nonce: nonce, Ciphertext: ciphertext}
+		return nil
+	})
+	return sealed, err
+}
+
+func (k *VaultKey) open(sealed crypto.Sealed) ([]byte, error) {
+	var plaintext []byte
+	err := k.use(sealed.Salt, func(key []byte) error {
+		var err error
+		plaintext, err = crypto.Decrypt(key, sealed.Ciphertext, sealed.Nonce)
+		return err
+	})
+	return plaintext, err
+}
+
+// use calls fn with the key of salt, which fn must not keep.
+func (k *VaultKey) use(salt []byte, fn func(key []byte) error) error {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+	if k.destroyed {
+		return secret.ErrDestroyed
+	}
+	slot, ok := k.index[hex.EncodeToString(salt)]
+	if !ok {
+		return ErrUnknownVaultKey
+	}
+	return k.keys.Use(func(b []byte) error {
+		return fn(slotKey(b, slot))
+	})
+}
+
+// add moves keys, by hex salt, into a new buffer after the known ones and
+// releases the old buffer. Callers hold k.mu or own k.
+func (k *VaultKey) add(keys map[string][]byte) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	buf, err := secret.New((len(k.index) + len(keys)) * crypto.AESKeyLen)
+	if err != nil {
+		return err
+	}
+	index := make(map[string]int, len(k.index)+len(keys))
+	err = buf.Use(func(dst []byte) error {
+		if k.keys != nil {
+			err := k.keys.Use(func(src []byte) error {
+				copy(dst, src)
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		for salt, slot := range k.index {
+			index[salt] = slot
+		}
+		next := len(k.index)
+		for salt, key := range keys {
+			copy(slotKey(dst, next), key)
+			index[salt] = next
+			next++
+		}
+		return nil
+	})
+	if err != nil {
+		buf.Destroy()
+		return err
+	}
+
+	if k.keys != nil {
+		k.keys.Destroy()
+	}
+	k.keys, k.index = buf, index
+	return nil
+}
+
+func slotKey(b []byte, slot int) []byte {
+	return b[slot*crypto.AESKeyLen : (slot+1)*crypto.AESKeyLen]
+}
+
+func wipeKeys(keys map[string][]byte) {
+	for _, key := range keys {
+		secret.Wipe(key)
+	}
+}
@@ -0,0 +1,200 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"encryptkeep-backend/internal/blockchain"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	FileName = "config.json"
+
+	DefaultProfile = "base-sepolia"
+)
+
+var (
+	ErrProfileNotFound = errors.New("profile not found")
+	ErrInvalidProfile  = errors.New("invalid profile")
+)
+
+// GasPolicy is how a profile prices its transactions. Prices are in gwei.
+type GasPolicy struct {
+	Limit        uint64  `json:"limit,omitempty"`          // gas limit of single writes, 0 keeps the default
+	PriceGwei    float64 `json:"price_gwei,omitempty"`     // fixed gas price, 0 asks the node
+	MaxPriceGwei float64 `json:"max_price_gwei,omitempty"` // refuse to send above this, 0 means no cap
+}
+
+// Profile is one network EncryptKeep can talk to.
+type Profile struct {
+	RPCURLs         []string  `json:"rpc_urls"`
+	ChainID         int64     `json:"chain_id"`
+	ContractAddress string    `json:"contract_address"`
+	NativeSymbol    string    `json:"native_symbol,omitempty"`
+	Gas             GasPolicy `json:"gas"`
+}
+
+// Config is the content of config.json in the keymanager config dir.
+type Config struct {
+	DefaultProfile string              `json:"default_profile"`
+	Profiles       map[string]*Profile `json:"profiles"`
+}
+
+// Default returns the built-in profiles, used when no config file exists.
+func Default() *Config {
+	base := blockchain.GetDefaultConfig()
+
+	return &Config{
+		DefaultProfile: DefaultProfile,
+		Profiles: map[string]*Profile{
+			DefaultProfile: {
+				RPCURLs:         base.Endpoints(),
+				ChainID:         base.ChainID,
+				ContractAddress: base.ContractAddress,
+				NativeSymbol:    base.NativeSymbol,
+				Gas:             GasPolicy{Limit: base.GasLimit},
+			},
+			"anvil": {
+				RPCURLs:         []string{"http://127.0.0.1:8545"},
+				ChainID:         31337,
+				ContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3", // first deployment of anvil's default account
+				NativeSymbol:    "ETH",
+			},
+		},
+	}
+}
+
+func Path(dir string) string {
+	return filepath.Join(dir, FileName)
+}
+
+// Load reads the config file in dir, falling back to Default when there
+// is none. Every profile is validated.
+func Load(dir string) (*Config, error) {
+	raw, err := os.ReadFile(Path(dir))
+	if errors.Is(err, os.ErrNotExist) {
+		return Default(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	if err := json.Unmarshal(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", Path(dir), err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func Save(dir string, cfg *Config) error {
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(Path(dir), data, 0600)
+}
+
+func (c *Config) Validate() error {
+	if len(c.Profiles) == 0 {
+		return fmt.Errorf("%w: no profiles defined", ErrInvalidProfile)
+	}
+	for name, profile := range c.Profiles {
+		if err := profile.Validate(); err != nil {
+			return fmt.Errorf("profile %q: %w", name, err)
+		}
+	}
+	if c.DefaultProfile != "" {
+		if _, ok := c.Profiles[c.DefaultProfile]; !ok {
+			return fmt.Errorf("default profile %q: %w", c.DefaultProfile, ErrProfileNotFound)
+		}
+	}
+	return nil
+}
+
+// Profile returns the named profile, or the default one for an empty name.
+func (c *Config) Profile(name string) (*Profile, error) {
+	if name == "" {
+		name = c.DefaultProfile
+	}
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (have %s)", ErrProfileNotFound, name, strings.Join(c.Names(), ", "))
+	}
+	return profile, nil
+}
+
+// Names returns the profile names in sorted order.
+func (c *Config) Names() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (p *Profile) Validate() error {
+	if p == nil {
+		return fmt.Errorf("%w: empty", ErrInvalidProfile)
+	}
+	if len(p.RPCURLs) == 0 {
+		return fmt.Errorf("%w: no rpc_urls", ErrInvalidProfile)
+	}
+	if p.ChainID <= 0 {
+		return fmt.Errorf("%w: chain_id must be positive", ErrInvalidProfile)
+	}
+	if !common.IsHexAddress(p.ContractAddress) {
+		return fmt.Errorf("%w: contract_address %q", ErrInvalidProfile, p.ContractAddress)
+	}
+	if p.Gas.PriceGwei < 0 || p.Gas.MaxPriceGwei < 0 {
+		return fmt.Errorf("%w: negative gas price", ErrInvalidProfile)
+	}
+	if p.Gas.MaxPriceGwei > 0 && p.Gas.PriceGwei > p.Gas.MaxPriceGwei {
+		return fmt.Errorf("%w: price_gwei above max_price_gwei", ErrInvalidProfile)
+	}
+	return nil
+}
+
+// BlockchainConfig turns the profile into a client configuration.
+func (p *Profile) BlockchainConfig() *blockchain.BlockchainConfig {
+	config := blockchain.GetDefaultConfig()
+	config.RPCEndpoint = p.RPCURLs[0]
+	config.RPCEndpoints = append([]string(nil), p.RPCURLs[1:]...)
+	config.ChainID = p.ChainID
+	config.ContractAddress = p.ContractAddress
+	if p.NativeSymbol != "" {
+		config.NativeSymbol = p.NativeSymbol
+	}
+	if p.Gas.Limit != 0 {
+		config.GasLimit = p.Gas.Limit
+	}
+	config.GasPrice = gweiToWei(p.Gas.PriceGwei)
+	config.MaxGasPrice = gweiToWei(p.Gas.MaxPriceGwei)
+	return config
+}
+
+func gweiToWei(gwei float64) *big.Int {
+	if gwei <= 0 {
+		return nil
+	}
+	return new(big.Int).SetUint64(uint64(math.Round(gwei * 1e9)))
+}
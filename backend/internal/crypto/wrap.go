@@ -0,0 +1,66 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+)
+
+// GenerateKey returns a random AES-256 key, used as the key of a shared
+// collection.
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, AESKeyLen)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+	return key, nil
+}
+
+// WrapKey encrypts key to a secp256k1 public key with ECIES, so only the
+// holder of the matching wallet key can recover it.
+func WrapKey(pub *ecdsa.PublicKey, key []byte) ([]byte, error) {
+	if len(key) != AESKeyLen {
+		return nil, fmt.Errorf("invalid key length: got %d, want %d", len(key), AESKeyLen)
+	}
+	return WrapSecret(pub, key)
+}
+
+func UnwrapKey(priv *ecdsa.PrivateKey, wrapped []byte) ([]byte, error) {
+	key, err := UnwrapSecret(priv, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != AESKeyLen {
+		return nil, fmt.Errorf("invalid key length: got %d, want %d", len(key), AESKeyLen)
+	}
+	return key, nil
+}
+
+// WrapSecret is WrapKey for secrets of any length, such as an emergency
+// access kit.
+func WrapSecret(pub *ecdsa.PublicKey, secret []byte) ([]byte, error) {
+	if pub == nil {
+		return nil, errors.New("public key must not be nil")
+	}
+
+	wrapped, err := ecies.Encrypt(rand.Reader, ecies.ImportECDSAPublic(pub), secret, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("wrap key: %w", err)
+	}
+	return wrapped, nil
+}
+
+func UnwrapSecret(priv *ecdsa.PrivateKey, wrapped []byte) ([]byte, error) {
+	if priv == nil {
+		return nil, errors.New("private key must not be nil")
+	}
+
+	secret, err := ecies.ImportECDSA(priv).Decrypt(wrapped, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("unwrap key: %w", err)
+	}
+	return secret, nil
+}
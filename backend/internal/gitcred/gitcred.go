@@ -0,0 +1,92 @@
+// Package gitcred speaks the git credential helper protocol: git writes
+// the attributes of the credential it needs as key=value lines and reads
+// the filled-in credential back the same way.
+package gitcred
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+)
+
+// Credential holds the attributes of one request. Attributes git may add
+// in later versions are ignored.
+type Credential struct {
+	Protocol string
+	Host     string // with the port, if git gave one
+	Path     string // only sent with credential.useHttpPath
+	Username string
+	Password string
+}
+
+// Read parses attributes up to a blank line or the end of r.
+func Read(r io.Reader) (*Credential, error) {
+	c := &Credential{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if line == "" {
+			break
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("gitcred: bad attribute line %q", line)
+		}
+		switch key {
+		case "protocol":
+			c.Protocol = value
+		case "host":
+			c.Host = value
+		case "path":
+			c.Path = value
+		case "username":
+			c.Username = value
+		case "password":
+			c.Password = value
+		case "url":
+			u, err := url.Parse(value)
+			if err != nil {
+				return nil, fmt.Errorf("gitcred: bad url: %w", err)
+			}
+			c.Protocol, c.Host, c.Path = u.Scheme, u.Host, strings.TrimPrefix(u.Path, "/")
+			if u.User != nil {
+				c.Username = u.User.Username()
+				if p, ok := u.User.Password(); ok {
+					c.Password = p
+				}
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Write sends the username and password back to git, leaving out those
+// that are empty.
+func (c *Credential) Write(w io.Writer) error {
+	for _, a := range [][2]string{{"username", c.Username}, {"password", c.Password}} {
+		if a[1] == "" {
+			continue
+		}
+		if strings.ContainsAny(a[1], "\n\x00") {
+			return fmt.Errorf("gitcred: %s cannot contain a newline or NUL", a[0])
+		}
+		if _, err := fmt.Fprintf(w, "%s=%s\n", a[0], a[1]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// URL is the address the credential is for, as stored in a new entry.
+func (c *Credential) URL() string {
+	u := url.URL{Scheme: c.Protocol, Host: c.Host}
+	if c.Path != "" {
+		u.Path = "/" + c.Path
+	}
+	return u.String()
+}
@@ -0,0 +1,131 @@
+package hdwallet
+
+import (
+	"crypto/ecdsa"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// DefaultBasePath is the BIP-44 Ethereum path used by MetaMask and most
+// hardware wallets; accounts are its children.
+const DefaultBasePath = "m/44'/60'/0'/0"
+
+const hardenedOffset = 0x80000000
+
+var ErrInvalidPath = errors.New("invalid derivation path")
+
+// AccountPath returns the path of the account-th key under
+// DefaultBasePath.
+func AccountPath(account uint32) string {
+	return fmt.Sprintf("%s/%d", DefaultBasePath, account)
+}
+
+// ParsePath reads a path such as m/44'/60'/0'/0/1 into child indices,
+// hardened ones offset by 2^31. Both ' and h mark hardened steps.
+func ParsePath(path string) ([]uint32, error) {
+	parts := strings.Split(strings.TrimSpace(path), "/")
+	if len(parts) == 0 || parts[0] != "m" {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+	}
+
+	indices := make([]uint32, 0, len(parts)-1)
+	for _, part := range parts[1:] {
+		offset := uint32(0)
+		if trimmed, ok := strings.CutSuffix(part, "'"); ok {
+			part, offset = trimmed, hardenedOffset
+		} else if trimmed, ok := strings.CutSuffix(part, "h"); ok {
+			part, offset = trimmed, hardenedOffset
+		}
+		n, err := strconv.ParseUint(part, 10, 31)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidPath, path)
+		}
+		indices = append(indices, uint32(n)+offset)
+	}
+	return indices, nil
+}
+
+// DeriveKey derives the private key at path from a BIP-39 seed with
+// BIP-32 private child derivation on secp256k1.
+func DeriveKey(seed []byte, path string) (*ecdsa.PrivateKey, error) {
+	indices, err := ParsePath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	key, chainCode := split(hmacSHA512([]byte("Bitcoin seed"), seed))
+	defer clear(key)
+	if err := checkKey(key); err != nil {
+		return nil, err
+	}
+
+	n := crypto.S256().Params().N
+	for _, index := range indices {
+		data := make([]byte, 0, 37)
+		if index >= hardenedOffset {
+			data = append(append(data, 0), key...)
+		} else {
+			parent, err := crypto.ToECDSA(key)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, crypto.CompressPubkey(&parent.PublicKey)...)
+		}
+		data = binary.BigEndian.AppendUint32(data, index)
+
+		tweak, childChain := split(hmacSHA512(chainCode, data))
+		clear(data)
+		if err := checkKey(tweak); err != nil {
+			return nil, err
+		}
+
+		child := new(big.Int).SetBytes(tweak)
+		child.Add(child, new(big.Int).SetBytes(key))
+		child.Mod(child, n)
+		if child.Sign() == 0 {
+			return nil, errors.New("derived an invalid key, use another index")
+		}
+		clear(key)
+		key, chainCode = child.FillBytes(make([]byte, 32)), childChain
+	}
+	return crypto.ToECDSA(key)
+}
+
+// DeriveAccount derives the key of account from mnemonic under
+// DefaultBasePath.
+func DeriveAccount(mnemonic, passphrase string, account uint32) (*ecdsa.PrivateKey, error) {
+	seed, err := Seed(mnemonic, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(seed)
+	return DeriveKey(seed, AccountPath(account))
+}
+
+func hmacSHA512(key, data []byte) []byte {
+	mac := hmac.New(sha512.New, key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
+
+func split(sum []byte) (left, right []byte) {
+	return sum[:32], sum[32:]
+}
+
+// checkKey rejects the astronomically unlikely HMAC outputs BIP-32 says to
+// skip: zero or not below the curve order.
+func checkKey(key []byte) error {
+	k := new(big.Int).SetBytes(key)
+	if k.Sign() == 0 || k.Cmp(crypto.S256().Params().N) >= 0 {
+		return errors.New("derived an invalid key, use another index")
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Package hdwallet implements BIP-39 mnemonics and BIP-32/BIP-44 key
+// derivation, so a vault's wallet key can be generated here and restored
+// from a phrase in any standard wallet.
+package hdwallet
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	_ "embed"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+//go:embed english.txt
+var englishWords string
+
+var (
+	wordList  = strings.Fields(englishWords)
+	wordIndex = func() map[string]int {
+		index := make(map[string]int, len(wordList))
+		for i, w := range wordList {
+			index[w] = i
+		}
+		return index
+	}()
+)
+
+var (
+	ErrInvalidWordCount = errors.New("mnemonic must have 12, 15, 18, 21 or 24 words")
+	ErrUnknownWord      = errors.New("word is not in the BIP-39 English list")
+	ErrInvalidChecksum  = errors.New("mnemonic checksum mismatch")
+)
+
+// DefaultWordCount gives 256 bits of entropy.
+const DefaultWordCount = 24
+
+// NewMnemonic returns a random English mnemonic of wordCount words.
+func NewMnemonic(wordCount int) (string, error) {
+	entropyBits, err := entropyBits(wordCount)
+	if err != nil {
+		return "", err
+	}
+	entropy := make([]byte, entropyBits/8)
+	if _, err := rand.Read(entropy); err != nil {
+		return "", fmt.Errorf("generate entropy: %w", err)
+	}
+	defer clear(entropy)
+	return MnemonicFromEntropy(entropy)
+}
+
+func entropyBits(wordCount int) (int, error) {
+	switch wordCount {
+	case 12, 15, 18, 21, 24:
+		// each word is 11 bits; one bit in 33 is checksum
+		return wordCount * 11 * 32 / 33, nil
+	}
+	return 0, ErrInvalidWordCount
+}
+
+// MnemonicFromEntropy encodes 16 to 32 bytes of entropy as words: the
+// SHA-256 checksum bits are appended and the result read as 11-bit word
+// indices.
+func MnemonicFromEntropy(entropy []byte) (string, error) {
+	if len(entropy) < 16 || len(entropy) > 32 || len(entropy)%4 != 0 {
+		return "", ErrInvalidWordCount
+	}
+	checksumBits := len(entropy) * 8 / 32
+	hash := sha256.Sum256(entropy)
+
+	bits := new(big.Int).SetBytes(entropy)
+	bits.Lsh(bits, uint(checksumBits))
+	bits.Or(bits, big.NewInt(int64(hash[0]>>(8-checksumBits))))
+
+	words := make([]string, (len(entropy)*8+checksumBits)/11)
+	mask := big.NewInt(2047)
+	for i := len(words) - 1; i >= 0; i-- {
+		words[i] = wordList[new(big.Int).And(bits, mask).Int64()]
+		bits.Rsh(bits, 11)
+	}
+	return strings.Join(words, " "), nil
+}
+
+// NormalizeMnemonic lowercases mnemonic and collapses its whitespace.
+func NormalizeMnemonic(mnemonic string) string {
+	return strings.Join(strings.Fields(strings.ToLower(mnemonic)), " ")
+}
+
+// ValidateMnemonic checks the words and the checksum of mnemonic.
+func ValidateMnemonic(mnemonic string) error {
+	words := strings.Fields(NormalizeMnemonic(mnemonic))
+	entropyBits, err := entropyBits(len(words))
+	if err != nil {
+		return err
+	}
+
+	bits := new(big.Int)
+	for _, w := range words {
+		i, ok := wordIndex[w]
+		if !ok {
+			return fmt.Errorf("%w: %q", ErrUnknownWord, w)
+		}
+		bits.Lsh(bits, 11)
+		bits.Or(bits, big.NewInt(int64(i)))
+	}
+
+	checksumBits := entropyBits / 32
+	checksum := new(big.Int).And(bits, big.NewInt(1<<checksumBits-1)).Int64()
+	entropy := bits.Rsh(bits, uint(checksumBits)).FillBytes(make([]byte, entropyBits/8))
+	defer clear(entropy)
+
+	hash := sha256.Sum256(entropy)
+	if int64(hash[0]>>(8-checksumBits)) != checksum {
+		return ErrInvalidChecksum
+	}
+	return nil
+}
+
+// Seed derives the 64-byte BIP-39 seed of a valid mnemonic. The optional
+// passphrase yields an entirely different wallet, so losing it loses the
+// keys as surely as losing the words.
+func Seed(mnemonic, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(mnemonic); err != nil {
+		return nil, err
+	}
+	password := norm.NFKD.String(NormalizeMnemonic(mnemonic))
+	salt := norm.NFKD.String("mnemonic" + passphrase)
+	return pbkdf2.Key(sha512.New, password, []byte(salt), 2048, 64)
+}
@@ -0,0 +1,76 @@
+package keymanager
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"encryptkeep-backend/internal/codec"
+	localcrypto "encryptkeep-backend/internal/crypto"
+	"encryptkeep-backend/internal/vault"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+var ErrInvalidEmergencyKit = errors.New("invalid emergency access kit")
+
+// emergencyKit is what an emergency contact receives: the owner's address
+// and vault keys, wrapped to the contact's public key. The keys open the
+// owner's vault data on chain; neither the master password nor the wallet
+// key is part of the kit, so a contact can read but never write the vault.
+type emergencyKit struct {
+	Owner     string            `json:"owner"`
+	VaultKeys map[string][]byte `json:"vault_keys"` // by hex salt, as codec.VaultKey.Export
+}
+
+// SealEmergencyKit wraps the vault keys of the active session for an
+// emergency contact.
+func (km *KeyManager) SealEmergencyKit(contact *ecdsa.PublicKey) ([]byte, error) {
+	km.mu.Lock()
+	if !km.sessionActive() {
+		km.mu.Unlock()
+		return nil, fmt.Errorf("session not active")
+	}
+	address := km.address
+	km.mu.Unlock()
+
+	keys, err := km.sessionVaultKeys(address)
+	if err != nil {
+		return nil, err
+	}
+	defer wipeVaultKeys(keys)
+
+	raw, err := json.Marshal(emergencyKit{Owner: address, VaultKeys: keys})
+	if err != nil {
+		return nil, err
+	}
+	defer clear(raw)
+	return localcrypto.WrapSecret(contact, raw)
+}
+
+// OpenEmergencyKit unwraps a kit sealed to this vault's wallet key and
+// returns the owner's address and vault key, which the caller destroys.
+func (km *KeyManager) OpenEmergencyKit(wrapped []byte) (string, *codec.VaultKey, error) {
+	var raw []byte
+	err := km.WithPrivateKey(func(key *ecdsa.PrivateKey) error {
+		var err error
+		raw, err = localcrypto.UnwrapSecret(key, wrapped)
+		return err
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	defer clear(raw)
+
+	var kit emergencyKit
+	defer func() { wipeVaultKeys(kit.VaultKeys) }()
+	if err := json.Unmarshal(raw, &kit); err != nil || !common.IsHexAddress(kit.Owner) || len(kit.VaultKeys) == 0 {
+		return "", nil, ErrInvalidEmergencyKit
+	}
+	key, err := codec.ImportVaultKey(kit.Owner, kit.VaultKeys, codec.FromVaultConfig(vault.DefaultVaultConfig()))
+	if err != nil {
+		return "", nil, err
+	}
+	return kit.Owner, key, nil
+}
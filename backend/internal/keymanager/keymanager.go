@@ -3,31 +3,51 @@ package keymanager
 import (
 	"crypto/ecdsa"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 	"unicode/utf8"
 
 	"encryptkeep-backend/internal/codec"
 	localcrypto "encryptkeep-backend/internal/crypto"
+	"encryptkeep-backend/internal/secret"
 	"encryptkeep-backend/internal/vault"
 
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+// ErrNoWalletKey is returned for wallet key operations in a session
+// started with UnlockVaultKey.
+var ErrNoWalletKey = errors.New("wallet key not unlocked in this session")
+
+// KeyManager is safe for concurrent use. SessionActive and LastActivity
+// are guarded by its lock once it is shared; set them only before that.
 type KeyManager struct {
 	ConfigDir     string
-	PrivateKey    *ecdsa.PrivateKey
-	MasterKey     []byte
 	SessionActive bool
 	LastActivity  time.Time
 	config        KeyManagerConfig
+
+	mu sync.Mutex // guards the session state below and the two fields above
+	// fileMu serializes access to keys.json; loadKeyData, saveKeyData and
+	// unseal expect the caller to hold it
+	fileMu sync.RWMutex
+
+	// set while a session is active; both live in locked memory and the
+	// master password itself is never kept
+	privateKey *secret.Secret
+	vaultKey   *codec.VaultKey
+	address    string
 }
 
 type KeyManagerConfig struct {
 	ConfigDir      string
 	SessionTimeout time.Duration
+	PasswordPolicy PasswordPolicy // zero value means DefaultPasswordPolicy
 }
 
 type StoredKeyData struct {
@@ -36,6 +56,9 @@ type StoredKeyData struct {
 	Nonce               []byte `json:"nonce"`
 	CreatedAt           string `json:"created_at"`
 	Address             string `json:"address"`
+	DerivationPath      string `json:"derivation_path,omitempty"` // set for keys derived from a mnemonic
+
+	Recovery *RecoveryData `json:"recovery,omitempty"`
 }
 
 func NewKeyManager(config KeyManagerConfig) *KeyManager {
@@ -44,7 +67,11 @@ func NewKeyManager(config KeyManagerConfig) *KeyManager {
 	}
 
 	if config.ConfigDir == "" {
-		config.ConfigDir = defaultConfigDir()
+		config.ConfigDir = DefaultConfigDir()
+	}
+
+	if config.PasswordPolicy == (PasswordPolicy{}) {
+		config.PasswordPolicy = DefaultPasswordPolicy()
 	}
 
 	return &KeyManager{
@@ -55,10 +82,12 @@ func NewKeyManager(config KeyManagerConfig) *KeyManager {
 	}
 }
 
+// DefaultConfigDir is where keys and the network config live unless
+// ENCRYPTKEEP_CONFIG_DIR says otherwise:
 // - Windows: %AppData%\encryptkeep\keys
 // - Linux:   $XDG_CONFIG_HOME/encryptkeep/keys or ~/.config/encryptkeep/keys
 // - macOS:   ~/Library/Application Support/encryptkeep/keys
-func defaultConfigDir() string {
+func DefaultConfigDir() string {
 	if v := os.Getenv("ENCRYPTKEEP_CONFIG_DIR"); v != "" {
 		return v
 	}
@@ -82,20 +111,16 @@ func (km *KeyManager) HasStoredKeys() bool {
 }
 
 func (km *KeyManager) GetAddress() (string, error) {
-	if !km.IsSessionActive() {
-		return "", fmt.Errorf("session is not active")
-	}
+	km.mu.Lock()
+	defer km.mu.Unlock()
 
-	publicKey := km.PrivateKey.Public()
-	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
-	if !ok {
-		return "", fmt.Errorf("invalid public key")
+	if !km.sessionActive() {
+		return "", fmt.Errorf("session is not active")
 	}
-	userAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
 
-	km.UpdateActivity()
+	km.LastActivity = time.Now()
 
-	return userAddress.Hex(), nil
+	return km.address, nil
 }
 
 func (km *KeyManager) getKeyFilePath() string {
@@ -133,63 +158,161 @@ func (km *KeyManager) loadKeyData() (StoredKeyData, error) {
 }
 
 func (km *KeyManager) IsSessionActive() bool {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	return km.sessionActive()
+}
+
+// sessionActive is IsSessionActive for callers holding km.mu.
+func (km *KeyManager) sessionActive() bool {
 	if !km.SessionActive {
 		return false
 	}
 
 	if time.Since(km.LastActivity) > km.config.SessionTimeout {
-		km.ClearSession()
+		km.clearSession()
 		return false
 	}
 
 	return true
 }
 
+// ClearSession wipes the wallet key and the vault key. Anything still
+// holding the vault key fails from now on.
 func (km *KeyManager) ClearSession() {
-	if km.PrivateKey != nil {
-		km.PrivateKey = nil
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	km.clearSession()
+}
+
+func (km *KeyManager) clearSession() {
+	if km.privateKey != nil {
+		km.privateKey.Destroy()
+		km.privateKey = nil
 	}
 
-	if km.MasterKey != nil {
-		for i := range km.MasterKey {
-			km.MasterKey[i] = 0
-		}
-		km.MasterKey = nil
+	if km.vaultKey != nil {
+		km.vaultKey.Destroy()
+		km.vaultKey = nil
 	}
 
+	km.address = ""
 	km.SessionActive = false
 }
 
+// GetPrivateKey returns a copy of the wallet key outside locked memory.
+// Prefer WithPrivateKey, which wipes the copy afterwards.
 func (km *KeyManager) GetPrivateKey() (*ecdsa.PrivateKey, error) {
-	if !km.IsSessionActive() {
-		return nil, fmt.Errorf("session not active")
-	}
+	var key *ecdsa.PrivateKey
+	err := km.WithPrivateKey(func(k *ecdsa.PrivateKey) error {
+		key = &ecdsa.PrivateKey{PublicKey: k.PublicKey, D: new(big.Int).Set(k.D)}
+		return nil
+	})
+	return key, err
+}
 
+// WithPrivateKey calls fn with the wallet key, rebuilt from locked memory
+// and wiped when fn returns. fn must not keep the key or call back into
+// km: a concurrent ClearSession waits for fn while holding the manager.
+func (km *KeyManager) WithPrivateKey(fn func(key *ecdsa.PrivateKey) error) error {
+	km.mu.Lock()
+	if !km.sessionActive() {
+		km.mu.Unlock()
+		return fmt.Errorf("session not active")
+	}
 	km.LastActivity = time.Now()
-	return km.PrivateKey, nil
+	privateKey := km.privateKey
+	km.mu.Unlock()
+	if privateKey == nil {
+		return ErrNoWalletKey
+	}
+
+	// a ClearSession racing fn destroys the secret; Use then fails with
+	// secret.ErrDestroyed instead of handing out a wiped key
+	return privateKey.Use(func(b []byte) error {
+		key, err := crypto.ToECDSA(b)
+		if err != nil {
+			return err
+		}
+		defer wipeKey(key)
+		return fn(key)
+	})
 }
 
-func (km *KeyManager) GetMasterKey() ([]byte, error) {
-	if !km.IsSessionActive() {
+// VaultKey returns the key vault data is sealed with this session. It is
+// destroyed by ClearSession.
+func (km *KeyManager) VaultKey() (*codec.VaultKey, error) {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+
+	if !km.sessionActive() {
 		return nil, fmt.Errorf("session not active")
 	}
 
 	km.LastActivity = time.Now()
-	return km.MasterKey, nil
+	return km.vaultKey, nil
 }
 
 func (km *KeyManager) UpdateActivity() {
+	km.mu.Lock()
+	defer km.mu.Unlock()
 	if km.SessionActive {
 		km.LastActivity = time.Now()
 	}
 }
 
 func (km *KeyManager) LoadFromStorage(masterPassword string) error {
-	loadedData, err := km.loadKeyData()
+	km.fileMu.RLock()
+	privateKey, err := km.unseal(masterPassword)
+	km.fileMu.RUnlock()
 	if err != nil {
 		return err
 	}
 
+	return km.startSession(privateKey, masterPassword)
+}
+
+// StoredAddress returns the wallet address recorded in keys.json, which
+// is kept in the clear; nothing is decrypted.
+func (km *KeyManager) StoredAddress() (string, error) {
+	km.fileMu.RLock()
+	defer km.fileMu.RUnlock()
+	data, err := km.loadKeyData()
+	if err != nil {
+		return "", err
+	}
+	return data.Address, nil
+}
+
+// UnlockVaultKey starts a session holding only the vault key of address,
+// for when transactions are signed outside this process. keys.json is
+// not opened, so the password is not checked against it: a wrong one
+// derives a key that fails to open the vault's data. Wallet key
+// operations fail with ErrNoWalletKey.
+func (km *KeyManager) UnlockVaultKey(address, masterPassword string) error {
+	vaultKey, err := codec.NewVaultKey(masterPassword, address, codec.FromVaultConfig(vault.DefaultVaultConfig()))
+	if err != nil {
+		return err
+	}
+
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	km.clearSession()
+	km.vaultKey = vaultKey
+	km.address = address
+	km.SessionActive = true
+	km.LastActivity = time.Now()
+	return nil
+}
+
+// unseal decrypts the stored wallet key with masterPassword and checks it
+// against the stored address.
+func (km *KeyManager) unseal(masterPassword string) (*ecdsa.PrivateKey, error) {
+	loadedData, err := km.loadKeyData()
+	if err != nil {
+		return nil, err
+	}
+
 	encrypted := localcrypto.Sealed{
 		Salt:       loadedData.Salt,
 		Nonce:      loadedData.Nonce,
@@ -198,34 +321,40 @@ func (km *KeyManager) LoadFromStorage(masterPassword string) error {
 
 	privateKeyHex, err := localcrypto.Open(masterPassword, codec.FromVaultConfig(vault.DefaultVaultConfig()), encrypted)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	privateKey, err := crypto.HexToECDSA(string(privateKeyHex))
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	publicKey := privateKey.Public()
 	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
 	if !ok {
-		return fmt.Errorf("invalid public key")
+		return nil, fmt.Errorf("invalid public key")
 	}
 	userAddress := crypto.PubkeyToAddress(*publicKeyECDSA)
 	if userAddress.Hex() != loadedData.Address {
-		return fmt.Errorf("addresses mismatch")
+		return nil, fmt.Errorf("addresses mismatch")
 	}
 
-	return km.startSession(privateKey, masterPassword)
+	return privateKey, nil
 }
 
 func (km *KeyManager) InitializeFirstTime(privateKeyHex, masterPassword string) error {
+	return km.initialize(privateKeyHex, masterPassword, "")
+}
+
+// initialize seals and stores the wallet key; derivationPath records where
+// a key derived from a mnemonic came from.
+func (km *KeyManager) initialize(privateKeyHex, masterPassword, derivationPath string) error {
 	if len(privateKeyHex) != 64 {
 		return fmt.Errorf("invalid private key")
 	}
 
-	if len(masterPassword) < 8 {
-		return fmt.Errorf("master password should be greater or equal 8")
+	if err := km.config.PasswordPolicy.Check(masterPassword); err != nil {
+		return err
 	}
 
 	if !utf8.ValidString(privateKeyHex) {
@@ -256,25 +385,48 @@ func (km *KeyManager) InitializeFirstTime(privateKeyHex, masterPassword string)
 		Nonce:               sealed.Nonce,
 		CreatedAt:           time.Now().Format(time.RFC3339),
 		Address:             userAddress.Hex(),
+		DerivationPath:      derivationPath,
 	}
 
-	if err := km.saveKeyData(storedData); err != nil {
+	km.fileMu.Lock()
+	err = km.saveKeyData(storedData)
+	km.fileMu.Unlock()
+	if err != nil {
 		return err
 	}
 
 	return km.startSession(privateKey, masterPassword)
 }
 
+// startSession moves privateKey into locked memory, wiping the caller's
+// copy, and derives the vault key from masterPassword.
 func (km *KeyManager) startSession(privateKey *ecdsa.PrivateKey, masterPassword string) error {
-	dk, err := localcrypto.DeriveKey(masterPassword, nil, codec.FromVaultConfig(vault.DefaultVaultConfig()))
+	defer wipeKey(privateKey)
+
+	address := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	vaultKey, err := codec.NewVaultKey(masterPassword, address, codec.FromVaultConfig(vault.DefaultVaultConfig()))
 	if err != nil {
 		return err
 	}
+	locked, err := secret.FromBytes(crypto.FromECDSA(privateKey))
+	if err != nil {
+		vaultKey.Destroy()
+		return err
+	}
 
-	km.PrivateKey = privateKey
-	km.MasterKey = dk.Key
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	km.clearSession()
+	km.privateKey = locked
+	km.vaultKey = vaultKey
+	km.address = address
 	km.SessionActive = true
 	km.LastActivity = time.Now()
 
 	return nil
 }
+
+// wipeKey zeroes the scalar of a key copy that is no longer needed.
+func wipeKey(key *ecdsa.PrivateKey) {
+	clear(key.D.Bits())
+}
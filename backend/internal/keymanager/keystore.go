@@ -0,0 +1,182 @@
+package keymanager
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+)
+
+// KeystoreKDF selects the key derivation function of an exported
+// Web3 Secret Storage (V3) file.
+type KeystoreKDF string
+
+const (
+	// KDFScrypt is geth's default, with its standard cost parameters.
+	KDFScrypt KeystoreKDF = "scrypt"
+	// KDFPBKDF2 uses PBKDF2-HMAC-SHA256, for tools without scrypt.
+	KDFPBKDF2 KeystoreKDF = "pbkdf2"
+
+	// pbkdf2Iterations matches the count ethereumjs-wallet and MetaMask
+	// write.
+	pbkdf2Iterations = 262144
+)
+
+var (
+	// ErrInvalidKeystore is returned for files that are not V3 keystores.
+	ErrInvalidKeystore = errors.New("invalid keystore file")
+	// ErrKeystorePassword is returned when the keystore password is wrong.
+	ErrKeystorePassword = keystore.ErrDecrypt
+)
+
+// keystoreV3 is the Web3 Secret Storage layout; Crypto is filled either
+// by geth's encoder or by encryptPBKDF2.
+type keystoreV3 struct {
+	Address string `json:"address"`
+	Crypto  any    `json:"crypto"`
+	ID      string `json:"id"`
+	Version int    `json:"version"`
+}
+
+// ExportKeystore returns the wallet key as a V3 keystore encrypted with
+// keystorePassword, which geth, clef and MetaMask import as is. The
+// master password unlocks keys.json first; it never leaves the process.
+func (km *KeyManager) ExportKeystore(masterPassword, keystorePassword string, kdf KeystoreKDF) ([]byte, error) {
+	if keystorePassword == "" {
+		return nil, fmt.Errorf("keystore password is empty")
+	}
+
+	km.fileMu.RLock()
+	privateKey, err := km.unseal(masterPassword)
+	km.fileMu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	defer wipeKey(privateKey)
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	key := &keystore.Key{
+		Id:         id,
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+
+	switch kdf {
+	case KDFScrypt, "":
+		return keystore.EncryptKey(key, keystorePassword, keystore.StandardScryptN, keystore.StandardScryptP)
+	case KDFPBKDF2:
+		cryptoJSON, err := encryptPBKDF2(crypto.FromECDSA(privateKey), keystorePassword)
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(keystoreV3{
+			Address: hex.EncodeToString(key.Address[:]),
+			Crypto:  cryptoJSON,
+			ID:      id.String(),
+			Version: 3,
+		})
+	default:
+		return nil, fmt.Errorf("unsupported keystore KDF %q", kdf)
+	}
+}
+
+// InitializeFromKeystore stores the key of a V3 keystore (scrypt or
+// pbkdf2) under masterPassword, then works like InitializeFirstTime.
+func (km *KeyManager) InitializeFromKeystore(keyJSON []byte, keystorePassword, masterPassword string) error {
+	if err := km.config.PasswordPolicy.Check(masterPassword); err != nil {
+		return err
+	}
+
+	key, err := decryptKeystore(keyJSON, keystorePassword)
+	if err != nil {
+		return err
+	}
+	privateKeyHex := hex.EncodeToString(crypto.FromECDSA(key.PrivateKey))
+	wipeKey(key.PrivateKey)
+
+	return km.initialize(privateKeyHex, masterPassword, "")
+}
+
+// KeystoreFileName returns the name geth gives the keystore of address,
+// so exported files can be dropped into a keystore directory.
+func KeystoreFileName(address string, now time.Time) string {
+	return fmt.Sprintf("UTC--%s--%s",
+		now.UTC().Format("2006-01-02T15-04-05.000000000Z"),
+		strings.ToLower(strings.TrimPrefix(address, "0x")))
+}
+
+// decryptKeystore wraps keystore.DecryptKey, whose KDF parameter parsing
+// panics on malformed files instead of returning an error.
+func decryptKeystore(keyJSON []byte, password string) (key *keystore.Key, err error) {
+	var probe struct {
+		Version any `json:"version"`
+	}
+	if json.Unmarshal(keyJSON, &probe) != nil || probe.Version == nil {
+		return nil, ErrInvalidKeystore
+	}
+
+	defer func() {
+		if recover() != nil {
+			key, err = nil, ErrInvalidKeystore
+		}
+	}()
+	key, err = keystore.DecryptKey(keyJSON, password)
+	if err != nil && !errors.Is(err, keystore.ErrDecrypt) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidKeystore, err)
+	}
+	return key, err
+}
+
+// encryptPBKDF2 builds the crypto section of a V3 keystore the way
+// EncryptDataV3 does for scrypt: AES-128-CTR under the first half of the
+// derived key, MAC = keccak256(second half || ciphertext).
+func encryptPBKDF2(data []byte, password string) (map[string]any, error) {
+	salt := make([]byte, 32)
+	iv := make([]byte, aes.BlockSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return nil, err
+	}
+
+	derivedKey, err := pbkdf2.Key(sha256.New, password, salt, pbkdf2Iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(derivedKey[:16])
+	if err != nil {
+		return nil, err
+	}
+	cipherText := make([]byte, len(data))
+	cipher.NewCTR(block, iv).XORKeyStream(cipherText, data)
+	mac := crypto.Keccak256(derivedKey[16:32], cipherText)
+
+	return map[string]any{
+		"cipher":       "aes-128-ctr",
+		"ciphertext":   hex.EncodeToString(cipherText),
+		"cipherparams": map[string]string{"iv": hex.EncodeToString(iv)},
+		"kdf":          "pbkdf2",
+		"kdfparams": map[string]any{
+			"c":     pbkdf2Iterations,
+			"dklen": 32,
+			"prf":   "hmac-sha256",
+			"salt":  hex.EncodeToString(salt),
+		},
+		"mac": hex.EncodeToString(mac),
+	}, nil
+}
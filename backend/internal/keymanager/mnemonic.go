@@ -0,0 +1,41 @@
+package keymanager
+
+import (
+	"encoding/hex"
+
+	"encryptkeep-backend/internal/hdwallet"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// InitializeFromMnemonic stores the wallet key of account derived from a
+// BIP-39 mnemonic along hdwallet.AccountPath, then works like
+// InitializeFirstTime. Only the derived key is kept: the words and the
+// passphrase stay with the user, and restoring them in any BIP-44 wallet
+// yields the same address.
+func (km *KeyManager) InitializeFromMnemonic(mnemonic, passphrase string, account uint32, masterPassword string) error {
+	if err := km.config.PasswordPolicy.Check(masterPassword); err != nil {
+		return err
+	}
+
+	privateKey, err := hdwallet.DeriveAccount(mnemonic, passphrase, account)
+	if err != nil {
+		return err
+	}
+	privateKeyHex := hex.EncodeToString(crypto.FromECDSA(privateKey))
+	wipeKey(privateKey)
+
+	return km.initialize(privateKeyHex, masterPassword, hdwallet.AccountPath(account))
+}
+
+// DerivationPath returns the path the stored key was derived along, or ""
+// for an imported raw key.
+func (km *KeyManager) DerivationPath() string {
+	km.fileMu.RLock()
+	defer km.fileMu.RUnlock()
+	data, err := km.loadKeyData()
+	if err != nil {
+		return ""
+	}
+	return data.DerivationPath
+}
@@ -0,0 +1,70 @@
+package keymanager
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+var ErrWeakPassword = errors.New("master password does not meet the vault policy")
+
+// minPasswordLength is the shortest master password the codec accepts; a
+// policy cannot go below it.
+const minPasswordLength = 8
+
+// PasswordPolicy is the master password rule set of a vault.
+type PasswordPolicy struct {
+	MinLength     int  `json:"min_length"`
+	RequireUpper  bool `json:"require_upper"`
+	RequireLower  bool `json:"require_lower"`
+	RequireDigit  bool `json:"require_digit"`
+	RequireSymbol bool `json:"require_symbol"`
+}
+
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{MinLength: minPasswordLength}
+}
+
+// Check returns ErrWeakPassword listing every rule password breaks. A
+// MinLength below 8 counts as 8.
+func (p PasswordPolicy) Check(password string) error {
+	var missing []string
+
+	minLength := max(p.MinLength, minPasswordLength)
+	if utf8.RuneCountInString(password) < minLength {
+		missing = append(missing, fmt.Sprintf("at least %d characters", minLength))
+	}
+
+	var upper, lower, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsDigit(r):
+			digit = true
+		case unicode.IsPunct(r) || unicode.IsSymbol(r) || unicode.IsSpace(r):
+			symbol = true
+		}
+	}
+	if p.RequireUpper && !upper {
+		missing = append(missing, "an upper-case letter")
+	}
+	if p.RequireLower && !lower {
+		missing = append(missing, "a lower-case letter")
+	}
+	if p.RequireDigit && !digit {
+		missing = append(missing, "a digit")
+	}
+	if p.RequireSymbol && !symbol {
+		missing = append(missing, "a symbol")
+	}
+
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: needs %s", ErrWeakPassword, strings.Join(missing, ", "))
+	}
+	return nil
+}
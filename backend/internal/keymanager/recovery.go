@@ -0,0 +1,385 @@
+package keymanager
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"encryptkeep-backend/internal/codec"
+	localcrypto "encryptkeep-backend/internal/crypto"
+	"encryptkeep-backend/internal/secret"
+	"encryptkeep-backend/internal/shamir"
+	"encryptkeep-backend/internal/vault"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	ErrNoRecovery       = errors.New("no recovery kit set up for this vault")
+	ErrForeignShare     = errors.New("share does not belong to this vault's recovery kit")
+	ErrRecoveryMismatch = errors.New("shares do not open this vault's recovery kit")
+	ErrOldRecoveryKit   = errors.New("recovery kit was made by an older version and cannot be opened, set up recovery again")
+)
+
+// RecoveryData is the wallet key and the vault keys wrapped to a random
+// recovery key. Only its public half is stored, so the kit can be
+// re-wrapped when the vault keys change; the private half exists only as
+// Shamir shares handed out when the kit is created.
+type RecoveryData struct {
+	Set       uint32 `json:"set"`
+	Shares    int    `json:"shares"`
+	Threshold int    `json:"threshold"`
+	PublicKey []byte `json:"public_key"`
+	Kit       []byte `json:"kit"`
+	CreatedAt string `json:"created_at"`
+}
+
+// recoveryKit is what the recovery key unwraps: the keys the master
+// password protects, never the password itself.
+type recoveryKit struct {
+	PrivateKey []byte            `json:"private_key"`
+	VaultKeys  map[string][]byte `json:"vault_keys"` // by hex salt, as codec.VaultKey.Export
+}
+
+// Recovery is a recovery kit opened from its shares. Destroy wipes it.
+type Recovery struct {
+	Address  string
+	VaultKey *codec.VaultKey
+
+	privateKey *secret.Secret
+	set        uint32
+}
+
+// WithPrivateKey calls fn with the recovered wallet key, wiped when fn
+// returns.
+func (r *Recovery) WithPrivateKey(fn func(key *ecdsa.PrivateKey) error) error {
+	return r.privateKey.Use(func(b []byte) error {
+		key, err := crypto.ToECDSA(b)
+		if err != nil {
+			return err
+		}
+		defer wipeKey(key)
+		return fn(key)
+	})
+}
+
+// Destroy wipes the recovered keys.
+func (r *Recovery) Destroy() {
+	r.privateKey.Destroy()
+	r.VaultKey.Destroy()
+}
+
+// HasRecovery reports whether keys.json holds a recovery kit.
+func (km *KeyManager) HasRecovery() bool {
+	km.fileMu.RLock()
+	defer km.fileMu.RUnlock()
+	data, err := km.loadKeyData()
+	return err == nil && data.Recovery != nil
+}
+
+// CheckPassword applies the vault's password policy.
+func (km *KeyManager) CheckPassword(password string) error {
+	return km.config.PasswordPolicy.Check(password)
+}
+
+// SetupRecovery creates a recovery kit for the keys masterPassword opens
+// and returns its n printable shares, threshold of which reset the
+// password with Recover and ResetMasterPassword. A new kit replaces the
+// old one, whose shares stop working.
+func (km *KeyManager) SetupRecovery(masterPassword string, n, threshold int) ([]string, error) {
+	km.fileMu.Lock()
+	defer km.fileMu.Unlock()
+
+	data, err := km.loadKeyData()
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := km.unseal(masterPassword)
+	if err != nil {
+		return nil, fmt.Errorf("verify master password: %w", err)
+	}
+	defer wipeKey(privateKey)
+
+	// an unlocked session also knows the keys of older envelopes
+	vaultKeys, err := km.sessionVaultKeys(data.Address)
+	if err != nil {
+		vaultKey, err := codec.NewVaultKey(masterPassword, data.Address, codec.FromVaultConfig(vault.DefaultVaultConfig()))
+		if err != nil {
+			return nil, err
+		}
+		vaultKeys, err = vaultKey.Export()
+		vaultKey.Destroy()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	key, recoveryKey, err := newRecoveryKey()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(key)
+	defer wipeKey(recoveryKey)
+
+	shares, err := shamir.Split(key, n, threshold)
+	if err != nil {
+		return nil, err
+	}
+	kit, err := wrapRecoveryKit(&recoveryKey.PublicKey, crypto.FromECDSA(privateKey), vaultKeys)
+	if err != nil {
+		return nil, err
+	}
+
+	data.Recovery = &RecoveryData{
+		Set:       shares[0].Set,
+		Shares:    n,
+		Threshold: threshold,
+		PublicKey: crypto.FromECDSAPub(&recoveryKey.PublicKey),
+		Kit:       kit,
+		CreatedAt: time.Now().Format(time.RFC3339),
+	}
+	if err := km.saveKeyData(data); err != nil {
+		return nil, err
+	}
+
+	encoded := make([]string, len(shares))
+	for i, s := range shares {
+		encoded[i] = s.String()
+	}
+	return encoded, nil
+}
+
+// RefreshRecovery re-wraps the recovery kit with the keys of the active
+// session, e.g. once keys of older envelopes have been derived. The
+// shares stay the same.
+func (km *KeyManager) RefreshRecovery() error {
+	km.fileMu.Lock()
+	defer km.fileMu.Unlock()
+
+	data, err := km.loadKeyData()
+	if err != nil {
+		return err
+	}
+	if data.Recovery == nil {
+		return ErrNoRecovery
+	}
+	if len(data.Recovery.PublicKey) == 0 {
+		return ErrOldRecoveryKit
+	}
+	pub, err := crypto.UnmarshalPubkey(data.Recovery.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	vaultKeys, err := km.sessionVaultKeys(data.Address)
+	if err != nil {
+		return err
+	}
+	defer wipeVaultKeys(vaultKeys)
+	var kit []byte
+	err = km.WithPrivateKey(func(key *ecdsa.PrivateKey) error {
+		kit, err = wrapRecoveryKit(pub, crypto.FromECDSA(key), vaultKeys)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	data.Recovery.Kit = kit
+	return km.saveKeyData(data)
+}
+
+// Recover rebuilds the recovery key from printed shares and opens the
+// keys wrapped in keys.json.
+func (km *KeyManager) Recover(shareTexts []string) (*Recovery, error) {
+	km.fileMu.RLock()
+	data, err := km.loadKeyData()
+	km.fileMu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+	if data.Recovery == nil {
+		return nil, ErrNoRecovery
+	}
+
+	shares := make([]shamir.Share, len(shareTexts))
+	for i, text := range shareTexts {
+		share, err := shamir.ParseShare(text)
+		if err != nil {
+			return nil, fmt.Errorf("share %d: %w", i+1, err)
+		}
+		if share.Set != data.Recovery.Set {
+			return nil, fmt.Errorf("share %d: %w", i+1, ErrForeignShare)
+		}
+		shares[i] = share
+	}
+	if len(data.Recovery.Kit) == 0 {
+		return nil, ErrOldRecoveryKit
+	}
+
+	key, err := shamir.Combine(shares)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(key)
+	recoveryKey, err := crypto.ToECDSA(key)
+	if err != nil {
+		return nil, ErrRecoveryMismatch
+	}
+	defer wipeKey(recoveryKey)
+
+	raw, err := localcrypto.UnwrapSecret(recoveryKey, data.Recovery.Kit)
+	if err != nil {
+		return nil, ErrRecoveryMismatch
+	}
+	defer clear(raw)
+	var kit recoveryKit
+	defer func() {
+		secret.Wipe(kit.PrivateKey)
+		wipeVaultKeys(kit.VaultKeys)
+	}()
+	if err := json.Unmarshal(raw, &kit); err != nil {
+		return nil, ErrRecoveryMismatch
+	}
+
+	privateKey, err := crypto.ToECDSA(kit.PrivateKey)
+	if err != nil {
+		return nil, ErrRecoveryMismatch
+	}
+	defer wipeKey(privateKey)
+	if crypto.PubkeyToAddress(privateKey.PublicKey).Hex() != data.Address {
+		return nil, fmt.Errorf("addresses mismatch")
+	}
+
+	vaultKey, err := codec.ImportVaultKey(data.Address, kit.VaultKeys, codec.FromVaultConfig(vault.DefaultVaultConfig()))
+	if err != nil {
+		return nil, err
+	}
+	locked, err := secret.FromBytes(kit.PrivateKey)
+	if err != nil {
+		vaultKey.Destroy()
+		return nil, err
+	}
+
+	return &Recovery{
+		Address:    data.Address,
+		VaultKey:   vaultKey,
+		privateKey: locked,
+		set:        data.Recovery.Set,
+	}, nil
+}
+
+// ResetMasterPassword seals the recovered wallet key under newPassword,
+// re-wraps the recovery kit with the vault key derived from it and starts
+// a session. The existing shares keep working. Vault data has to be
+// re-sealed under the new key separately, before this call, so a failure
+// there leaves the old password usable.
+func (km *KeyManager) ResetMasterPassword(r *Recovery, newPassword string) error {
+	if err := km.CheckPassword(newPassword); err != nil {
+		return err
+	}
+	km.fileMu.Lock()
+	defer km.fileMu.Unlock()
+
+	data, err := km.loadKeyData()
+	if err != nil {
+		return err
+	}
+	if data.Recovery == nil || data.Recovery.Set != r.set {
+		return ErrRecoveryMismatch
+	}
+	pub, err := crypto.UnmarshalPubkey(data.Recovery.PublicKey)
+	if err != nil {
+		return err
+	}
+
+	vaultKey, err := codec.NewVaultKey(newPassword, data.Address, codec.FromVaultConfig(vault.DefaultVaultConfig()))
+	if err != nil {
+		return err
+	}
+	vaultKeys, err := vaultKey.Export()
+	vaultKey.Destroy()
+	if err != nil {
+		return err
+	}
+
+	var privateKey *ecdsa.PrivateKey
+	err = r.WithPrivateKey(func(key *ecdsa.PrivateKey) error {
+		privateKeyHex := hex.EncodeToString(crypto.FromECDSA(key))
+		sealed, err := localcrypto.Seal(newPassword, codec.FromVaultConfig(vault.DefaultVaultConfig()), []byte(privateKeyHex))
+		if err != nil {
+			return err
+		}
+		kit, err := wrapRecoveryKit(pub, crypto.FromECDSA(key), vaultKeys)
+		if err != nil {
+			return err
+		}
+
+		data.EncryptedPrivateKey = sealed.Ciphertext
+		data.Salt = sealed.Salt
+		data.Nonce = sealed.Nonce
+		data.Recovery.Kit = kit
+		if err := km.saveKeyData(data); err != nil {
+			return err
+		}
+		// startSession wipes this copy
+		privateKey, err = crypto.ToECDSA(crypto.FromECDSA(key))
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	return km.startSession(privateKey, newPassword)
+}
+
+// sessionVaultKeys exports the vault keys of the active session when it
+// belongs to address.
+func (km *KeyManager) sessionVaultKeys(address string) (map[string][]byte, error) {
+	km.mu.Lock()
+	defer km.mu.Unlock()
+	if !km.sessionActive() || km.address != address {
+		return nil, fmt.Errorf("session not active")
+	}
+	return km.vaultKey.Export()
+}
+
+// newRecoveryKey returns a random secp256k1 key and its raw scalar, which
+// is what the shares split.
+func newRecoveryKey() ([]byte, *ecdsa.PrivateKey, error) {
+	for {
+		key, err := localcrypto.GenerateKey()
+		if err != nil {
+			return nil, nil, err
+		}
+		// a random scalar is out of range with negligible probability
+		if priv, err := crypto.ToECDSA(key); err == nil {
+			return key, priv, nil
+		}
+		clear(key)
+	}
+}
+
+// wrapRecoveryKit wraps the wallet key and vaultKeys to pub, wiping both.
+func wrapRecoveryKit(pub *ecdsa.PublicKey, privateKey []byte, vaultKeys map[string][]byte) ([]byte, error) {
+	defer func() {
+		secret.Wipe(privateKey)
+		wipeVaultKeys(vaultKeys)
+	}()
+
+	raw, err := json.Marshal(recoveryKit{PrivateKey: privateKey, VaultKeys: vaultKeys})
+	if err != nil {
+		return nil, err
+	}
+	defer clear(raw)
+	return localcrypto.WrapSecret(pub, raw)
+}
+
+func wipeVaultKeys(keys map[string][]byte) {
+	for _, key := range keys {
+		secret.Wipe(key)
+	}
+}
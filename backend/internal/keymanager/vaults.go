@@ -0,0 +1,259 @@
+package keymanager
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"encryptkeep-backend/internal/hdwallet"
+)
+
+const (
+	vaultsFileName = "vaults.json"
+
+	// DefaultVaultName is the vault whose keys.json sits directly in the
+	// base config dir, where single-vault installs keep it.
+	DefaultVaultName = "default"
+)
+
+var (
+	ErrVaultNotFound = errors.New("vault not found")
+	ErrVaultExists   = errors.New("vault already exists")
+	ErrInvalidName   = errors.New("invalid vault name")
+
+	vaultNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,31}$`)
+)
+
+// VaultInfo describes one named vault: its own wallet key lives in Dir,
+// it talks to the network Profile and enforces PasswordPolicy.
+type VaultInfo struct {
+	Name           string         `json:"name"`
+	Dir            string         `json:"dir"` // relative to the registry base dir
+	Address        string         `json:"address,omitempty"`
+	DerivationPath string         `json:"derivation_path,omitempty"` // BIP-44 path when the key came from a mnemonic
+	Profile        string         `json:"profile,omitempty"` // empty uses the config's default profile
+	PasswordPolicy PasswordPolicy `json:"password_policy"`
+	CreatedAt      time.Time      `json:"created_at"`
+	LastSync       time.Time      `json:"last_sync,omitempty"`
+	LastSyncError  string         `json:"last_sync_error,omitempty"`
+	Entries        int            `json:"entries"`
+}
+
+// VaultOptions are the settings of a new vault.
+type VaultOptions struct {
+	Profile        string
+	PasswordPolicy PasswordPolicy
+}
+
+type registryFile struct {
+	Active string                `json:"active"`
+	Vaults map[string]*VaultInfo `json:"vaults"`
+}
+
+// Registry keeps the list of vaults in vaults.json under the base config
+// dir. Each vault gets its own KeyManager rooted in the vault's dir.
+type Registry struct {
+	baseDir string
+	data    registryFile
+}
+
+// NewRegistry loads the registry in baseDir. Without a vaults.json the
+// registry holds just the default vault, so existing single-vault installs
+// keep working unchanged.
+func NewRegistry(baseDir string) (*Registry, error) {
+	if baseDir == "" {
+		baseDir = DefaultConfigDir()
+	}
+	r := &Registry{baseDir: baseDir}
+
+	raw, err := os.ReadFile(r.path())
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		r.data = registryFile{
+			Active: DefaultVaultName,
+			Vaults: map[string]*VaultInfo{
+				DefaultVaultName: {
+					Name:           DefaultVaultName,
+					Dir:            ".",
+					PasswordPolicy: DefaultPasswordPolicy(),
+					CreatedAt:      time.Now(),
+				},
+			},
+		}
+		return r, nil
+	case err != nil:
+		return nil, err
+	}
+
+	if err := json.Unmarshal(raw, &r.data); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", r.path(), err)
+	}
+	if r.data.Vaults == nil {
+		r.data.Vaults = make(map[string]*VaultInfo)
+	}
+	return r, nil
+}
+
+func (r *Registry) path() string {
+	return filepath.Join(r.baseDir, vaultsFileName)
+}
+
+func (r *Registry) save() error {
+	if err := os.MkdirAll(r.baseDir, 0700); err != nil {
+		return err
+	}
+
+	data, err := json.MarshalIndent(r.data, "", " ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(r.path(), data, 0600)
+}
+
+// List returns the vaults sorted by name.
+func (r *Registry) List() []VaultInfo {
+	list := make([]VaultInfo, 0, len(r.data.Vaults))
+	for _, info := range r.data.Vaults {
+		list = append(list, *info)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+func (r *Registry) Get(name string) (VaultInfo, error) {
+	info, ok := r.data.Vaults[name]
+	if !ok {
+		return VaultInfo{}, fmt.Errorf("%w: %s", ErrVaultNotFound, name)
+	}
+	return *info, nil
+}
+
+// Create registers a new vault. Its keys are written by the KeyManager
+// on first unlock.
+func (r *Registry) Create(name string, opts VaultOptions) (VaultInfo, error) {
+	if !vaultNamePattern.MatchString(name) {
+		return VaultInfo{}, fmt.Errorf("%w: %q (lower-case letters, digits, - and _)", ErrInvalidName, name)
+	}
+	if _, ok := r.data.Vaults[name]; ok {
+		return VaultInfo{}, fmt.Errorf("%w: %s", ErrVaultExists, name)
+	}
+
+	policy := opts.PasswordPolicy
+	if policy == (PasswordPolicy{}) {
+		policy = DefaultPasswordPolicy()
+	}
+
+	dir := "."
+	if name != DefaultVaultName {
+		dir = filepath.Join("vaults", name)
+	}
+
+	info := &VaultInfo{
+		Name:           name,
+		Dir:            dir,
+		Profile:        opts.Profile,
+		PasswordPolicy: policy,
+		CreatedAt:      time.Now(),
+	}
+	r.data.Vaults[name] = info
+	if err := r.save(); err != nil {
+		delete(r.data.Vaults, name)
+		return VaultInfo{}, err
+	}
+	return *info, nil
+}
+
+// Active returns the name of the vault opened by default.
+func (r *Registry) Active() string {
+	return r.data.Active
+}
+
+func (r *Registry) SetActive(name string) error {
+	if _, ok := r.data.Vaults[name]; !ok {
+		return fmt.Errorf("%w: %s", ErrVaultNotFound, name)
+	}
+	r.data.Active = name
+	return r.save()
+}
+
+// KeyManager returns a KeyManager for the vault's own key file.
+func (r *Registry) KeyManager(name string, sessionTimeout time.Duration) (*KeyManager, error) {
+	info, ok := r.data.Vaults[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrVaultNotFound, name)
+	}
+
+	return NewKeyManager(KeyManagerConfig{
+		ConfigDir:      filepath.Join(r.baseDir, info.Dir),
+		SessionTimeout: sessionTimeout,
+		PasswordPolicy: info.PasswordPolicy,
+	}), nil
+}
+
+// SetAddress records the wallet address of a vault once its key is known.
+func (r *Registry) SetAddress(name, address string) error {
+	info, ok := r.data.Vaults[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrVaultNotFound, name)
+	}
+	if info.Address == address {
+		return nil
+	}
+	info.Address = address
+	return r.save()
+}
+
+// SetDerivationPath records the mnemonic path of a vault's wallet key.
+func (r *Registry) SetDerivationPath(name, path string) error {
+	info, ok := r.data.Vaults[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrVaultNotFound, name)
+	}
+	info.DerivationPath = path
+	return r.save()
+}
+
+// NextAccount returns the lowest account index under
+// hdwallet.DefaultBasePath that no vault uses yet, so vaults restored
+// from one mnemonic get separate wallets.
+func (r *Registry) NextAccount() uint32 {
+	used := make(map[uint32]bool)
+	for _, info := range r.data.Vaults {
+		index, ok := strings.CutPrefix(info.DerivationPath, hdwallet.DefaultBasePath+"/")
+		if !ok {
+			continue
+		}
+		if n, err := strconv.ParseUint(index, 10, 31); err == nil {
+			used[uint32(n)] = true
+		}
+	}
+	next := uint32(0)
+	for used[next] {
+		next++
+	}
+	return next
+}
+
+// RecordSync stores the outcome of the latest sync of a vault.
+func (r *Registry) RecordSync(name string, entries int, syncErr error) error {
+	info, ok := r.data.Vaults[name]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrVaultNotFound, name)
+	}
+
+	info.LastSync = time.Now()
+	info.LastSyncError = ""
+	if syncErr != nil {
+		info.LastSyncError = syncErr.Error()
+	} else {
+		info.Entries = entries
+	}
+	return r.save()
+}
@@ -0,0 +1,216 @@
+// Package nativehost is the native messaging host browser extensions use
+// for autofill. The browser starts it and exchanges length-prefixed JSON
+// over its stdin and stdout; it answers from the running agent. An
+// extension can see which logins match the page it is on, but a password
+// only leaves the vault once the user approves that fill.
+package nativehost
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+
+	"encryptkeep-backend/internal/agent"
+)
+
+// Name is the host's name in the browsers' native messaging manifests.
+const Name = "com.encryptkeep.host"
+
+// Request is a message from the extension. Action is "status", "list",
+// which returns the logins matching URL without secrets, or "fill",
+// which returns login EntryID with its password once approved.
+type Request struct {
+	ID      json.RawMessage `json:"id,omitempty"`
+	Action  string          `json:"action"`
+	URL     string          `json:"url,omitempty"`
+	EntryID string          `json:"entry_id,omitempty"`
+	Match   string          `json:"match,omitempty"` // rule for addresses stored without one, domain by default
+}
+
+// Response answers the Request with the same ID.
+type Response struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Status *agent.Status   `json:"status,omitempty"`
+	Logins []Login         `json:"logins,omitempty"`
+	Login  *Login          `json:"login,omitempty"`
+	Error  string          `json:"error,omitempty"`
+}
+
+// Login is a matching entry; Password and OTP are only set by "fill".
+type Login struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Username string `json:"username"`
+	Password string `json:"password,omitempty"`
+	OTP      string `json:"otp,omitempty"`
+	HasOTP   bool   `json:"has_otp"`
+}
+
+var (
+	errDenied     = errors.New("denied by the user")
+	errBadRequest = errors.New("bad request")
+)
+
+// Host answers one browser's extension until the browser closes the pipe.
+type Host struct {
+	// Caller is the extension the browser started the host for, as the
+	// browser passed it; approval prompts name it.
+	Caller string
+	// Dial connects to the agent. The host dials again after the agent
+	// went away, so it works once the user starts or unlocks one.
+	Dial func() (*agent.Client, error)
+	// Confirm asks the user to approve a fill; nil approves none.
+	Confirm func(prompt string) bool
+
+	client *agent.Client
+}
+
+// Serve answers requests from r on w until r ends.
+func (h *Host) Serve(r io.Reader, w io.Writer) error {
+	defer func() {
+		if h.client != nil {
+			h.client.Close()
+			h.client = nil
+		}
+	}()
+	for {
+		var req Request
+		err := ReadMessage(r, &req)
+		var syntax *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case errors.As(err, &syntax), errors.As(err, &typeErr):
+			// the frame was read whole, so the stream is still in step
+			if err := WriteMessage(w, &Response{Error: fmt.Sprintf("%v: %v", errBadRequest, err)}); err != nil {
+				return err
+			}
+			continue
+		case err != nil:
+			return err
+		}
+
+		resp := h.handle(&req)
+		resp.ID = req.ID
+		if err := WriteMessage(w, resp); err != nil {
+			return err
+		}
+	}
+}
+
+func (h *Host) handle(req *Request) *Response {
+	client, err := h.connect()
+	if err != nil {
+		return &Response{Error: err.Error()}
+	}
+	var resp *Response
+	switch req.Action {
+	case "status":
+		var status agent.Status
+		if err = client.Call("status", nil, &status); err == nil {
+			resp = &Response{Status: &status}
+		}
+	case "list":
+		var logins []Login
+		if logins, err = h.list(client, req); err == nil {
+			resp = &Response{Logins: logins}
+		}
+	case "fill":
+		var login *Login
+		if login, err = h.fill(client, req); err == nil {
+			resp = &Response{Login: login}
+		}
+	default:
+		err = fmt.Errorf("%w: unknown action %q", errBadRequest, req.Action)
+	}
+	if err != nil {
+		// anything but an answer from the agent or the host's own refusal
+		// means the connection broke
+		var rpcErr *agent.Error
+		if !errors.As(err, &rpcErr) && !errors.Is(err, errDenied) && !errors.Is(err, errBadRequest) {
+			h.client.Close()
+			h.client = nil
+		}
+		return &Response{Error: err.Error()}
+	}
+	return resp
+}
+
+func (h *Host) connect() (*agent.Client, error) {
+	if h.client == nil {
+		client, err := h.Dial()
+		if err != nil {
+			return nil, fmt.Errorf("EncryptKeep agent is not running: %w", err)
+		}
+		h.client = client
+	}
+	return h.client, nil
+}
+
+// matching returns the summaries of the entries for req.URL, best first,
+// as the agent matches them.
+func matching(client *agent.Client, req *Request) ([]agent.Summary, error) {
+	var list []agent.Summary
+	err := client.Call("search", agent.SearchParams{URL: req.URL, Match: req.Match}, &list)
+	return list, err
+}
+
+func (h *Host) list(client *agent.Client, req *Request) ([]Login, error) {
+	summaries, err := matching(client, req)
+	if err != nil {
+		return nil, err
+	}
+	logins := make([]Login, 0, len(summaries))
+	for _, s := range summaries {
+		logins = append(logins, Login{ID: s.ID, Title: s.Title, Username: s.Username, HasOTP: s.HasOTP})
+	}
+	return logins, nil
+}
+
+// fill hands out one login for the page at req.URL. The entry must match
+// that page, so an extension cannot ask for one site's password while on
+// another.
+func (h *Host) fill(client *agent.Client, req *Request) (*Login, error) {
+	summaries, err := matching(client, req)
+	if err != nil {
+		return nil, err
+	}
+	var found *agent.Summary
+	for i := range summaries {
+		if summaries[i].ID == req.EntryID {
+			found = &summaries[i]
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("%w: no login %q for %s", errBadRequest, req.EntryID, req.URL)
+	}
+
+	host := req.URL
+	if u, err := url.Parse(req.URL); err == nil {
+		host = u.Host
+	}
+	prompt := fmt.Sprintf("Fill the EncryptKeep login %q (%s) on %s?\n\nRequested by %s", found.Title, found.Username, host, h.Caller)
+	if h.Confirm == nil || !h.Confirm(prompt) {
+		return nil, errDenied
+	}
+
+	var e struct {
+		Username string `json:"username"`
+		Password string `json:"password"`
+	}
+	if err := client.Call("get", agent.IDParams{ID: found.ID}, &e); err != nil {
+		return nil, err
+	}
+	login := &Login{ID: found.ID, Title: found.Title, Username: e.Username, Password: e.Password, HasOTP: found.HasOTP}
+	if found.HasOTP {
+		var code agent.OTPResult
+		if err := client.Call("otp", agent.IDParams{ID: found.ID}, &code); err != nil {
+			return nil, err
+		}
+		login.OTP = code.Code
+	}
+	return login, nil
+}
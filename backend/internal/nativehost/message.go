@@ -0,0 +1,47 @@
+package nativehost
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// MaxMessage bounds a message either way. Browsers refuse anything longer
+// from a host; requests are far smaller.
+const MaxMessage = 1 << 20
+
+var ErrTooLarge = errors.New("nativehost: message too large")
+
+// ReadMessage reads one message, a 32-bit length in native byte order
+// followed by that much JSON, into v. It returns io.EOF when the browser
+// has closed the pipe between messages.
+func ReadMessage(r io.Reader, v any) error {
+	var size uint32
+	if err := binary.Read(r, binary.NativeEndian, &size); err != nil {
+		return err
+	}
+	if size > MaxMessage {
+		return fmt.Errorf("%w: %d bytes", ErrTooLarge, size)
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return io.ErrUnexpectedEOF
+	}
+	return json.Unmarshal(buf, v)
+}
+
+// WriteMessage writes v as one message.
+func WriteMessage(w io.Writer, v any) error {
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if len(buf) > MaxMessage {
+		return fmt.Errorf("%w: %d bytes", ErrTooLarge, len(buf))
+	}
+	msg := binary.NativeEndian.AppendUint32(make([]byte, 0, 4+len(buf)), uint32(len(buf)))
+	_, err = w.Write(append(msg, buf...))
+	return err
+}
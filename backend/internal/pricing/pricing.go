@@ -0,0 +1,59 @@
+package pricing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var ErrPriceNotFound = errors.New("price not found")
+
+// Source quotes the price of one unit of a token in a fiat currency.
+type Source interface {
+	Price(ctx context.Context, symbol, currency string) (float64, error)
+}
+
+// FileSource reads prices from a local JSON file shaped like
+//
+//	{"ETH": {"USD": 3150.42, "EUR": 2890.1}}
+//
+// The file is re-read on every lookup so an external job can refresh it.
+type FileSource struct {
+	path string
+}
+
+func NewFileSource(path string) *FileSource {
+	return &FileSource{path: path}
+}
+
+func (s *FileSource) Price(ctx context.Context, symbol, currency string) (float64, error) {
+	raw, err := os.ReadFile(s.path)
+	if err != nil {
+		return 0, fmt.Errorf("read price file: %w", err)
+	}
+
+	var prices map[string]map[string]float64
+	if err := json.Unmarshal(raw, &prices); err != nil {
+		return 0, fmt.Errorf("parse price file %s: %w", s.path, err)
+	}
+
+	price, ok := prices[strings.ToUpper(symbol)][strings.ToUpper(currency)]
+	if !ok || price <= 0 {
+		return 0, fmt.Errorf("%w: %s/%s", ErrPriceNotFound, symbol, currency)
+	}
+	return price, nil
+}
+
+// StaticSource is a fixed price table, mostly useful in tests.
+type StaticSource map[string]map[string]float64
+
+func (s StaticSource) Price(ctx context.Context, symbol, currency string) (float64, error) {
+	price, ok := s[strings.ToUpper(symbol)][strings.ToUpper(currency)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s/%s", ErrPriceNotFound, symbol, currency)
+	}
+	return price, nil
+}
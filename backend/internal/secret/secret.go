@@ -0,0 +1,96 @@
+// Package secret keeps key material where the Go runtime cannot move or
+// copy it: in memory locked against swap, left out of core dumps where
+// the OS allows, fenced by inaccessible guard pages and wiped on Destroy.
+package secret
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+)
+
+var (
+	// ErrDestroyed is returned when a destroyed secret is used.
+	ErrDestroyed = errors.New("secret has been destroyed")
+	// ErrMemoryLock is returned when the OS refuses to lock the memory,
+	// usually because RLIMIT_MEMLOCK is too low.
+	ErrMemoryLock = errors.New("cannot lock secret memory")
+)
+
+// Secret is a fixed-size buffer of locked memory. Access goes through Use
+// so the memory cannot be unmapped by Destroy while it is being read.
+type Secret struct {
+	mu   sync.RWMutex
+	data []byte // the secret, nil once destroyed
+	mem  []byte // the whole allocation, guard pages included
+}
+
+// New allocates a zeroed secret of size bytes.
+func New(size int) (*Secret, error) {
+	if size <= 0 {
+		return nil, errors.New("secret size must be positive")
+	}
+	data, mem, err := alloc(size)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Secret{data: data, mem: mem}
+	runtime.SetFinalizer(s, (*Secret).Destroy)
+	return s, nil
+}
+
+// FromBytes moves b into a new secret and wipes b.
+func FromBytes(b []byte) (*Secret, error) {
+	defer Wipe(b)
+
+	s, err := New(len(b))
+	if err != nil {
+		return nil, err
+	}
+	copy(s.data, b)
+	return s, nil
+}
+
+// Use calls fn with the secret's bytes. fn must not keep the slice.
+func (s *Secret) Use(fn func(b []byte) error) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.data == nil {
+		return ErrDestroyed
+	}
+	return fn(s.data)
+}
+
+// Len returns the size of the secret, 0 once destroyed.
+func (s *Secret) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.data)
+}
+
+// Destroyed reports whether Destroy has been called.
+func (s *Secret) Destroyed() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.data == nil
+}
+
+// Destroy wipes and releases the memory. It is safe to call repeatedly.
+func (s *Secret) Destroy() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data == nil {
+		return
+	}
+	Wipe(s.data)
+	free(s.mem)
+	s.data, s.mem = nil, nil
+	runtime.SetFinalizer(s, nil)
+}
+
+// Wipe overwrites b with zeros.
+func Wipe(b []byte) {
+	clear(b)
+	runtime.KeepAlive(b)
+}
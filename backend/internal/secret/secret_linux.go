@@ -0,0 +1,8 @@
+package secret
+
+import "golang.org/x/sys/unix"
+
+// excludeFromDumps keeps the pages out of core dumps.
+func excludeFromDumps(b []byte) {
+	unix.Madvise(b, unix.MADV_DONTDUMP)
+}
@@ -0,0 +1,7 @@
+//go:build darwin || freebsd || netbsd || openbsd
+
+package secret
+
+// excludeFromDumps is a no-op where madvise has no dump exclusion; the
+// pages are still locked and guarded.
+func excludeFromDumps(b []byte) {}
@@ -0,0 +1,14 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd)
+
+package secret
+
+// alloc falls back to the Go heap where memory cannot be locked (e.g.
+// Windows). The secret is still wiped on Destroy.
+func alloc(size int) (data, mem []byte, err error) {
+	data = make([]byte, size)
+	return data, data, nil
+}
+
+func free(mem []byte) {
+	Wipe(mem)
+}
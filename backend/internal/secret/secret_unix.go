@@ -0,0 +1,46 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd
+
+package secret
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// alloc maps the secret between two PROT_NONE guard pages and locks the
+// pages in between. The secret ends exactly at the upper guard page so an
+// overrun faults instead of reading neighbouring memory.
+func alloc(size int) (data, mem []byte, err error) {
+	page := unix.Getpagesize()
+	inner := (size + page - 1) / page * page
+
+	mem, err = unix.Mmap(-1, 0, inner+2*page, unix.PROT_READ|unix.PROT_WRITE, unix.MAP_PRIVATE|unix.MAP_ANON)
+	if err != nil {
+		return nil, nil, fmt.Errorf("map secret memory: %w", err)
+	}
+	if err := unix.Mprotect(mem[:page], unix.PROT_NONE); err != nil {
+		unix.Munmap(mem)
+		return nil, nil, fmt.Errorf("protect guard page: %w", err)
+	}
+	if err := unix.Mprotect(mem[page+inner:], unix.PROT_NONE); err != nil {
+		unix.Munmap(mem)
+		return nil, nil, fmt.Errorf("protect guard page: %w", err)
+	}
+	if err := unix.Mlock(mem[page : page+inner]); err != nil {
+		unix.Munmap(mem)
+		return nil, nil, fmt.Errorf("%w: %v", ErrMemoryLock, err)
+	}
+	excludeFromDumps(mem[page : page+inner])
+
+	end := page + inner
+	return mem[end-size : end : end], mem, nil
+}
+
+func free(mem []byte) {
+	page := unix.Getpagesize()
+	inner := mem[page : len(mem)-page]
+	Wipe(inner)
+	unix.Munlock(inner)
+	unix.Munmap(mem)
+}
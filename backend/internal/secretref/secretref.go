@@ -0,0 +1,170 @@
+// Package secretref resolves secret references, ek://vault/entry/field,
+// so configuration files can name vault items instead of holding their
+// values.
+package secretref
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"encryptkeep-backend/internal/otp"
+	"encryptkeep-backend/internal/vault"
+)
+
+const Scheme = "ek://"
+
+var (
+	ErrInvalid   = errors.New("invalid secret reference")
+	ErrNotFound  = errors.New("no such entry")
+	ErrAmbiguous = errors.New("several entries have that title")
+)
+
+// Fields are the entry fields a reference may name. otp is the current
+// code, not the secret.
+var Fields = []string{"password", "username", "url", "title", "otp", "ssh_key"}
+
+// Ref is a parsed reference. Entry is an entry ID or title; Vault is the
+// name of a vault in the registry.
+type Ref struct {
+	Vault string
+	Entry string
+	Field string
+}
+
+// Parse reads ek://vault/entry/field. Each part is path-escaped, so a
+// title with a slash or a space is written with %2F or %20.
+func Parse(s string) (Ref, error) {
+	rest, ok := strings.CutPrefix(s, Scheme)
+	parts := strings.Split(rest, "/")
+	if !ok || len(parts) != 3 {
+		return Ref{}, fmt.Errorf("%w %q: want %svault/entry/field", ErrInvalid, s, Scheme)
+	}
+	for i, p := range parts {
+		var err error
+		if parts[i], err = url.PathUnescape(p); err != nil || parts[i] == "" {
+			return Ref{}, fmt.Errorf("%w %q", ErrInvalid, s)
+		}
+	}
+	ref := Ref{Vault: parts[0], Entry: parts[1], Field: strings.ToLower(parts[2])}
+	if !validField(ref.Field) {
+		return Ref{}, fmt.Errorf("%w %q: unknown field %q, want one of %s", ErrInvalid, s, parts[2], strings.Join(Fields, ", "))
+	}
+	return ref, nil
+}
+
+func (r Ref) String() string {
+	return Scheme + url.PathEscape(r.Vault) + "/" + url.PathEscape(r.Entry) + "/" + r.Field
+}
+
+func validField(field string) bool {
+	for _, f := range Fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns the entry whose ID is ref or, failing that, the only one
+// titled ref, ignoring case.
+func Find(entries []*vault.PasswordEntry, ref string) (*vault.PasswordEntry, error) {
+	var titled []*vault.PasswordEntry
+	for _, e := range entries {
+		if e.ID == ref {
+			return e, nil
+		}
+		if strings.EqualFold(e.Title, ref) {
+			titled = append(titled, e)
+		}
+	}
+	switch len(titled) {
+	case 0:
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, ref)
+	case 1:
+		return titled[0], nil
+	}
+	return nil, fmt.Errorf("%w: %d entries are titled %q; use an ID", ErrAmbiguous, len(titled), ref)
+}
+
+// Value returns field of e, with the OTP code as of now.
+func Value(e *vault.PasswordEntry, field string, now time.Time) (string, error) {
+	switch field {
+	case "password":
+		return e.Password, nil
+	case "username":
+		return e.Username, nil
+	case "url":
+		return e.URL, nil
+	case "title":
+		return e.Title, nil
+	case "ssh_key":
+		return e.SSHKey, nil
+	case "otp":
+		if e.OTP == "" {
+			return "", fmt.Errorf("entry %q has no OTP secret", e.Title)
+		}
+		key, err := otp.Parse(e.OTP)
+		if err != nil {
+			return "", err
+		}
+		code, _ := key.Code(now)
+		return code, nil
+	}
+	return "", fmt.Errorf("%w: unknown field %q", ErrInvalid, field)
+}
+
+// Resolve looks ref up in v, the unlocked vault name.
+func Resolve(name string, v *vault.LocalVault, ref Ref) (string, error) {
+	if ref.Vault != name {
+		return "", fmt.Errorf("%w: %s is in vault %q, not %q", ErrNotFound, ref, ref.Vault, name)
+	}
+	e, err := Find(v.List(), ref.Entry)
+	if err != nil {
+		return "", err
+	}
+	return Value(e, ref.Field, time.Now())
+}
+
+// A reference in a template is either bare, ending at the field name, or
+// inside {{ }}, where it may be padded with spaces.
+var templateRef = regexp.MustCompile(`\{\{\s*(ek://[^}\s]+)\s*\}\}|ek://[^/\s"'<>{}]+/[^/\s"'<>{}]+/[A-Za-z_]+`)
+
+// Render replaces every reference in tpl with its value. It resolves each
+// distinct reference once and fails as a whole if any does not resolve,
+// so a half-rendered file is never written.
+func Render(tpl []byte, resolve func(Ref) (string, error)) ([]byte, error) {
+	values := make(map[string]string)
+	failed := make(map[string]bool)
+	var errs []error
+	out := templateRef.ReplaceAllFunc(tpl, func(m []byte) []byte {
+		s := string(m)
+		if sub := templateRef.FindSubmatch(m); sub[1] != nil {
+			s = string(sub[1])
+		}
+		if v, ok := values[s]; ok {
+			return []byte(v)
+		}
+		if failed[s] {
+			return m
+		}
+		ref, err := Parse(s)
+		if err == nil {
+			var v string
+			if v, err = resolve(ref); err == nil {
+				values[s] = v
+				return []byte(v)
+			}
+		}
+		failed[s] = true
+		errs = append(errs, err)
+		return m
+	})
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return out, nil
+}
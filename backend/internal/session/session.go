@@ -0,0 +1,247 @@
+// Package session decides when an unlocked vault locks again: after an
+// idle timeout, at the end of an absolute lifetime, or on request.
+// Locking runs the hooks registered at unlock, which wipe the keys and
+// decrypted entries of every part of the vault that holds them.
+package session
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultIdleTimeout = 15 * time.Minute
+	DefaultMaxLifetime = 8 * time.Hour
+)
+
+var (
+	ErrLocked = errors.New("vault is locked")
+	// ErrIdle and ErrExpired wrap ErrLocked and tell why the session
+	// locked by itself.
+	ErrIdle    = fmt.Errorf("%w after the idle timeout", ErrLocked)
+	ErrExpired = fmt.Errorf("%w at the end of the session lifetime", ErrLocked)
+)
+
+type Config struct {
+	IdleTimeout time.Duration // zero means DefaultIdleTimeout
+	MaxLifetime time.Duration // zero means DefaultMaxLifetime, counted from unlock
+
+	// OnAutoLock is called after the session locked itself, with ErrIdle
+	// or ErrExpired. It runs with the session's lock held and must not
+	// call back into it.
+	OnAutoLock func(reason error)
+}
+
+// Session is the lock state of one vault. It starts locked; Unlock begins
+// a period that ends with Lock, the idle timeout or the lifetime, and
+// whichever comes first runs the period's hooks.
+//
+// Work on the vault goes between Hold and Release (or inside Do): it
+// counts as activity, and the timer never locks while work is under way,
+// so hooks and work don't race. A deadline passed during the work locks
+// the vault on Release.
+type Session struct {
+	cfg Config
+
+	mu           sync.Mutex
+	locked       bool
+	busy         int
+	unlockedAt   time.Time
+	lastActivity time.Time
+	hooks        []func()
+	timer        *time.Timer
+}
+
+func New(cfg Config) *Session {
+	if cfg.IdleTimeout <= 0 {
+		cfg.IdleTimeout = DefaultIdleTimeout
+	}
+	if cfg.MaxLifetime <= 0 {
+		cfg.MaxLifetime = DefaultMaxLifetime
+	}
+	return &Session{cfg: cfg, locked: true}
+}
+
+// Config returns the timeouts in effect, defaults filled in.
+func (s *Session) Config() Config {
+	return s.cfg
+}
+
+// Unlock starts an unlocked period. onLock run in reverse order when it
+// ends, so hooks registered as secrets are set up tear them down in the
+// opposite order. An unlocked session is locked first.
+func (s *Session) Unlock(onLock ...func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.lock()
+	now := time.Now()
+	s.locked = false
+	s.unlockedAt, s.lastActivity = now, now
+	s.hooks = onLock
+	s.schedule(now)
+}
+
+// Lock ends the unlocked period and runs its hooks. Locking a locked
+// session does nothing.
+func (s *Session) Lock() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lock()
+}
+
+func (s *Session) Locked() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.locked
+}
+
+// Deadline returns when the session locks if left idle, the zero time
+// when it is locked.
+func (s *Session) Deadline() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.locked {
+		return time.Time{}
+	}
+	return s.deadline()
+}
+
+// Hold marks the vault as in use until the matching Release. It fails
+// with ErrLocked, or ErrIdle/ErrExpired when a deadline passed unnoticed.
+func (s *Session) Hold() error {
+	return s.hold(true)
+}
+
+// Release ends work started with Hold; the idle timeout counts from here.
+func (s *Session) Release() {
+	s.release(true)
+}
+
+// Do runs fn between Hold and Release.
+func (s *Session) Do(fn func() error) error {
+	if err := s.Hold(); err != nil {
+		return err
+	}
+	defer s.Release()
+	return fn()
+}
+
+// DoBackground runs fn like Do, but as work the user did not ask for,
+// such as a background sync: the timer still waits for it, yet it does
+// not count as activity, so the idle timeout keeps running.
+func (s *Session) DoBackground(fn func() error) error {
+	if err := s.hold(false); err != nil {
+		return err
+	}
+	defer s.release(false)
+	return fn()
+}
+
+func (s *Session) hold(activity bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.locked {
+		return ErrLocked
+	}
+	now := time.Now()
+	if reason := s.expired(now); reason != nil {
+		s.autoLock(reason)
+		return reason
+	}
+	s.busy++
+	if activity {
+		s.lastActivity = now
+	}
+	return nil
+}
+
+func (s *Session) release(activity bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.busy > 0 {
+		s.busy--
+	}
+	if s.locked {
+		return
+	}
+	now := time.Now()
+	if reason := s.expired(now); reason != nil && s.busy == 0 {
+		s.autoLock(reason)
+		return
+	}
+	if activity {
+		s.lastActivity = now
+	}
+	s.schedule(now)
+}
+
+func (s *Session) lock() {
+	if s.locked {
+		return
+	}
+	s.locked = true
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	for i := len(s.hooks) - 1; i >= 0; i-- {
+		s.hooks[i]()
+	}
+	s.hooks = nil
+}
+
+func (s *Session) autoLock(reason error) {
+	s.lock()
+	if s.cfg.OnAutoLock != nil {
+		s.cfg.OnAutoLock(reason)
+	}
+}
+
+// expired reports which deadline has passed at now, if any.
+func (s *Session) expired(now time.Time) error {
+	switch {
+	case !now.Before(s.unlockedAt.Add(s.cfg.MaxLifetime)):
+		return ErrExpired
+	case !now.Before(s.lastActivity.Add(s.cfg.IdleTimeout)):
+		return ErrIdle
+	}
+	return nil
+}
+
+func (s *Session) deadline() time.Time {
+	idle := s.lastActivity.Add(s.cfg.IdleTimeout)
+	if end := s.unlockedAt.Add(s.cfg.MaxLifetime); end.Before(idle) {
+		return end
+	}
+	return idle
+}
+
+// schedule arms the timer for the next deadline; callers hold s.mu.
+func (s *Session) schedule(now time.Time) {
+	if s.timer != nil {
+		s.timer.Stop()
+	}
+	s.timer = time.AfterFunc(s.deadline().Sub(now), s.fire)
+}
+
+// fire locks the session when its deadline has passed and nothing holds
+// it; Release reschedules otherwise.
+func (s *Session) fire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.locked || s.busy > 0 {
+		return
+	}
+	now := time.Now()
+	if reason := s.expired(now); reason != nil {
+		s.autoLock(reason)
+		return
+	}
+	s.schedule(now)
+}
@@ -0,0 +1,82 @@
+package shamir
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+// Shares are printed as
+//
+//	EK1-XXXXX-XXXXX-...
+//
+// where the groups are unpadded base32 of
+//
+//	set(4) | threshold(1) | x(1) | data | sha256(prefix + previous fields)[:4]
+//
+// Base32, digits and '-' are all in the QR alphanumeric set, and the
+// checksum catches mistyped characters before they reach Combine.
+const (
+	sharePrefix   = "EK1"
+	groupLen      = 5
+	checksumLen   = 4
+	shareOverhead = 4 + 1 + 1 + checksumLen
+)
+
+var (
+	ErrInvalidShare = errors.New("invalid share")
+	ErrChecksum     = errors.New("share checksum mismatch")
+)
+
+var shareEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+func (s Share) String() string {
+	raw := make([]byte, 0, shareOverhead+len(s.Data))
+	raw = binary.BigEndian.AppendUint32(raw, s.Set)
+	raw = append(raw, byte(s.Threshold), s.X)
+	raw = append(raw, s.Data...)
+	raw = append(raw, checksum(raw)...)
+
+	encoded := shareEncoding.EncodeToString(raw)
+	var b strings.Builder
+	b.WriteString(sharePrefix)
+	for i := 0; i < len(encoded); i += groupLen {
+		b.WriteByte('-')
+		b.WriteString(encoded[i:min(i+groupLen, len(encoded))])
+	}
+	return b.String()
+}
+
+// ParseShare reads a share printed by Share.String. Case, spaces and
+// group separators are ignored.
+func ParseShare(text string) (Share, error) {
+	text = strings.ToUpper(strings.Join(strings.Fields(text), ""))
+	body, ok := strings.CutPrefix(text, sharePrefix+"-")
+	if !ok {
+		return Share{}, ErrInvalidShare
+	}
+
+	raw, err := shareEncoding.DecodeString(strings.ReplaceAll(body, "-", ""))
+	if err != nil || len(raw) <= shareOverhead {
+		return Share{}, ErrInvalidShare
+	}
+	payload, sum := raw[:len(raw)-checksumLen], raw[len(raw)-checksumLen:]
+	if !bytes.Equal(checksum(payload), sum) {
+		return Share{}, ErrChecksum
+	}
+
+	return Share{
+		Set:       binary.BigEndian.Uint32(payload[:4]),
+		Threshold: int(payload[4]),
+		X:         payload[5],
+		Data:      append([]byte(nil), payload[6:]...),
+	}, nil
+}
+
+func checksum(payload []byte) []byte {
+	sum := sha256.Sum256(append([]byte(sharePrefix), payload...))
+	return sum[:checksumLen]
+}
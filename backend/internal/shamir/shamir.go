@@ -0,0 +1,148 @@
+// Package shamir splits a secret into shares with Shamir's scheme over
+// GF(2^8): any threshold of the shares rebuild the secret, fewer reveal
+// nothing about it.
+package shamir
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const MaxShares = 255
+
+var (
+	ErrInvalidThreshold = errors.New("threshold must be between 2 and the number of shares")
+	ErrNotEnoughShares  = errors.New("not enough shares")
+	ErrMixedShares      = errors.New("shares belong to different splits")
+	ErrDuplicateShare   = errors.New("duplicate share")
+)
+
+// Share is one point of every byte's polynomial. Set identifies the split
+// it came from, so shares of two backups are never combined.
+type Share struct {
+	Set       uint32
+	Threshold int
+	X         byte
+	Data      []byte
+}
+
+// Split divides secret into n shares, any threshold of which rebuild it.
+func Split(secret []byte, n, threshold int) ([]Share, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("secret must not be empty")
+	}
+	if n > MaxShares || threshold < 2 || threshold > n {
+		return nil, ErrInvalidThreshold
+	}
+
+	var set [4]byte
+	if _, err := rand.Read(set[:]); err != nil {
+		return nil, fmt.Errorf("generate share set: %w", err)
+	}
+
+	shares := make([]Share, n)
+	for i := range shares {
+		shares[i] = Share{
+			Set:       binary.BigEndian.Uint32(set[:]),
+			Threshold: threshold,
+			X:         byte(i + 1),
+			Data:      make([]byte, len(secret)),
+		}
+	}
+
+	coeffs := make([]byte, threshold)
+	for b, s := range secret {
+		// coeffs[0] is the secret byte, the rest random
+		if _, err := rand.Read(coeffs[1:]); err != nil {
+			return nil, fmt.Errorf("generate coefficients: %w", err)
+		}
+		coeffs[0] = s
+		for i := range shares {
+			shares[i].Data[b] = evaluate(coeffs, shares[i].X)
+		}
+	}
+	clear(coeffs)
+	return shares, nil
+}
+
+// Combine rebuilds the secret from at least Threshold shares of one split.
+func Combine(shares []Share) ([]byte, error) {
+	if len(shares) == 0 {
+		return nil, ErrNotEnoughShares
+	}
+	first := shares[0]
+	if len(shares) < first.Threshold {
+		return nil, fmt.Errorf("%w: have %d, need %d", ErrNotEnoughShares, len(shares), first.Threshold)
+	}
+
+	seen := make(map[byte]bool, len(shares))
+	for _, s := range shares {
+		if s.Set != first.Set || s.Threshold != first.Threshold || len(s.Data) != len(first.Data) {
+			return nil, ErrMixedShares
+		}
+		if s.X == 0 || seen[s.X] {
+			return nil, ErrDuplicateShare
+		}
+		seen[s.X] = true
+	}
+	shares = shares[:first.Threshold]
+
+	secret := make([]byte, len(first.Data))
+	for b := range secret {
+		// Lagrange interpolation at x = 0
+		var value byte
+		for i, si := range shares {
+			basis := byte(1)
+			for j, sj := range shares {
+				if i != j {
+					basis = mul(basis, div(sj.X, sj.X^si.X))
+				}
+			}
+			value ^= mul(si.Data[b], basis)
+		}
+		secret[b] = value
+	}
+	return secret, nil
+}
+
+// evaluate computes the polynomial at x with Horner's rule.
+func evaluate(coeffs []byte, x byte) byte {
+	var y byte
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		y = mul(y, x) ^ coeffs[i]
+	}
+	return y
+}
+
+// exp and log tables of GF(2^8) with the AES polynomial and generator 3.
+var expTable, logTable = func() (exp [510]byte, log [256]byte) {
+	x := byte(1)
+	for i := 0; i < 255; i++ {
+		exp[i], exp[i+255] = x, x
+		log[x] = byte(i)
+		// multiply by 3: x*2 ^ x, reducing by 0x11b
+		hi := x & 0x80
+		x2 := x << 1
+		if hi != 0 {
+			x2 ^= 0x1b
+		}
+		x = x2 ^ x
+	}
+	return
+}()
+
+func mul(a, b byte) byte {
+	if a == 0 || b == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+int(logTable[b])]
+}
+
+func div(a, b byte) byte {
+	if a == 0 {
+		return 0
+	}
+	return expTable[int(logTable[a])+255-int(logTable[b])]
+}
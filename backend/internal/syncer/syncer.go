@@ -0,0 +1,226 @@
+// Package syncer keeps an unlocked vault in step with the chain while it
+// stays open. It follows the Keeper's events for the vault's account,
+// through an eth_subscribe subscription when the endpoint offers one and
+// by polling otherwise, merges what changed and tells its subscribers.
+package syncer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"encryptkeep-backend/internal/blockchain"
+	"encryptkeep-backend/internal/session"
+	"encryptkeep-backend/internal/vault"
+	"encryptkeep-backend/internal/vaultmanager"
+)
+
+const DefaultPollInterval = 30 * time.Second
+
+type Config struct {
+	// PollInterval is how often the chain is asked for new events; with
+	// a subscription the poll only catches up on what it missed. Zero
+	// means DefaultPollInterval.
+	PollInterval time.Duration
+}
+
+type EventKind string
+
+const (
+	EventChanged EventKind = "changed"
+	EventError   EventKind = "error"
+)
+
+// Event is one notification to subscribers: entries a sync brought in
+// from another device, or a sync that failed and will be retried.
+type Event struct {
+	Vault string    `json:"vault"`
+	Kind  EventKind `json:"kind"`
+	vault.Changes
+	Block uint64    `json:"block,omitempty"`
+	Error string    `json:"error,omitempty"`
+	Time  time.Time `json:"time"`
+}
+
+// Syncer follows one vault. Run does the work; Subscribe may be called
+// before or while it runs.
+type Syncer struct {
+	name    string
+	account string
+	svc     blockchain.BlockchainService
+	vm      *vaultmanager.VaultManager
+	vault   *vault.LocalVault
+	sess    *session.Session
+	cfg     Config
+
+	mu   sync.Mutex // guards subs
+	subs map[chan Event]struct{}
+}
+
+// New returns a Syncer for vault name, stored by account and opened with
+// vm. Syncs run inside sess as background work, so they never keep the
+// vault from locking on the idle timeout.
+func New(name, account string, svc blockchain.BlockchainService, vm *vaultmanager.VaultManager, v *vault.LocalVault, sess *session.Session, cfg Config) *Syncer {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = DefaultPollInterval
+	}
+	return &Syncer{
+		name:    name,
+		account: account,
+		svc:     svc,
+		vm:      vm,
+		vault:   v,
+		sess:    sess,
+		cfg:     cfg,
+		subs:    make(map[chan Event]struct{}),
+	}
+}
+
+// Subscribe returns a channel of events and the function that ends the
+// subscription. A subscriber that falls more than buffer events behind
+// misses the newer ones rather than stalling the sync.
+func (s *Syncer) Subscribe(buffer int) (<-chan Event, func()) {
+	ch := make(chan Event, buffer)
+	s.mu.Lock()
+	s.subs[ch] = struct{}{}
+	s.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.subs, ch)
+			s.mu.Unlock()
+			close(ch)
+		})
+	}
+}
+
+func (s *Syncer) publish(ev Event) {
+	ev.Vault, ev.Time = s.name, time.Now()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for ch := range s.subs {
+		select {
+		case ch <- ev:
+		default:
+		}
+	}
+}
+
+// Run follows the chain until ctx is done, returning nil, or until the
+// vault locks, returning session.ErrLocked. The vault is taken to be in
+// step with the chain head when Run starts. Failed syncs are reported as
+// EventError and retried at the next poll.
+func (s *Syncer) Run(ctx context.Context) error {
+	var (
+		cursor    uint64
+		hasCursor bool
+	)
+	if head, err := s.svc.LatestBlock(ctx); err == nil {
+		cursor, hasCursor = head, true
+	} else if ctx.Err() == nil {
+		s.publish(Event{Kind: EventError, Error: err.Error()})
+	}
+
+	live := make(chan blockchain.VaultChange, 64)
+	var subErr <-chan error
+	if sub, err := s.svc.WatchVault(ctx, s.account, live); err == nil {
+		defer sub.Unsubscribe()
+		subErr = sub.Err()
+	}
+
+	ticker := time.NewTicker(s.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return nil
+		case change := <-live:
+			err = s.apply(ctx, drain(live, []blockchain.VaultChange{change}), change.Block)
+		case err = <-subErr:
+			// the subscription is gone; polling carries on alone
+			subErr = nil
+			if err == nil {
+				continue
+			}
+		case <-ticker.C:
+			if !hasCursor {
+				if cursor, err = s.svc.LatestBlock(ctx); err == nil {
+					hasCursor = true
+				}
+				break
+			}
+			cursor, err = s.poll(ctx, cursor)
+		}
+
+		switch {
+		case ctx.Err() != nil:
+			return nil
+		case s.sess.Locked():
+			return session.ErrLocked
+		case err != nil:
+			s.publish(Event{Kind: EventError, Error: err.Error()})
+		}
+	}
+}
+
+// poll merges the events mined after cursor and returns the new cursor.
+// A chain head behind the cursor means a reorg, and a deployment without
+// events can only be re-read; both sync the whole vault.
+func (s *Syncer) poll(ctx context.Context, cursor uint64) (uint64, error) {
+	head, err := s.svc.LatestBlock(ctx)
+	if err != nil {
+		return cursor, err
+	}
+	if head == cursor {
+		return cursor, nil
+	}
+
+	var changes []blockchain.VaultChange
+	if head > cursor {
+		changes, err = s.svc.VaultChanges(ctx, s.account, cursor+1, head)
+		if errors.Is(err, blockchain.ErrUnsupportedOperation) {
+			changes, err = nil, nil
+		}
+		if err != nil {
+			return cursor, err
+		}
+		if changes != nil && len(changes) == 0 {
+			return head, nil
+		}
+	}
+	if err := s.apply(ctx, changes, head); err != nil {
+		return cursor, err
+	}
+	return head, nil
+}
+
+// apply merges changes, nil for the whole vault, and publishes what moved.
+func (s *Syncer) apply(ctx context.Context, changes []blockchain.VaultChange, block uint64) error {
+	return s.sess.DoBackground(func() error {
+		moved, err := s.vm.SyncChanges(ctx, s.vault, s.account, changes)
+		if err != nil {
+			return err
+		}
+		if !moved.Empty() {
+			s.publish(Event{Kind: EventChanged, Changes: moved, Block: block})
+		}
+		return nil
+	})
+}
+
+// drain adds the changes already waiting on ch to batch, so a burst of
+// events is merged in one sync.
+func drain(ch <-chan blockchain.VaultChange, batch []blockchain.VaultChange) []blockchain.VaultChange {
+	for {
+		select {
+		case change := <-ch:
+			batch = append(batch, change)
+		default:
+			return batch
+		}
+	}
+}
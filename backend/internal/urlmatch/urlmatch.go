@@ -0,0 +1,224 @@
+// Package urlmatch decides whether an entry's addresses belong to a page
+// or app, so search, autofill and the git credential helper only offer a
+// login where it was saved for.
+package urlmatch
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"encryptkeep-backend/internal/vault"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// Rule is how an entry address is compared with a page.
+type Rule int
+
+const (
+	// Domain matches pages on the same registrable domain, eTLD+1 by the
+	// public suffix list: an example.co.uk entry fits app.example.co.uk
+	// but not example2.co.uk or co.uk.
+	Domain Rule = iota
+	// Host matches pages on the same host and port only.
+	Host
+	// StartsWith matches pages whose full URL starts with the address.
+	StartsWith
+	// Regex matches pages whose full URL the address, a regular
+	// expression, matches anywhere, ignoring case.
+	Regex
+	// Never matches nothing: the address is kept but not offered.
+	Never
+)
+
+// AndroidScheme marks Android app IDs, androidapp://com.example.app. Apps
+// only ever match the same app ID.
+const AndroidScheme = "androidapp"
+
+var ruleNames = map[Rule]string{
+	Domain:     "domain",
+	Host:       "host",
+	StartsWith: "starts_with",
+	Regex:      "regex",
+	Never:      "never",
+}
+
+// ParseRule reads a rule name as stored with an entry; empty means Domain.
+func ParseRule(s string) (Rule, error) {
+	if s == "" {
+		return Domain, nil
+	}
+	for r, name := range ruleNames {
+		if strings.EqualFold(s, name) {
+			return r, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown match rule %q, want domain, host, starts_with, regex or never", s)
+}
+
+func (r Rule) String() string {
+	return ruleNames[r]
+}
+
+// site is the part of an address the Domain and Host rules look at.
+type site struct {
+	scheme string
+	host   string // lowercase, without the port; the app ID for Android
+	port   string // empty for the scheme's default
+}
+
+// parse reads a page or entry address. Entry URLs are often saved without
+// a scheme; those are taken as https.
+func parse(raw string) (site, bool) {
+	raw = withScheme(raw)
+	if raw == "" {
+		return site{}, false
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Hostname() == "" {
+		return site{}, false
+	}
+	s := site{
+		scheme: strings.ToLower(u.Scheme),
+		host:   strings.TrimSuffix(strings.ToLower(u.Hostname()), "."),
+		port:   u.Port(),
+	}
+	if (s.scheme == "https" && s.port == "443") || (s.scheme == "http" && s.port == "80") {
+		s.port = ""
+	}
+	return s, true
+}
+
+func withScheme(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw != "" && !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	return raw
+}
+
+func (s site) web() bool {
+	return s.scheme == "https" || s.scheme == "http"
+}
+
+// BaseDomain returns the registrable domain of host. IP addresses, hosts
+// without a dot and public suffixes themselves are their own base.
+func BaseDomain(host string) string {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if net.ParseIP(host) != nil || !strings.Contains(host, ".") {
+		return host
+	}
+	base, err := publicsuffix.EffectiveTLDPlusOne(host)
+	if err != nil {
+		return host
+	}
+	return base
+}
+
+// Match reports whether the address uri fits page, a page URL or an
+// Android app ID, under rule. With Domain and Host, an https address
+// never fits an http page, so a downgraded connection is not handed the
+// password, and an app ID only fits the same app.
+func Match(uri, page string, rule Rule) bool {
+	switch rule {
+	case Never:
+		return false
+	case StartsWith:
+		prefix := withScheme(uri)
+		return prefix != "" && strings.HasPrefix(strings.TrimSpace(page), prefix)
+	case Regex:
+		if strings.TrimSpace(uri) == "" {
+			return false
+		}
+		re, err := regexp.Compile("(?i)" + uri)
+		return err == nil && re.MatchString(strings.TrimSpace(page))
+	}
+
+	p, ok := parse(page)
+	if !ok {
+		return false
+	}
+	e, ok := parse(uri)
+	if !ok {
+		return false
+	}
+	if p.scheme == AndroidScheme || e.scheme == AndroidScheme {
+		return p.scheme == e.scheme && p.host == e.host
+	}
+	if !p.web() || !e.web() || (e.scheme == "https" && p.scheme == "http") {
+		return false
+	}
+
+	if rule == Host {
+		return e.host == p.host && e.port == p.port
+	}
+	return BaseDomain(e.host) == BaseDomain(p.host)
+}
+
+// Rank rates how well e fits page: -1 when none of its addresses does,
+// otherwise higher the narrower the best matching address's rule, so a
+// login saved for one host or path comes before one for the whole domain.
+// Addresses without their own rule use def.
+func Rank(e *vault.PasswordEntry, page string, def Rule) int {
+	best := -1
+	try := func(uri, match string) {
+		rule := def
+		if match != "" {
+			var err error
+			if rule, err = ParseRule(match); err != nil {
+				return
+			}
+		}
+		if !Match(uri, page, rule) {
+			return
+		}
+		rank := int(rule)
+		if rule == StartsWith {
+			// the longer the prefix, the narrower
+			rank += len(uri)
+		}
+		best = max(best, rank)
+	}
+	if e.URL != "" {
+		try(e.URL, e.URLMatch)
+	}
+	for _, u := range e.URIs {
+		try(u.URI, u.Match)
+	}
+	return best
+}
+
+// Filter keeps the entries that fit page, ordered by Rank and otherwise
+// as they were.
+func Filter(entries []*vault.PasswordEntry, page string, def Rule) []*vault.PasswordEntry {
+	ranks := make(map[*vault.PasswordEntry]int, len(entries))
+	var out []*vault.PasswordEntry
+	for _, e := range entries {
+		if rank := Rank(e, page, def); rank >= 0 {
+			ranks[e] = rank
+			out = append(out, e)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return ranks[out[i]] > ranks[out[j]] })
+	return out
+}
+
+// Validate checks the rule of an address and, for Regex, that the
+// expression compiles, so a broken one is refused when it is saved rather
+// than silently never matching.
+func Validate(uri, match string) error {
+	rule, err := ParseRule(match)
+	if err != nil {
+		return err
+	}
+	if rule == Regex {
+		if _, err := regexp.Compile("(?i)" + uri); err != nil {
+			return fmt.Errorf("match rule regex: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+package vault
+
+import (
+	"errors"
+	"math/big"
+	"slices"
+	"sort"
+	"time"
+)
+
+// ErrWiped is returned by Merge when the vault was wiped after the merged
+// data was read.
+var ErrWiped = errors.New("vault was wiped during the sync")
+
+// Changes lists the entries a sync added, updated or removed, by entry ID.
+type Changes struct {
+	Added    []string `json:"added,omitempty"`
+	Updated  []string `json:"updated,omitempty"`
+	Removed  []string `json:"removed,omitempty"`
+	Metadata bool     `json:"metadata,omitempty"`
+}
+
+func (c Changes) Empty() bool {
+	return len(c.Added) == 0 && len(c.Updated) == 0 && len(c.Removed) == 0 && !c.Metadata
+}
+
+// Get returns a copy of entry id, so callers can edit it without racing
+// a sync.
+func (v *LocalVault) Get(id string) (*PasswordEntry, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	e, ok := v.Entries[id]
+	if !ok {
+		return nil, false
+	}
+	return e.clone(), true
+}
+
+// ContractID returns the on-chain ID entry id is stored under.
+func (v *LocalVault) ContractID(id string) (*big.Int, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	contractID, ok := v.BlockchainEntries[id]
+	return contractID, ok
+}
+
+// List returns copies of every entry ordered by title.
+func (v *LocalVault) List() []*PasswordEntry {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	entries := make([]*PasswordEntry, 0, len(v.Entries))
+	for _, e := range v.Entries {
+		entries = append(entries, e.clone())
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Title != entries[j].Title {
+			return entries[i].Title < entries[j].Title
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries
+}
+
+func (v *LocalVault) Len() int {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return len(v.Entries)
+}
+
+func (v *LocalVault) LastSync() time.Time {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.LastSyncTime
+}
+
+// Put records a copy of entry; a nil contractID keeps the ID already
+// recorded for it.
+func (v *LocalVault) Put(entry *PasswordEntry, contractID *big.Int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	e := entry.clone()
+	v.Entries[e.ID] = e
+	if contractID != nil {
+		v.BlockchainEntries[e.ID] = contractID
+	}
+	v.updateTotals()
+}
+
+func (v *LocalVault) Remove(id string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	delete(v.Entries, id)
+	delete(v.BlockchainEntries, id)
+	v.updateTotals()
+}
+
+// Replace swaps in the result of a full sync.
+func (v *LocalVault) Replace(meta *UserMetadata, entries map[string]*PasswordEntry, contractIDs map[string]*big.Int) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.Metadata = meta
+	v.Entries = entries
+	v.BlockchainEntries = contractIDs
+	v.LastSyncTime = meta.UpdatedAt
+	v.IsDirty = false
+}
+
+// Epoch identifies the vault's contents between two wipes. Read it before
+// fetching data to Merge.
+func (v *LocalVault) Epoch() uint64 {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	return v.epoch
+}
+
+// Merge applies an incremental sync read while the vault was at epoch:
+// entries[i] is stored under contractIDs[i], removed lists contract IDs
+// that no longer hold an entry, and a non-nil meta replaces the metadata.
+// It fails with ErrWiped when a Wipe came in between, so a sync racing a
+// lock cannot bring the entries back.
+func (v *LocalVault) Merge(epoch uint64, meta *UserMetadata, entries []*PasswordEntry, contractIDs []*big.Int, removed []*big.Int) (Changes, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	var changes Changes
+	if v.epoch != epoch {
+		return changes, ErrWiped
+	}
+
+	for _, contractID := range removed {
+		if id, ok := v.entryAt(contractID); ok {
+			delete(v.Entries, id)
+			delete(v.BlockchainEntries, id)
+			changes.Removed = append(changes.Removed, id)
+		}
+	}
+	for i, entry := range entries {
+		// a slot rewritten with another entry drops the one it held
+		if id, ok := v.entryAt(contractIDs[i]); ok && id != entry.ID {
+			delete(v.Entries, id)
+			delete(v.BlockchainEntries, id)
+			changes.Removed = append(changes.Removed, id)
+		}
+
+		e := *entry
+		switch current, ok := v.Entries[e.ID]; {
+		case !ok:
+			changes.Added = append(changes.Added, e.ID)
+		case !sameEntry(current, &e):
+			changes.Updated = append(changes.Updated, e.ID)
+		}
+		v.Entries[e.ID] = &e
+		v.BlockchainEntries[e.ID] = contractIDs[i]
+	}
+	if meta != nil {
+		changes.Metadata = v.Metadata == nil || !v.Metadata.UpdatedAt.Equal(meta.UpdatedAt)
+		v.Metadata = meta
+	}
+	v.updateTotals()
+	v.LastSyncTime = time.Now()
+	return changes, nil
+}
+
+// entryAt returns the ID of the entry stored under contractID.
+func (v *LocalVault) entryAt(contractID *big.Int) (string, bool) {
+	for id, c := range v.BlockchainEntries {
+		if c.Cmp(contractID) == 0 {
+			return id, true
+		}
+	}
+	return "", false
+}
+
+func sameEntry(a, b *PasswordEntry) bool {
+	return a.ID == b.ID && a.Title == b.Title && a.Username == b.Username &&
+		a.Password == b.Password && a.URL == b.URL && a.IsFavorite == b.IsFavorite && a.OTP == b.OTP &&
+		a.SSHKey == b.SSHKey && a.SSHConfirm == b.SSHConfirm && a.SSHLifetime == b.SSHLifetime &&
+		a.URLMatch == b.URLMatch && slices.Equal(a.URIs, b.URIs) &&
+		a.CreatedAt.Equal(b.CreatedAt) && a.UpdatedAt.Equal(b.UpdatedAt)
+}
+
+// Wipe drops every decrypted entry and the metadata, leaving v as empty
+// as NewLocalVault does. Go strings cannot be overwritten in place, so the
+// entries' fields are cleared and the garbage collector reclaims them.
+func (v *LocalVault) Wipe() {
+	empty := NewLocalVault()
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	wipeEntries(v.Entries)
+	v.epoch++
+	v.Entries = empty.Entries
+	v.Metadata = empty.Metadata
+	v.LastSyncTime = empty.LastSyncTime
+	v.IsDirty = false
+	v.BlockchainEntries = empty.BlockchainEntries
+}
+
+func (v *LocalVault) updateTotals() {
+	if v.Metadata != nil {
+		v.Metadata.TotalEntries = len(v.Entries)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"math/big"
+	"slices"
+	"sync"
 	"time"
 )
 
@@ -18,6 +20,35 @@ type PasswordEntry struct {
 	CreatedAt  time.Time `json:"created_at"`
 	UpdatedAt  time.Time `json:"updated_at"`
 	IsFavorite bool      `json:"is_favorite"`
+	OTP        string    `json:"otp,omitempty"` // otpauth:// URI or base32 TOTP secret
+
+	// URLMatch is how pages are matched against URL: domain (the default),
+	// host, starts_with, regex or never. URIs are further addresses the
+	// entry is used for, such as androidapp://com.example.app.
+	URLMatch string `json:"url_match,omitempty"`
+	URIs     []URI  `json:"uris,omitempty"`
+
+	// SSHKey is an unencrypted OpenSSH or PEM private key the agent
+	// serves over the ssh-agent protocol. SSHConfirm asks before every
+	// signature; SSHLifetime, in seconds, stops serving the key that long
+	// after the agent first offered it.
+	SSHKey      string `json:"ssh_key,omitempty"`
+	SSHConfirm  bool   `json:"ssh_confirm,omitempty"`
+	SSHLifetime int    `json:"ssh_lifetime,omitempty"`
+}
+
+// URI is an additional address of an entry and how pages are matched
+// against it, as for PasswordEntry.URLMatch.
+type URI struct {
+	URI   string `json:"uri"`
+	Match string `json:"match,omitempty"`
+}
+
+// clone copies e, including its URIs.
+func (e *PasswordEntry) clone() *PasswordEntry {
+	c := *e
+	c.URIs = slices.Clone(e.URIs)
+	return &c
 }
 
 type BlockchainEntry struct {
@@ -52,7 +83,13 @@ type UserMetadata struct {
 	TotalEntries int               `json:"total_entries"`
 }
 
+// LocalVault is the decrypted copy of a vault. Its methods are safe for
+// concurrent use; the exported fields are for building a vault and for
+// reading one that no other goroutine can reach.
 type LocalVault struct {
+	mu    sync.RWMutex
+	epoch uint64 // bumped by Wipe, see Merge
+
 	Entries      map[string]*PasswordEntry `json:"entries"`
 	Metadata     *UserMetadata             `json:"metadata"`
 	LastSyncTime time.Time                 `json:"last_sync_time"`
@@ -61,6 +98,24 @@ type LocalVault struct {
 	BlockchainEntries map[string]uint256 `json:"blockchain_entries"`
 }
 
+// SharedCollection is a team vault: entries are sealed with a random
+// collection key that every member unwraps with their own wallet key.
+type SharedCollection struct {
+	ID       uint256  `json:"id"`
+	Owner    string   `json:"owner"`
+	Members  []string `json:"members"` // includes the owner
+	KeyEpoch uint256  `json:"key_epoch"`
+
+	Key []byte `json:"-"` // unwrapped collection key
+	// RotatingKey is the new key of a rotation that has not finished;
+	// entries may be sealed with either key until it does.
+	RotatingKey []byte `json:"-"`
+
+	Entries           map[string]*PasswordEntry `json:"entries"`
+	BlockchainEntries map[string]uint256        `json:"blockchain_entries"`
+	LastSyncTime      time.Time                 `json:"last_sync_time"`
+}
+
 type MasterKey struct {
 	Key  []byte
 	Salt []byte
@@ -105,6 +160,23 @@ func NewLocalVault() *LocalVault {
 	}
 }
 
+// Wipe zeroes the collection keys and drops the decrypted entries.
+func (c *SharedCollection) Wipe() {
+	clear(c.Key)
+	clear(c.RotatingKey)
+	c.Key, c.RotatingKey = nil, nil
+	wipeEntries(c.Entries)
+	c.Entries = make(map[string]*PasswordEntry)
+	c.BlockchainEntries = make(map[string]uint256)
+}
+
+func wipeEntries(entries map[string]*PasswordEntry) {
+	for id, e := range entries {
+		*e = PasswordEntry{}
+		delete(entries, id)
+	}
+}
+
 func NewPasswordEntry(title, username, password string) *PasswordEntry {
 	now := time.Now()
 	return &PasswordEntry{
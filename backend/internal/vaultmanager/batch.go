@@ -0,0 +1,138 @@
+package vaultmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"runtime"
+	"sync"
+
+	"encryptkeep-backend/internal/codec"
+	"encryptkeep-backend/internal/vault"
+)
+
+// maxPackWorkers bounds concurrent PackEntry calls. The vault key is
+// derived once, so each call only compresses and seals.
+const maxPackWorkers = 4
+
+// AddEntries stores entries with as few transactions as the contract allows
+// and records them in v once all receipts are in, without a full resync.
+// Entries that were written before an error are still recorded.
+func (vm *VaultManager) AddEntries(ctx context.Context, v *vault.LocalVault, entries []*vault.PasswordEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	for _, entry := range entries {
+		if entry == nil {
+			return fmt.Errorf("entry is nil")
+		}
+	}
+
+	data, err := vm.packEntries(entries)
+	if err != nil {
+		return err
+	}
+
+	result, err := vm.service.StoreDataBatch(ctx, data)
+	if result != nil {
+		for i, id := range result.IDs {
+			if id != nil {
+				v.Put(entries[i], id)
+			}
+		}
+	}
+	return err
+}
+
+func (vm *VaultManager) UpdateEntries(ctx context.Context, v *vault.LocalVault, entries []*vault.PasswordEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	contractIDs := make([]*big.Int, len(entries))
+	for i, entry := range entries {
+		if entry == nil {
+			return fmt.Errorf("entry is nil")
+		}
+		contractID, ok := v.ContractID(entry.ID)
+		if !ok {
+			return fmt.Errorf("contract id not found for entry %s", entry.ID)
+		}
+		contractIDs[i] = contractID
+	}
+
+	data, err := vm.packEntries(entries)
+	if err != nil {
+		return err
+	}
+
+	result, err := vm.service.ChangeDataBatch(ctx, contractIDs, data)
+	if result != nil {
+		for i, id := range result.IDs {
+			if id != nil {
+				v.Put(entries[i], nil)
+			}
+		}
+	}
+	return err
+}
+
+func (vm *VaultManager) DeleteEntries(ctx context.Context, v *vault.LocalVault, entryIDs []string) error {
+	if len(entryIDs) == 0 {
+		return nil
+	}
+
+	contractIDs := make([]*big.Int, len(entryIDs))
+	for i, entryID := range entryIDs {
+		contractID, ok := v.ContractID(entryID)
+		if !ok {
+			return fmt.Errorf("contract id not found for entry %s", entryID)
+		}
+		contractIDs[i] = contractID
+	}
+
+	result, err := vm.service.RemoveDataBatch(ctx, contractIDs)
+	if result != nil {
+		for i, id := range result.IDs {
+			if id != nil {
+				v.Remove(entryIDs[i])
+			}
+		}
+	}
+	return err
+}
+
+// packEntries seals entries concurrently, keeping the input order.
+func (vm *VaultManager) packEntries(entries []*vault.PasswordEntry) ([][]byte, error) {
+	return vm.packEntriesWith(entries, vm.vaultKey())
+}
+
+func (vm *VaultManager) packEntriesWith(entries []*vault.PasswordEntry, key *codec.VaultKey) ([][]byte, error) {
+	data := make([][]byte, len(entries))
+	errs := make([]error, len(entries))
+
+	sem := make(chan struct{}, min(maxPackWorkers, runtime.NumCPU()))
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			packed, err := vm.codec.PackEntryWithVaultKey(entry, key)
+			if err != nil {
+				errs[i] = fmt.Errorf("pack entry %s: %w", entry.ID, err)
+				return
+			}
+			data[i] = packed
+		}()
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
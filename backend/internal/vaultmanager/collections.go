@@ -0,0 +1,429 @@
+package vaultmanager
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"encryptkeep-backend/internal/blockchain"
+	localcrypto "encryptkeep-backend/internal/crypto"
+	"encryptkeep-backend/internal/secret"
+	"encryptkeep-backend/internal/vault"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var ErrNoIdentity = errors.New("wallet key not set")
+
+// SetIdentity gives the manager the wallet key that collection keys are
+// wrapped to. The key is copied into locked memory; the caller may wipe
+// its own copy.
+func (vm *VaultManager) SetIdentity(key *ecdsa.PrivateKey) error {
+	s, err := secret.FromBytes(crypto.FromECDSA(key))
+	if err != nil {
+		return err
+	}
+	pub := key.PublicKey
+
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	vm.clearIdentity()
+	vm.identity, vm.identityPub = s, &pub
+	return nil
+}
+
+// ClearIdentity wipes the wallet key.
+func (vm *VaultManager) ClearIdentity() {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	vm.clearIdentity()
+}
+
+func (vm *VaultManager) clearIdentity() {
+	if vm.identity != nil {
+		vm.identity.Destroy()
+	}
+	vm.identity, vm.identityPub = nil, nil
+}
+
+// identityKeys returns the wallet key and its public key, nil without an
+// identity. A ClearIdentity racing the caller makes the key's Use fail.
+func (vm *VaultManager) identityKeys() (*secret.Secret, *ecdsa.PublicKey) {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+	return vm.identity, vm.identityPub
+}
+
+// withIdentity rebuilds the wallet key for fn and wipes it afterwards.
+func (vm *VaultManager) withIdentity(fn func(*ecdsa.PrivateKey) error) error {
+	identity, _ := vm.identityKeys()
+	if identity == nil {
+		return ErrNoIdentity
+	}
+	return identity.Use(func(b []byte) error {
+		key, err := crypto.ToECDSA(b)
+		if err != nil {
+			return err
+		}
+		defer clear(key.D.Bits())
+		return fn(key)
+	})
+}
+
+func (vm *VaultManager) address() (string, error) {
+	_, pub := vm.identityKeys()
+	if pub == nil {
+		return "", ErrNoIdentity
+	}
+	return crypto.PubkeyToAddress(*pub).Hex(), nil
+}
+
+// PublishPublicKey registers the wallet public key on chain, once, so
+// other owners can add this account to their collections.
+func (vm *VaultManager) PublishPublicKey(ctx context.Context) error {
+	addr, err := vm.address()
+	if err != nil {
+		return err
+	}
+
+	if _, err := vm.service.GetPublicKey(ctx, addr); err == nil {
+		return nil
+	} else if !errors.Is(err, blockchain.ErrPublicKeyNotRegistered) {
+		return err
+	}
+
+	_, err = vm.service.RegisterPublicKey(ctx)
+	return err
+}
+
+// CreateCollection creates a shared collection owned by this account with
+// a fresh collection key.
+func (vm *VaultManager) CreateCollection(ctx context.Context) (*vault.SharedCollection, error) {
+	_, pub := vm.identityKeys()
+	if pub == nil {
+		return nil, ErrNoIdentity
+	}
+	addr := crypto.PubkeyToAddress(*pub).Hex()
+
+	key, err := localcrypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	wrapped, err := localcrypto.WrapKey(pub, key)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := vm.service.CreateCollection(ctx, wrapped)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil || len(result.IDs) == 0 || result.IDs[0] == nil {
+		return nil, fmt.Errorf("collection id missing from receipt")
+	}
+
+	return &vault.SharedCollection{
+		ID:                result.IDs[0],
+		Owner:             addr,
+		Members:           []string{addr},
+		KeyEpoch:          big.NewInt(0),
+		Key:               key,
+		Entries:           make(map[string]*vault.PasswordEntry),
+		BlockchainEntries: make(map[string]*big.Int),
+		LastSyncTime:      time.Now(),
+	}, nil
+}
+
+// OpenCollection unwraps this account's copy of the collection key and
+// loads the entries.
+func (vm *VaultManager) OpenCollection(ctx context.Context, collectionID *big.Int) (*vault.SharedCollection, error) {
+	c := &vault.SharedCollection{
+		ID:                new(big.Int).Set(collectionID),
+		Entries:           make(map[string]*vault.PasswordEntry),
+		BlockchainEntries: make(map[string]*big.Int),
+	}
+	if err := vm.SyncCollection(ctx, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// SyncCollection reloads members and entries, fetching the collection key
+// again when it was rotated since the last sync.
+func (vm *VaultManager) SyncCollection(ctx context.Context, c *vault.SharedCollection) error {
+	addr, err := vm.address()
+	if err != nil {
+		return err
+	}
+
+	info, err := vm.service.GetCollection(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+
+	if c.Key == nil || c.KeyEpoch == nil || c.KeyEpoch.Cmp(info.KeyEpoch) != 0 {
+		wrapped, err := vm.service.GetCollectionKey(ctx, c.ID, addr)
+		if err != nil {
+			return err
+		}
+		var key []byte
+		err = vm.withIdentity(func(identity *ecdsa.PrivateKey) error {
+			key, err = localcrypto.UnwrapKey(identity, wrapped)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		c.Key = key
+	}
+
+	ids, err := vm.service.GetCollectionIds(ctx, c.ID)
+	if err != nil {
+		return err
+	}
+
+	entries := make(map[string]*vault.PasswordEntry)
+	blockchainEntries := make(map[string]*big.Int)
+	for _, id := range ids {
+		data, err := vm.service.GetCollectionData(ctx, c.ID, id)
+		if err != nil {
+			return err
+		}
+		entry, err := vm.unpackCollectionEntry(c, data)
+		if err != nil {
+			return fmt.Errorf("collection %s entry %s: %w", c.ID, id, err)
+		}
+		entries[entry.ID] = entry
+		blockchainEntries[entry.ID] = id
+	}
+
+	c.Owner = info.Owner
+	c.Members = info.Members
+	c.KeyEpoch = info.KeyEpoch
+	c.Entries = entries
+	c.BlockchainEntries = blockchainEntries
+	c.LastSyncTime = time.Now()
+	return nil
+}
+
+// unpackCollectionEntry opens data with the collection key, or with the
+// key of an unfinished rotation.
+func (vm *VaultManager) unpackCollectionEntry(c *vault.SharedCollection, data []byte) (*vault.PasswordEntry, error) {
+	entry, err := vm.codec.UnpackEntryWithKey(data, c.Key)
+	if err != nil && c.RotatingKey != nil {
+		return vm.codec.UnpackEntryWithKey(data, c.RotatingKey)
+	}
+	return entry, err
+}
+
+func (vm *VaultManager) AddCollectionEntries(ctx context.Context, c *vault.SharedCollection, entries []*vault.PasswordEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	data, err := vm.packWithKey(entries, c.Key)
+	if err != nil {
+		return err
+	}
+
+	result, err := vm.service.StoreCollectionData(ctx, c.ID, data)
+	if result != nil {
+		for i, id := range result.IDs {
+			if id != nil {
+				c.Entries[entries[i].ID] = entries[i]
+				c.BlockchainEntries[entries[i].ID] = id
+			}
+		}
+	}
+	return err
+}
+
+func (vm *VaultManager) UpdateCollectionEntries(ctx context.Context, c *vault.SharedCollection, entries []*vault.PasswordEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	contractIDs := make([]*big.Int, len(entries))
+	for i, entry := range entries {
+		if entry == nil {
+			return fmt.Errorf("entry is nil")
+		}
+		contractID, ok := c.BlockchainEntries[entry.ID]
+		if !ok {
+			return fmt.Errorf("contract id not found for entry %s", entry.ID)
+		}
+		contractIDs[i] = contractID
+	}
+
+	data, err := vm.packWithKey(entries, c.Key)
+	if err != nil {
+		return err
+	}
+
+	result, err := vm.service.ChangeCollectionData(ctx, c.ID, contractIDs, data)
+	if result != nil {
+		for i, id := range result.IDs {
+			if id != nil {
+				c.Entries[entries[i].ID] = entries[i]
+			}
+		}
+	}
+	return err
+}
+
+func (vm *VaultManager) DeleteCollectionEntries(ctx context.Context, c *vault.SharedCollection, entryIDs []string) error {
+	if len(entryIDs) == 0 {
+		return nil
+	}
+
+	contractIDs := make([]*big.Int, len(entryIDs))
+	for i, entryID := range entryIDs {
+		contractID, ok := c.BlockchainEntries[entryID]
+		if !ok {
+			return fmt.Errorf("contract id not found for entry %s", entryID)
+		}
+		contractIDs[i] = contractID
+	}
+
+	result, err := vm.service.RemoveCollectionData(ctx, c.ID, contractIDs)
+	if result != nil {
+		for i, id := range result.IDs {
+			if id != nil {
+				delete(c.Entries, entryIDs[i])
+				delete(c.BlockchainEntries, entryIDs[i])
+			}
+		}
+	}
+	return err
+}
+
+// AddCollectionMember wraps the collection key to the public key member
+// registered on chain and grants them write access.
+func (vm *VaultManager) AddCollectionMember(ctx context.Context, c *vault.SharedCollection, member string) error {
+	pub, err := vm.memberPublicKey(ctx, member)
+	if err != nil {
+		return err
+	}
+	wrapped, err := localcrypto.WrapKey(pub, c.Key)
+	if err != nil {
+		return err
+	}
+
+	if _, err := vm.service.AddCollectionMember(ctx, c.ID, member, wrapped); err != nil {
+		return err
+	}
+	c.Members = append(c.Members, crypto.PubkeyToAddress(*pub).Hex())
+	return nil
+}
+
+// RemoveCollectionMember revokes member and rotates the collection key so
+// entries written from now on are out of their reach. Entries they could
+// read before stay in their hands; change those secrets too.
+func (vm *VaultManager) RemoveCollectionMember(ctx context.Context, c *vault.SharedCollection, member string) error {
+	if _, err := vm.service.RemoveCollectionMember(ctx, c.ID, member); err != nil {
+		return err
+	}
+
+	members := c.Members[:0]
+	for _, m := range c.Members {
+		if !strings.EqualFold(m, member) {
+			members = append(members, m)
+		}
+	}
+	c.Members = members
+
+	return vm.RotateCollectionKey(ctx, c)
+}
+
+// RotateCollectionKey re-seals every entry with a new key and then hands
+// the new key to the current members. A failed rotation keeps the new key
+// in c.RotatingKey; calling again resumes it.
+func (vm *VaultManager) RotateCollectionKey(ctx context.Context, c *vault.SharedCollection) error {
+	if identity, _ := vm.identityKeys(); identity == nil {
+		return ErrNoIdentity
+	}
+
+	// wrap first: a member without a public key fails before any write
+	if c.RotatingKey == nil {
+		key, err := localcrypto.GenerateKey()
+		if err != nil {
+			return err
+		}
+		c.RotatingKey = key
+	}
+
+	wrappedKeys := make([][]byte, len(c.Members))
+	for i, member := range c.Members {
+		pub, err := vm.memberPublicKey(ctx, member)
+		if err != nil {
+			return fmt.Errorf("member %s: %w", member, err)
+		}
+		if wrappedKeys[i], err = localcrypto.WrapKey(pub, c.RotatingKey); err != nil {
+			return err
+		}
+	}
+
+	if len(c.Entries) > 0 {
+		ids := make([]*big.Int, 0, len(c.Entries))
+		entries := make([]*vault.PasswordEntry, 0, len(c.Entries))
+		for entryID, entry := range c.Entries {
+			ids = append(ids, c.BlockchainEntries[entryID])
+			entries = append(entries, entry)
+		}
+		data, err := vm.packWithKey(entries, c.RotatingKey)
+		if err != nil {
+			return err
+		}
+		if _, err := vm.service.ChangeCollectionData(ctx, c.ID, ids, data); err != nil {
+			return fmt.Errorf("re-seal entries: %w", err)
+		}
+	}
+
+	if _, err := vm.service.RotateCollectionKey(ctx, c.ID, c.Members, wrappedKeys); err != nil {
+		return fmt.Errorf("publish rotated key: %w", err)
+	}
+
+	c.Key, c.RotatingKey = c.RotatingKey, nil
+	c.KeyEpoch = new(big.Int).Add(c.KeyEpoch, big.NewInt(1))
+	return nil
+}
+
+// memberPublicKey returns the public key member registered on chain; for
+// this account it is taken from the wallet key directly.
+func (vm *VaultManager) memberPublicKey(ctx context.Context, member string) (*ecdsa.PublicKey, error) {
+	if _, pub := vm.identityKeys(); pub != nil && strings.EqualFold(crypto.PubkeyToAddress(*pub).Hex(), member) {
+		return pub, nil
+	}
+
+	raw, err := vm.service.GetPublicKey(ctx, member)
+	if err != nil {
+		return nil, err
+	}
+	pub, err := crypto.UnmarshalPubkey(append([]byte{0x04}, raw...))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", blockchain.ErrInvalidPublicKey, err)
+	}
+	if !strings.EqualFold(crypto.PubkeyToAddress(*pub).Hex(), member) {
+		return nil, blockchain.ErrInvalidPublicKey
+	}
+	return pub, nil
+}
+
+func (vm *VaultManager) packWithKey(entries []*vault.PasswordEntry, key []byte) ([][]byte, error) {
+	data := make([][]byte, len(entries))
+	for i, entry := range entries {
+		if entry == nil {
+			return nil, fmt.Errorf("entry is nil")
+		}
+		packed, err := vm.codec.PackEntryWithKey(entry, key)
+		if err != nil {
+			return nil, fmt.Errorf("pack entry %s: %w", entry.ID, err)
+		}
+		data[i] = packed
+	}
+	return data, nil
+}
@@ -0,0 +1,198 @@
+package vaultmanager
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"strings"
+	"time"
+
+	"encryptkeep-backend/internal/blockchain"
+	"encryptkeep-backend/internal/codec"
+	"encryptkeep-backend/internal/vault"
+)
+
+var (
+	ErrSelfEmergencyContact = errors.New("cannot be your own emergency contact")
+	ErrEmergencyKitMismatch = errors.New("emergency access kit belongs to another owner")
+)
+
+// EmergencyKeys seals and opens emergency access kits; the key manager of
+// the open vault implements it.
+type EmergencyKeys interface {
+	SealEmergencyKit(contact *ecdsa.PublicKey) ([]byte, error)
+	OpenEmergencyKit(wrapped []byte) (owner string, key *codec.VaultKey, err error)
+}
+
+// AddEmergencyContact wraps the vault keys to contact's registered public
+// key and stores them with the wait period, so the contact can open the
+// vault after a request has waited out the period even if the owner never
+// comes back. Calling it again for the same contact replaces the kit and
+// the period; an open request stays open.
+//
+// The contract only hands the kit out once the period has passed, but
+// chain storage is public: the delay binds honest clients, it is not a
+// cryptographic lock, and a contact removed later may already have read
+// the kit. Only add contacts you would trust with the vault, and change
+// the master password after removing one.
+func (vm *VaultManager) AddEmergencyContact(ctx context.Context, keys EmergencyKeys, contact string, waitPeriod time.Duration) error {
+	addr, err := vm.address()
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(addr, contact) {
+		return ErrSelfEmergencyContact
+	}
+
+	pub, err := vm.memberPublicKey(ctx, contact)
+	if err != nil {
+		return err
+	}
+	wrapped, err := keys.SealEmergencyKit(pub)
+	if err != nil {
+		return err
+	}
+
+	_, err = vm.service.SetEmergencyContact(ctx, contact, waitPeriod, wrapped)
+	return err
+}
+
+// RefreshEmergencyContacts re-wraps the kit of every contact with the
+// current vault keys, keeping their wait periods and open requests. Kits
+// go stale when the master password changes or keys of older envelopes
+// are derived. It returns how many kits it replaced.
+func (vm *VaultManager) RefreshEmergencyContacts(ctx context.Context, keys EmergencyKeys) (int, error) {
+	contacts, err := vm.EmergencyContacts(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, c := range contacts {
+		if err := vm.AddEmergencyContact(ctx, keys, c.Contact, c.WaitPeriod); err != nil {
+			return i, err
+		}
+	}
+	return len(contacts), nil
+}
+
+// RemoveEmergencyContact deletes contact's kit and request. A contact who
+// already read the kit keeps what it opens: change the master password
+// afterwards to re-seal the vault under keys they never had.
+func (vm *VaultManager) RemoveEmergencyContact(ctx context.Context, contact string) error {
+	_, err := vm.service.RemoveEmergencyContact(ctx, contact)
+	return err
+}
+
+// EmergencyContacts lists this account's contacts with their request state.
+func (vm *VaultManager) EmergencyContacts(ctx context.Context) ([]*blockchain.EmergencyAccess, error) {
+	addr, err := vm.address()
+	if err != nil {
+		return nil, err
+	}
+
+	contacts, err := vm.service.GetEmergencyContacts(ctx, addr)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*blockchain.EmergencyAccess, 0, len(contacts))
+	for _, contact := range contacts {
+		access, err := vm.service.GetEmergencyAccess(ctx, addr, contact)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, access)
+	}
+	return result, nil
+}
+
+// RequestEmergencyAccess asks owner for access as their contact and
+// returns the resulting state, whose AvailableAt is when the vault opens
+// unless the owner rejects the request first.
+func (vm *VaultManager) RequestEmergencyAccess(ctx context.Context, owner string) (*blockchain.EmergencyAccess, error) {
+	addr, err := vm.address()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := vm.service.RequestEmergencyAccess(ctx, owner); err != nil {
+		return nil, err
+	}
+	return vm.service.GetEmergencyAccess(ctx, owner, addr)
+}
+
+// ApproveEmergencyAccess opens the kit to a contact with an open request
+// before the wait period ends.
+func (vm *VaultManager) ApproveEmergencyAccess(ctx context.Context, contact string) error {
+	_, err := vm.service.ApproveEmergencyAccess(ctx, contact)
+	return err
+}
+
+func (vm *VaultManager) RejectEmergencyAccess(ctx context.Context, contact string) error {
+	_, err := vm.service.RejectEmergencyAccess(ctx, contact)
+	return err
+}
+
+// OpenEmergencyVault reads owner's vault with the kit they left for this
+// account. It fails with blockchain.ErrEmergencyAccessNotGranted until
+// the owner approves or the wait period passes; no client of the owner
+// has to run for that. The result is a read-only snapshot: the contact
+// never holds the owner's wallet key or password.
+func (vm *VaultManager) OpenEmergencyVault(ctx context.Context, keys EmergencyKeys, owner string) (*vault.LocalVault, error) {
+	addr, err := vm.address()
+	if err != nil {
+		return nil, err
+	}
+
+	wrapped, err := vm.service.GetEmergencyKey(ctx, owner, addr)
+	if err != nil {
+		return nil, err
+	}
+	kitOwner, key, err := keys.OpenEmergencyKit(wrapped)
+	if err != nil {
+		return nil, err
+	}
+	defer key.Destroy()
+	if !strings.EqualFold(kitOwner, owner) {
+		return nil, ErrEmergencyKitMismatch
+	}
+
+	return vm.readVault(ctx, owner, key)
+}
+
+// readVault decrypts account's on-chain vault with key.
+func (vm *VaultManager) readVault(ctx context.Context, account string, key *codec.VaultKey) (*vault.LocalVault, error) {
+	v := vault.NewLocalVault()
+
+	metaBytes, err := vm.service.GetUserMetadata(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	if len(metaBytes) > 0 {
+		meta, err := vm.codec.UnpackMetadataWithVaultKey(metaBytes, key)
+		if err != nil {
+			return nil, err
+		}
+		v.Metadata = meta
+		v.LastSyncTime = meta.UpdatedAt
+	}
+
+	ids, err := vm.service.GetActiveIds(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		if id == nil {
+			continue
+		}
+		data, err := vm.service.GetUserData(ctx, account, id)
+		if err != nil {
+			return nil, err
+		}
+		entry, err := vm.codec.UnpackEntryWithVaultKey(data, key)
+		if err != nil {
+			return nil, err
+		}
+		v.Entries[entry.ID] = entry
+		v.BlockchainEntries[entry.ID] = id
+	}
+	return v, nil
+}
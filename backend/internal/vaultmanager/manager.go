@@ -2,32 +2,55 @@ package vaultmanager
 
 import (
 	"context"
+	"crypto/ecdsa"
 	"fmt"
+	"sync"
 
 	"encryptkeep-backend/internal/blockchain"
 	"encryptkeep-backend/internal/codec"
+	"encryptkeep-backend/internal/pricing"
+	"encryptkeep-backend/internal/secret"
 	"encryptkeep-backend/internal/vault"
 )
 
+// VaultManager is safe for concurrent use. Calls on the same LocalVault
+// may interleave; each one leaves the vault consistent, and the last sync
+// wins.
 type VaultManager struct {
-	service        blockchain.BlockchainService
-	codec          *codec.Codec
-	masterPassword string
+	service blockchain.BlockchainService
+	codec   *codec.Codec
+
+	mu  sync.RWMutex // guards key, the price source and the identity
+	key *codec.VaultKey
+
+	prices   pricing.Source
+	currency string
+
+	identity    *secret.Secret // wallet key, unwraps shared collection keys
+	identityPub *ecdsa.PublicKey
 }
 
-func NewVaultManager(service blockchain.BlockchainService, masterPassword string) *VaultManager {
+// NewVaultManager seals and opens entries with key; the manager does not
+// own it, the caller destroys it on lock.
+func NewVaultManager(service blockchain.BlockchainService, key *codec.VaultKey) *VaultManager {
 	return &VaultManager{
-		service:        service,
-		codec:          codec.NewCodec(),
-		masterPassword: masterPassword,
+		service: service,
+		codec:   codec.NewCodec(),
+		key:     key,
 	}
 }
 
+func (vm *VaultManager) vaultKey() *codec.VaultKey {
+	vm.mu.RLock()
+	defer vm.mu.RUnlock()
+	return vm.key
+}
+
 func (vm *VaultManager) AddEntry(ctx context.Context, v *vault.LocalVault, entry *vault.PasswordEntry) error {
 	if entry == nil {
 		return fmt.Errorf("entry is nil")
 	}
-	data, err := vm.codec.PackEntry(entry, vm.masterPassword)
+	data, err := vm.codec.PackEntryWithVaultKey(entry, vm.vaultKey())
 	if err != nil {
 		return err
 	}
@@ -42,12 +65,12 @@ func (vm *VaultManager) UpdateEntry(ctx context.Context, v *vault.LocalVault, en
 	if entry == nil {
 		return fmt.Errorf("entry is nil")
 	}
-	contractID, ok := v.BlockchainEntries[entry.ID]
+	contractID, ok := v.ContractID(entry.ID)
 	if !ok {
 		return fmt.Errorf("contract id not found for entry %s", entry.ID)
 	}
 
-	data, err := vm.codec.PackEntry(entry, vm.masterPassword)
+	data, err := vm.codec.PackEntryWithVaultKey(entry, vm.vaultKey())
 	if err != nil {
 		return err
 	}
@@ -59,7 +82,7 @@ func (vm *VaultManager) UpdateEntry(ctx context.Context, v *vault.LocalVault, en
 }
 
 func (vm *VaultManager) DeleteEntry(ctx context.Context, v *vault.LocalVault, entryID string) error {
-	contractID, ok := v.BlockchainEntries[entryID]
+	contractID, ok := v.ContractID(entryID)
 	if !ok {
 		return fmt.Errorf("contract id not found for entry %s", entryID)
 	}
@@ -74,7 +97,7 @@ func (vm *VaultManager) GetEntryFromVault(v *vault.LocalVault, entryID string) (
 	if entryID == "" {
 		return nil, fmt.Errorf("entryID is empty")
 	}
-	entry, ok := v.Entries[entryID]
+	entry, ok := v.Get(entryID)
 	if !ok {
 		return nil, fmt.Errorf("entry not found: %s", entryID)
 	}
@@ -82,18 +105,14 @@ func (vm *VaultManager) GetEntryFromVault(v *vault.LocalVault, entryID string) (
 }
 
 func (vm *VaultManager) GetAllEntries(v *vault.LocalVault) []*vault.PasswordEntry {
-	result := make([]*vault.PasswordEntry, 0, len(v.Entries))
-	for _, e := range v.Entries {
-		result = append(result, e)
-	}
-	return result
+	return v.List()
 }
 
 func (vm *VaultManager) StoreMetadata(ctx context.Context, meta *vault.UserMetadata) error {
 	if meta == nil {
 		return fmt.Errorf("metadata is nil")
 	}
-	data, err := vm.codec.PackMetadata(meta, vm.masterPassword)
+	data, err := vm.codec.PackMetadataWithVaultKey(meta, vm.vaultKey())
 	if err != nil {
 		return err
 	}
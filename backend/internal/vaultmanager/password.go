@@ -0,0 +1,124 @@
+package vaultmanager
+
+import (
+	"context"
+	"math/big"
+	"slices"
+
+	"encryptkeep-backend/internal/codec"
+	"encryptkeep-backend/internal/vault"
+)
+
+// ChangeMasterPassword re-seals every entry and the metadata of account's
+// vault under newKey, derived from the new password, then switches the
+// manager to it. Records that already open with newKey are left alone, so
+// a change interrupted by a failed transaction can simply be run again
+// with the same passwords. Records under salts the current key has not
+// seen need DeriveOlderKeys first. The caller destroys the old key.
+func (vm *VaultManager) ChangeMasterPassword(ctx context.Context, account string, newKey *codec.VaultKey) error {
+	oldKey := vm.vaultKey()
+	ids, err := vm.service.GetActiveIds(ctx, account)
+	if err != nil {
+		return err
+	}
+
+	var (
+		stale    []*vault.PasswordEntry
+		staleIDs []*big.Int
+	)
+	for _, id := range ids {
+		if id == nil {
+			continue
+		}
+		data, err := vm.service.GetUserData(ctx, account, id)
+		if err != nil {
+			return err
+		}
+		if _, err := vm.codec.UnpackEntryWithVaultKey(data, newKey); err == nil {
+			continue
+		}
+		entry, err := vm.codec.UnpackEntryWithVaultKey(data, oldKey)
+		if err != nil {
+			return err
+		}
+		stale = append(stale, entry)
+		staleIDs = append(staleIDs, id)
+	}
+
+	if len(stale) > 0 {
+		data, err := vm.packEntriesWith(stale, newKey)
+		if err != nil {
+			return err
+		}
+		if _, err := vm.service.ChangeDataBatch(ctx, staleIDs, data); err != nil {
+			return err
+		}
+	}
+
+	metaBytes, err := vm.service.GetUserMetadata(ctx, account)
+	if err != nil {
+		return err
+	}
+	if len(metaBytes) > 0 {
+		if _, err := vm.codec.UnpackMetadataWithVaultKey(metaBytes, newKey); err != nil {
+			meta, err := vm.codec.UnpackMetadataWithVaultKey(metaBytes, oldKey)
+			if err != nil {
+				return err
+			}
+			data, err := vm.codec.PackMetadataWithVaultKey(meta, newKey)
+			if err != nil {
+				return err
+			}
+			if _, err := vm.service.StoreMetadata(ctx, data); err != nil {
+				return err
+			}
+		}
+	}
+
+	vm.mu.Lock()
+	vm.key = newKey
+	vm.mu.Unlock()
+	return nil
+}
+
+// DeriveOlderKeys derives from masterPassword the keys of account's
+// records sealed under salts other than the account's own, as older
+// clients wrote them, so they open after the password is dropped. It
+// returns how many keys it added.
+func (vm *VaultManager) DeriveOlderKeys(ctx context.Context, account, masterPassword string) (int, error) {
+	own := codec.VaultSalt(account)
+	var salts [][]byte
+	collect := func(data []byte) {
+		salt, ok := codec.EnvelopeSalt(data)
+		if !ok || slices.Equal(salt, own) || slices.ContainsFunc(salts, func(s []byte) bool { return slices.Equal(s, salt) }) {
+			return
+		}
+		salts = append(salts, salt)
+	}
+
+	metaBytes, err := vm.service.GetUserMetadata(ctx, account)
+	if err != nil {
+		return 0, err
+	}
+	collect(metaBytes)
+
+	ids, err := vm.service.GetActiveIds(ctx, account)
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		if id == nil {
+			continue
+		}
+		data, err := vm.service.GetUserData(ctx, account, id)
+		if err != nil {
+			return 0, err
+		}
+		collect(data)
+	}
+
+	if len(salts) == 0 {
+		return 0, nil
+	}
+	return vm.vaultKey().Derive(masterPassword, salts...)
+}
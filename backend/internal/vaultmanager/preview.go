@@ -0,0 +1,168 @@
+package vaultmanager
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"encryptkeep-backend/internal/blockchain"
+	"encryptkeep-backend/internal/pricing"
+	"encryptkeep-backend/internal/vault"
+)
+
+// SetPriceSource enables fiat conversion of cost previews. A nil source
+// turns it off again.
+func (vm *VaultManager) SetPriceSource(source pricing.Source, currency string) {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	vm.prices = source
+	vm.currency = currency
+}
+
+// PreviewAddEntry estimates what AddEntry would cost for entry without
+// sending anything. The same holds for the other Preview methods, each of
+// which prices the calls its write method sends.
+func (vm *VaultManager) PreviewAddEntry(ctx context.Context, entry *vault.PasswordEntry) (*blockchain.CostEstimate, error) {
+	if entry == nil {
+		return nil, fmt.Errorf("entry is nil")
+	}
+	data, err := vm.codec.PackEntryWithVaultKey(entry, vm.vaultKey())
+	if err != nil {
+		return nil, err
+	}
+
+	estimate, err := vm.service.EstimateStoreData(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return vm.withFiat(ctx, estimate), nil
+}
+
+func (vm *VaultManager) PreviewUpdateEntry(ctx context.Context, v *vault.LocalVault, entry *vault.PasswordEntry) (*blockchain.CostEstimate, error) {
+	if entry == nil {
+		return nil, fmt.Errorf("entry is nil")
+	}
+	contractID, ok := v.ContractID(entry.ID)
+	if !ok {
+		return nil, fmt.Errorf("contract id not found for entry %s", entry.ID)
+	}
+	data, err := vm.codec.PackEntryWithVaultKey(entry, vm.vaultKey())
+	if err != nil {
+		return nil, err
+	}
+
+	estimate, err := vm.service.EstimateChangeData(ctx, contractID, data)
+	if err != nil {
+		return nil, err
+	}
+	return vm.withFiat(ctx, estimate), nil
+}
+
+func (vm *VaultManager) PreviewDeleteEntry(ctx context.Context, v *vault.LocalVault, entryID string) (*blockchain.CostEstimate, error) {
+	contractID, ok := v.ContractID(entryID)
+	if !ok {
+		return nil, fmt.Errorf("contract id not found for entry %s", entryID)
+	}
+
+	estimate, err := vm.service.EstimateRemoveData(ctx, contractID)
+	if err != nil {
+		return nil, err
+	}
+	return vm.withFiat(ctx, estimate), nil
+}
+
+func (vm *VaultManager) PreviewAddEntries(ctx context.Context, entries []*vault.PasswordEntry) (*blockchain.CostEstimate, error) {
+	for _, entry := range entries {
+		if entry == nil {
+			return nil, fmt.Errorf("entry is nil")
+		}
+	}
+
+	data, err := vm.packEntries(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate, err := vm.service.EstimateStoreDataBatch(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return vm.withFiat(ctx, estimate), nil
+}
+
+func (vm *VaultManager) PreviewUpdateEntries(ctx context.Context, v *vault.LocalVault, entries []*vault.PasswordEntry) (*blockchain.CostEstimate, error) {
+	contractIDs := make([]*big.Int, len(entries))
+	for i, entry := range entries {
+		if entry == nil {
+			return nil, fmt.Errorf("entry is nil")
+		}
+		contractID, ok := v.ContractID(entry.ID)
+		if !ok {
+			return nil, fmt.Errorf("contract id not found for entry %s", entry.ID)
+		}
+		contractIDs[i] = contractID
+	}
+
+	data, err := vm.packEntries(entries)
+	if err != nil {
+		return nil, err
+	}
+
+	estimate, err := vm.service.EstimateChangeDataBatch(ctx, contractIDs, data)
+	if err != nil {
+		return nil, err
+	}
+	return vm.withFiat(ctx, estimate), nil
+}
+
+func (vm *VaultManager) PreviewDeleteEntries(ctx context.Context, v *vault.LocalVault, entryIDs []string) (*blockchain.CostEstimate, error) {
+	contractIDs := make([]*big.Int, len(entryIDs))
+	for i, entryID := range entryIDs {
+		contractID, ok := v.ContractID(entryID)
+		if !ok {
+			return nil, fmt.Errorf("contract id not found for entry %s", entryID)
+		}
+		contractIDs[i] = contractID
+	}
+
+	estimate, err := vm.service.EstimateRemoveDataBatch(ctx, contractIDs)
+	if err != nil {
+		return nil, err
+	}
+	return vm.withFiat(ctx, estimate), nil
+}
+
+func (vm *VaultManager) PreviewStoreMetadata(ctx context.Context, meta *vault.UserMetadata) (*blockchain.CostEstimate, error) {
+	if meta == nil {
+		return nil, fmt.Errorf("metadata is nil")
+	}
+	data, err := vm.codec.PackMetadataWithVaultKey(meta, vm.vaultKey())
+	if err != nil {
+		return nil, err
+	}
+
+	estimate, err := vm.service.EstimateStoreMetadata(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return vm.withFiat(ctx, estimate), nil
+}
+
+// withFiat fills in the fiat value when a price source is set. Fiat is
+// optional: a missing quote leaves the estimate in native token only.
+func (vm *VaultManager) withFiat(ctx context.Context, estimate *blockchain.CostEstimate) *blockchain.CostEstimate {
+	vm.mu.RLock()
+	prices, currency := vm.prices, vm.currency
+	vm.mu.RUnlock()
+	if prices == nil || currency == "" {
+		return estimate
+	}
+
+	price, err := prices.Price(ctx, estimate.Symbol, currency)
+	if err != nil {
+		return estimate
+	}
+	estimate.FiatValue = estimate.FeeNative() * price
+	estimate.FiatCurrency = currency
+	return estimate
+}
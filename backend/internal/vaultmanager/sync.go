@@ -0,0 +1,104 @@
+package vaultmanager
+
+import (
+	"context"
+	"math/big"
+
+	"encryptkeep-backend/internal/blockchain"
+	"encryptkeep-backend/internal/vault"
+)
+
+// SyncChanges brings v up to date with the writes in changes and reports
+// what moved. Only the touched IDs are read back from the chain; each is
+// read at its current state, so the order of the changes and logs
+// reverted by a reorg do not matter. A nil changes re-reads the whole
+// vault, for deployments without events.
+//
+// A lock wiping v while the chain is read makes SyncChanges fail with
+// vault.ErrWiped and leaves v empty.
+func (vm *VaultManager) SyncChanges(ctx context.Context, v *vault.LocalVault, account string, changes []blockchain.VaultChange) (vault.Changes, error) {
+	epoch := v.Epoch()
+	key := vm.vaultKey()
+
+	var (
+		readMeta bool
+		ids      []*big.Int
+		removed  []*big.Int
+	)
+	if changes == nil {
+		active, err := vm.service.GetActiveIds(ctx, account)
+		if err != nil {
+			return vault.Changes{}, err
+		}
+		readMeta, ids = true, active
+		removed = vm.vanished(v, active)
+	} else {
+		seen := make(map[string]bool)
+		for i := len(changes) - 1; i >= 0; i-- {
+			change := changes[i]
+			if change.Kind == blockchain.ChangeMetadata {
+				readMeta = true
+				continue
+			}
+			if change.ID == nil || seen[change.ID.String()] {
+				continue
+			}
+			seen[change.ID.String()] = true
+			if change.Kind == blockchain.ChangeRemoved && !change.Reverted {
+				removed = append(removed, change.ID)
+			} else {
+				ids = append(ids, change.ID)
+			}
+		}
+	}
+
+	var meta *vault.UserMetadata
+	if readMeta {
+		data, err := vm.service.GetUserMetadata(ctx, account)
+		if err != nil {
+			return vault.Changes{}, err
+		}
+		if len(data) > 0 {
+			if meta, err = vm.codec.UnpackMetadataWithVaultKey(data, key); err != nil {
+				return vault.Changes{}, err
+			}
+		}
+	}
+
+	entries := make([]*vault.PasswordEntry, 0, len(ids))
+	contractIDs := make([]*big.Int, 0, len(ids))
+	for _, id := range ids {
+		data, err := vm.service.GetUserData(ctx, account, id)
+		if err != nil {
+			return vault.Changes{}, err
+		}
+		if len(data) == 0 {
+			removed = append(removed, id)
+			continue
+		}
+		entry, err := vm.codec.UnpackEntryWithVaultKey(data, key)
+		if err != nil {
+			return vault.Changes{}, err
+		}
+		entries = append(entries, entry)
+		contractIDs = append(contractIDs, id)
+	}
+
+	return v.Merge(epoch, meta, entries, contractIDs, removed)
+}
+
+// vanished returns the contract IDs v holds entries under that are no
+// longer in active.
+func (vm *VaultManager) vanished(v *vault.LocalVault, active []*big.Int) []*big.Int {
+	live := make(map[string]bool, len(active))
+	for _, id := range active {
+		live[id.String()] = true
+	}
+	var gone []*big.Int
+	for _, entry := range v.List() {
+		if id, ok := v.ContractID(entry.ID); ok && !live[id.String()] {
+			gone = append(gone, id)
+		}
+	}
+	return gone
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"encryptkeep-backend/internal/blockchain"
+	"encryptkeep-backend/internal/codec"
 	"encryptkeep-backend/internal/vault"
 )
 
@@ -183,6 +184,15 @@ func GetTestMasterKey() []byte {
 	return []byte("test-master-key-32-bytes-long-123")
 }
 
+// NewTestVaultKey выводит ключ хранилища account из password с настройками по умолчанию
+func NewTestVaultKey(password, account string) *codec.VaultKey {
+	key, err := codec.NewVaultKey(password, account, codec.FromVaultConfig(vault.DefaultVaultConfig()))
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
 // GetTestSalt создает тестовую соль
 func GetTestSalt() []byte {
 	return []byte("test-salt-16-by")
@@ -0,0 +1,755 @@
+package mocks
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"encryptkeep-backend/internal/blockchain"
+	"encryptkeep-backend/internal/codec"
+	"encryptkeep-backend/internal/vault"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// MockBlockchainService хранит данные в памяти вместо контракта Keeper
+type MockBlockchainService struct {
+	mu       sync.Mutex
+	Address  string
+	Metadata []byte
+	Data     map[string][]byte // ID.String() -> данные
+	NextID   int64
+
+	// FailAfter ограничивает число успешно записанных элементов пакета (-1 без ограничения)
+	FailAfter int
+	// Calls считает вызовы методов по имени
+	Calls map[string]int
+
+	// GasPerItem и GasPrice задают результат Estimate* методов
+	GasPerItem uint64
+	GasPrice   *big.Int
+
+	// PublicKeys и Collections моделируют общие коллекции; вызывающим
+	// считается Address, поэтому тест может сменить участника
+	PublicKeys  map[string][]byte
+	Collections map[string]*MockCollection
+	signer      blockchain.Signer
+
+	// Emergency хранит доступ контактов по ключу "владелец/контакт";
+	// Now заменяет время блока, чтобы тест мог промотать период ожидания
+	Emergency map[string]*MockEmergency
+	Now       func() time.Time
+
+	// Block растёт с каждой записью, Changes хранит события хранилища
+	// для VaultChanges; NoEvents моделирует контракт v1 без событий,
+	// Subscriptions включает WatchVault (иначе узел как по HTTP)
+	Block         uint64
+	Changes       []blockchain.VaultChange
+	NoEvents      bool
+	Subscriptions bool
+	feed          event.Feed
+	pending       []blockchain.VaultChange
+}
+
+// MockEmergency хранит состояние доступа одного экстренного контакта
+type MockEmergency struct {
+	WaitPeriod  time.Duration
+	RequestedAt time.Time
+	Approved    bool
+	Key         []byte
+}
+
+// MockCollection хранит состояние одной общей коллекции
+type MockCollection struct {
+	Owner    string
+	Members  []string
+	Keys     map[string][]byte // участник -> обёрнутый ключ
+	KeyEpoch int64
+	Data     map[string][]byte
+	NextID   int64
+}
+
+func NewMockBlockchainService() *MockBlockchainService {
+	return &MockBlockchainService{
+		Address:     "0x1234567890123456789012345678901234567890",
+		Data:        make(map[string][]byte),
+		FailAfter:   -1,
+		Calls:       make(map[string]int),
+		GasPerItem:  50_000,
+		GasPrice:    big.NewInt(1_000_000_000),
+		PublicKeys:  make(map[string][]byte),
+		Collections: make(map[string]*MockCollection),
+		Emergency:   make(map[string]*MockEmergency),
+		Now:         time.Now,
+	}
+}
+
+func (m *MockBlockchainService) call(name string) {
+	m.Calls[name]++
+}
+
+// CallCount возвращает число вызовов метода; в отличие от Calls его можно
+// читать, пока мок используется из других горутин
+func (m *MockBlockchainService) CallCount(name string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Calls[name]
+}
+
+// record добавляет событие хранилища в текущий блок (требует m.mu)
+func (m *MockBlockchainService) record(kind blockchain.ChangeKind, id *big.Int) {
+	change := blockchain.VaultChange{Kind: kind, Block: m.Block}
+	if id != nil {
+		change.ID = new(big.Int).Set(id)
+		change.Revision = big.NewInt(1)
+	}
+	m.Changes = append(m.Changes, change)
+	m.pending = append(m.pending, change)
+}
+
+// flush рассылает подписчикам накопленные события; вызывается без m.mu,
+// чтобы подписчик мог читать данные мока
+func (m *MockBlockchainService) flush() {
+	m.mu.Lock()
+	pending := m.pending
+	m.pending = nil
+	m.mu.Unlock()
+	for _, change := range pending {
+		m.feed.Send(change)
+	}
+}
+
+func (m *MockBlockchainService) Connect() error    { return nil }
+func (m *MockBlockchainService) Disconnect() error { return nil }
+func (m *MockBlockchainService) IsConnected() bool { return true }
+
+func (m *MockBlockchainService) GetStatus() (*blockchain.SyncStatus, error) {
+	return &blockchain.SyncStatus{IsOnline: true}, nil
+}
+
+func (m *MockBlockchainService) StartSession(signer blockchain.Signer, key *codec.VaultKey) (*blockchain.Session, error) {
+	m.mu.Lock()
+	m.signer = signer
+	m.mu.Unlock()
+	return &blockchain.Session{Address: m.Address, Signer: signer, VaultKey: key}, nil
+}
+
+func (m *MockBlockchainService) EndSession() {
+	m.mu.Lock()
+	m.signer = nil
+	m.mu.Unlock()
+}
+
+func (m *MockBlockchainService) StoreMetadata(ctx context.Context, data []byte) (*blockchain.TransactionResult, error) {
+	defer m.flush()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("StoreMetadata")
+	m.Metadata = data
+	m.Block++
+	m.record(blockchain.ChangeMetadata, nil)
+	return &blockchain.TransactionResult{Success: true}, nil
+}
+
+func (m *MockBlockchainService) GetUserMetadata(ctx context.Context, userAddress string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Metadata, nil
+}
+
+func (m *MockBlockchainService) StoreData(ctx context.Context, data []byte) (*blockchain.TransactionResult, error) {
+	return m.StoreDataBatch(ctx, [][]byte{data})
+}
+
+func (m *MockBlockchainService) ChangeData(ctx context.Context, dataID *big.Int, data []byte) (*blockchain.TransactionResult, error) {
+	return m.ChangeDataBatch(ctx, []*big.Int{dataID}, [][]byte{data})
+}
+
+func (m *MockBlockchainService) RemoveData(ctx context.Context, dataID *big.Int) (*blockchain.TransactionResult, error) {
+	return m.RemoveDataBatch(ctx, []*big.Int{dataID})
+}
+
+func (m *MockBlockchainService) GetUserData(ctx context.Context, userAddress string, dataID *big.Int) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Data[dataID.String()], nil
+}
+
+func (m *MockBlockchainService) GetActiveIds(ctx context.Context, userAddress string) ([]*big.Int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("GetActiveIds")
+	ids := make([]*big.Int, 0, len(m.Data))
+	for id := range m.Data {
+		n, _ := new(big.Int).SetString(id, 10)
+		ids = append(ids, n)
+	}
+	return ids, nil
+}
+
+func (m *MockBlockchainService) GetDataRevision(ctx context.Context, userAddress string, dataID *big.Int) (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
+func (m *MockBlockchainService) allowed(i int) bool {
+	return m.FailAfter < 0 || i < m.FailAfter
+}
+
+func (m *MockBlockchainService) StoreDataBatch(ctx context.Context, data [][]byte) (*blockchain.TransactionResult, error) {
+	defer m.flush()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("StoreDataBatch")
+	m.Block++
+
+	result := &blockchain.TransactionResult{Success: true, IDs: make([]*big.Int, len(data))}
+	for i, d := range data {
+		if !m.allowed(i) {
+			result.Success = false
+			return result, blockchain.ErrTransactionFailed
+		}
+		id := big.NewInt(m.NextID)
+		m.NextID++
+		m.Data[id.String()] = d
+		m.record(blockchain.ChangeStored, id)
+		result.IDs[i] = id
+	}
+	return result, nil
+}
+
+func (m *MockBlockchainService) ChangeDataBatch(ctx context.Context, dataIDs []*big.Int, data [][]byte) (*blockchain.TransactionResult, error) {
+	defer m.flush()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("ChangeDataBatch")
+	m.Block++
+
+	result := &blockchain.TransactionResult{Success: true, IDs: make([]*big.Int, len(data))}
+	for i, id := range dataIDs {
+		if !m.allowed(i) {
+			result.Success = false
+			return result, blockchain.ErrTransactionFailed
+		}
+		if _, ok := m.Data[id.String()]; !ok {
+			result.Success = false
+			return result, blockchain.ErrCannotChangeNonExistentData
+		}
+		m.Data[id.String()] = data[i]
+		m.record(blockchain.ChangeChanged, id)
+		result.IDs[i] = id
+	}
+	return result, nil
+}
+
+func (m *MockBlockchainService) RemoveDataBatch(ctx context.Context, dataIDs []*big.Int) (*blockchain.TransactionResult, error) {
+	defer m.flush()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("RemoveDataBatch")
+	m.Block++
+
+	result := &blockchain.TransactionResult{Success: true, IDs: make([]*big.Int, len(dataIDs))}
+	for i, id := range dataIDs {
+		if !m.allowed(i) {
+			result.Success = false
+			return result, blockchain.ErrTransactionFailed
+		}
+		if _, ok := m.Data[id.String()]; !ok {
+			result.Success = false
+			return result, blockchain.ErrCannotRemoveNonExistentData
+		}
+		delete(m.Data, id.String())
+		m.record(blockchain.ChangeRemoved, id)
+		result.IDs[i] = id
+	}
+	return result, nil
+}
+
+func (m *MockBlockchainService) estimate(name string, items int) (*blockchain.CostEstimate, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call(name)
+
+	if items == 0 {
+		return nil, blockchain.ErrInvalidDataLength
+	}
+	gas := m.GasPerItem * uint64(items)
+	return &blockchain.CostEstimate{
+		Transactions: 1,
+		Gas:          gas,
+		GasPrice:     m.GasPrice,
+		Fee:          new(big.Int).Mul(new(big.Int).SetUint64(gas), m.GasPrice),
+		Symbol:       "ETH",
+	}, nil
+}
+
+func (m *MockBlockchainService) EstimateStoreMetadata(ctx context.Context, data []byte) (*blockchain.CostEstimate, error) {
+	return m.estimate("EstimateStoreMetadata", 1)
+}
+
+func (m *MockBlockchainService) EstimateStoreData(ctx context.Context, data []byte) (*blockchain.CostEstimate, error) {
+	if len(data) == 0 {
+		return nil, blockchain.ErrInvalidDataLength
+	}
+	return m.estimate("EstimateStoreData", 1)
+}
+
+func (m *MockBlockchainService) EstimateChangeData(ctx context.Context, dataID *big.Int, data []byte) (*blockchain.CostEstimate, error) {
+	if len(data) == 0 {
+		return nil, blockchain.ErrInvalidDataLength
+	}
+	return m.estimate("EstimateChangeData", 1)
+}
+
+func (m *MockBlockchainService) EstimateRemoveData(ctx context.Context, dataID *big.Int) (*blockchain.CostEstimate, error) {
+	return m.estimate("EstimateRemoveData", 1)
+}
+
+func (m *MockBlockchainService) EstimateStoreDataBatch(ctx context.Context, data [][]byte) (*blockchain.CostEstimate, error) {
+	return m.estimate("EstimateStoreDataBatch", len(data))
+}
+
+func (m *MockBlockchainService) EstimateChangeDataBatch(ctx context.Context, dataIDs []*big.Int, data [][]byte) (*blockchain.CostEstimate, error) {
+	if len(dataIDs) != len(data) {
+		return nil, blockchain.ErrBatchLengthMismatch
+	}
+	return m.estimate("EstimateChangeDataBatch", len(data))
+}
+
+func (m *MockBlockchainService) EstimateRemoveDataBatch(ctx context.Context, dataIDs []*big.Int) (*blockchain.CostEstimate, error) {
+	return m.estimate("EstimateRemoveDataBatch", len(dataIDs))
+}
+
+func normalize(address string) string {
+	return common.HexToAddress(address).Hex()
+}
+
+// collection возвращает коллекцию и проверяет права вызывающего
+func (m *MockBlockchainService) collection(id *big.Int, ownerOnly bool) (*MockCollection, error) {
+	c, ok := m.Collections[id.String()]
+	if !ok {
+		return nil, blockchain.ErrUnknownCollection
+	}
+	caller := normalize(m.Address)
+	if ownerOnly && c.Owner != caller {
+		return nil, blockchain.ErrNotCollectionOwner
+	}
+	if _, ok := c.Keys[caller]; !ok {
+		return nil, blockchain.ErrNotCollectionMember
+	}
+	return c, nil
+}
+
+func (m *MockBlockchainService) RegisterPublicKey(ctx context.Context) (*blockchain.TransactionResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("RegisterPublicKey")
+
+	if m.signer == nil {
+		return nil, blockchain.ErrInvalidPrivateKey
+	}
+	pub, err := m.signer.PublicKey()
+	if err != nil {
+		return nil, err
+	}
+	m.PublicKeys[crypto.PubkeyToAddress(*pub).Hex()] = crypto.FromECDSAPub(pub)[1:]
+	return &blockchain.TransactionResult{Success: true}, nil
+}
+
+func (m *MockBlockchainService) GetPublicKey(ctx context.Context, account string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key, ok := m.PublicKeys[normalize(account)]
+	if !ok {
+		return nil, blockchain.ErrPublicKeyNotRegistered
+	}
+	return key, nil
+}
+
+func (m *MockBlockchainService) CreateCollection(ctx context.Context, wrappedKey []byte) (*blockchain.TransactionResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("CreateCollection")
+
+	id := big.NewInt(int64(len(m.Collections)))
+	owner := normalize(m.Address)
+	m.Collections[id.String()] = &MockCollection{
+		Owner:   owner,
+		Members: []string{owner},
+		Keys:    map[string][]byte{owner: wrappedKey},
+		Data:    make(map[string][]byte),
+	}
+	return &blockchain.TransactionResult{Success: true, IDs: []*big.Int{id}}, nil
+}
+
+func (m *MockBlockchainService) GetCollection(ctx context.Context, collectionID *big.Int) (*blockchain.CollectionInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.Collections[collectionID.String()]
+	if !ok {
+		return nil, blockchain.ErrUnknownCollection
+	}
+	return &blockchain.CollectionInfo{
+		ID:       collectionID,
+		Owner:    c.Owner,
+		Members:  append([]string(nil), c.Members...),
+		KeyEpoch: big.NewInt(c.KeyEpoch),
+	}, nil
+}
+
+func (m *MockBlockchainService) GetCollectionKey(ctx context.Context, collectionID *big.Int, member string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.Collections[collectionID.String()]
+	if !ok {
+		return nil, blockchain.ErrUnknownCollection
+	}
+	key, ok := c.Keys[normalize(member)]
+	if !ok {
+		return nil, blockchain.ErrNotCollectionMember
+	}
+	return key, nil
+}
+
+func (m *MockBlockchainService) AddCollectionMember(ctx context.Context, collectionID *big.Int, member string, wrappedKey []byte) (*blockchain.TransactionResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("AddCollectionMember")
+
+	c, err := m.collection(collectionID, true)
+	if err != nil {
+		return nil, err
+	}
+	member = normalize(member)
+	if _, ok := c.Keys[member]; ok {
+		return nil, blockchain.ErrAlreadyCollectionMember
+	}
+	c.Keys[member] = wrappedKey
+	c.Members = append(c.Members, member)
+	return &blockchain.TransactionResult{Success: true}, nil
+}
+
+func (m *MockBlockchainService) RemoveCollectionMember(ctx context.Context, collectionID *big.Int, member string) (*blockchain.TransactionResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("RemoveCollectionMember")
+
+	c, err := m.collection(collectionID, true)
+	if err != nil {
+		return nil, err
+	}
+	member = normalize(member)
+	if member == c.Owner {
+		return nil, blockchain.ErrCannotRemoveCollectionOwner
+	}
+	if _, ok := c.Keys[member]; !ok {
+		return nil, blockchain.ErrNotCollectionMember
+	}
+	delete(c.Keys, member)
+	for i, existing := range c.Members {
+		if existing == member {
+			c.Members = append(c.Members[:i], c.Members[i+1:]...)
+			break
+		}
+	}
+	return &blockchain.TransactionResult{Success: true}, nil
+}
+
+func (m *MockBlockchainService) RotateCollectionKey(ctx context.Context, collectionID *big.Int, members []string, wrappedKeys [][]byte) (*blockchain.TransactionResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("RotateCollectionKey")
+
+	c, err := m.collection(collectionID, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) != len(wrappedKeys) || len(members) != len(c.Members) {
+		return nil, blockchain.ErrBatchLengthMismatch
+	}
+	seen := make(map[string]bool)
+	for _, member := range members {
+		member = normalize(member)
+		if _, ok := c.Keys[member]; !ok || seen[member] {
+			return nil, blockchain.ErrNotCollectionMember
+		}
+		seen[member] = true
+	}
+	for i, member := range members {
+		c.Keys[normalize(member)] = wrappedKeys[i]
+	}
+	c.KeyEpoch++
+	return &blockchain.TransactionResult{Success: true}, nil
+}
+
+func (m *MockBlockchainService) StoreCollectionData(ctx context.Context, collectionID *big.Int, data [][]byte) (*blockchain.TransactionResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("StoreCollectionData")
+
+	c, err := m.collection(collectionID, false)
+	if err != nil {
+		return nil, err
+	}
+	result := &blockchain.TransactionResult{Success: true, IDs: make([]*big.Int, len(data))}
+	for i, d := range data {
+		id := big.NewInt(c.NextID)
+		c.NextID++
+		c.Data[id.String()] = d
+		result.IDs[i] = id
+	}
+	return result, nil
+}
+
+func (m *MockBlockchainService) ChangeCollectionData(ctx context.Context, collectionID *big.Int, dataIDs []*big.Int, data [][]byte) (*blockchain.TransactionResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("ChangeCollectionData")
+
+	c, err := m.collection(collectionID, false)
+	if err != nil {
+		return nil, err
+	}
+	result := &blockchain.TransactionResult{Success: true, IDs: make([]*big.Int, len(data))}
+	for i, id := range dataIDs {
+		if !m.allowed(i) {
+			result.Success = false
+			return result, blockchain.ErrTransactionFailed
+		}
+		if _, ok := c.Data[id.String()]; !ok {
+			result.Success = false
+			return result, blockchain.ErrCannotChangeNonExistentData
+		}
+		c.Data[id.String()] = data[i]
+		result.IDs[i] = id
+	}
+	return result, nil
+}
+
+func (m *MockBlockchainService) RemoveCollectionData(ctx context.Context, collectionID *big.Int, dataIDs []*big.Int) (*blockchain.TransactionResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("RemoveCollectionData")
+
+	c, err := m.collection(collectionID, false)
+	if err != nil {
+		return nil, err
+	}
+	result := &blockchain.TransactionResult{Success: true, IDs: make([]*big.Int, len(dataIDs))}
+	for i, id := range dataIDs {
+		if _, ok := c.Data[id.String()]; !ok {
+			result.Success = false
+			return result, blockchain.ErrCannotRemoveNonExistentData
+		}
+		delete(c.Data, id.String())
+		result.IDs[i] = id
+	}
+	return result, nil
+}
+
+func (m *MockBlockchainService) GetCollectionIds(ctx context.Context, collectionID *big.Int) ([]*big.Int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.Collections[collectionID.String()]
+	if !ok {
+		return nil, blockchain.ErrUnknownCollection
+	}
+	ids := make([]*big.Int, 0, len(c.Data))
+	for id := range c.Data {
+		n, _ := new(big.Int).SetString(id, 10)
+		ids = append(ids, n)
+	}
+	return ids, nil
+}
+
+func (m *MockBlockchainService) GetCollectionData(ctx context.Context, collectionID, dataID *big.Int) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.Collections[collectionID.String()]
+	if !ok {
+		return nil, blockchain.ErrUnknownCollection
+	}
+	return c.Data[dataID.String()], nil
+}
+
+func (m *MockBlockchainService) SyncVault(v *vault.LocalVault) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("SyncVault")
+	return nil
+}
+
+func (m *MockBlockchainService) LatestBlock(ctx context.Context) (uint64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.Block, nil
+}
+
+func (m *MockBlockchainService) VaultChanges(ctx context.Context, account string, fromBlock, toBlock uint64) ([]blockchain.VaultChange, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("VaultChanges")
+	if m.NoEvents {
+		return nil, blockchain.ErrUnsupportedOperation
+	}
+	changes := []blockchain.VaultChange{}
+	for _, change := range m.Changes {
+		if change.Block >= fromBlock && change.Block <= toBlock {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+func (m *MockBlockchainService) WatchVault(ctx context.Context, account string, ch chan<- blockchain.VaultChange) (ethereum.Subscription, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("WatchVault")
+	if m.NoEvents {
+		return nil, blockchain.ErrUnsupportedOperation
+	}
+	if !m.Subscriptions {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	return m.feed.Subscribe(ch), nil
+}
+
+func (m *MockBlockchainService) MigrateTo(ctx context.Context, toAddress string, progress func(blockchain.MigrationProgress)) (*blockchain.MigrationReport, error) {
+	return nil, blockchain.ErrUnsupportedOperation
+}
+
+func (m *MockBlockchainService) UseContract(ctx context.Context, contractAddress string) error {
+	return nil
+}
+
+var _ blockchain.BlockchainService = (*MockBlockchainService)(nil)
+
+// emergency возвращает состояние пары владелец/контакт
+func (m *MockBlockchainService) emergency(owner, contact string) (*MockEmergency, error) {
+	e, ok := m.Emergency[normalize(owner)+"/"+normalize(contact)]
+	if !ok {
+		return nil, blockchain.ErrNotEmergencyContact
+	}
+	return e, nil
+}
+
+func (m *MockBlockchainService) SetEmergencyContact(ctx context.Context, contact string, waitPeriod time.Duration, wrappedKey []byte) (*blockchain.TransactionResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("SetEmergencyContact")
+
+	if len(wrappedKey) == 0 {
+		return nil, blockchain.ErrInvalidDataLength
+	}
+	key := normalize(m.Address) + "/" + normalize(contact)
+	e, ok := m.Emergency[key]
+	if !ok {
+		e = &MockEmergency{}
+		m.Emergency[key] = e
+	}
+	e.WaitPeriod, e.Key = waitPeriod, wrappedKey
+	return &blockchain.TransactionResult{Success: true}, nil
+}
+
+func (m *MockBlockchainService) RemoveEmergencyContact(ctx context.Context, contact string) (*blockchain.TransactionResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("RemoveEmergencyContact")
+
+	if _, err := m.emergency(m.Address, contact); err != nil {
+		return nil, err
+	}
+	delete(m.Emergency, normalize(m.Address)+"/"+normalize(contact))
+	return &blockchain.TransactionResult{Success: true}, nil
+}
+
+func (m *MockBlockchainService) GetEmergencyContacts(ctx context.Context, owner string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	prefix := normalize(owner) + "/"
+	contacts := []string{}
+	for key := range m.Emergency {
+		if contact, ok := strings.CutPrefix(key, prefix); ok {
+			contacts = append(contacts, contact)
+		}
+	}
+	return contacts, nil
+}
+
+func (m *MockBlockchainService) GetEmergencyAccess(ctx context.Context, owner, contact string) (*blockchain.EmergencyAccess, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, err := m.emergency(owner, contact)
+	if err != nil {
+		return nil, err
+	}
+	return &blockchain.EmergencyAccess{
+		Owner:       normalize(owner),
+		Contact:     normalize(contact),
+		WaitPeriod:  e.WaitPeriod,
+		RequestedAt: e.RequestedAt,
+		Approved:    e.Approved,
+	}, nil
+}
+
+func (m *MockBlockchainService) RequestEmergencyAccess(ctx context.Context, owner string) (*blockchain.TransactionResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("RequestEmergencyAccess")
+
+	e, err := m.emergency(owner, m.Address)
+	if err != nil {
+		return nil, err
+	}
+	if !e.RequestedAt.IsZero() {
+		return nil, blockchain.ErrEmergencyRequestPending
+	}
+	e.RequestedAt = m.Now()
+	return &blockchain.TransactionResult{Success: true}, nil
+}
+
+func (m *MockBlockchainService) ApproveEmergencyAccess(ctx context.Context, contact string) (*blockchain.TransactionResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("ApproveEmergencyAccess")
+
+	e, ok := m.Emergency[normalize(m.Address)+"/"+normalize(contact)]
+	if !ok || e.RequestedAt.IsZero() {
+		return nil, blockchain.ErrNoEmergencyRequest
+	}
+	e.Approved = true
+	return &blockchain.TransactionResult{Success: true}, nil
+}
+
+func (m *MockBlockchainService) RejectEmergencyAccess(ctx context.Context, contact string) (*blockchain.TransactionResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.call("RejectEmergencyAccess")
+
+	e, ok := m.Emergency[normalize(m.Address)+"/"+normalize(contact)]
+	if !ok || e.RequestedAt.IsZero() {
+		return nil, blockchain.ErrNoEmergencyRequest
+	}
+	e.RequestedAt, e.Approved = time.Time{}, false
+	return &blockchain.TransactionResult{Success: true}, nil
+}
+
+func (m *MockBlockchainService) GetEmergencyKey(ctx context.Context, owner, contact string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	e, err := m.emergency(owner, contact)
+	if err != nil {
+		return nil, err
+	}
+	access := blockchain.EmergencyAccess{WaitPeriod: e.WaitPeriod, RequestedAt: e.RequestedAt, Approved: e.Approved}
+	if !access.Granted(m.Now()) {
+		return nil, blockchain.ErrEmergencyAccessNotGranted
+	}
+	return e.Key, nil
+}
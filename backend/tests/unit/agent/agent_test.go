@@ -0,0 +1,394 @@
+package agent_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"encryptkeep-backend/internal/agent"
+	"encryptkeep-backend/internal/blockchain"
+	"encryptkeep-backend/internal/session"
+	"encryptkeep-backend/internal/syncer"
+	"encryptkeep-backend/internal/vault"
+	"encryptkeep-backend/internal/vaultmanager"
+	"encryptkeep-backend/tests/fixtures"
+	mocks "encryptkeep-backend/tests/mocks/blockchain"
+)
+
+// testAgent — запущенный агент над хранилищем в моке
+type testAgent struct {
+	server  *agent.Server
+	client  *agent.Client
+	service *mocks.MockBlockchainService
+	vault   *vault.LocalVault
+	sess    *session.Session
+	path    string
+}
+
+// startAgent запускает агент на сокете во временном каталоге и подключает клиента
+func startAgent(t *testing.T) *testAgent {
+	t.Helper()
+	service := mocks.NewMockBlockchainService()
+	vm := vaultmanager.NewVaultManager(service, fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address))
+	v := vault.NewLocalVault()
+	sess := session.New(session.Config{IdleTimeout: time.Hour})
+	sess.Unlock(v.Wipe)
+	t.Cleanup(sess.Lock)
+
+	path := filepath.Join(t.TempDir(), "encryptkeep", "agent.sock")
+	ln, err := agent.Listen(path)
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	server := agent.NewServer("main", vm, v, sess, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- server.Serve(ctx, ln) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Serve failed: %v", err)
+		}
+	})
+
+	client, err := agent.Dial(path)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return &testAgent{server: server, client: client, service: service, vault: v, sess: sess, path: path}
+}
+
+// TestAgentEntries тестирует добавление, поиск, изменение и удаление записей через сокет
+func TestAgentEntries(t *testing.T) {
+	a := startAgent(t)
+
+	var added vault.PasswordEntry
+	err := a.client.Call("add", &vault.PasswordEntry{
+		Title: "GitHub", Username: "octocat", Password: "hunter2", URL: "https://github.com/login",
+	}, &added)
+	if err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	if added.ID == "" || a.vault.Len() != 1 {
+		t.Fatalf("Entry should be stored, got %+v", added)
+	}
+	if err := a.client.Call("add", &vault.PasswordEntry{Title: "Mail", Username: "me"}, nil); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+
+	// в списке нет паролей
+	var list []agent.Summary
+	if err := a.client.Call("list", nil, &list); err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("Expected 2 entries, got %d", len(list))
+	}
+
+	var found []agent.Summary
+	if err := a.client.Call("search", &agent.SearchParams{Query: "GITHUB.com"}, &found); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if len(found) != 1 || found[0].ID != added.ID {
+		t.Errorf("Expected only %s, got %+v", added.ID, found)
+	}
+
+	added.Password = "rotated"
+	if err := a.client.Call("update", &added, nil); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	var got vault.PasswordEntry
+	if err := a.client.Call("get", &agent.IDParams{ID: added.ID}, &got); err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if got.Password != "rotated" || !got.CreatedAt.Equal(added.CreatedAt) {
+		t.Errorf("Unexpected entry after update: %+v", got)
+	}
+
+	if err := a.client.Call("delete", &agent.IDParams{ID: added.ID}, nil); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	var rpcErr *agent.Error
+	err = a.client.Call("get", &agent.IDParams{ID: added.ID}, &got)
+	if !errors.As(err, &rpcErr) || rpcErr.Code != agent.CodeNotFound {
+		t.Errorf("Expected CodeNotFound, got %v", err)
+	}
+}
+
+// TestAgentOTP тестирует выдачу одноразового кода
+func TestAgentOTP(t *testing.T) {
+	a := startAgent(t)
+
+	var rpcErr *agent.Error
+	err := a.client.Call("add", &vault.PasswordEntry{Title: "Bank", OTP: "not base32!"}, nil)
+	if !errors.As(err, &rpcErr) || rpcErr.Code != agent.CodeInvalidParams {
+		t.Fatalf("Expected CodeInvalidParams for a bad secret, got %v", err)
+	}
+
+	var added vault.PasswordEntry
+	if err := a.client.Call("add", &vault.PasswordEntry{Title: "Bank", OTP: "JBSWY3DPEHPK3PXP"}, &added); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	var code agent.OTPResult
+	if err := a.client.Call("otp", &agent.IDParams{ID: added.ID}, &code); err != nil {
+		t.Fatalf("otp failed: %v", err)
+	}
+	if len(code.Code) != 6 || code.ExpiresIn <= 0 || code.ExpiresIn > 30 {
+		t.Errorf("Unexpected code %+v", code)
+	}
+}
+
+// TestAgentSearchURL тестирует поиск записей по адресу страницы
+func TestAgentSearchURL(t *testing.T) {
+	a := startAgent(t)
+
+	var rpcErr *agent.Error
+	err := a.client.Call("add", &vault.PasswordEntry{Title: "Broken", URL: "(", URLMatch: "regex"}, nil)
+	if !errors.As(err, &rpcErr) || rpcErr.Code != agent.CodeInvalidParams {
+		t.Fatalf("Expected CodeInvalidParams for a bad expression, got %v", err)
+	}
+
+	var wide, narrow vault.PasswordEntry
+	if err := a.client.Call("add", &vault.PasswordEntry{Title: "Example", URL: "https://example.com"}, &wide); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	err = a.client.Call("add", &vault.PasswordEntry{
+		Title: "Example SSO", URL: "https://other.net",
+		URIs: []vault.URI{{URI: "https://sso.example.com", Match: "host"}},
+	}, &narrow)
+	if err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+
+	var found []agent.Summary
+	if err := a.client.Call("search", &agent.SearchParams{URL: "https://sso.example.com/login"}, &found); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	// запись для одного хоста идёт раньше записи для всего домена
+	if len(found) != 2 || found[0].ID != narrow.ID || found[1].ID != wide.ID {
+		t.Errorf("Expected %s then %s, got %+v", narrow.ID, wide.ID, found)
+	}
+
+	if err := a.client.Call("search", &agent.SearchParams{URL: "https://www.example.com", Match: "host"}, &found); err != nil {
+		t.Fatalf("search failed: %v", err)
+	}
+	if len(found) != 0 {
+		t.Errorf("Expected no entries under the host rule, got %+v", found)
+	}
+
+	err = a.client.Call("search", &agent.SearchParams{URL: "https://example.com", Match: "fuzzy"}, nil)
+	if !errors.As(err, &rpcErr) || rpcErr.Code != agent.CodeInvalidParams {
+		t.Errorf("Expected CodeInvalidParams for an unknown rule, got %v", err)
+	}
+}
+
+// TestAgentErrors тестирует ошибки протокола и ответ заблокированного хранилища
+func TestAgentErrors(t *testing.T) {
+	a := startAgent(t)
+
+	var rpcErr *agent.Error
+	if err := a.client.Call("export", nil, nil); !errors.As(err, &rpcErr) || rpcErr.Code != agent.CodeMethodNotFound {
+		t.Errorf("Expected CodeMethodNotFound, got %v", err)
+	}
+	if err := a.client.Call("subscribe", nil, nil); !errors.As(err, &rpcErr) || rpcErr.Code != agent.CodeInvalidRequest {
+		t.Errorf("Expected subscribe to fail without background sync, got %v", err)
+	}
+
+	var status agent.Status
+	if err := a.client.Call("status", nil, &status); err != nil {
+		t.Fatalf("status failed: %v", err)
+	}
+	if status.Vault != "main" || status.Sync {
+		t.Errorf("Unexpected status %+v", status)
+	}
+
+	a.sess.Lock()
+	if err := a.client.Call("list", nil, nil); !errors.As(err, &rpcErr) || rpcErr.Code != agent.CodeLocked {
+		t.Errorf("Expected CodeLocked, got %v", err)
+	}
+}
+
+// TestAgentApprove тестирует отказ в записи с комиссией выше лимита
+func TestAgentApprove(t *testing.T) {
+	a := startAgent(t)
+	a.server.Approve = func(e *blockchain.CostEstimate) error {
+		return errors.New("too expensive")
+	}
+
+	var rpcErr *agent.Error
+	err := a.client.Call("add", &vault.PasswordEntry{Title: "Site"}, nil)
+	if !errors.As(err, &rpcErr) || rpcErr.Code != agent.CodeRejected {
+		t.Errorf("Expected CodeRejected, got %v", err)
+	}
+	if a.service.CallCount("StoreDataBatch") != 0 || a.vault.Len() != 0 {
+		t.Error("A rejected write should not reach the chain")
+	}
+}
+
+// TestListen тестирует права на сокет и замену сокета завершившегося агента
+func TestListen(t *testing.T) {
+	a := startAgent(t)
+
+	info, err := os.Stat(a.path)
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("Expected socket mode 0600, got %o", info.Mode().Perm())
+	}
+	if _, err := agent.Listen(a.path); !errors.Is(err, agent.ErrAgentRunning) {
+		t.Errorf("Expected ErrAgentRunning, got %v", err)
+	}
+
+	// сокет, оставшийся без процесса, заменяется
+	stale := filepath.Join(t.TempDir(), "encryptkeep", "stale.sock")
+	if err := os.Mkdir(filepath.Dir(stale), 0o700); err != nil {
+		t.Fatalf("Mkdir failed: %v", err)
+	}
+	ln, err := net.Listen("unix", stale)
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	ln.(*net.UnixListener).SetUnlinkOnClose(false)
+	ln.Close()
+	ln, err = agent.Listen(stale)
+	if err != nil {
+		t.Fatalf("Listen over a stale socket failed: %v", err)
+	}
+	ln.Close()
+}
+
+// TestListenUnsafeDirectory тестирует отказ слушать в каталоге, куда может
+// попасть другой пользователь, или в подложенной ссылке
+func TestListenUnsafeDirectory(t *testing.T) {
+	shared := filepath.Join(t.TempDir(), "shared")
+	if err := os.Mkdir(shared, 0o700); err != nil {
+		t.Fatalf("Mkdir failed: %v", err)
+	}
+	// Chmod, потому что Mkdir урезает права по umask
+	if err := os.Chmod(shared, 0o777); err != nil {
+		t.Fatalf("Chmod failed: %v", err)
+	}
+	if _, err := agent.Listen(filepath.Join(shared, "agent.sock")); err == nil {
+		t.Error("Listen should refuse a directory others can write to")
+	}
+
+	target := t.TempDir()
+	link := filepath.Join(t.TempDir(), "link")
+	if err := os.Symlink(target, link); err != nil {
+		t.Fatalf("Symlink failed: %v", err)
+	}
+	if _, err := agent.Listen(filepath.Join(link, "agent.sock")); err == nil {
+		t.Error("Listen should refuse a symlinked directory")
+	}
+
+	// недостающий каталог создаётся с правами 0700
+	fresh := filepath.Join(t.TempDir(), "encryptkeep")
+	ln, err := agent.Listen(filepath.Join(fresh, "agent.sock"))
+	if err != nil {
+		t.Fatalf("Listen in a new directory failed: %v", err)
+	}
+	ln.Close()
+}
+
+// TestAgentSubscribe тестирует уведомления об изменениях с другого устройства
+func TestAgentSubscribe(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	service.Subscriptions = true
+	key := fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address)
+	vm := vaultmanager.NewVaultManager(service, key)
+	v := vault.NewLocalVault()
+	sess := session.New(session.Config{IdleTimeout: time.Hour})
+	sess.Unlock(v.Wipe)
+	defer sess.Lock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sy := syncer.New("main", service.Address, service, vm, v, sess, syncer.Config{PollInterval: time.Hour})
+	go sy.Run(ctx)
+
+	path := filepath.Join(t.TempDir(), "encryptkeep", "agent.sock")
+	ln, err := agent.Listen(path)
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	go agent.NewServer("main", vm, v, sess, sy).Serve(ctx, ln)
+
+	// подписка читается напрямую: Client пропускает уведомления
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	defer conn.Close()
+	if _, err := conn.Write([]byte(`{"jsonrpc":"2.0","id":1,"method":"subscribe"}` + "\n")); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	dec := json.NewDecoder(conn)
+	var reply struct {
+		Result bool         `json:"result"`
+		Error  *agent.Error `json:"error"`
+	}
+	if err := dec.Decode(&reply); err != nil || !reply.Result {
+		t.Fatalf("subscribe failed: %v %v", err, reply.Error)
+	}
+
+	// ждём подписку синхронизатора на события контракта
+	for service.CallCount("WatchVault") == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	other := vaultmanager.NewVaultManager(service, key)
+	entry := vault.NewPasswordEntry("Elsewhere", "user", "pass")
+	if err := other.AddEntries(context.Background(), vault.NewLocalVault(), []*vault.PasswordEntry{entry}); err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var note struct {
+		Method string       `json:"method"`
+		Params syncer.Event `json:"params"`
+	}
+	if err := dec.Decode(&note); err != nil {
+		t.Fatalf("No notification: %v", err)
+	}
+	if note.Method != "changed" || len(note.Params.Added) != 1 || note.Params.Added[0] != entry.ID {
+		t.Errorf("Unexpected notification %+v", note)
+	}
+}
+
+// TestAgentResolve тестирует разрешение ссылок ek:// через сокет
+func TestAgentResolve(t *testing.T) {
+	a := startAgent(t)
+	if err := a.client.Call("add", &vault.PasswordEntry{Title: "Postgres admin", Username: "admin", Password: "s3cret"}, nil); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+
+	var got agent.ResolveResult
+	if err := a.client.Call("resolve", agent.ResolveParams{Ref: "ek://main/Postgres%20admin/password"}, &got); err != nil {
+		t.Fatalf("resolve failed: %v", err)
+	}
+	if got.Value != "s3cret" {
+		t.Errorf("Expected s3cret, got %q", got.Value)
+	}
+
+	tests := []struct {
+		ref  string
+		code int
+	}{
+		{"ek://main/missing/password", agent.CodeNotFound},
+		{"ek://work/Postgres%20admin/password", agent.CodeNotFound},
+		{"ek://main/Postgres%20admin/secret", agent.CodeInvalidParams},
+	}
+	for _, tt := range tests {
+		err := a.client.Call("resolve", agent.ResolveParams{Ref: tt.ref}, nil)
+		var rpcErr *agent.Error
+		if !errors.As(err, &rpcErr) || rpcErr.Code != tt.code {
+			t.Errorf("resolve %s: expected code %d, got %v", tt.ref, tt.code, err)
+		}
+	}
+}
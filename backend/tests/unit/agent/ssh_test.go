@@ -0,0 +1,226 @@
+package agent_test
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"errors"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"encryptkeep-backend/internal/agent"
+	"encryptkeep-backend/internal/session"
+	"encryptkeep-backend/internal/vault"
+
+	"golang.org/x/crypto/ssh"
+	sshagent "golang.org/x/crypto/ssh/agent"
+)
+
+// newSSHKey создаёт ключ ed25519 и возвращает его в виде для записи хранилища
+func newSSHKey(t *testing.T, title string) (string, ssh.PublicKey) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	block, err := ssh.MarshalPrivateKey(priv, title)
+	if err != nil {
+		t.Fatalf("MarshalPrivateKey failed: %v", err)
+	}
+	key, err := agent.ImportSSHKey(pem.EncodeToMemory(block), nil, title)
+	if err != nil {
+		t.Fatalf("ImportSSHKey failed: %v", err)
+	}
+	sshPub, _ := ssh.NewPublicKey(pub)
+	return key, sshPub
+}
+
+// startSSHAgent запускает ssh-агент над хранилищем и подключает клиента
+func startSSHAgent(t *testing.T, v *vault.LocalVault, confirm func(string) bool) sshagent.ExtendedAgent {
+	t.Helper()
+	sess := session.New(session.Config{IdleTimeout: time.Hour})
+	sess.Unlock()
+	t.Cleanup(sess.Lock)
+
+	path := filepath.Join(t.TempDir(), "encryptkeep", "ssh-agent.sock")
+	ln, err := agent.Listen(path)
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	keys := agent.NewSSHAgent(v, sess)
+	keys.Confirm = confirm
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- keys.Serve(ctx, ln) }()
+	t.Cleanup(func() {
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("Serve failed: %v", err)
+		}
+	})
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatalf("Dial failed: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return sshagent.NewClient(conn)
+}
+
+// TestSSHAgentSign тестирует выдачу ключей хранилища и подпись через протокол ssh-agent
+func TestSSHAgentSign(t *testing.T) {
+	v := vault.NewLocalVault()
+	key, pub := newSSHKey(t, "deploy")
+	entry := vault.NewPasswordEntry("deploy", "", "")
+	entry.SSHKey = key
+	v.Put(entry, nil)
+	v.Put(vault.NewPasswordEntry("Mail", "me", "hunter2"), nil)
+
+	client := startSSHAgent(t, v, nil)
+	keys, err := client.List()
+	if err != nil {
+		t.Fatalf("List failed: %v", err)
+	}
+	if len(keys) != 1 || keys[0].Comment != "deploy" || string(keys[0].Blob) != string(pub.Marshal()) {
+		t.Fatalf("Expected the deploy key, got %v", keys)
+	}
+
+	data := []byte("session data")
+	sig, err := client.Sign(pub, data)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	if err := pub.Verify(data, sig); err != nil {
+		t.Errorf("Signature does not verify: %v", err)
+	}
+
+	// ключи нельзя менять через ssh-add
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	if err := client.Add(sshagent.AddedKey{PrivateKey: priv}); err == nil {
+		t.Error("Add should be refused")
+	}
+	if err := client.RemoveAll(); err == nil {
+		t.Error("RemoveAll should be refused")
+	}
+
+	// ключ, добавленный в хранилище позже, виден без перезапуска
+	other, _ := newSSHKey(t, "ci")
+	entry = vault.NewPasswordEntry("ci", "", "")
+	entry.SSHKey = other
+	v.Put(entry, nil)
+	if keys, _ = client.List(); len(keys) != 2 {
+		t.Errorf("Expected 2 keys, got %d", len(keys))
+	}
+}
+
+// TestSSHAgentConfirm тестирует подтверждение каждой подписи
+func TestSSHAgentConfirm(t *testing.T) {
+	v := vault.NewLocalVault()
+	key, pub := newSSHKey(t, "prod")
+	entry := vault.NewPasswordEntry("prod", "", "")
+	entry.SSHKey = key
+	entry.SSHConfirm = true
+	v.Put(entry, nil)
+
+	allow := false
+	var asked []string
+	client := startSSHAgent(t, v, func(title string) bool {
+		asked = append(asked, title)
+		return allow
+	})
+
+	if _, err := client.Sign(pub, []byte("data")); err == nil {
+		t.Error("Sign should fail when the user declines")
+	}
+	allow = true
+	if _, err := client.Sign(pub, []byte("data")); err != nil {
+		t.Errorf("Sign failed after approval: %v", err)
+	}
+	if len(asked) != 2 || asked[0] != "prod" {
+		t.Errorf("Expected two questions about prod, got %v", asked)
+	}
+
+	// без способа спросить ключ с подтверждением не подписывает
+	client = startSSHAgent(t, v, nil)
+	if _, err := client.Sign(pub, []byte("data")); err == nil {
+		t.Error("Sign should fail without Confirm")
+	}
+}
+
+// TestSSHAgentLifetime тестирует срок жизни ключа
+func TestSSHAgentLifetime(t *testing.T) {
+	v := vault.NewLocalVault()
+	key, pub := newSSHKey(t, "temp")
+	entry := vault.NewPasswordEntry("temp", "", "")
+	entry.SSHKey = key
+	entry.SSHLifetime = 1
+	v.Put(entry, nil)
+
+	client := startSSHAgent(t, v, nil)
+	if keys, _ := client.List(); len(keys) != 1 {
+		t.Fatalf("Expected the key before its lifetime ends, got %d", len(keys))
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+	if keys, _ := client.List(); len(keys) != 0 {
+		t.Errorf("Expected no keys after the lifetime, got %d", len(keys))
+	}
+	if _, err := client.Sign(pub, []byte("data")); err == nil {
+		t.Error("Sign should fail after the lifetime")
+	}
+}
+
+// TestImportSSHKeyPassphrase тестирует импорт зашифрованного ключа
+func TestImportSSHKeyPassphrase(t *testing.T) {
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	block, err := ssh.MarshalPrivateKeyWithPassphrase(priv, "laptop", []byte("correct horse"))
+	if err != nil {
+		t.Fatalf("MarshalPrivateKeyWithPassphrase failed: %v", err)
+	}
+	data := pem.EncodeToMemory(block)
+
+	if _, err := agent.ImportSSHKey(data, nil, "laptop"); err == nil {
+		t.Error("Import without the passphrase should fail")
+	}
+	if _, err := agent.ImportSSHKey(data, []byte("wrong"), "laptop"); err == nil {
+		t.Error("Import with a wrong passphrase should fail")
+	}
+
+	// в хранилище ключ лежит без парольной фразы
+	key, err := agent.ImportSSHKey(data, []byte("correct horse"), "laptop")
+	if err != nil {
+		t.Fatalf("ImportSSHKey failed: %v", err)
+	}
+	if _, err := ssh.ParsePrivateKey([]byte(key)); err != nil {
+		t.Errorf("Imported key should parse without a passphrase: %v", err)
+	}
+}
+
+// TestAgentSSHEntry тестирует сохранение SSH-ключа при добавлении и изменении через сокет
+func TestAgentSSHEntry(t *testing.T) {
+	a := startAgent(t)
+	key, _ := newSSHKey(t, "deploy")
+
+	var added vault.PasswordEntry
+	if err := a.client.Call("add", &vault.PasswordEntry{Title: "deploy", SSHKey: key, SSHConfirm: true}, &added); err != nil {
+		t.Fatalf("add failed: %v", err)
+	}
+	added.Title = "deploy key"
+	if err := a.client.Call("update", &added, nil); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	got, _ := a.vault.Get(added.ID)
+	if got.SSHKey != key || !got.SSHConfirm {
+		t.Errorf("SSH key lost on update: %+v", got)
+	}
+
+	// ключ, который не разбирается, не сохраняется
+	err := a.client.Call("add", &vault.PasswordEntry{Title: "broken", SSHKey: "not a key"}, nil)
+	var rpcErr *agent.Error
+	if !errors.As(err, &rpcErr) || rpcErr.Code != agent.CodeInvalidParams {
+		t.Errorf("Expected CodeInvalidParams, got %v", err)
+	}
+}
@@ -43,6 +43,13 @@ func TestParseContractError(t *testing.T) {
 			expectedCode:   2004,
 			expectedPrefix: "Cannot remove non-existent data",
 		},
+		{
+			name:           "BatchLengthMismatch error",
+			inputError:     errors.New("execution reverted: BatchLengthMismatch(2, 3)"),
+			expectedType:   "BATCH_LENGTH_MISMATCH",
+			expectedCode:   2005,
+			expectedPrefix: "Batch ids and data length mismatch",
+		},
 		{
 			name:           "Gas estimation failed",
 			inputError:     errors.New("gas required exceeds allowance"),
@@ -0,0 +1,154 @@
+package blockchain_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"encryptkeep-backend/internal/blockchain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// nonceNode — JSON-RPC узел, который ведёт счётчик nonce как настоящий:
+// eth_getTransactionCount отдаёт число принятых транзакций, а
+// eth_sendRawTransaction отклоняет уже использованный nonce
+type nonceNode struct {
+	mu     sync.Mutex
+	nonces []uint64
+}
+
+func newNonceNode(t *testing.T) (*nonceNode, *blockchain.Client) {
+	t.Helper()
+	node := &nonceNode{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		switch req.Method {
+		case "eth_chainId":
+			resp["result"] = "0x14a34"
+		case "net_version":
+			resp["result"] = "84532"
+		case "eth_blockNumber":
+			resp["result"] = "0x10"
+		case "eth_getCode":
+			resp["result"] = "0x6001"
+		case "eth_call":
+			// version() == 3
+			resp["result"] = "0x0000000000000000000000000000000000000000000000000000000000000003"
+		case "eth_getTransactionCount":
+			node.mu.Lock()
+			pending := len(node.nonces)
+			node.mu.Unlock()
+			// окно между чтением nonce и отправкой, в которое без блокировки
+			// успевает второй отправитель
+			time.Sleep(50 * time.Millisecond)
+			resp["result"] = hexutil.Uint64(pending)
+		case "eth_sendRawTransaction":
+			var raw hexutil.Bytes
+			tx := new(types.Transaction)
+			if err := json.Unmarshal(req.Params[0], &raw); err != nil || tx.UnmarshalBinary(raw) != nil {
+				resp["error"] = map[string]interface{}{"code": -32602, "message": "invalid transaction"}
+				break
+			}
+			node.mu.Lock()
+			if tx.Nonce() != uint64(len(node.nonces)) {
+				resp["error"] = map[string]interface{}{"code": -32000, "message": "nonce too low"}
+			} else {
+				node.nonces = append(node.nonces, tx.Nonce())
+				resp["result"] = tx.Hash()
+			}
+			node.mu.Unlock()
+		case "eth_getTransactionReceipt":
+			var hash common.Hash
+			json.Unmarshal(req.Params[0], &hash)
+			resp["result"] = map[string]interface{}{
+				"status":            "0x1",
+				"cumulativeGasUsed": "0x5208",
+				"gasUsed":           "0x5208",
+				"logsBloom":         types.Bloom{},
+				"logs":              []interface{}{},
+				"transactionHash":   hash,
+				"blockHash":         common.Hash{2},
+				"blockNumber":       "0x11",
+				"transactionIndex":  "0x0",
+			}
+		default:
+			resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	retry := testRetryPolicy()
+	client, err := blockchain.NewClient(&blockchain.BlockchainConfig{
+		RPCEndpoint:     server.URL,
+		ContractAddress: "0x02a06b3427A2D949E971Bd80606996C75ae9fEa9",
+		ChainID:         84532,
+		GasLimit:        1_000_000,
+		GasPrice:        big.NewInt(1_000_000_000),
+		Retry:           &retry,
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return node, client
+}
+
+// TestClientConcurrentWritesUseDistinctNonces тестирует, что одновременные
+// StoreData одного аккаунта не берут один и тот же nonce (запускать с -race)
+func TestClientConcurrentWritesUseDistinctNonces(t *testing.T) {
+	node, client := newNonceNode(t)
+
+	key, _ := crypto.GenerateKey()
+	signer, err := blockchain.NewKeySigner(key)
+	if err != nil {
+		t.Fatalf("NewKeySigner failed: %v", err)
+	}
+	defer signer.Destroy()
+	if _, err := client.CreateSession(signer, nil); err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = client.StoreData(ctx, []byte{byte(i)})
+		}(i)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("StoreData %d failed: %v", i, err)
+		}
+	}
+	if len(node.nonces) != 2 || node.nonces[0] != 0 || node.nonces[1] != 1 {
+		t.Errorf("Expected nonces [0 1], got %v", node.nonces)
+	}
+}
@@ -0,0 +1,188 @@
+package blockchain_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"encryptkeep-backend/internal/blockchain"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// rpcDataError повторяет ошибку JSON-RPC с полем data, как ее возвращает ethclient
+type rpcDataError struct {
+	message string
+	data    interface{}
+}
+
+func (e *rpcDataError) Error() string          { return e.message }
+func (e *rpcDataError) ErrorData() interface{} { return e.data }
+
+func keeperRevert(t *testing.T, name string, args ...interface{}) error {
+	t.Helper()
+
+	parsed, err := blockchain.KeeperMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("GetAbi failed: %v", err)
+	}
+	abiErr, ok := parsed.Errors[name]
+	if !ok {
+		t.Fatalf("Unknown error %s", name)
+	}
+	packed, err := abiErr.Inputs.Pack(args...)
+	if err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+
+	data := append(append([]byte{}, abiErr.ID[:4]...), packed...)
+	return &rpcDataError{message: "execution reverted", data: hexutil.Encode(data)}
+}
+
+// TestParseContractErrorDecodesRevertData тестирует декодирование custom errors по ABI
+func TestParseContractErrorDecodesRevertData(t *testing.T) {
+	account := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	rpcErr := keeperRevert(t, "CannotChangeNonExistentData", account, big.NewInt(7))
+
+	// ошибка может прийти обернутой
+	parsed := blockchain.ParseContractError(fmt.Errorf("changeData: %w", rpcErr))
+
+	if parsed.Type != "CANNOT_CHANGE_NON_EXISTENT_DATA" || parsed.Code != 2003 {
+		t.Errorf("Unexpected classification %s/%d", parsed.Type, parsed.Code)
+	}
+	if !errors.Is(parsed, blockchain.ErrCannotChangeNonExistentData) {
+		t.Error("Expected errors.Is to match ErrCannotChangeNonExistentData")
+	}
+	if errors.Is(parsed, blockchain.ErrCannotRemoveNonExistentData) {
+		t.Error("Did not expect errors.Is to match ErrCannotRemoveNonExistentData")
+	}
+
+	var contractErr *blockchain.ContractError
+	if !errors.As(parsed, &contractErr) {
+		t.Fatal("Expected errors.As to find ContractError")
+	}
+	if contractErr.ID == nil || contractErr.ID.Int64() != 7 {
+		t.Errorf("Expected offending ID 7, got %v", contractErr.ID)
+	}
+	if contractErr.Account != account {
+		t.Errorf("Expected account %s, got %s", account, contractErr.Account)
+	}
+
+	// GetErrorCode видит код и через обертку
+	if blockchain.GetErrorCode(fmt.Errorf("wrapped: %w", parsed)) != 2003 {
+		t.Error("Expected GetErrorCode to see through wrapping")
+	}
+}
+
+// TestParseContractErrorBatchMismatch тестирует ошибку без ID
+func TestParseContractErrorBatchMismatch(t *testing.T) {
+	parsed := blockchain.ParseContractError(keeperRevert(t, "BatchLengthMismatch", big.NewInt(2), big.NewInt(3)))
+
+	if !errors.Is(parsed, blockchain.ErrBatchLengthMismatch) {
+		t.Error("Expected errors.Is to match ErrBatchLengthMismatch")
+	}
+	var contractErr *blockchain.ContractError
+	if !errors.As(parsed, &contractErr) {
+		t.Fatal("Expected errors.As to find ContractError")
+	}
+	if contractErr.ID != nil {
+		t.Errorf("Expected no ID, got %v", contractErr.ID)
+	}
+	if len(contractErr.Args) != 2 {
+		t.Errorf("Expected 2 args, got %d", len(contractErr.Args))
+	}
+}
+
+// TestParseContractErrorCollection тестирует декодирование ошибок общих коллекций
+func TestParseContractErrorCollection(t *testing.T) {
+	member := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	parsed := blockchain.ParseContractError(keeperRevert(t, "NotCollectionOwner", big.NewInt(4), member))
+
+	if !errors.Is(parsed, blockchain.ErrNotCollectionOwner) {
+		t.Error("Expected errors.Is to match ErrNotCollectionOwner")
+	}
+	var contractErr *blockchain.ContractError
+	if !errors.As(parsed, &contractErr) {
+		t.Fatal("Expected errors.As to find ContractError")
+	}
+	if contractErr.Account != member || contractErr.ID == nil || contractErr.ID.Int64() != 4 {
+		t.Errorf("Expected collection 4 and member %s, got %v and %s", member.Hex(), contractErr.ID, contractErr.Account.Hex())
+	}
+
+	unknown := blockchain.ParseContractError(keeperRevert(t, "UnknownCollection", big.NewInt(9)))
+	if !errors.Is(unknown, blockchain.ErrUnknownCollection) || blockchain.GetErrorCode(unknown) != 2007 {
+		t.Errorf("Expected UNKNOWN_COLLECTION (2007), got %v", unknown)
+	}
+}
+
+// TestParseContractErrorEmergency тестирует декодирование ошибки экстренного доступа
+func TestParseContractErrorEmergency(t *testing.T) {
+	owner := common.HexToAddress("0x1234567890123456789012345678901234567890")
+	contact := common.HexToAddress("0x00000000000000000000000000000000000000c0")
+	parsed := blockchain.ParseContractError(keeperRevert(t, "EmergencyAccessNotGranted", owner, contact, big.NewInt(1_700_000_000)))
+
+	if !errors.Is(parsed, blockchain.ErrEmergencyAccessNotGranted) || blockchain.GetErrorCode(parsed) != 2015 {
+		t.Errorf("Expected EMERGENCY_ACCESS_NOT_GRANTED (2015), got %v", parsed)
+	}
+	var contractErr *blockchain.ContractError
+	if !errors.As(parsed, &contractErr) || contractErr.Account != owner || len(contractErr.Args) != 3 {
+		t.Errorf("Expected owner %s and 3 args, got %+v", owner.Hex(), contractErr)
+	}
+}
+
+// TestDecodeRevertDataPanic тестирует декодирование Panic(uint256) и Error(string)
+func TestDecodeRevertDataPanic(t *testing.T) {
+	uint256, _ := abi.NewType("uint256", "", nil)
+	packed, err := abi.Arguments{{Type: uint256}}.Pack(big.NewInt(0x32))
+	if err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	data := append(crypto.Keccak256([]byte("Panic(uint256)"))[:4], packed...)
+
+	decoded := blockchain.DecodeRevertData(data)
+	if decoded == nil || decoded.PanicCode == nil || decoded.PanicCode.Int64() != 0x32 {
+		t.Fatalf("Expected panic 0x32, got %+v", decoded)
+	}
+	if !errors.Is(decoded, blockchain.ErrTransactionReverted) {
+		t.Error("Expected panic to unwrap to ErrTransactionReverted")
+	}
+
+	str, _ := abi.NewType("string", "", nil)
+	packed, err = abi.Arguments{{Type: str}}.Pack("not allowed")
+	if err != nil {
+		t.Fatalf("Pack failed: %v", err)
+	}
+	data = append(crypto.Keccak256([]byte("Error(string)"))[:4], packed...)
+
+	parsed := blockchain.ParseContractError(&rpcDataError{message: "execution reverted", data: hexutil.Encode(data)})
+	if parsed.Code != 1001 || parsed.Message != "Contract execution reverted: not allowed" {
+		t.Errorf("Unexpected result %d %q", parsed.Code, parsed.Message)
+	}
+
+	if blockchain.DecodeRevertData([]byte{0xde, 0xad, 0xbe, 0xef}) != nil {
+		t.Error("Expected nil for unknown selector")
+	}
+}
+
+// TestParseContractErrorTransient тестирует отличие сетевых ошибок от revert
+func TestParseContractErrorTransient(t *testing.T) {
+	parsed := blockchain.ParseContractError(errors.New("dial tcp: connection refused"))
+	if !errors.Is(parsed, blockchain.ErrConnectionFailed) {
+		t.Error("Expected errors.Is to match ErrConnectionFailed")
+	}
+	if errors.Is(parsed, blockchain.ErrTransactionReverted) {
+		t.Error("Connection error must not look like a revert")
+	}
+	if blockchain.IsContractError(parsed) {
+		t.Error("Connection error must not be a contract error")
+	}
+
+	reverted := blockchain.ParseContractError(&rpcDataError{message: "execution reverted"})
+	if !errors.Is(reverted, blockchain.ErrTransactionReverted) {
+		t.Error("Expected errors.Is to match ErrTransactionReverted")
+	}
+}
@@ -0,0 +1,259 @@
+package blockchain_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"encryptkeep-backend/internal/blockchain"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// rpcStub — минимальный JSON-RPC узел: отвечает на eth_chainId/net_version/eth_blockNumber,
+// на eth_call возвращает revert с данными, либо всегда отдает HTTP-статус status
+type rpcStub struct {
+	server *httptest.Server
+	hits   atomic.Int32
+	status atomic.Int32
+}
+
+func newRPCStub(t *testing.T, status int) *rpcStub {
+	stub := &rpcStub{}
+	stub.status.Store(int32(status))
+	stub.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		stub.hits.Add(1)
+		if status := int(stub.status.Load()); status != 0 {
+			http.Error(w, http.StatusText(status), status)
+			return
+		}
+
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		switch req.Method {
+		case "eth_chainId":
+			resp["result"] = "0x14a34"
+		case "net_version":
+			resp["result"] = "84532"
+		case "eth_blockNumber":
+			resp["result"] = "0x10"
+		case "eth_call":
+			// InvalidDataLength()
+			resp["error"] = map[string]interface{}{"code": 3, "message": "execution reverted", "data": "0xdfe93090"}
+		default:
+			resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(stub.server.Close)
+	return stub
+}
+
+func testRetryPolicy() blockchain.RetryPolicy {
+	return blockchain.RetryPolicy{
+		MaxAttempts:      4,
+		BaseDelay:        time.Millisecond,
+		MaxDelay:         5 * time.Millisecond,
+		FailureThreshold: 1,
+		Cooldown:         time.Minute,
+	}
+}
+
+// TestRPCPoolFailover тестирует переключение на резервный узел
+func TestRPCPoolFailover(t *testing.T) {
+	down := newRPCStub(t, http.StatusServiceUnavailable)
+	up := newRPCStub(t, 0)
+
+	pool, err := blockchain.NewRPCPool([]string{down.server.URL, up.server.URL}, testRetryPolicy())
+	if err != nil {
+		t.Fatalf("NewRPCPool failed: %v", err)
+	}
+	defer pool.Close()
+
+	chainID, err := pool.ChainID(context.Background())
+	if err != nil {
+		t.Fatalf("ChainID failed: %v", err)
+	}
+	if chainID.Int64() != 84532 {
+		t.Errorf("Expected chain ID 84532, got %s", chainID)
+	}
+
+	// Цепь неисправного узла разомкнута, повторный запрос к нему не идет
+	before := down.hits.Load()
+	if _, err := pool.ChainID(context.Background()); err != nil {
+		t.Fatalf("ChainID failed: %v", err)
+	}
+	if down.hits.Load() != before {
+		t.Error("Expected open circuit to skip the failing endpoint")
+	}
+
+	status := pool.Status()
+	if status[0].Healthy || status[0].OpenUntil.IsZero() {
+		t.Errorf("Expected first endpoint to be unhealthy with open circuit, got %+v", status[0])
+	}
+	if !status[1].Healthy {
+		t.Errorf("Expected second endpoint to be healthy, got %+v", status[1])
+	}
+}
+
+// TestRPCPoolRetriesExhausted тестирует ограничение числа попыток
+func TestRPCPoolRetriesExhausted(t *testing.T) {
+	limited := newRPCStub(t, http.StatusTooManyRequests)
+
+	policy := testRetryPolicy()
+	policy.FailureThreshold = 100
+	pool, err := blockchain.NewRPCPool([]string{limited.server.URL}, policy)
+	if err != nil {
+		t.Fatalf("NewRPCPool failed: %v", err)
+	}
+	defer pool.Close()
+
+	_, err = pool.ChainID(context.Background())
+	if err == nil {
+		t.Fatal("Expected error from rate limited endpoint")
+	}
+	if blockchain.GetErrorType(blockchain.ParseContractError(err)) != "RATE_LIMITED" {
+		t.Errorf("Expected RATE_LIMITED, got %v", err)
+	}
+	if got := limited.hits.Load(); got != int32(policy.MaxAttempts) {
+		t.Errorf("Expected %d attempts, got %d", policy.MaxAttempts, got)
+	}
+}
+
+// TestRPCPoolRevertNotRetried тестирует, что revert не повторяется
+func TestRPCPoolRevertNotRetried(t *testing.T) {
+	up := newRPCStub(t, 0)
+
+	pool, err := blockchain.NewRPCPool([]string{up.server.URL}, testRetryPolicy())
+	if err != nil {
+		t.Fatalf("NewRPCPool failed: %v", err)
+	}
+	defer pool.Close()
+
+	to := common.HexToAddress("0x02a06b3427A2D949E971Bd80606996C75ae9fEa9")
+	_, err = pool.CallContract(context.Background(), ethereum.CallMsg{To: &to}, nil)
+	if !errors.Is(blockchain.ParseContractError(err), blockchain.ErrInvalidDataLength) {
+		t.Errorf("Expected ErrInvalidDataLength, got %v", err)
+	}
+	if up.hits.Load() != 1 {
+		t.Errorf("Expected a single request, got %d", up.hits.Load())
+	}
+	if !pool.Status()[0].Healthy {
+		t.Error("A revert must not mark the endpoint unhealthy")
+	}
+}
+
+// TestRPCPoolHealthCheck тестирует проверку состояния узлов
+func TestRPCPoolHealthCheck(t *testing.T) {
+	down := newRPCStub(t, http.StatusBadGateway)
+	up := newRPCStub(t, 0)
+
+	pool, err := blockchain.NewRPCPool([]string{down.server.URL, up.server.URL}, testRetryPolicy())
+	if err != nil {
+		t.Fatalf("NewRPCPool failed: %v", err)
+	}
+	defer pool.Close()
+
+	status := pool.HealthCheck(context.Background())
+	if status[0].Healthy || status[0].LastError == "" {
+		t.Errorf("Expected first endpoint to fail the check, got %+v", status[0])
+	}
+	if !status[1].Healthy || status[1].CheckedAt.IsZero() {
+		t.Errorf("Expected second endpoint to pass the check, got %+v", status[1])
+	}
+
+	// Узел снова доступен: после проверки цепь замыкается
+	down.status.Store(0)
+	if status := pool.HealthCheck(context.Background()); !status[0].Healthy {
+		t.Errorf("Expected recovered endpoint to be healthy, got %+v", status[0])
+	}
+}
+
+// TestIsTransient тестирует классификацию временных ошибок
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected bool
+	}{
+		{"Rate limited", rpc.HTTPError{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests"}, true},
+		{"Service unavailable", rpc.HTTPError{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable"}, true},
+		{"Connection refused", errors.New("dial tcp 127.0.0.1:8545: connect: connection refused"), true},
+		{"Deadline", context.DeadlineExceeded, true},
+		{"Canceled", context.Canceled, false},
+		{"Revert", errors.New("execution reverted"), false},
+		{"Nonce too low", errors.New("nonce too low"), false},
+		{"Nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := blockchain.IsTransient(tt.err); got != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+// TestConfigEndpoints тестирует список узлов из конфигурации
+func TestConfigEndpoints(t *testing.T) {
+	config := &blockchain.BlockchainConfig{
+		RPCEndpoint:  "http://a",
+		RPCEndpoints: []string{"http://b", "http://a", " ", "http://c"},
+	}
+
+	endpoints := config.Endpoints()
+	expected := []string{"http://a", "http://b", "http://c"}
+	if len(endpoints) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, endpoints)
+	}
+	for i := range expected {
+		if endpoints[i] != expected[i] {
+			t.Errorf("Expected %v, got %v", expected, endpoints)
+		}
+	}
+}
+
+// TestRPCPoolChainIDMismatch тестирует отключение узла другой сети
+func TestRPCPoolChainIDMismatch(t *testing.T) {
+	up := newRPCStub(t, 0)
+
+	pool, err := blockchain.NewRPCPool([]string{up.server.URL}, testRetryPolicy())
+	if err != nil {
+		t.Fatalf("NewRPCPool failed: %v", err)
+	}
+	defer pool.Close()
+
+	// Заглушка отвечает chain ID 84532
+	pool.ExpectChainID(1)
+
+	_, err = pool.NetworkID(context.Background())
+	if !errors.Is(err, blockchain.ErrInvalidChainID) {
+		t.Fatalf("Expected ErrInvalidChainID, got %v", err)
+	}
+	if pool.Status()[0].Healthy {
+		t.Error("Expected endpoint of another chain to be disabled")
+	}
+
+	// Новое ожидание проверяется заново
+	pool.ExpectChainID(84532)
+	if _, err := pool.NetworkID(context.Background()); err != nil {
+		t.Errorf("Expected matching endpoint to be used, got %v", err)
+	}
+}
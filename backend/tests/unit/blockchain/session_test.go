@@ -0,0 +1,185 @@
+package blockchain_test
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"encryptkeep-backend/internal/blockchain"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// keeperLogs — ответ узла на eth_getLogs: DataStored(account, 7, 1) в блоке 0x10
+var keeperLogs = func() []map[string]interface{} {
+	parsed, _ := blockchain.KeeperMetaData.GetAbi()
+	return []map[string]interface{}{{
+		"address": "0x02a06b3427a2d949e971bd80606996c75ae9fea9",
+		"topics": []string{
+			parsed.Events["DataStored"].ID.Hex(),
+			common.BytesToHash(common.HexToAddress(logAccount).Bytes()).Hex(),
+			common.BigToHash(big.NewInt(7)).Hex(),
+		},
+		"data":             common.BigToHash(big.NewInt(1)).Hex(),
+		"blockNumber":      "0x10",
+		"transactionHash":  common.Hash{1}.Hex(),
+		"transactionIndex": "0x0",
+		"blockHash":        common.Hash{2}.Hex(),
+		"logIndex":         "0x0",
+		"removed":          false,
+	}}
+}()
+
+const logAccount = "0x1234567890123456789012345678901234567890"
+
+// newKeeperNode запускает JSON-RPC узел с контрактом Keeper версии 3 по любому адресу
+func newKeeperNode(t *testing.T) *blockchain.Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		resp := map[string]interface{}{"jsonrpc": "2.0", "id": req.ID}
+		switch req.Method {
+		case "eth_chainId":
+			resp["result"] = "0x14a34"
+		case "net_version":
+			resp["result"] = "84532"
+		case "eth_blockNumber":
+			resp["result"] = "0x10"
+		case "eth_getCode":
+			resp["result"] = "0x6001"
+		case "eth_call":
+			// version() == 3
+			resp["result"] = "0x0000000000000000000000000000000000000000000000000000000000000003"
+		case "eth_getLogs":
+			resp["result"] = keeperLogs
+		default:
+			resp["error"] = map[string]interface{}{"code": -32601, "message": "method not found"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(resp)
+	}))
+	t.Cleanup(server.Close)
+
+	retry := testRetryPolicy()
+	client, err := blockchain.NewClient(&blockchain.BlockchainConfig{
+		RPCEndpoint:     server.URL,
+		ContractAddress: "0x02a06b3427A2D949E971Bd80606996C75ae9fEa9",
+		ChainID:         84532,
+		Retry:           &retry,
+	})
+	if err != nil {
+		t.Fatalf("NewClient failed: %v", err)
+	}
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// TestClientGetSessionReturnsCopy тестирует, что GetSession не отдаёт внутреннее состояние
+func TestClientGetSessionReturnsCopy(t *testing.T) {
+	client := newKeeperNode(t)
+	if client.GetSession() != nil {
+		t.Fatal("New client should have no session")
+	}
+
+	key, _ := crypto.GenerateKey()
+	signer, err := blockchain.NewKeySigner(key)
+	if err != nil {
+		t.Fatalf("NewKeySigner failed: %v", err)
+	}
+	defer signer.Destroy()
+	if _, err := client.CreateSession(signer, nil); err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+
+	session := client.GetSession()
+	session.Address = "0x0"
+	if client.GetSession().Address != signer.Address().Hex() {
+		t.Error("Changing the returned session should not affect the client")
+	}
+
+	client.ClearSession()
+	if client.GetSession() != nil {
+		t.Error("ClearSession should drop the session")
+	}
+	if session.Signer == nil {
+		t.Error("ClearSession should not touch copies held by callers")
+	}
+}
+
+// TestClientSessionConcurrentUse тестирует одновременную работу с сессией клиента (запускать с -race)
+func TestClientSessionConcurrentUse(t *testing.T) {
+	client := newKeeperNode(t)
+
+	key, _ := crypto.GenerateKey()
+	signer, err := blockchain.NewKeySigner(key)
+	if err != nil {
+		t.Fatalf("NewKeySigner failed: %v", err)
+	}
+	defer signer.Destroy()
+
+	var wg sync.WaitGroup
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				if s := client.GetSession(); s != nil && s.LastUsed.After(time.Now()) {
+					t.Error("LastUsed should not be in the future")
+				}
+				client.ContractAddress()
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			if _, err := client.CreateSession(signer, nil); err != nil {
+				t.Errorf("CreateSession failed: %v", err)
+			}
+			client.ClearSession()
+		}
+	}()
+	// смена контракта во время чтения адреса
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 10 {
+			if err := client.UseContract(context.Background(), "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"); err != nil {
+				t.Errorf("UseContract failed: %v", err)
+			}
+		}
+	}()
+	wg.Wait()
+}
+
+// TestClientVaultChanges тестирует разбор событий хранилища из журнала контракта
+func TestClientVaultChanges(t *testing.T) {
+	client := newKeeperNode(t)
+
+	changes, err := client.VaultChanges(context.Background(), logAccount, 0, 16)
+	if err != nil {
+		t.Fatalf("VaultChanges failed: %v", err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("Expected 1 change, got %d", len(changes))
+	}
+	c := changes[0]
+	if c.Kind != blockchain.ChangeStored || c.ID.Int64() != 7 || c.Revision.Int64() != 1 || c.Block != 16 {
+		t.Errorf("Unexpected change %+v", c)
+	}
+}
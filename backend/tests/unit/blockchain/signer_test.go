@@ -0,0 +1,191 @@
+package blockchain_test
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"math/big"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"encryptkeep-backend/internal/blockchain"
+	"encryptkeep-backend/internal/secret"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+)
+
+// clefStub — минимальный внешний подписант с API clef: account_version,
+// account_list, account_signTransaction и account_signData
+type clefStub struct {
+	key      *ecdsa.PrivateKey
+	deny     atomic.Bool
+	approved atomic.Int32
+}
+
+func (s *clefStub) Version() string { return "6.1.0" }
+
+func (s *clefStub) List() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(s.key.PublicKey)}
+}
+
+func (s *clefStub) SignTransaction(args apitypes.SendTxArgs) (map[string]any, error) {
+	if s.deny.Load() {
+		return nil, errors.New("request denied")
+	}
+	tx, err := args.ToTransaction()
+	if err != nil {
+		return nil, err
+	}
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID((*big.Int)(args.ChainID)), s.key)
+	if err != nil {
+		return nil, err
+	}
+	raw, _ := signed.MarshalBinary()
+	s.approved.Add(1)
+	return map[string]any{"raw": hexutil.Bytes(raw), "tx": signed}, nil
+}
+
+func (s *clefStub) SignData(contentType string, addr common.MixedcaseAddress, data hexutil.Bytes) (hexutil.Bytes, error) {
+	sig, err := crypto.Sign(accounts.TextHash(data), s.key)
+	if err != nil {
+		return nil, err
+	}
+	sig[64] += 27 // clef отдаёт V в формате 27/28
+	s.approved.Add(1)
+	return sig, nil
+}
+
+// newClefStub запускает заглушку clef на локальном HTTP-сервере
+func newClefStub(t *testing.T) (*clefStub, string) {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	stub := &clefStub{key: key}
+
+	server := rpc.NewServer()
+	if err := server.RegisterName("account", stub); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	httpServer := httptest.NewServer(server)
+	t.Cleanup(func() {
+		httpServer.Close()
+		server.Stop()
+	})
+	return stub, httpServer.URL
+}
+
+// checkSigner проверяет, что подписант подписывает транзакцию своим адресом и знает свой публичный ключ
+func checkSigner(t *testing.T, signer blockchain.Signer, want common.Address) {
+	t.Helper()
+	if signer.Address() != want {
+		t.Fatalf("Expected address %s, got %s", want.Hex(), signer.Address().Hex())
+	}
+
+	chainID := big.NewInt(31337)
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	tx := types.NewTx(&types.LegacyTx{Nonce: 7, To: &to, Gas: 21000, GasPrice: big.NewInt(1e9), Data: []byte{1, 2, 3}})
+	signed, err := signer.SignTx(tx, chainID)
+	if err != nil {
+		t.Fatalf("SignTx failed: %v", err)
+	}
+	from, err := types.Sender(types.LatestSignerForChainID(chainID), signed)
+	if err != nil || from != want {
+		t.Errorf("Transaction signed by %s (%v), want %s", from.Hex(), err, want.Hex())
+	}
+	if signed.Nonce() != 7 || signed.ChainId().Cmp(chainID) != 0 {
+		t.Errorf("Signer changed the transaction: nonce %d, chain %s", signed.Nonce(), signed.ChainId())
+	}
+
+	pub, err := signer.PublicKey()
+	if err != nil {
+		t.Fatalf("PublicKey failed: %v", err)
+	}
+	if crypto.PubkeyToAddress(*pub) != want {
+		t.Error("PublicKey does not belong to the signer's address")
+	}
+}
+
+// TestKeySigner тестирует подписание ключом в памяти
+func TestKeySigner(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	signer, err := blockchain.NewKeySigner(key)
+	if err != nil {
+		t.Fatalf("NewKeySigner failed: %v", err)
+	}
+	checkSigner(t, signer, crypto.PubkeyToAddress(key.PublicKey))
+
+	// после Destroy ключ стёрт и подписание невозможно
+	signer.Destroy()
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	if _, err := signer.SignTx(types.NewTx(&types.LegacyTx{To: &to, Gas: 21000, GasPrice: big.NewInt(1)}), big.NewInt(1)); !errors.Is(err, secret.ErrDestroyed) {
+		t.Errorf("Expected ErrDestroyed after Destroy, got %v", err)
+	}
+	if _, err := signer.PublicKey(); !errors.Is(err, secret.ErrDestroyed) {
+		t.Errorf("Expected ErrDestroyed from PublicKey, got %v", err)
+	}
+}
+
+// TestKeystoreSigner тестирует подписание ключом из каталога keystore geth
+func TestKeystoreSigner(t *testing.T) {
+	dir := t.TempDir()
+	key, _ := crypto.GenerateKey()
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	ks := keystore.NewKeyStore(dir, keystore.LightScryptN, keystore.LightScryptP)
+	if _, err := ks.ImportECDSA(key, "keystore-password"); err != nil {
+		t.Fatalf("ImportECDSA: %v", err)
+	}
+
+	if _, err := blockchain.NewKeystoreSigner(dir, "", "wrong"); !errors.Is(err, keystore.ErrDecrypt) {
+		t.Errorf("Expected ErrDecrypt, got %v", err)
+	}
+	if _, err := blockchain.NewKeystoreSigner(dir, "0x00000000000000000000000000000000000000bb", "keystore-password"); !errors.Is(err, blockchain.ErrSignerAccount) {
+		t.Errorf("Expected ErrSignerAccount for a foreign account, got %v", err)
+	}
+
+	signer, err := blockchain.NewKeystoreSigner(dir, address.Hex(), "keystore-password")
+	if err != nil {
+		t.Fatalf("NewKeystoreSigner failed: %v", err)
+	}
+	checkSigner(t, signer, address)
+}
+
+// TestExternalSigner тестирует подписание через JSON-RPC внешнего подписанта (clef)
+func TestExternalSigner(t *testing.T) {
+	stub, endpoint := newClefStub(t)
+	address := crypto.PubkeyToAddress(stub.key.PublicKey)
+
+	signer, err := blockchain.NewExternalSigner(endpoint, "")
+	if err != nil {
+		t.Fatalf("NewExternalSigner failed: %v", err)
+	}
+	checkSigner(t, signer, address)
+
+	// публичный ключ запрашивается у подписанта один раз
+	before := stub.approved.Load()
+	if _, err := signer.PublicKey(); err != nil || stub.approved.Load() != before {
+		t.Errorf("PublicKey should be cached, got %v", err)
+	}
+
+	// отказ пользователя в clef возвращается как ошибка подписи
+	stub.deny.Store(true)
+	to := common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	if _, err := signer.SignTx(types.NewTx(&types.LegacyTx{To: &to, Gas: 21000, GasPrice: big.NewInt(1)}), big.NewInt(1)); err == nil {
+		t.Error("Expected a denied request to fail")
+	}
+
+	if _, err := blockchain.NewExternalSigner(endpoint, "0x00000000000000000000000000000000000000bb"); !errors.Is(err, blockchain.ErrSignerAccount) {
+		t.Errorf("Expected ErrSignerAccount, got %v", err)
+	}
+	if _, err := blockchain.NewExternalSigner("http://127.0.0.1:1", ""); !errors.Is(err, blockchain.ErrConnectionFailed) {
+		t.Errorf("Expected ErrConnectionFailed, got %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package blockchain_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"encryptkeep-backend/internal/blockchain"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// fakeBackend отвечает на вызовы контракта по селектору метода
+type fakeBackend struct {
+	bind.ContractBackend
+	code      []byte
+	responses map[string][]byte // имя метода -> ABI-закодированный ответ
+	parsed    *abi.ABI
+}
+
+func newFakeBackend(t *testing.T, code []byte) *fakeBackend {
+	parsed, err := blockchain.KeeperMetaData.GetAbi()
+	if err != nil {
+		t.Fatalf("Failed to parse ABI: %v", err)
+	}
+	return &fakeBackend{code: code, responses: map[string][]byte{}, parsed: parsed}
+}
+
+func (f *fakeBackend) respond(t *testing.T, method string, values ...interface{}) {
+	out, err := f.parsed.Methods[method].Outputs.Pack(values...)
+	if err != nil {
+		t.Fatalf("Failed to pack %s output: %v", method, err)
+	}
+	f.responses[method] = out
+}
+
+func (f *fakeBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	return f.code, nil
+}
+
+func (f *fakeBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	for name, out := range f.responses {
+		if bytes.HasPrefix(call.Data, f.parsed.Methods[name].ID) {
+			return out, nil
+		}
+	}
+	return nil, errors.New("execution reverted")
+}
+
+// TestDetectKeeperVersion_NoCode тестирует адрес без кода контракта
+func TestDetectKeeperVersion_NoCode(t *testing.T) {
+	backend := newFakeBackend(t, nil)
+
+	_, err := blockchain.DetectKeeperVersion(context.Background(), backend, common.HexToAddress("0x01"))
+	if !errors.Is(err, blockchain.ErrContractNotFound) {
+		t.Errorf("Expected ErrContractNotFound, got %v", err)
+	}
+}
+
+// TestDetectKeeperVersion_VersionView тестирует определение версии через version()
+func TestDetectKeeperVersion_VersionView(t *testing.T) {
+	backend := newFakeBackend(t, []byte{0x60, 0x80})
+	backend.respond(t, "version", big.NewInt(2))
+
+	version, err := blockchain.DetectKeeperVersion(context.Background(), backend, common.HexToAddress("0x01"))
+	if err != nil {
+		t.Fatalf("DetectKeeperVersion failed: %v", err)
+	}
+	if version != blockchain.KeeperVersion2 {
+		t.Errorf("Expected version 2, got %d", version)
+	}
+}
+
+// TestDetectKeeperVersion_ERC165 тестирует определение версии через supportsInterface
+func TestDetectKeeperVersion_ERC165(t *testing.T) {
+	backend := newFakeBackend(t, []byte{0x60, 0x80})
+	backend.respond(t, "supportsInterface", true)
+
+	version, err := blockchain.DetectKeeperVersion(context.Background(), backend, common.HexToAddress("0x01"))
+	if err != nil {
+		t.Fatalf("DetectKeeperVersion failed: %v", err)
+	}
+	if version != blockchain.KeeperVersion2 {
+		t.Errorf("Expected version 2, got %d", version)
+	}
+}
+
+// TestDetectKeeperVersion_Legacy тестирует распознавание первой версии контракта
+func TestDetectKeeperVersion_Legacy(t *testing.T) {
+	backend := newFakeBackend(t, []byte{0x60, 0x80})
+	backend.respond(t, "nextDataId", big.NewInt(0))
+
+	version, err := blockchain.DetectKeeperVersion(context.Background(), backend, common.HexToAddress("0x01"))
+	if err != nil {
+		t.Fatalf("DetectKeeperVersion failed: %v", err)
+	}
+	if version != blockchain.KeeperVersion1 {
+		t.Errorf("Expected version 1, got %d", version)
+	}
+}
+
+// TestDetectKeeperVersion_Unsupported тестирует чужой контракт по адресу
+func TestDetectKeeperVersion_Unsupported(t *testing.T) {
+	backend := newFakeBackend(t, []byte{0x60, 0x80})
+
+	_, err := blockchain.DetectKeeperVersion(context.Background(), backend, common.HexToAddress("0x01"))
+	if !errors.Is(err, blockchain.ErrUnsupportedContract) {
+		t.Errorf("Expected ErrUnsupportedContract, got %v", err)
+	}
+}
+
+// TestKeeperInterfaceID тестирует вычисление ERC-165 идентификатора IKeeper
+func TestKeeperInterfaceID(t *testing.T) {
+	id, err := blockchain.KeeperInterfaceID()
+	if err != nil {
+		t.Fatalf("KeeperInterfaceID failed: %v", err)
+	}
+	if id == [4]byte{} {
+		t.Error("Interface ID should not be zero")
+	}
+	if id == [4]byte{0x01, 0xff, 0xc9, 0xa7} {
+		t.Error("Interface ID should differ from the ERC-165 interface ID")
+	}
+}
@@ -0,0 +1,271 @@
+package codec_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"encryptkeep-backend/internal/codec"
+	"encryptkeep-backend/internal/crypto"
+	"encryptkeep-backend/internal/secret"
+	"encryptkeep-backend/internal/vault"
+)
+
+// fastArgon2 — дешёвые параметры Argon2, чтобы тесты не тратили время на KDF
+var fastArgon2 = crypto.Argon2Config{Time: 1, Memory: 8 * 1024, Threads: 1, KeyLength: 32}
+
+const vaultKeyAccount = "0x9858EfFD232B4033E47d90003D41EC34EcaEda94"
+
+// TestVaultKeyOpensWithPassword тестирует совместимость конвертов ключа хранилища с паролем
+func TestVaultKeyOpensWithPassword(t *testing.T) {
+	cdc := codec.NewCodecWithConfig(fastArgon2)
+	key, err := codec.NewVaultKey("master-password", vaultKeyAccount, fastArgon2)
+	if err != nil {
+		t.Fatalf("NewVaultKey failed: %v", err)
+	}
+	defer key.Destroy()
+
+	entry := vault.NewPasswordEntry("Site", "user", "pass")
+	data, err := cdc.PackEntryWithVaultKey(entry, key)
+	if err != nil {
+		t.Fatalf("PackEntryWithVaultKey failed: %v", err)
+	}
+
+	// старые клиенты открывают конверт паролем
+	opened, err := cdc.UnpackEntry(data, "master-password")
+	if err != nil || opened.Password != "pass" {
+		t.Fatalf("Entry should open with the password, got %v", err)
+	}
+
+	// другое устройство выводит тот же ключ для того же аккаунта
+	other, err := codec.NewVaultKey("master-password", vaultKeyAccount, fastArgon2)
+	if err != nil {
+		t.Fatalf("NewVaultKey failed: %v", err)
+	}
+	if _, err := cdc.UnpackEntryWithVaultKey(data, other); err != nil {
+		t.Errorf("Entry should open with a key derived on another device: %v", err)
+	}
+
+	meta := &vault.UserMetadata{Version: "1.0", PasswordIDs: []string{entry.ID}, TotalEntries: 1}
+	metaData, err := cdc.PackMetadataWithVaultKey(meta, key)
+	if err != nil {
+		t.Fatalf("PackMetadataWithVaultKey failed: %v", err)
+	}
+	if _, err := cdc.UnpackMetadataWithVaultKey(metaData, key); err != nil {
+		t.Errorf("Metadata should open with the vault key: %v", err)
+	}
+}
+
+// TestVaultKeyLegacySalts тестирует заблаговременный вывод ключей старых конвертов
+func TestVaultKeyLegacySalts(t *testing.T) {
+	cdc := codec.NewCodecWithConfig(fastArgon2)
+	legacy, err := cdc.PackEntry(vault.NewPasswordEntry("Old", "user", "pass"), "master-password")
+	if err != nil {
+		t.Fatalf("PackEntry failed: %v", err)
+	}
+
+	key, err := codec.NewVaultKey("master-password", vaultKeyAccount, fastArgon2)
+	if err != nil {
+		t.Fatalf("NewVaultKey failed: %v", err)
+	}
+	defer key.Destroy()
+
+	// без вывода ключ случайной соли неизвестен
+	if _, err := cdc.UnpackEntryWithVaultKey(legacy, key); !errors.Is(err, codec.ErrUnknownVaultKey) {
+		t.Fatalf("Expected ErrUnknownVaultKey, got %v", err)
+	}
+
+	salt, ok := codec.EnvelopeSalt(legacy)
+	if !ok {
+		t.Fatal("EnvelopeSalt should find the salt of a password envelope")
+	}
+	added, err := key.Derive("master-password", salt, codec.VaultSalt(vaultKeyAccount))
+	if err != nil {
+		t.Fatalf("Derive failed: %v", err)
+	}
+	if added != 1 {
+		t.Errorf("Derive should skip known salts, added %d", added)
+	}
+	if _, err := cdc.UnpackEntryWithVaultKey(legacy, key); err != nil {
+		t.Errorf("Legacy entry should open after Derive: %v", err)
+	}
+
+	// ключи других солей без пароля не выводятся
+	other, _ := cdc.PackEntry(vault.NewPasswordEntry("Other", "user", "pass"), "master-password")
+	if _, err := cdc.UnpackEntryWithVaultKey(other, key); !errors.Is(err, codec.ErrUnknownVaultKey) {
+		t.Errorf("Expected ErrUnknownVaultKey for a salt not derived, got %v", err)
+	}
+}
+
+// TestVaultKeyManyLegacySalts тестирует хранилище, где у каждой старой записи
+// своя соль: все ключи помещаются в один буфер защищённой памяти вместо
+// отдельной заблокированной страницы на соль
+func TestVaultKeyManyLegacySalts(t *testing.T) {
+	const entries = 200
+	cdc := codec.NewCodecWithConfig(fastArgon2)
+	key, err := codec.NewVaultKey("master-password", vaultKeyAccount, fastArgon2)
+	if err != nil {
+		t.Fatalf("NewVaultKey failed: %v", err)
+	}
+	defer key.Destroy()
+
+	legacy := make([][]byte, entries)
+	salts := make([][]byte, entries)
+	for i := range legacy {
+		legacy[i], err = cdc.PackEntry(vault.NewPasswordEntry("Old", "user", "pass"), "master-password")
+		if err != nil {
+			t.Fatalf("PackEntry failed: %v", err)
+		}
+		salts[i], _ = codec.EnvelopeSalt(legacy[i])
+	}
+
+	added, err := key.Derive("master-password", salts...)
+	if err != nil {
+		t.Fatalf("Derive failed: %v", err)
+	}
+	if added != entries {
+		t.Errorf("Expected %d derived keys, got %d", entries, added)
+	}
+	for i, data := range legacy {
+		if _, err := cdc.UnpackEntryWithVaultKey(data, key); err != nil {
+			t.Fatalf("Legacy entry %d should open after Derive: %v", i, err)
+		}
+	}
+
+	// ключи, выведенные раньше, переживают перестройку буфера
+	current, err := cdc.PackEntryWithVaultKey(vault.NewPasswordEntry("Site", "user", "pass"), key)
+	if err != nil {
+		t.Fatalf("PackEntryWithVaultKey failed: %v", err)
+	}
+	extra, _ := cdc.PackEntry(vault.NewPasswordEntry("Extra", "user", "pass"), "master-password")
+	extraSalt, _ := codec.EnvelopeSalt(extra)
+	if _, err := key.Derive("master-password", extraSalt); err != nil {
+		t.Fatalf("Derive failed: %v", err)
+	}
+	for _, data := range [][]byte{current, extra, legacy[0], legacy[entries-1]} {
+		if _, err := cdc.UnpackEntryWithVaultKey(data, key); err != nil {
+			t.Errorf("Envelope should still open after adding a key: %v", err)
+		}
+	}
+
+	exported, err := key.Export()
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if len(exported) != entries+2 {
+		t.Fatalf("Expected %d exported keys, got %d", entries+2, len(exported))
+	}
+	imported, err := codec.ImportVaultKey(vaultKeyAccount, exported, fastArgon2)
+	if err != nil {
+		t.Fatalf("ImportVaultKey failed: %v", err)
+	}
+	defer imported.Destroy()
+	if _, err := cdc.UnpackEntryWithVaultKey(legacy[entries/2], imported); err != nil {
+		t.Errorf("Imported key should open a legacy envelope: %v", err)
+	}
+}
+
+// TestEnvelopeSalt тестирует извлечение соли из конвертов разных форматов
+func TestEnvelopeSalt(t *testing.T) {
+	cdc := codec.NewCodecWithConfig(fastArgon2)
+	key, err := codec.NewVaultKey("master-password", vaultKeyAccount, fastArgon2)
+	if err != nil {
+		t.Fatalf("NewVaultKey failed: %v", err)
+	}
+	defer key.Destroy()
+
+	data, err := cdc.PackEntryWithVaultKey(vault.NewPasswordEntry("Site", "user", "pass"), key)
+	if err != nil {
+		t.Fatalf("PackEntryWithVaultKey failed: %v", err)
+	}
+	if salt, ok := codec.EnvelopeSalt(data); !ok || !bytes.Equal(salt, codec.VaultSalt(vaultKeyAccount)) {
+		t.Errorf("Expected the account salt, got %x", salt)
+	}
+
+	legacy := []byte(`{"salt":"AAECAwQFBgcICQoLDA0ODw==","nonce":"","encrypted_data":""}`)
+	if salt, ok := codec.EnvelopeSalt(legacy); !ok || len(salt) != crypto.DefaultSaltLen {
+		t.Errorf("Expected the salt of a legacy blob, got %x", salt)
+	}
+
+	keyed, err := cdc.PackEntryWithKey(vault.NewPasswordEntry("Site", "user", "pass"), make([]byte, 32))
+	if err != nil {
+		t.Fatalf("PackEntryWithKey failed: %v", err)
+	}
+	if _, ok := codec.EnvelopeSalt(keyed); ok {
+		t.Error("Envelopes sealed with a raw key have no salt")
+	}
+}
+
+// TestVaultKeyExportImport тестирует перенос выведенных ключей без пароля
+func TestVaultKeyExportImport(t *testing.T) {
+	cdc := codec.NewCodecWithConfig(fastArgon2)
+	key, err := codec.NewVaultKey("master-password", vaultKeyAccount, fastArgon2)
+	if err != nil {
+		t.Fatalf("NewVaultKey failed: %v", err)
+	}
+	defer key.Destroy()
+
+	legacy, _ := cdc.PackEntry(vault.NewPasswordEntry("Old", "user", "pass"), "master-password")
+	salt, _ := codec.EnvelopeSalt(legacy)
+	if _, err := key.Derive("master-password", salt); err != nil {
+		t.Fatalf("Derive failed: %v", err)
+	}
+	data, err := cdc.PackEntryWithVaultKey(vault.NewPasswordEntry("Site", "user", "pass"), key)
+	if err != nil {
+		t.Fatalf("PackEntryWithVaultKey failed: %v", err)
+	}
+
+	exported, err := key.Export()
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if len(exported) != 2 {
+		t.Fatalf("Expected 2 exported keys, got %d", len(exported))
+	}
+	imported, err := codec.ImportVaultKey(vaultKeyAccount, exported, fastArgon2)
+	if err != nil {
+		t.Fatalf("ImportVaultKey failed: %v", err)
+	}
+	defer imported.Destroy()
+	for _, k := range exported {
+		if !bytes.Equal(k, make([]byte, len(k))) {
+			t.Error("ImportVaultKey should wipe the exported copies")
+		}
+	}
+
+	for _, d := range [][]byte{data, legacy} {
+		if _, err := cdc.UnpackEntryWithVaultKey(d, imported); err != nil {
+			t.Errorf("Imported key should open the envelope: %v", err)
+		}
+	}
+
+	if _, err := codec.ImportVaultKey(vaultKeyAccount, map[string][]byte{"zz": make([]byte, 32)}, fastArgon2); err == nil {
+		t.Error("Expected an error for a malformed salt")
+	}
+}
+
+// TestVaultKeyDestroy тестирует стирание ключа хранилища
+func TestVaultKeyDestroy(t *testing.T) {
+	cdc := codec.NewCodecWithConfig(fastArgon2)
+	key, err := codec.NewVaultKey("master-password", vaultKeyAccount, fastArgon2)
+	if err != nil {
+		t.Fatalf("NewVaultKey failed: %v", err)
+	}
+	entry := vault.NewPasswordEntry("Site", "user", "pass")
+	data, err := cdc.PackEntryWithVaultKey(entry, key)
+	if err != nil {
+		t.Fatalf("PackEntryWithVaultKey failed: %v", err)
+	}
+
+	key.Destroy()
+	key.Destroy() // повторный вызов безопасен
+	if !key.Destroyed() {
+		t.Error("Destroyed should report true")
+	}
+	if _, err := cdc.PackEntryWithVaultKey(entry, key); !errors.Is(err, secret.ErrDestroyed) {
+		t.Errorf("Expected ErrDestroyed on pack, got %v", err)
+	}
+	if _, err := cdc.UnpackEntryWithVaultKey(data, key); !errors.Is(err, secret.ErrDestroyed) {
+		t.Errorf("Expected ErrDestroyed on unpack, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package config_test
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"encryptkeep-backend/internal/config"
+)
+
+// TestLoadWithoutFile тестирует встроенные профили при отсутствии файла
+func TestLoadWithoutFile(t *testing.T) {
+	cfg, err := config.Load(t.TempDir())
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	profile, err := cfg.Profile("")
+	if err != nil {
+		t.Fatalf("Profile failed: %v", err)
+	}
+	if profile.ChainID != 84532 {
+		t.Errorf("Expected Base Sepolia by default, got chain %d", profile.ChainID)
+	}
+	if _, err := cfg.Profile("anvil"); err != nil {
+		t.Errorf("Expected built-in anvil profile: %v", err)
+	}
+}
+
+// TestSaveAndLoad тестирует сохранение и чтение профилей
+func TestSaveAndLoad(t *testing.T) {
+	dir := t.TempDir()
+
+	cfg := config.Default()
+	cfg.Profiles["production"] = &config.Profile{
+		RPCURLs:         []string{"https://rpc-1.example.org", "https://rpc-2.example.org"},
+		ChainID:         8453,
+		ContractAddress: "0x1234567890123456789012345678901234567890",
+		Gas:             config.GasPolicy{Limit: 500_000, MaxPriceGwei: 0.3},
+	}
+	cfg.DefaultProfile = "production"
+
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	info, err := os.Stat(config.Path(dir))
+	if err != nil {
+		t.Fatalf("Stat failed: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("Expected 0600 permissions, got %v", info.Mode().Perm())
+	}
+
+	loaded, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	profile, err := loaded.Profile("")
+	if err != nil {
+		t.Fatalf("Profile failed: %v", err)
+	}
+
+	bc := profile.BlockchainConfig()
+	if bc.ChainID != 8453 || bc.GasLimit != 500_000 {
+		t.Errorf("Unexpected blockchain config %+v", bc)
+	}
+	endpoints := bc.Endpoints()
+	if len(endpoints) != 2 || endpoints[0] != "https://rpc-1.example.org" {
+		t.Errorf("Unexpected endpoints %v", endpoints)
+	}
+	if bc.GasPrice != nil {
+		t.Errorf("Expected node gas price, got %s", bc.GasPrice)
+	}
+	if bc.MaxGasPrice == nil || bc.MaxGasPrice.Int64() != 300_000_000 {
+		t.Errorf("Expected max gas price 0.3 gwei, got %v", bc.MaxGasPrice)
+	}
+}
+
+// TestProfileNotFound тестирует выбор несуществующего профиля
+func TestProfileNotFound(t *testing.T) {
+	if _, err := config.Default().Profile("mainnet"); !errors.Is(err, config.ErrProfileNotFound) {
+		t.Errorf("Expected ErrProfileNotFound, got %v", err)
+	}
+}
+
+// TestValidate тестирует проверку профилей
+func TestValidate(t *testing.T) {
+	valid := func() *config.Profile {
+		return &config.Profile{
+			RPCURLs:         []string{"http://127.0.0.1:8545"},
+			ChainID:         31337,
+			ContractAddress: "0x5FbDB2315678afecb367f032d93F642f64180aa3",
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(p *config.Profile)
+	}{
+		{"No RPC URLs", func(p *config.Profile) { p.RPCURLs = nil }},
+		{"Zero chain ID", func(p *config.Profile) { p.ChainID = 0 }},
+		{"Bad contract address", func(p *config.Profile) { p.ContractAddress = "0x123" }},
+		{"Price above cap", func(p *config.Profile) { p.Gas = config.GasPolicy{PriceGwei: 2, MaxPriceGwei: 1} }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid()
+			tt.modify(p)
+			if err := p.Validate(); !errors.Is(err, config.ErrInvalidProfile) {
+				t.Errorf("Expected ErrInvalidProfile, got %v", err)
+			}
+		})
+	}
+
+	if err := valid().Validate(); err != nil {
+		t.Errorf("Expected valid profile, got %v", err)
+	}
+
+	dir := t.TempDir()
+	if err := os.WriteFile(config.Path(dir), []byte(`{"profiles": {"x": {"rpc_urls": [], "chain_id": 1}}}`), 0600); err != nil {
+		t.Fatalf("write config: %v", err)
+	}
+	if _, err := config.Load(dir); !errors.Is(err, config.ErrInvalidProfile) {
+		t.Errorf("Expected Load to reject invalid profile, got %v", err)
+	}
+}
@@ -1,9 +1,14 @@
 package crypto_test
 
 import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
 	"testing"
 
 	"encryptkeep-backend/internal/crypto"
+
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
 )
 
 // TestArgon2Config тестирует структуру Argon2Config
@@ -424,3 +429,36 @@ func TestDerivedKeyConsistency(t *testing.T) {
 		}
 	}
 }
+
+// TestWrapKey тестирует обёртывание ключа коллекции публичным ключом участника
+func TestWrapKey(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	if len(key) != crypto.AESKeyLen {
+		t.Fatalf("Expected %d byte key, got %d", crypto.AESKeyLen, len(key))
+	}
+
+	member, _ := ecdsa.GenerateKey(gethcrypto.S256(), rand.Reader)
+	other, _ := ecdsa.GenerateKey(gethcrypto.S256(), rand.Reader)
+
+	wrapped, err := crypto.WrapKey(&member.PublicKey, key)
+	if err != nil {
+		t.Fatalf("WrapKey failed: %v", err)
+	}
+	unwrapped, err := crypto.UnwrapKey(member, wrapped)
+	if err != nil {
+		t.Fatalf("UnwrapKey failed: %v", err)
+	}
+	if !bytes.Equal(unwrapped, key) {
+		t.Error("Unwrapped key does not match")
+	}
+
+	if _, err := crypto.UnwrapKey(other, wrapped); err == nil {
+		t.Error("Expected error unwrapping with another member's key")
+	}
+	if _, err := crypto.WrapKey(&member.PublicKey, key[:16]); err == nil {
+		t.Error("Expected error for short key")
+	}
+}
@@ -0,0 +1,55 @@
+package gitcred_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"encryptkeep-backend/internal/gitcred"
+)
+
+// TestRead тестирует разбор атрибутов, которые присылает git
+func TestRead(t *testing.T) {
+	input := "protocol=https\nhost=github.com\nusername=octocat\ncapability[]=authtype\n\nprotocol=ignored\n"
+	c, err := gitcred.Read(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if c.Protocol != "https" || c.Host != "github.com" || c.Username != "octocat" || c.Path != "" {
+		t.Errorf("Unexpected credential: %+v", c)
+	}
+
+	// атрибут url раскладывается на части
+	c, err = gitcred.Read(strings.NewReader("url=https://me@git.example.com:8443/team/repo.git\n"))
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if c.Protocol != "https" || c.Host != "git.example.com:8443" || c.Path != "team/repo.git" || c.Username != "me" {
+		t.Errorf("Unexpected credential from url: %+v", c)
+	}
+	if c.URL() != "https://git.example.com:8443/team/repo.git" {
+		t.Errorf("Unexpected URL %q", c.URL())
+	}
+
+	if _, err := gitcred.Read(strings.NewReader("garbage\n")); err == nil {
+		t.Error("Expected an error for a line without =")
+	}
+}
+
+// TestWrite тестирует ответ git
+func TestWrite(t *testing.T) {
+	var buf bytes.Buffer
+	c := &gitcred.Credential{Username: "octocat", Password: "hunter2"}
+	if err := c.Write(&buf); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+	if buf.String() != "username=octocat\npassword=hunter2\n" {
+		t.Errorf("Unexpected output %q", buf.String())
+	}
+
+	// перевод строки в пароле сломал бы протокол
+	c.Password = "a\nhost=evil.example"
+	if err := c.Write(&bytes.Buffer{}); err == nil {
+		t.Error("Expected an error for a password with a newline")
+	}
+}
@@ -0,0 +1,127 @@
+package hdwallet_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"encryptkeep-backend/internal/hdwallet"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TestMnemonicVectors тестирует мнемоники и seed по векторам BIP-39 (пароль TREZOR)
+func TestMnemonicVectors(t *testing.T) {
+	vectors := []struct {
+		entropy, mnemonic, seed string
+	}{
+		{
+			"00000000000000000000000000000000",
+			"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			"c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04",
+		},
+		{
+			"7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f7f",
+			"legal winner thank year wave sausage worth useful legal winner thank yellow",
+			"2e8905819b8723fe2c1d161860e5ee1830318dbf49a83bd451cfb8440c28bd6fa457fe1296106559a3c80937a1c1069be3a3a5bd381ee6260e8d9739fce1f607",
+		},
+		{
+			"ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff",
+			"zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote",
+			"dd48c104698c30cfe2b6142103248622fb7bb0ff692eebb00089b32d22484e1613912f0a5b694407be899ffd31ed3992c456cdf60f5d4564b8ba3f05a69890ad",
+		},
+	}
+
+	for _, v := range vectors {
+		entropy, _ := hex.DecodeString(v.entropy)
+		mnemonic, err := hdwallet.MnemonicFromEntropy(entropy)
+		if err != nil || mnemonic != v.mnemonic {
+			t.Errorf("MnemonicFromEntropy(%s) = %q, %v", v.entropy, mnemonic, err)
+		}
+		seed, err := hdwallet.Seed(v.mnemonic, "TREZOR")
+		if err != nil || hex.EncodeToString(seed) != v.seed {
+			t.Errorf("Seed(%q) = %x, %v", v.mnemonic, seed, err)
+		}
+	}
+}
+
+// TestValidateMnemonic тестирует проверку слов и контрольной суммы
+func TestValidateMnemonic(t *testing.T) {
+	mnemonic, err := hdwallet.NewMnemonic(hdwallet.DefaultWordCount)
+	if err != nil {
+		t.Fatalf("NewMnemonic failed: %v", err)
+	}
+	if n := len(strings.Fields(mnemonic)); n != 24 {
+		t.Fatalf("Expected 24 words, got %d", n)
+	}
+	if err := hdwallet.ValidateMnemonic("  " + strings.ToUpper(mnemonic) + "\n"); err != nil {
+		t.Errorf("Generated mnemonic should validate regardless of case and spacing: %v", err)
+	}
+
+	swapped := strings.Replace("abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about", "about", "abandon", 1)
+	if err := hdwallet.ValidateMnemonic(swapped); !errors.Is(err, hdwallet.ErrInvalidChecksum) {
+		t.Errorf("Expected ErrInvalidChecksum, got %v", err)
+	}
+	if err := hdwallet.ValidateMnemonic("abandon abandon abandon"); !errors.Is(err, hdwallet.ErrInvalidWordCount) {
+		t.Errorf("Expected ErrInvalidWordCount, got %v", err)
+	}
+	if err := hdwallet.ValidateMnemonic(strings.Replace(mnemonic, strings.Fields(mnemonic)[0], "notaword", 1)); !errors.Is(err, hdwallet.ErrUnknownWord) {
+		t.Errorf("Expected ErrUnknownWord, got %v", err)
+	}
+	if _, err := hdwallet.NewMnemonic(13); !errors.Is(err, hdwallet.ErrInvalidWordCount) {
+		t.Errorf("Expected ErrInvalidWordCount, got %v", err)
+	}
+}
+
+// TestDeriveKeyBIP32Vector тестирует вывод ключей по тестовому вектору 1 из BIP-32
+func TestDeriveKeyBIP32Vector(t *testing.T) {
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	vectors := map[string]string{
+		"m":           "e8f32e723decf4051aefac8e2c93c9c5b214313817cdb01a1494b917c8436b35",
+		"m/0'":        "edb2e14f9ee77d26dd93b4ecede8d16ed408ce149b6cd80b0715a2d911a0afea",
+		"m/0'/1":      "3c6cb8d0f6a264c91ea8b5030fadaa8e538b020f0a387421a12de9319dc93368",
+		"m/0h/1/2h":   "cbce0d719ecf7431d88e6a89fa1483e02e35092af60c042b1df2ff59fa424dca",
+		"m/0'/1/2'/2": "0f479245fb19a38a1954c5c7c0ebab2f9bdfd96a17563ef28a6a4b1a2a764ef4",
+	}
+	for path, want := range vectors {
+		key, err := hdwallet.DeriveKey(seed, path)
+		if err != nil {
+			t.Fatalf("DeriveKey(%s) failed: %v", path, err)
+		}
+		if got := hex.EncodeToString(crypto.FromECDSA(key)); got != want {
+			t.Errorf("DeriveKey(%s) = %s, want %s", path, got, want)
+		}
+	}
+
+	for _, bad := range []string{"", "44'/60'", "m/x", "m/2147483648"} {
+		if _, err := hdwallet.DeriveKey(seed, bad); !errors.Is(err, hdwallet.ErrInvalidPath) {
+			t.Errorf("DeriveKey(%q): expected ErrInvalidPath, got %v", bad, err)
+		}
+	}
+}
+
+// TestDeriveAccount тестирует адреса аккаунтов по пути BIP-44, совместимые с MetaMask
+func TestDeriveAccount(t *testing.T) {
+	mnemonic := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+	first, err := hdwallet.DeriveAccount(mnemonic, "", 0)
+	if err != nil {
+		t.Fatalf("DeriveAccount failed: %v", err)
+	}
+	if got := crypto.PubkeyToAddress(first.PublicKey).Hex(); got != "0x9858EfFD232B4033E47d90003D41EC34EcaEda94" {
+		t.Errorf("Unexpected account 0 address %s", got)
+	}
+
+	// у каждого хранилища свой аккаунт из той же фразы
+	second, err := hdwallet.DeriveAccount(mnemonic, "", 1)
+	if err != nil {
+		t.Fatalf("DeriveAccount failed: %v", err)
+	}
+	if crypto.PubkeyToAddress(second.PublicKey) == crypto.PubkeyToAddress(first.PublicKey) {
+		t.Error("Accounts 0 and 1 should differ")
+	}
+	if hdwallet.AccountPath(1) != "m/44'/60'/0'/0/1" {
+		t.Errorf("Unexpected account path %s", hdwallet.AccountPath(1))
+	}
+}
@@ -0,0 +1,81 @@
+package keymanager_test
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"encryptkeep-backend/internal/secret"
+)
+
+// TestKeyManagerConcurrentUse тестирует одновременное использование сессии и файла ключей (запускать с -race)
+func TestKeyManagerConcurrentUse(t *testing.T) {
+	const password = "concurrent-password-1"
+	km, address := initializedKeyManager(t, password)
+
+	var wg sync.WaitGroup
+
+	// чтение сессии из нескольких горутин
+	for range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 50 {
+				km.IsSessionActive()
+				km.UpdateActivity()
+				if addr, err := km.GetAddress(); err == nil && addr != address {
+					t.Errorf("Unexpected address %s", addr)
+				}
+				err := km.WithPrivateKey(func(key *ecdsa.PrivateKey) error {
+					if key.D.Sign() == 0 {
+						return errors.New("wallet key is zero inside WithPrivateKey")
+					}
+					return nil
+				})
+				// во время очистки сессии допустимы только эти ошибки
+				if err != nil && !errors.Is(err, secret.ErrDestroyed) && !strings.Contains(err.Error(), "session not active") {
+					t.Error(err)
+				}
+				km.VaultKey()
+			}
+		}()
+	}
+
+	// чтение keys.json во время записи
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 20 {
+			km.HasRecovery()
+			km.DerivationPath()
+		}
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if _, err := km.SetupRecovery(password, 3, 2); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	// очистка и повторное открытие сессии посреди работы
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		km.ClearSession()
+		if err := km.LoadFromStorage(password); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	wg.Wait()
+
+	if !km.IsSessionActive() {
+		t.Error("Session should be active after LoadFromStorage")
+	}
+	if !km.HasRecovery() {
+		t.Error("Recovery kit should be stored")
+	}
+}
@@ -4,12 +4,16 @@ import (
 	"crypto/ecdsa"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
 
+	"encryptkeep-backend/internal/codec"
 	"encryptkeep-backend/internal/keymanager"
+	"encryptkeep-backend/internal/vault"
 
 	"github.com/ethereum/go-ethereum/crypto"
 )
@@ -35,12 +39,12 @@ func TestNewKeyManager(t *testing.T) {
 		t.Error("Session should not be active initially")
 	}
 
-	if km.PrivateKey != nil {
-		t.Error("PrivateKey should be nil initially")
+	if _, err := km.GetPrivateKey(); err == nil {
+		t.Error("PrivateKey should not be available initially")
 	}
 
-	if km.MasterKey != nil {
-		t.Error("MasterKey should be nil initially")
+	if _, err := km.VaultKey(); err == nil {
+		t.Error("VaultKey should not be available initially")
 	}
 }
 
@@ -138,21 +142,14 @@ func TestClearSession(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Failed to generate private key: %v", err)
 	}
-
-	masterKey := []byte("test-master-key-32-bytes-long")
-
-	// Устанавливаем данные
-	km.PrivateKey = privateKey
-	km.MasterKey = make([]byte, len(masterKey))
-	copy(km.MasterKey, masterKey)
-	km.SessionActive = true
+	if err := km.InitializeFirstTime(hex.EncodeToString(crypto.FromECDSA(privateKey)), "test-password-123"); err != nil {
+		t.Fatalf("Failed to initialize: %v", err)
+	}
 
 	// Проверяем, что данные установлены
-	if km.PrivateKey == nil {
-		t.Error("PrivateKey should be set")
-	}
-	if km.MasterKey == nil {
-		t.Error("MasterKey should be set")
+	vaultKey, err := km.VaultKey()
+	if err != nil {
+		t.Fatalf("VaultKey should be set: %v", err)
 	}
 	if !km.IsSessionActive() {
 		t.Error("Session should be active")
@@ -161,12 +158,15 @@ func TestClearSession(t *testing.T) {
 	// Очищаем сессию
 	km.ClearSession()
 
-	// Проверяем, что данные очищены
-	if km.PrivateKey != nil {
-		t.Error("PrivateKey should be nil after ClearSession")
+	// Проверяем, что данные очищены, а ключ хранилища стёрт
+	if _, err := km.GetPrivateKey(); err == nil {
+		t.Error("PrivateKey should not be available after ClearSession")
 	}
-	if km.MasterKey != nil {
-		t.Error("MasterKey should be nil after ClearSession")
+	if _, err := km.VaultKey(); err == nil {
+		t.Error("VaultKey should not be available after ClearSession")
+	}
+	if !vaultKey.Destroyed() {
+		t.Error("VaultKey should be destroyed by ClearSession")
 	}
 	if km.SessionActive {
 		t.Error("Session should be inactive after ClearSession")
@@ -197,9 +197,9 @@ func TestGetPrivateKey(t *testing.T) {
 	}
 
 	// Активируем сессию
-	km.PrivateKey = privateKey
-	km.SessionActive = true
-	km.LastActivity = time.Now()
+	if err := km.InitializeFirstTime(hex.EncodeToString(crypto.FromECDSA(privateKey)), "test-password-123"); err != nil {
+		t.Fatalf("Failed to initialize: %v", err)
+	}
 
 	// Тест с активной сессией
 	retrievedKey, err := km.GetPrivateKey()
@@ -218,8 +218,8 @@ func TestGetPrivateKey(t *testing.T) {
 	}
 }
 
-// TestGetMasterKey тестирует получение мастер-ключа
-func TestGetMasterKey(t *testing.T) {
+// TestVaultKey тестирует получение ключа хранилища
+func TestVaultKey(t *testing.T) {
 	tempDir := t.TempDir()
 
 	config := keymanager.KeyManagerConfig{
@@ -230,33 +230,43 @@ func TestGetMasterKey(t *testing.T) {
 	km := keymanager.NewKeyManager(config)
 
 	// Тест без активной сессии
-	_, err := km.GetMasterKey()
+	_, err := km.VaultKey()
 	if err == nil {
 		t.Error("Should return error when session is not active")
 	}
 
-	// Создаем тестовый мастер-ключ
-	masterKey := []byte("test-master-key-32-bytes-long")
-
-	// Активируем сессию
-	km.MasterKey = make([]byte, len(masterKey))
-	copy(km.MasterKey, masterKey)
-	km.SessionActive = true
-	km.LastActivity = time.Now()
+	privateKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("Failed to generate private key: %v", err)
+	}
+	masterPassword := "test-password-123"
+	if err := km.InitializeFirstTime(hex.EncodeToString(crypto.FromECDSA(privateKey)), masterPassword); err != nil {
+		t.Fatalf("Failed to initialize: %v", err)
+	}
 
 	// Тест с активной сессией
-	retrievedKey, err := km.GetMasterKey()
+	vaultKey, err := km.VaultKey()
 	if err != nil {
-		t.Errorf("Should not return error when session is active: %v", err)
+		t.Fatalf("Should not return error when session is active: %v", err)
 	}
 
-	if retrievedKey == nil {
-		t.Error("Retrieved master key should not be nil")
+	// Ключ хранилища совпадает с выведенным из пароля для этого адреса
+	address := crypto.PubkeyToAddress(privateKey.PublicKey).Hex()
+	cdc := codec.NewCodec()
+	entry := vault.NewPasswordEntry("Site", "user", "pass")
+	data, err := cdc.PackEntryWithVaultKey(entry, vaultKey)
+	if err != nil {
+		t.Fatalf("PackEntryWithVaultKey failed: %v", err)
 	}
-
-	// Проверяем, что это тот же ключ
-	if len(retrievedKey) != len(masterKey) {
-		t.Errorf("Retrieved master key length should match original: got %d, want %d", len(retrievedKey), len(masterKey))
+	if _, err := cdc.UnpackEntry(data, masterPassword); err != nil {
+		t.Errorf("Entry sealed with the vault key should open with the password: %v", err)
+	}
+	other, err := codec.NewVaultKey(masterPassword, address, codec.FromVaultConfig(vault.DefaultVaultConfig()))
+	if err != nil {
+		t.Fatalf("NewVaultKey failed: %v", err)
+	}
+	if _, err := cdc.UnpackEntryWithVaultKey(data, other); err != nil {
+		t.Errorf("Entry should open with a key derived for the same account: %v", err)
 	}
 }
 
@@ -338,13 +348,13 @@ func TestInitializeFirstTime(t *testing.T) {
 	}
 
 	// Проверяем, что приватный ключ установлен
-	if km.PrivateKey == nil {
-		t.Error("PrivateKey should be set after InitializeFirstTime")
+	if _, err := km.GetPrivateKey(); err != nil {
+		t.Errorf("PrivateKey should be set after InitializeFirstTime: %v", err)
 	}
 
-	// Проверяем, что мастер-ключ установлен
-	if km.MasterKey == nil {
-		t.Error("MasterKey should be set after InitializeFirstTime")
+	// Проверяем, что ключ хранилища установлен
+	if _, err := km.VaultKey(); err != nil {
+		t.Errorf("VaultKey should be set after InitializeFirstTime: %v", err)
 	}
 
 	// Проверяем, что файл создан
@@ -400,13 +410,14 @@ func TestLoadFromStorage(t *testing.T) {
 	}
 
 	// Проверяем, что приватный ключ восстановлен
-	if km.PrivateKey == nil {
-		t.Error("PrivateKey should be restored after LoadFromStorage")
+	restored, err := km.GetPrivateKey()
+	if err != nil || restored.D.Cmp(privateKey.D) != 0 {
+		t.Errorf("PrivateKey should be restored after LoadFromStorage: %v", err)
 	}
 
-	// Проверяем, что мастер-ключ восстановлен
-	if km.MasterKey == nil {
-		t.Error("MasterKey should be restored after LoadFromStorage")
+	// Проверяем, что ключ хранилища восстановлен
+	if _, err := km.VaultKey(); err != nil {
+		t.Errorf("VaultKey should be restored after LoadFromStorage: %v", err)
 	}
 
 	// Тест загрузки с неправильным паролем
@@ -417,6 +428,49 @@ func TestLoadFromStorage(t *testing.T) {
 	}
 }
 
+// TestUnlockVaultKey тестирует сессию только с ключом хранилища, без
+// расшифровки keys.json
+func TestUnlockVaultKey(t *testing.T) {
+	km := keymanager.NewKeyManager(keymanager.KeyManagerConfig{ConfigDir: t.TempDir()})
+	key, _ := crypto.GenerateKey()
+	if err := km.InitializeFirstTime(hex.EncodeToString(crypto.FromECDSA(key)), "test-password"); err != nil {
+		t.Fatalf("InitializeFirstTime failed: %v", err)
+	}
+	full, err := km.VaultKey()
+	if err != nil {
+		t.Fatalf("VaultKey failed: %v", err)
+	}
+	want, err := full.Export()
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	km.ClearSession()
+
+	address, err := km.StoredAddress()
+	if err != nil || address != crypto.PubkeyToAddress(key.PublicKey).Hex() {
+		t.Fatalf("StoredAddress = %q, %v", address, err)
+	}
+	if err := km.UnlockVaultKey(address, "test-password"); err != nil {
+		t.Fatalf("UnlockVaultKey failed: %v", err)
+	}
+	defer km.ClearSession()
+
+	vaultKey, err := km.VaultKey()
+	if err != nil {
+		t.Fatalf("VaultKey failed: %v", err)
+	}
+	got, err := vaultKey.Export()
+	if err != nil {
+		t.Fatalf("Export failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Error("UnlockVaultKey should derive the same vault key as LoadFromStorage")
+	}
+	if _, err := km.GetPrivateKey(); !errors.Is(err, keymanager.ErrNoWalletKey) {
+		t.Errorf("Expected ErrNoWalletKey, got %v", err)
+	}
+}
+
 // TestGetAddress тестирует получение адреса
 func TestGetAddress(t *testing.T) {
 	tempDir := t.TempDir()
@@ -441,9 +495,9 @@ func TestGetAddress(t *testing.T) {
 	}
 
 	// Активируем сессию
-	km.PrivateKey = privateKey
-	km.SessionActive = true
-	km.LastActivity = time.Now()
+	if err := km.InitializeFirstTime(hex.EncodeToString(crypto.FromECDSA(privateKey)), "test-password-123"); err != nil {
+		t.Fatalf("Failed to initialize: %v", err)
+	}
 
 	// Тест получения адреса
 	address, err := km.GetAddress()
@@ -0,0 +1,97 @@
+package keymanager_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"encryptkeep-backend/internal/keymanager"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TestExportKeystoreReadableByGeth тестирует экспорт ключа в формат V3, который читает geth
+func TestExportKeystoreReadableByGeth(t *testing.T) {
+	km, address := initializedKeyManager(t, "master-password")
+
+	if _, err := km.ExportKeystore("wrong-password", "keystore-password", keymanager.KDFScrypt); err == nil {
+		t.Fatal("ExportKeystore should reject a wrong master password")
+	}
+
+	for _, kdf := range []keymanager.KeystoreKDF{keymanager.KDFScrypt, keymanager.KDFPBKDF2} {
+		keyJSON, err := km.ExportKeystore("master-password", "keystore-password", kdf)
+		if err != nil {
+			t.Fatalf("ExportKeystore(%s) failed: %v", kdf, err)
+		}
+
+		var header struct {
+			Version int `json:"version"`
+			Crypto  struct {
+				KDF string `json:"kdf"`
+			} `json:"crypto"`
+		}
+		if err := json.Unmarshal(keyJSON, &header); err != nil || header.Version != 3 || header.Crypto.KDF != string(kdf) {
+			t.Errorf("Unexpected keystore header %+v (%v)", header, err)
+		}
+
+		// файл открывается штатным декодером geth
+		key, err := keystore.DecryptKey(keyJSON, "keystore-password")
+		if err != nil {
+			t.Fatalf("geth DecryptKey(%s) failed: %v", kdf, err)
+		}
+		if key.Address.Hex() != address {
+			t.Errorf("Expected address %s, got %s", address, key.Address.Hex())
+		}
+		if _, err := keystore.DecryptKey(keyJSON, "wrong"); !errors.Is(err, keystore.ErrDecrypt) {
+			t.Errorf("Expected ErrDecrypt for a wrong keystore password, got %v", err)
+		}
+	}
+}
+
+// TestInitializeFromKeystore тестирует импорт ключа из файла keystore geth
+func TestInitializeFromKeystore(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	keyJSON, err := keystore.EncryptKey(&keystore.Key{Address: address, PrivateKey: key}, "keystore-password", keystore.LightScryptN, keystore.LightScryptP)
+	if err != nil {
+		t.Fatalf("EncryptKey: %v", err)
+	}
+
+	km := keymanager.NewKeyManager(keymanager.KeyManagerConfig{ConfigDir: t.TempDir()})
+	if err := km.InitializeFromKeystore(keyJSON, "wrong", "master-password"); !errors.Is(err, keymanager.ErrKeystorePassword) {
+		t.Errorf("Expected ErrKeystorePassword, got %v", err)
+	}
+	for _, bad := range [][]byte{[]byte("not json"), []byte(`{"version":3,"crypto":{"kdf":"pbkdf2","kdfparams":{}}}`)} {
+		if err := km.InitializeFromKeystore(bad, "keystore-password", "master-password"); !errors.Is(err, keymanager.ErrInvalidKeystore) {
+			t.Errorf("Expected ErrInvalidKeystore for %s, got %v", bad, err)
+		}
+	}
+	if km.HasStoredKeys() {
+		t.Fatal("Failed imports should not store keys")
+	}
+
+	if err := km.InitializeFromKeystore(keyJSON, "keystore-password", "master-password"); err != nil {
+		t.Fatalf("InitializeFromKeystore failed: %v", err)
+	}
+	km.ClearSession()
+	if err := km.LoadFromStorage("master-password"); err != nil {
+		t.Fatalf("LoadFromStorage failed: %v", err)
+	}
+	loaded, _ := km.GetPrivateKey()
+	if !bytes.Equal(crypto.FromECDSA(loaded), crypto.FromECDSA(key)) {
+		t.Error("Imported key differs from the keystore key")
+	}
+
+	// имя файла совпадает с форматом каталога keystore geth
+	name := keymanager.KeystoreFileName(address.Hex(), time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC))
+	if !strings.HasPrefix(name, "UTC--2024-05-01T12-00-00.") || !strings.HasSuffix(name, "--"+strings.ToLower(address.Hex()[2:])) {
+		t.Errorf("Unexpected keystore file name %s", name)
+	}
+}
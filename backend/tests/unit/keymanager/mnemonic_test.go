@@ -0,0 +1,67 @@
+package keymanager_test
+
+import (
+	"testing"
+
+	"encryptkeep-backend/internal/hdwallet"
+	"encryptkeep-backend/internal/keymanager"
+)
+
+const testMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// TestInitializeFromMnemonic тестирует получение ключа хранилища из мнемоники по пути BIP-44
+func TestInitializeFromMnemonic(t *testing.T) {
+	km := keymanager.NewKeyManager(keymanager.KeyManagerConfig{ConfigDir: t.TempDir()})
+
+	if err := km.InitializeFromMnemonic(testMnemonic+" abandon", "", 0, "master-password"); err == nil {
+		t.Fatal("Expected an invalid mnemonic to be rejected")
+	}
+	if err := km.InitializeFromMnemonic(testMnemonic, "", 0, "master-password"); err != nil {
+		t.Fatalf("InitializeFromMnemonic failed: %v", err)
+	}
+	if got := km.DerivationPath(); got != hdwallet.DefaultBasePath+"/0" {
+		t.Errorf("Unexpected derivation path %q", got)
+	}
+
+	// тот же адрес, что у любого кошелька BIP-44 с этой мнемоникой
+	km.ClearSession()
+	if err := km.LoadFromStorage("master-password"); err != nil {
+		t.Fatalf("LoadFromStorage failed: %v", err)
+	}
+	if got, _ := km.GetAddress(); got != "0x9858EfFD232B4033E47d90003D41EC34EcaEda94" {
+		t.Errorf("Unexpected address %s", got)
+	}
+
+	// ключ из сырого hex не имеет пути
+	raw, _ := initializedKeyManager(t, "master-password")
+	if got := raw.DerivationPath(); got != "" {
+		t.Errorf("Imported key should have no derivation path, got %q", got)
+	}
+}
+
+// TestRegistryNextAccount тестирует выбор свободного индекса счёта для нового хранилища
+func TestRegistryNextAccount(t *testing.T) {
+	reg, err := keymanager.NewRegistry(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+	if got := reg.NextAccount(); got != 0 {
+		t.Fatalf("Expected account 0 for an empty registry, got %d", got)
+	}
+
+	for name, account := range map[string]uint32{"work": 0, "home": 2} {
+		if _, err := reg.Create(name, keymanager.VaultOptions{}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+		if err := reg.SetDerivationPath(name, hdwallet.AccountPath(account)); err != nil {
+			t.Fatalf("SetDerivationPath: %v", err)
+		}
+	}
+	if got := reg.NextAccount(); got != 1 {
+		t.Errorf("Expected the gap at account 1, got %d", got)
+	}
+
+	if err := reg.SetDerivationPath("missing", hdwallet.AccountPath(1)); err == nil {
+		t.Error("Expected an error for an unknown vault")
+	}
+}
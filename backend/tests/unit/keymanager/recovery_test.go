@@ -0,0 +1,208 @@
+package keymanager_test
+
+import (
+	"crypto/ecdsa"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"encryptkeep-backend/internal/codec"
+	"encryptkeep-backend/internal/keymanager"
+	"encryptkeep-backend/internal/shamir"
+	"encryptkeep-backend/internal/vault"
+	"encryptkeep-backend/tests/fixtures"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// initializedKeyManager создаёт менеджер ключей с сохранёнными ключами и возвращает адрес
+func initializedKeyManager(t *testing.T, password string) (*keymanager.KeyManager, string) {
+	t.Helper()
+	km := keymanager.NewKeyManager(keymanager.KeyManagerConfig{ConfigDir: t.TempDir()})
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	if err := km.InitializeFirstTime(hex.EncodeToString(crypto.FromECDSA(key)), password); err != nil {
+		t.Fatalf("InitializeFirstTime: %v", err)
+	}
+	return km, crypto.PubkeyToAddress(key.PublicKey).Hex()
+}
+
+// TestRecoveryResetsMasterPassword тестирует сброс мастер-пароля по K долям
+func TestRecoveryResetsMasterPassword(t *testing.T) {
+	km, address := initializedKeyManager(t, "forgotten-password")
+
+	if km.HasRecovery() {
+		t.Fatal("New vault should have no recovery kit")
+	}
+	if _, err := km.SetupRecovery("wrong-password", 5, 3); err == nil {
+		t.Fatal("SetupRecovery should reject a wrong master password")
+	}
+	shares, err := km.SetupRecovery("forgotten-password", 5, 3)
+	if err != nil {
+		t.Fatalf("SetupRecovery failed: %v", err)
+	}
+	if len(shares) != 5 || !km.HasRecovery() {
+		t.Fatalf("Expected 5 shares and a stored kit, got %d", len(shares))
+	}
+
+	if _, err := km.Recover(shares[:2]); !errors.Is(err, shamir.ErrNotEnoughShares) {
+		t.Errorf("Expected ErrNotEnoughShares, got %v", err)
+	}
+
+	recovery, err := km.Recover([]string{shares[4], shares[0], shares[2]})
+	if err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	defer recovery.Destroy()
+	if recovery.Address != address {
+		t.Errorf("Expected address %s, got %s", address, recovery.Address)
+	}
+	err = recovery.WithPrivateKey(func(key *ecdsa.PrivateKey) error {
+		if got := crypto.PubkeyToAddress(key.PublicKey).Hex(); got != address {
+			t.Errorf("Recovered wallet key of %s", got)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("WithPrivateKey failed: %v", err)
+	}
+
+	// комплект открывает данные хранилища без пароля
+	cdc := codec.NewCodec()
+	sealed, err := cdc.PackEntry(vault.NewPasswordEntry("Site", "user", "pass"), "forgotten-password")
+	if err != nil {
+		t.Fatalf("PackEntry failed: %v", err)
+	}
+	current := fixtures.NewTestVaultKey("forgotten-password", address)
+	defer current.Destroy()
+	data, err := cdc.PackEntryWithVaultKey(vault.NewPasswordEntry("Site", "user", "pass"), current)
+	if err != nil {
+		t.Fatalf("PackEntryWithVaultKey failed: %v", err)
+	}
+	if _, err := cdc.UnpackEntryWithVaultKey(data, recovery.VaultKey); err != nil {
+		t.Errorf("Recovered vault key should open vault data: %v", err)
+	}
+	if _, err := cdc.UnpackEntryWithVaultKey(sealed, recovery.VaultKey); !errors.Is(err, codec.ErrUnknownVaultKey) {
+		t.Errorf("Keys of salts not in the kit should be unknown, got %v", err)
+	}
+
+	// в keys.json нет мастер-пароля ни в каком виде
+	raw, err := os.ReadFile(filepath.Join(km.ConfigDir, "keys.json"))
+	if err != nil {
+		t.Fatalf("read keys.json: %v", err)
+	}
+	if strings.Contains(string(raw), "forgotten-password") {
+		t.Error("keys.json should not hold the master password")
+	}
+	if err := km.ResetMasterPassword(recovery, "short"); !errors.Is(err, keymanager.ErrWeakPassword) {
+		t.Errorf("Expected ErrWeakPassword, got %v", err)
+	}
+	if err := km.ResetMasterPassword(recovery, "brand-new-password"); err != nil {
+		t.Fatalf("ResetMasterPassword failed: %v", err)
+	}
+
+	// ключ кошелька открывается только новым паролем
+	km.ClearSession()
+	if err := km.LoadFromStorage("forgotten-password"); err == nil {
+		t.Error("Old master password should no longer open the keys")
+	}
+	if err := km.LoadFromStorage("brand-new-password"); err != nil {
+		t.Fatalf("LoadFromStorage with new password failed: %v", err)
+	}
+	if got, _ := km.GetAddress(); got != address {
+		t.Errorf("Expected address %s, got %s", address, got)
+	}
+
+	// те же доли продолжают работать после сброса и открывают данные под новым паролем
+	again, err := km.Recover(shares[1:4])
+	if err != nil {
+		t.Fatalf("Shares should still open the kit, got %v", err)
+	}
+	defer again.Destroy()
+	newKey := fixtures.NewTestVaultKey("brand-new-password", address)
+	defer newKey.Destroy()
+	data, err = cdc.PackEntryWithVaultKey(vault.NewPasswordEntry("Site", "user", "pass"), newKey)
+	if err != nil {
+		t.Fatalf("PackEntryWithVaultKey failed: %v", err)
+	}
+	if _, err := cdc.UnpackEntryWithVaultKey(data, again.VaultKey); err != nil {
+		t.Errorf("Kit should hold the vault key of the new password: %v", err)
+	}
+}
+
+// TestRefreshRecovery тестирует обновление комплекта ключами старых конвертов
+func TestRefreshRecovery(t *testing.T) {
+	km, address := initializedKeyManager(t, "master-password")
+	if err := km.RefreshRecovery(); !errors.Is(err, keymanager.ErrNoRecovery) {
+		t.Errorf("Expected ErrNoRecovery, got %v", err)
+	}
+	shares, err := km.SetupRecovery("master-password", 3, 2)
+	if err != nil {
+		t.Fatalf("SetupRecovery failed: %v", err)
+	}
+
+	// сессия выводит ключ конверта старого клиента
+	cdc := codec.NewCodec()
+	legacy, err := cdc.PackEntry(vault.NewPasswordEntry("Old", "user", "pass"), "master-password")
+	if err != nil {
+		t.Fatalf("PackEntry failed: %v", err)
+	}
+	salt, _ := codec.EnvelopeSalt(legacy)
+	key, err := km.VaultKey()
+	if err != nil {
+		t.Fatalf("VaultKey failed: %v", err)
+	}
+	if _, err := key.Derive("master-password", salt); err != nil {
+		t.Fatalf("Derive failed: %v", err)
+	}
+	if err := km.RefreshRecovery(); err != nil {
+		t.Fatalf("RefreshRecovery failed: %v", err)
+	}
+
+	recovery, err := km.Recover(shares[:2])
+	if err != nil {
+		t.Fatalf("Recover failed: %v", err)
+	}
+	defer recovery.Destroy()
+	if recovery.Address != address {
+		t.Errorf("Expected address %s, got %s", address, recovery.Address)
+	}
+	if _, err := cdc.UnpackEntryWithVaultKey(legacy, recovery.VaultKey); err != nil {
+		t.Errorf("Refreshed kit should open older envelopes: %v", err)
+	}
+}
+
+// TestRecoveryRejectsForeignShares тестирует отказ принимать доли чужого комплекта
+func TestRecoveryRejectsForeignShares(t *testing.T) {
+	km, _ := initializedKeyManager(t, "first-password")
+	other, _ := initializedKeyManager(t, "other-password")
+
+	if _, err := km.Recover(nil); !errors.Is(err, keymanager.ErrNoRecovery) {
+		t.Errorf("Expected ErrNoRecovery, got %v", err)
+	}
+
+	old, err := km.SetupRecovery("first-password", 3, 2)
+	if err != nil {
+		t.Fatalf("SetupRecovery failed: %v", err)
+	}
+	foreign, err := other.SetupRecovery("other-password", 3, 2)
+	if err != nil {
+		t.Fatalf("SetupRecovery failed: %v", err)
+	}
+	if _, err := km.Recover(foreign[:2]); !errors.Is(err, keymanager.ErrForeignShare) {
+		t.Errorf("Expected ErrForeignShare, got %v", err)
+	}
+
+	// новый комплект отменяет доли старого
+	if _, err := km.SetupRecovery("first-password", 3, 2); err != nil {
+		t.Fatalf("SetupRecovery failed: %v", err)
+	}
+	if _, err := km.Recover(old[:2]); !errors.Is(err, keymanager.ErrForeignShare) {
+		t.Errorf("Expected old shares to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package keymanager_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"encryptkeep-backend/internal/keymanager"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TestPasswordPolicyCheck тестирует проверку мастер-пароля по политике хранилища
+func TestPasswordPolicyCheck(t *testing.T) {
+	policy := keymanager.PasswordPolicy{MinLength: 10, RequireUpper: true, RequireDigit: true, RequireSymbol: true}
+
+	if err := policy.Check("Str0ng-Password"); err != nil {
+		t.Errorf("Expected strong password to pass, got %v", err)
+	}
+
+	for _, weak := range []string{"Sh0rt-", "lowercase-only1", "NoDigitsHere!", "NoSymbols123"} {
+		if err := policy.Check(weak); !errors.Is(err, keymanager.ErrWeakPassword) {
+			t.Errorf("Expected ErrWeakPassword for %q, got %v", weak, err)
+		}
+	}
+
+	if err := keymanager.DefaultPasswordPolicy().Check("12345678"); err != nil {
+		t.Errorf("Default policy should only require 8 characters, got %v", err)
+	}
+
+	// политика не может разрешить пароль короче, чем принимает кодек
+	if err := (keymanager.PasswordPolicy{MinLength: 4}).Check("12345"); !errors.Is(err, keymanager.ErrWeakPassword) {
+		t.Errorf("Expected ErrWeakPassword below 8 characters, got %v", err)
+	}
+}
+
+// TestInitializeFirstTimeEnforcesPolicy тестирует применение политики при первом запуске
+func TestInitializeFirstTimeEnforcesPolicy(t *testing.T) {
+	km := keymanager.NewKeyManager(keymanager.KeyManagerConfig{
+		ConfigDir:      t.TempDir(),
+		PasswordPolicy: keymanager.PasswordPolicy{MinLength: 12, RequireDigit: true},
+	})
+
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	privHex := hex.EncodeToString(crypto.FromECDSA(key))
+
+	if err := km.InitializeFirstTime(privHex, "longpassword"); !errors.Is(err, keymanager.ErrWeakPassword) {
+		t.Fatalf("Expected ErrWeakPassword, got %v", err)
+	}
+	if km.HasStoredKeys() {
+		t.Error("Keys should not be stored for a rejected password")
+	}
+
+	if err := km.InitializeFirstTime(privHex, "longpassword1"); err != nil {
+		t.Fatalf("Expected password to pass the policy, got %v", err)
+	}
+}
+
+// TestRegistryDefaultVault тестирует реестр без vaults.json (установка с одним хранилищем)
+func TestRegistryDefaultVault(t *testing.T) {
+	dir := t.TempDir()
+
+	reg, err := keymanager.NewRegistry(dir)
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+
+	if reg.Active() != keymanager.DefaultVaultName {
+		t.Errorf("Expected active vault %q, got %q", keymanager.DefaultVaultName, reg.Active())
+	}
+	vaults := reg.List()
+	if len(vaults) != 1 || vaults[0].Name != keymanager.DefaultVaultName {
+		t.Fatalf("Expected only the default vault, got %+v", vaults)
+	}
+
+	// ключи хранилища по умолчанию лежат прямо в базовом каталоге
+	km, err := reg.KeyManager(keymanager.DefaultVaultName, 0)
+	if err != nil {
+		t.Fatalf("KeyManager: %v", err)
+	}
+	if km.ConfigDir != filepath.Join(dir, ".") {
+		t.Errorf("Expected default vault in %s, got %s", dir, km.ConfigDir)
+	}
+}
+
+// TestRegistryCreateAndSwitch тестирует создание хранилищ и переключение между ними
+func TestRegistryCreateAndSwitch(t *testing.T) {
+	dir := t.TempDir()
+
+	reg, err := keymanager.NewRegistry(dir)
+	if err != nil {
+		t.Fatalf("NewRegistry: %v", err)
+	}
+
+	work, err := reg.Create("work", keymanager.VaultOptions{
+		Profile:        "anvil",
+		PasswordPolicy: keymanager.PasswordPolicy{MinLength: 16, RequireSymbol: true},
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if work.Profile != "anvil" || work.PasswordPolicy.MinLength != 16 {
+		t.Errorf("Unexpected vault info: %+v", work)
+	}
+
+	if _, err := reg.Create("work", keymanager.VaultOptions{}); !errors.Is(err, keymanager.ErrVaultExists) {
+		t.Errorf("Expected ErrVaultExists, got %v", err)
+	}
+	if _, err := reg.Create("Bad Name", keymanager.VaultOptions{}); !errors.Is(err, keymanager.ErrInvalidName) {
+		t.Errorf("Expected ErrInvalidName, got %v", err)
+	}
+	if err := reg.SetActive("missing"); !errors.Is(err, keymanager.ErrVaultNotFound) {
+		t.Errorf("Expected ErrVaultNotFound, got %v", err)
+	}
+
+	if err := reg.SetActive("work"); err != nil {
+		t.Fatalf("SetActive: %v", err)
+	}
+
+	// у каждого хранилища свой ключ и своя политика
+	workKM, err := reg.KeyManager("work", 0)
+	if err != nil {
+		t.Fatalf("KeyManager: %v", err)
+	}
+	defaultKM, _ := reg.KeyManager(keymanager.DefaultVaultName, 0)
+	if workKM.ConfigDir == defaultKM.ConfigDir {
+		t.Error("Vaults should keep their keys in separate directories")
+	}
+
+	key, _ := crypto.GenerateKey()
+	privHex := hex.EncodeToString(crypto.FromECDSA(key))
+	if err := workKM.InitializeFirstTime(privHex, "short-pass"); !errors.Is(err, keymanager.ErrWeakPassword) {
+		t.Errorf("Expected work vault policy to reject password, got %v", err)
+	}
+	if err := workKM.InitializeFirstTime(privHex, "a-much-longer-pass!"); err != nil {
+		t.Fatalf("InitializeFirstTime: %v", err)
+	}
+	if defaultKM.HasStoredKeys() {
+		t.Error("Default vault should not see the work vault's keys")
+	}
+
+	reloaded, err := keymanager.NewRegistry(dir)
+	if err != nil {
+		t.Fatalf("reload registry: %v", err)
+	}
+	if reloaded.Active() != "work" {
+		t.Errorf("Expected active vault to persist, got %q", reloaded.Active())
+	}
+	names := []string{}
+	for _, info := range reloaded.List() {
+		names = append(names, info.Name)
+	}
+	if len(names) != 2 || names[0] != keymanager.DefaultVaultName || names[1] != "work" {
+		t.Errorf("Expected sorted [default work], got %v", names)
+	}
+}
+
+// TestRegistryRecordSync тестирует сохранение статуса последней синхронизации
+func TestRegistryRecordSync(t *testing.T) {
+	dir := t.TempDir()
+
+	reg, _ := keymanager.NewRegistry(dir)
+	if _, err := reg.Create("personal", keymanager.VaultOptions{}); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	if err := reg.SetAddress("personal", "0xabc"); err != nil {
+		t.Fatalf("SetAddress: %v", err)
+	}
+	if err := reg.RecordSync("personal", 7, nil); err != nil {
+		t.Fatalf("RecordSync: %v", err)
+	}
+	if err := reg.RecordSync("personal", 0, errors.New("rpc down")); err != nil {
+		t.Fatalf("RecordSync: %v", err)
+	}
+
+	reloaded, _ := keymanager.NewRegistry(dir)
+	info, err := reloaded.Get("personal")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if info.Address != "0xabc" {
+		t.Errorf("Expected address 0xabc, got %s", info.Address)
+	}
+	if info.LastSync.IsZero() || info.LastSyncError != "rpc down" {
+		t.Errorf("Expected failed sync to be recorded, got %+v", info)
+	}
+	if info.Entries != 7 {
+		t.Errorf("Failed sync should keep the last known entry count, got %d", info.Entries)
+	}
+	if info.PasswordPolicy != keymanager.DefaultPasswordPolicy() {
+		t.Errorf("Expected default policy, got %+v", info.PasswordPolicy)
+	}
+}
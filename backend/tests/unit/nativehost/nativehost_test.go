@@ -0,0 +1,223 @@
+package nativehost_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"encryptkeep-backend/internal/agent"
+	"encryptkeep-backend/internal/nativehost"
+	"encryptkeep-backend/internal/session"
+	"encryptkeep-backend/internal/vault"
+	"encryptkeep-backend/internal/vaultmanager"
+	"encryptkeep-backend/tests/fixtures"
+	mocks "encryptkeep-backend/tests/mocks/blockchain"
+)
+
+// startAgent запускает агент с записями и возвращает путь к его сокету
+func startAgent(t *testing.T, entries ...*vault.PasswordEntry) string {
+	t.Helper()
+	service := mocks.NewMockBlockchainService()
+	vm := vaultmanager.NewVaultManager(service, fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address))
+	v := vault.NewLocalVault()
+	sess := session.New(session.Config{IdleTimeout: time.Hour})
+	sess.Unlock(v.Wipe)
+	t.Cleanup(sess.Lock)
+	if err := vm.AddEntries(context.Background(), v, entries); err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "encryptkeep", "agent.sock")
+	ln, err := agent.Listen(path)
+	if err != nil {
+		t.Fatalf("Listen failed: %v", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- agent.NewServer("main", vm, v, sess, nil).Serve(ctx, ln) }()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return path
+}
+
+// exchange отправляет запросы хосту и возвращает его ответы
+func exchange(t *testing.T, h *nativehost.Host, requests ...nativehost.Request) []nativehost.Response {
+	t.Helper()
+	var in, out bytes.Buffer
+	for _, req := range requests {
+		if err := nativehost.WriteMessage(&in, req); err != nil {
+			t.Fatalf("WriteMessage failed: %v", err)
+		}
+	}
+	if err := h.Serve(&in, &out); err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+	var responses []nativehost.Response
+	for {
+		var resp nativehost.Response
+		err := nativehost.ReadMessage(&out, &resp)
+		if errors.Is(err, io.EOF) {
+			return responses
+		}
+		if err != nil {
+			t.Fatalf("ReadMessage failed: %v", err)
+		}
+		responses = append(responses, resp)
+	}
+}
+
+// TestMessageFraming тестирует формат сообщений: длина в 4 байтах и JSON
+func TestMessageFraming(t *testing.T) {
+	var buf bytes.Buffer
+	if err := nativehost.WriteMessage(&buf, map[string]string{"action": "status"}); err != nil {
+		t.Fatalf("WriteMessage failed: %v", err)
+	}
+	raw := buf.Bytes()
+	if size := binary.NativeEndian.Uint32(raw[:4]); int(size) != len(raw)-4 || string(raw[4:]) != `{"action":"status"}` {
+		t.Errorf("Unexpected frame %q", raw)
+	}
+
+	var req nativehost.Request
+	if err := nativehost.ReadMessage(&buf, &req); err != nil || req.Action != "status" {
+		t.Errorf("ReadMessage = %+v, %v", req, err)
+	}
+	if err := nativehost.ReadMessage(&buf, &req); !errors.Is(err, io.EOF) {
+		t.Errorf("Expected io.EOF at the end, got %v", err)
+	}
+
+	// слишком длинное сообщение не читается
+	huge := binary.NativeEndian.AppendUint32(nil, nativehost.MaxMessage+1)
+	if err := nativehost.ReadMessage(bytes.NewReader(huge), &req); !errors.Is(err, nativehost.ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge, got %v", err)
+	}
+	if err := nativehost.WriteMessage(io.Discard, strings.Repeat("x", nativehost.MaxMessage)); !errors.Is(err, nativehost.ErrTooLarge) {
+		t.Errorf("Expected ErrTooLarge on write, got %v", err)
+	}
+}
+
+// TestHostFill тестирует подбор логинов для страницы и выдачу пароля после подтверждения
+func TestHostFill(t *testing.T) {
+	site := vault.NewPasswordEntry("Example", "alice", "hunter2")
+	site.URL = "https://example.co.uk/login"
+	site.OTP = "JBSWY3DPEHPK3PXP"
+	other := vault.NewPasswordEntry("Other", "bob", "secret")
+	other.URL = "https://other.example"
+	path := startAgent(t, site, other)
+
+	var prompts []string
+	allow := true
+	h := &nativehost.Host{
+		Caller: "chrome-extension://abc/",
+		Dial:   func() (*agent.Client, error) { return agent.Dial(path) },
+		Confirm: func(prompt string) bool {
+			prompts = append(prompts, prompt)
+			return allow
+		},
+	}
+
+	responses := exchange(t, h,
+		nativehost.Request{ID: []byte("1"), Action: "list", URL: "https://accounts.example.co.uk/signin"},
+		nativehost.Request{ID: []byte("2"), Action: "list", URL: "https://accounts.example.co.uk/signin", Match: "host"},
+		nativehost.Request{ID: []byte("3"), Action: "fill", URL: "https://accounts.example.co.uk/signin", EntryID: site.ID},
+		// пароль другого сайта не выдаётся
+		nativehost.Request{ID: []byte("4"), Action: "fill", URL: "https://accounts.example.co.uk/signin", EntryID: other.ID},
+		nativehost.Request{ID: []byte("5"), Action: "status"},
+	)
+	if len(responses) != 5 {
+		t.Fatalf("Expected 5 responses, got %d", len(responses))
+	}
+
+	list := responses[0]
+	if string(list.ID) != "1" || len(list.Logins) != 1 || list.Logins[0].ID != site.ID || list.Logins[0].Password != "" || !list.Logins[0].HasOTP {
+		t.Errorf("Unexpected list response %+v", list)
+	}
+	if len(responses[1].Logins) != 0 {
+		t.Errorf("Host matching should not offer the entry, got %+v", responses[1].Logins)
+	}
+
+	fill := responses[2].Login
+	if fill == nil || fill.Username != "alice" || fill.Password != "hunter2" || len(fill.OTP) != 6 {
+		t.Errorf("Unexpected fill response %+v", responses[2])
+	}
+	if len(prompts) != 1 || !strings.Contains(prompts[0], "accounts.example.co.uk") || !strings.Contains(prompts[0], "chrome-extension://abc/") {
+		t.Errorf("Unexpected prompts %q", prompts)
+	}
+	if responses[3].Error == "" || responses[3].Login != nil {
+		t.Errorf("Fill of another site's login should fail, got %+v", responses[3])
+	}
+	if responses[4].Status == nil || responses[4].Status.Vault != "main" {
+		t.Errorf("Unexpected status response %+v", responses[4])
+	}
+
+	// отказ пользователя
+	allow = false
+	responses = exchange(t, h, nativehost.Request{Action: "fill", URL: "https://example.co.uk", EntryID: site.ID})
+	if responses[0].Login != nil || !strings.Contains(responses[0].Error, "denied") {
+		t.Errorf("Expected a denial, got %+v", responses[0])
+	}
+
+	// без Confirm ничего не выдаётся
+	h.Confirm = nil
+	responses = exchange(t, h, nativehost.Request{Action: "fill", URL: "https://example.co.uk", EntryID: site.ID})
+	if responses[0].Login != nil {
+		t.Errorf("Expected no login without Confirm, got %+v", responses[0])
+	}
+}
+
+// TestHostErrors тестирует ответы без агента и на испорченные запросы
+func TestHostErrors(t *testing.T) {
+	h := &nativehost.Host{Dial: func() (*agent.Client, error) {
+		return agent.Dial(filepath.Join(t.TempDir(), "missing.sock"))
+	}}
+	responses := exchange(t, h, nativehost.Request{ID: []byte(`"a"`), Action: "status"})
+	if len(responses) != 1 || string(responses[0].ID) != `"a"` || !strings.Contains(responses[0].Error, "not running") {
+		t.Errorf("Expected an agent error, got %+v", responses)
+	}
+
+	// испорченный JSON не обрывает обмен
+	var in, out bytes.Buffer
+	in.Write(binary.NativeEndian.AppendUint32(nil, 5))
+	in.WriteString("{oops")
+	nativehost.WriteMessage(&in, nativehost.Request{Action: "bogus"})
+	if err := h.Serve(&in, &out); err != nil {
+		t.Fatalf("Serve failed: %v", err)
+	}
+	var first, second nativehost.Response
+	nativehost.ReadMessage(&out, &first)
+	nativehost.ReadMessage(&out, &second)
+	if !strings.Contains(first.Error, "bad request") || second.Error == "" {
+		t.Errorf("Unexpected responses %+v, %+v", first, second)
+	}
+}
+
+// TestHostKeepsConnection тестирует, что ошибки запроса не рвут соединение с агентом
+func TestHostKeepsConnection(t *testing.T) {
+	path := startAgent(t)
+	dials := 0
+	h := &nativehost.Host{Dial: func() (*agent.Client, error) {
+		dials++
+		return agent.Dial(path)
+	}}
+	responses := exchange(t, h,
+		nativehost.Request{Action: "bogus"},
+		nativehost.Request{Action: "list", URL: "https://example.com", Match: "fuzzy"},
+		nativehost.Request{Action: "fill", URL: "https://example.com", EntryID: "missing"},
+		nativehost.Request{Action: "status"},
+	)
+	for i, resp := range responses[:3] {
+		if resp.Error == "" {
+			t.Errorf("Request %d should fail", i)
+		}
+	}
+	if responses[3].Status == nil || dials != 1 {
+		t.Errorf("Expected one connection for all requests, got %d dials, %+v", dials, responses[3])
+	}
+}
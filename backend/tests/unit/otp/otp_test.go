@@ -0,0 +1,76 @@
+package otp_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"encryptkeep-backend/internal/otp"
+)
+
+// TestCodeRFC6238 тестирует коды по контрольным векторам RFC 6238
+func TestCodeRFC6238(t *testing.T) {
+	// секреты "12345678901234567890" разной длины в base32
+	secrets := map[string]string{
+		"SHA1":   "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ",
+		"SHA256": "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZA",
+		"SHA512": "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQGEZDGNA",
+	}
+	tests := []struct {
+		unix      int64
+		algorithm string
+		want      string
+	}{
+		{59, "SHA1", "94287082"},
+		{59, "SHA256", "46119246"},
+		{59, "SHA512", "90693936"},
+		{1111111109, "SHA1", "07081804"},
+		{1111111109, "SHA256", "68084774"},
+		{1234567890, "SHA512", "93441116"},
+		{20000000000, "SHA1", "65353130"},
+	}
+	for _, tt := range tests {
+		key, err := otp.Parse("otpauth://totp/test?digits=8&algorithm=" + tt.algorithm + "&secret=" + secrets[tt.algorithm])
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+		if got, _ := key.Code(time.Unix(tt.unix, 0)); got != tt.want {
+			t.Errorf("%s at %d: expected %s, got %s", tt.algorithm, tt.unix, tt.want, got)
+		}
+	}
+}
+
+// TestParseBareSecret тестирует разбор секрета без URI и оставшееся время кода
+func TestParseBareSecret(t *testing.T) {
+	key, err := otp.Parse("jbsw y3dp ehpk 3pxp")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if key.Digits != otp.DefaultDigits || key.Period != otp.DefaultPeriod || key.Algorithm != "SHA1" {
+		t.Errorf("Unexpected defaults: %+v", key)
+	}
+
+	code, left := key.Code(time.Unix(1700000020, 0))
+	if len(code) != 6 {
+		t.Errorf("Expected 6 digits, got %q", code)
+	}
+	if left != 20*time.Second {
+		t.Errorf("Expected 20s left, got %s", left)
+	}
+}
+
+// TestParseInvalid тестирует отказ на некорректных секретах
+func TestParseInvalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"not base32!",
+		"otpauth://hotp/test?secret=JBSWY3DPEHPK3PXP&counter=1",
+		"otpauth://totp/test?secret=JBSWY3DPEHPK3PXP&digits=12",
+		"otpauth://totp/test?secret=JBSWY3DPEHPK3PXP&algorithm=MD5",
+		"otpauth://totp/test?secret=JBSWY3DPEHPK3PXP&period=0",
+	} {
+		if _, err := otp.Parse(s); !errors.Is(err, otp.ErrInvalidSecret) {
+			t.Errorf("Parse(%q): expected ErrInvalidSecret, got %v", s, err)
+		}
+	}
+}
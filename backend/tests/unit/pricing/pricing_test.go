@@ -0,0 +1,49 @@
+package pricing_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"encryptkeep-backend/internal/pricing"
+)
+
+// TestFileSource тестирует чтение котировок из локального файла
+func TestFileSource(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.json")
+	if err := os.WriteFile(path, []byte(`{"ETH": {"USD": 3000.5, "EUR": 2800}}`), 0600); err != nil {
+		t.Fatalf("write price file: %v", err)
+	}
+
+	source := pricing.NewFileSource(path)
+	price, err := source.Price(context.Background(), "eth", "usd")
+	if err != nil {
+		t.Fatalf("Price failed: %v", err)
+	}
+	if price != 3000.5 {
+		t.Errorf("Expected 3000.5, got %v", price)
+	}
+
+	if _, err := source.Price(context.Background(), "ETH", "GBP"); !errors.Is(err, pricing.ErrPriceNotFound) {
+		t.Errorf("Expected ErrPriceNotFound, got %v", err)
+	}
+}
+
+// TestFileSourceErrors тестирует отсутствующий и поврежденный файл
+func TestFileSourceErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := pricing.NewFileSource(filepath.Join(dir, "missing.json")).Price(context.Background(), "ETH", "USD"); err == nil {
+		t.Error("Expected error for missing file")
+	}
+
+	path := filepath.Join(dir, "broken.json")
+	if err := os.WriteFile(path, []byte("not json"), 0600); err != nil {
+		t.Fatalf("write price file: %v", err)
+	}
+	if _, err := pricing.NewFileSource(path).Price(context.Background(), "ETH", "USD"); err == nil {
+		t.Error("Expected error for broken file")
+	}
+}
@@ -0,0 +1,100 @@
+package secret_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"encryptkeep-backend/internal/secret"
+)
+
+// TestFromBytes тестирует перенос данных в защищённую память со стиранием исходного буфера
+func TestFromBytes(t *testing.T) {
+	src := []byte("0123456789abcdef0123456789abcdef")
+	want := bytes.Clone(src)
+
+	s, err := secret.FromBytes(src)
+	if err != nil {
+		t.Fatalf("FromBytes failed: %v", err)
+	}
+	defer s.Destroy()
+
+	// исходный буфер стёрт
+	if !bytes.Equal(src, make([]byte, len(src))) {
+		t.Error("FromBytes should wipe its input")
+	}
+	if s.Len() != len(want) {
+		t.Errorf("Expected length %d, got %d", len(want), s.Len())
+	}
+
+	err = s.Use(func(b []byte) error {
+		if !bytes.Equal(b, want) {
+			t.Error("Secret content mismatch")
+		}
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Use failed: %v", err)
+	}
+
+	// ошибка из fn возвращается как есть
+	errFn := errors.New("fn failed")
+	if err := s.Use(func([]byte) error { return errFn }); !errors.Is(err, errFn) {
+		t.Errorf("Expected the error of fn, got %v", err)
+	}
+}
+
+// TestNew тестирует выделение обнулённого секрета
+func TestNew(t *testing.T) {
+	if _, err := secret.New(0); err == nil {
+		t.Error("Should return error for zero size")
+	}
+
+	// размер больше страницы проверяет расчёт защитных страниц
+	s, err := secret.New(10000)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	defer s.Destroy()
+
+	err = s.Use(func(b []byte) error {
+		if len(b) != 10000 || !bytes.Equal(b, make([]byte, 10000)) {
+			t.Error("New secret should be zeroed")
+		}
+		b[len(b)-1] = 1 // последний байт доступен для записи
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Use failed: %v", err)
+	}
+}
+
+// TestDestroy тестирует стирание секрета
+func TestDestroy(t *testing.T) {
+	s, err := secret.FromBytes([]byte("secret"))
+	if err != nil {
+		t.Fatalf("FromBytes failed: %v", err)
+	}
+
+	s.Destroy()
+	s.Destroy() // повторный вызов безопасен
+
+	if !s.Destroyed() {
+		t.Error("Destroyed should report true")
+	}
+	if s.Len() != 0 {
+		t.Errorf("Expected length 0 after Destroy, got %d", s.Len())
+	}
+	if err := s.Use(func([]byte) error { return nil }); !errors.Is(err, secret.ErrDestroyed) {
+		t.Errorf("Expected ErrDestroyed, got %v", err)
+	}
+}
+
+// TestWipe тестирует обнуление буфера
+func TestWipe(t *testing.T) {
+	b := []byte("password")
+	secret.Wipe(b)
+	if !bytes.Equal(b, make([]byte, len(b))) {
+		t.Error("Wipe should zero the buffer")
+	}
+}
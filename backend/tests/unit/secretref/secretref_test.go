@@ -0,0 +1,134 @@
+package secretref_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"encryptkeep-backend/internal/secretref"
+	"encryptkeep-backend/internal/vault"
+)
+
+// TestParse тестирует разбор ссылок ek://
+func TestParse(t *testing.T) {
+	ref, err := secretref.Parse("ek://main/Postgres%20admin/PASSWORD")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if ref != (secretref.Ref{Vault: "main", Entry: "Postgres admin", Field: "password"}) {
+		t.Errorf("Unexpected ref %+v", ref)
+	}
+	if ref.String() != "ek://main/Postgres%20admin/password" {
+		t.Errorf("Unexpected String() %q", ref.String())
+	}
+
+	// заголовок со слешем экранируется и разбирается обратно
+	slash := secretref.Ref{Vault: "work", Entry: "ci/cd", Field: "username"}
+	if back, err := secretref.Parse(slash.String()); err != nil || back != slash {
+		t.Errorf("Round trip of %+v gave %+v, %v", slash, back, err)
+	}
+
+	for _, s := range []string{
+		"https://main/a/password",
+		"ek://main/a",
+		"ek://main/a/b/password",
+		"ek://main//password",
+		"ek://main/a/secret",
+		"ek://main/%zz/password",
+	} {
+		if _, err := secretref.Parse(s); !errors.Is(err, secretref.ErrInvalid) {
+			t.Errorf("Parse(%q) = %v, want ErrInvalid", s, err)
+		}
+	}
+}
+
+// TestResolve тестирует поиск записи по ID и заголовку и выдачу полей
+func TestResolve(t *testing.T) {
+	v := vault.NewLocalVault()
+	db := vault.NewPasswordEntry("Postgres", "admin", "s3cret")
+	db.URL = "postgres://db.internal"
+	db.OTP = "JBSWY3DPEHPK3PXP"
+	v.Put(db, nil)
+	v.Put(vault.NewPasswordEntry("Mail", "me", "one"), nil)
+	v.Put(vault.NewPasswordEntry("mail", "other", "two"), nil)
+
+	tests := []struct {
+		ref   secretref.Ref
+		value string
+	}{
+		{secretref.Ref{Vault: "main", Entry: "postgres", Field: "password"}, "s3cret"},
+		{secretref.Ref{Vault: "main", Entry: db.ID, Field: "username"}, "admin"},
+		{secretref.Ref{Vault: "main", Entry: "Postgres", Field: "url"}, "postgres://db.internal"},
+	}
+	for _, tt := range tests {
+		got, err := secretref.Resolve("main", v, tt.ref)
+		if err != nil || got != tt.value {
+			t.Errorf("Resolve(%s) = %q, %v, want %q", tt.ref, got, err, tt.value)
+		}
+	}
+
+	code, err := secretref.Resolve("main", v, secretref.Ref{Vault: "main", Entry: "Postgres", Field: "otp"})
+	if err != nil || len(code) != 6 {
+		t.Errorf("Expected a 6-digit code, got %q, %v", code, err)
+	}
+
+	if _, err := secretref.Resolve("main", v, secretref.Ref{Vault: "main", Entry: "Mail", Field: "password"}); !errors.Is(err, secretref.ErrAmbiguous) {
+		t.Errorf("Expected ErrAmbiguous, got %v", err)
+	}
+	if _, err := secretref.Resolve("main", v, secretref.Ref{Vault: "main", Entry: "nope", Field: "password"}); !errors.Is(err, secretref.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	// другое хранилище не читается
+	if _, err := secretref.Resolve("main", v, secretref.Ref{Vault: "work", Entry: "Postgres", Field: "password"}); !errors.Is(err, secretref.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for another vault, got %v", err)
+	}
+
+	// OTP-кода без секрета нет
+	e, _ := secretref.Find(v.List(), "Postgres")
+	e.OTP = ""
+	if _, err := secretref.Value(e, "otp", time.Now()); err == nil {
+		t.Error("Expected an error for an entry without OTP")
+	}
+}
+
+// TestRender тестирует подстановку значений в шаблон
+func TestRender(t *testing.T) {
+	values := map[string]string{
+		"ek://main/Postgres/password":  "s3cret",
+		"ek://main/Postgres/username":  "admin",
+		"ek://main/API%20key/password": "tok",
+	}
+	calls := 0
+	resolve := func(ref secretref.Ref) (string, error) {
+		calls++
+		if v, ok := values[ref.String()]; ok {
+			return v, nil
+		}
+		return "", fmt.Errorf("%w: %s", secretref.ErrNotFound, ref)
+	}
+
+	tpl := "DB_USER=ek://main/Postgres/username\n" +
+		"DB_PASSWORD=\"ek://main/Postgres/password\"\n" +
+		"API_KEY={{ ek://main/API%20key/password }}\n" +
+		"AGAIN={{ek://main/Postgres/password}}\n" +
+		"URL=https://example.com/ek\n"
+	out, err := secretref.Render([]byte(tpl), resolve)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	want := "DB_USER=admin\nDB_PASSWORD=\"s3cret\"\nAPI_KEY=tok\nAGAIN=s3cret\nURL=https://example.com/ek\n"
+	if string(out) != want {
+		t.Errorf("Unexpected output:\n%s\nwant:\n%s", out, want)
+	}
+	// каждая ссылка разрешается один раз
+	if calls != 3 {
+		t.Errorf("Expected 3 lookups, got %d", calls)
+	}
+
+	// одна неразрешённая ссылка — нет вывода вообще
+	out, err = secretref.Render([]byte("A=ek://main/Postgres/password\nB=ek://main/missing/password\n"), resolve)
+	if !errors.Is(err, secretref.ErrNotFound) || out != nil {
+		t.Errorf("Expected ErrNotFound and no output, got %q, %v", out, err)
+	}
+}
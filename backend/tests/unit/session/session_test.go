@@ -0,0 +1,181 @@
+package session_test
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"encryptkeep-backend/internal/session"
+)
+
+// TestNewSessionDefaults тестирует значения по умолчанию и начальное состояние
+func TestNewSessionDefaults(t *testing.T) {
+	s := session.New(session.Config{})
+
+	cfg := s.Config()
+	if cfg.IdleTimeout != session.DefaultIdleTimeout || cfg.MaxLifetime != session.DefaultMaxLifetime {
+		t.Errorf("Expected default timeouts, got %s and %s", cfg.IdleTimeout, cfg.MaxLifetime)
+	}
+	if !s.Locked() {
+		t.Error("New session should be locked")
+	}
+	if err := s.Hold(); !errors.Is(err, session.ErrLocked) {
+		t.Errorf("Expected ErrLocked, got %v", err)
+	}
+	if !s.Deadline().IsZero() {
+		t.Error("Locked session should have no deadline")
+	}
+}
+
+// TestLockRunsHooks тестирует вызов хуков блокировки в обратном порядке
+func TestLockRunsHooks(t *testing.T) {
+	s := session.New(session.Config{IdleTimeout: time.Hour})
+
+	var order []string
+	s.Unlock(
+		func() { order = append(order, "keys") },
+		func() { order = append(order, "entries") },
+	)
+	if s.Locked() {
+		t.Fatal("Session should be unlocked")
+	}
+
+	s.Lock()
+	s.Lock() // повторная блокировка ничего не делает
+
+	if len(order) != 2 || order[0] != "entries" || order[1] != "keys" {
+		t.Errorf("Expected hooks in reverse order once, got %v", order)
+	}
+	if err := s.Do(func() error { return nil }); !errors.Is(err, session.ErrLocked) {
+		t.Errorf("Expected ErrLocked after Lock, got %v", err)
+	}
+}
+
+// TestIdleTimeout тестирует автоматическую блокировку после простоя
+func TestIdleTimeout(t *testing.T) {
+	reasons := make(chan error, 1)
+	s := session.New(session.Config{
+		IdleTimeout: 100 * time.Millisecond,
+		OnAutoLock:  func(reason error) { reasons <- reason },
+	})
+	var wiped atomic.Bool
+	s.Unlock(func() { wiped.Store(true) })
+
+	// активность откладывает блокировку
+	for range 3 {
+		time.Sleep(30 * time.Millisecond)
+		if err := s.Do(func() error { return nil }); err != nil {
+			t.Fatalf("Session should stay unlocked while active: %v", err)
+		}
+	}
+
+	select {
+	case reason := <-reasons:
+		if !errors.Is(reason, session.ErrIdle) || !errors.Is(reason, session.ErrLocked) {
+			t.Errorf("Expected ErrIdle, got %v", reason)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Session should lock itself after the idle timeout")
+	}
+	if !s.Locked() || !wiped.Load() {
+		t.Error("Idle timeout should run the lock hooks")
+	}
+}
+
+// TestMaxLifetime тестирует блокировку по истечении срока жизни несмотря на активность
+func TestMaxLifetime(t *testing.T) {
+	s := session.New(session.Config{IdleTimeout: time.Hour, MaxLifetime: 60 * time.Millisecond})
+	s.Unlock()
+
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if err := s.Hold(); err != nil {
+			if !errors.Is(err, session.ErrLocked) {
+				t.Fatalf("Expected a lock error, got %v", err)
+			}
+			return
+		}
+		s.Release()
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatal("Session should lock at the end of its lifetime")
+}
+
+// TestHoldDefersLock тестирует, что таймер не блокирует сессию во время работы
+func TestHoldDefersLock(t *testing.T) {
+	reasons := make(chan error, 1)
+	s := session.New(session.Config{
+		IdleTimeout: 20 * time.Millisecond,
+		MaxLifetime: 40 * time.Millisecond,
+		OnAutoLock:  func(reason error) { reasons <- reason },
+	})
+	var wiped atomic.Bool
+	s.Unlock(func() { wiped.Store(true) })
+
+	if err := s.Hold(); err != nil {
+		t.Fatalf("Hold failed: %v", err)
+	}
+	time.Sleep(80 * time.Millisecond)
+	if wiped.Load() {
+		t.Fatal("Hooks should not run while the session is held")
+	}
+
+	// срок жизни истёк во время работы: блокировка происходит при Release
+	s.Release()
+	if !s.Locked() || !wiped.Load() {
+		t.Error("Release should lock a session whose lifetime ended")
+	}
+	if reason := <-reasons; !errors.Is(reason, session.ErrExpired) {
+		t.Errorf("Expected ErrExpired, got %v", reason)
+	}
+}
+
+// TestUnlockAgain тестирует повторную разблокировку после блокировки
+func TestUnlockAgain(t *testing.T) {
+	s := session.New(session.Config{IdleTimeout: time.Hour})
+	var first, second int
+	s.Unlock(func() { first++ })
+
+	// разблокировка открытой сессии сначала закрывает предыдущий период
+	s.Unlock(func() { second++ })
+	if first != 1 || second != 0 {
+		t.Errorf("Unlock should end the previous period, got %d and %d", first, second)
+	}
+	if d := time.Until(s.Deadline()); d <= 0 || d > time.Hour {
+		t.Errorf("Unexpected deadline in %s", d)
+	}
+
+	s.Lock()
+	if second != 1 {
+		t.Error("Lock should run the hooks of the current period")
+	}
+}
+
+// TestDoBackground тестирует, что фоновая работа не продлевает время простоя
+func TestDoBackground(t *testing.T) {
+	s := session.New(session.Config{IdleTimeout: 100 * time.Millisecond})
+	s.Unlock()
+
+	before := s.Deadline()
+	time.Sleep(10 * time.Millisecond)
+	if err := s.DoBackground(func() error { return nil }); err != nil {
+		t.Fatalf("DoBackground failed: %v", err)
+	}
+	if !s.Deadline().Equal(before) {
+		t.Error("Background work should not move the idle deadline")
+	}
+
+	// при этом Do считается активностью
+	if err := s.Do(func() error { return nil }); err != nil {
+		t.Fatalf("Do failed: %v", err)
+	}
+	if !s.Deadline().After(before) {
+		t.Error("Do should move the idle deadline")
+	}
+
+	s.Lock()
+	if err := s.DoBackground(func() error { return nil }); !errors.Is(err, session.ErrLocked) {
+		t.Errorf("Expected ErrLocked, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package shamir_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"encryptkeep-backend/internal/shamir"
+)
+
+// TestSplitCombine тестирует восстановление секрета из любых K долей
+func TestSplitCombine(t *testing.T) {
+	secret := []byte("0123456789abcdef0123456789abcdef")
+
+	shares, err := shamir.Split(secret, 5, 3)
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+	if len(shares) != 5 {
+		t.Fatalf("Expected 5 shares, got %d", len(shares))
+	}
+
+	for i := 0; i < 5; i++ {
+		for j := i + 1; j < 5; j++ {
+			for k := j + 1; k < 5; k++ {
+				got, err := shamir.Combine([]shamir.Share{shares[k], shares[i], shares[j]})
+				if err != nil {
+					t.Fatalf("Combine(%d,%d,%d) failed: %v", i, j, k, err)
+				}
+				if !bytes.Equal(got, secret) {
+					t.Errorf("Combine(%d,%d,%d) returned a wrong secret", i, j, k)
+				}
+			}
+		}
+	}
+
+	if _, err := shamir.Combine(shares[:2]); !errors.Is(err, shamir.ErrNotEnoughShares) {
+		t.Errorf("Expected ErrNotEnoughShares, got %v", err)
+	}
+	if _, err := shamir.Combine([]shamir.Share{shares[0], shares[0], shares[1]}); !errors.Is(err, shamir.ErrDuplicateShare) {
+		t.Errorf("Expected ErrDuplicateShare, got %v", err)
+	}
+}
+
+// TestSplitInvalidThreshold тестирует проверку параметров разделения
+func TestSplitInvalidThreshold(t *testing.T) {
+	secret := []byte("secret")
+	for _, tc := range []struct{ n, k int }{{3, 1}, {3, 4}, {256, 3}} {
+		if _, err := shamir.Split(secret, tc.n, tc.k); !errors.Is(err, shamir.ErrInvalidThreshold) {
+			t.Errorf("Split(n=%d, k=%d): expected ErrInvalidThreshold, got %v", tc.n, tc.k, err)
+		}
+	}
+}
+
+// TestCombineMixedSets тестирует отказ смешивать доли разных разделений
+func TestCombineMixedSets(t *testing.T) {
+	a, _ := shamir.Split([]byte("first secret"), 3, 2)
+	b, _ := shamir.Split([]byte("other secret"), 3, 2)
+
+	if _, err := shamir.Combine([]shamir.Share{a[0], b[1]}); !errors.Is(err, shamir.ErrMixedShares) {
+		t.Errorf("Expected ErrMixedShares, got %v", err)
+	}
+}
+
+// TestShareEncoding тестирует печатный формат долей и контрольную сумму
+func TestShareEncoding(t *testing.T) {
+	shares, err := shamir.Split([]byte("0123456789abcdef0123456789abcdef"), 3, 2)
+	if err != nil {
+		t.Fatalf("Split failed: %v", err)
+	}
+	text := shares[1].String()
+
+	if !strings.HasPrefix(text, "EK1-") {
+		t.Errorf("Unexpected share prefix: %s", text)
+	}
+	for _, r := range text {
+		if !strings.ContainsRune("ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-", r) {
+			t.Fatalf("Share %q is not QR alphanumeric", text)
+		}
+	}
+
+	// регистр, пробелы и переносы при ручном вводе не мешают
+	parsed, err := shamir.ParseShare(" " + strings.ToLower(strings.ReplaceAll(text, "-", " - ")) + "\n")
+	if err != nil {
+		t.Fatalf("ParseShare failed: %v", err)
+	}
+	if parsed.Set != shares[1].Set || parsed.X != shares[1].X || parsed.Threshold != 2 || !bytes.Equal(parsed.Data, shares[1].Data) {
+		t.Errorf("Parsed share differs: %+v vs %+v", parsed, shares[1])
+	}
+
+	// опечатка в одном символе ловится контрольной суммой
+	typo := []byte(text)
+	i := len(typo) - 10
+	if typo[i] == 'A' {
+		typo[i] = 'B'
+	} else {
+		typo[i] = 'A'
+	}
+	if _, err := shamir.ParseShare(string(typo)); !errors.Is(err, shamir.ErrChecksum) {
+		t.Errorf("Expected ErrChecksum for a typo, got %v", err)
+	}
+	if _, err := shamir.ParseShare("not a share"); !errors.Is(err, shamir.ErrInvalidShare) {
+		t.Errorf("Expected ErrInvalidShare, got %v", err)
+	}
+}
@@ -0,0 +1,210 @@
+package syncer_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"encryptkeep-backend/internal/session"
+	"encryptkeep-backend/internal/syncer"
+	"encryptkeep-backend/internal/vault"
+	"encryptkeep-backend/internal/vaultmanager"
+	"encryptkeep-backend/tests/fixtures"
+	mocks "encryptkeep-backend/tests/mocks/blockchain"
+)
+
+// device — открытое хранилище на одном устройстве
+type device struct {
+	vm    *vaultmanager.VaultManager
+	vault *vault.LocalVault
+}
+
+// newDevices возвращает два устройства с одним хранилищем в общем моке и
+// разблокированную сессию для второго
+func newDevices(t *testing.T, service *mocks.MockBlockchainService) (writer, reader device, sess *session.Session) {
+	t.Helper()
+	key := fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address)
+	writer = device{vaultmanager.NewVaultManager(service, key), vault.NewLocalVault()}
+	reader = device{vaultmanager.NewVaultManager(service, key), vault.NewLocalVault()}
+	sess = session.New(session.Config{IdleTimeout: time.Hour})
+	sess.Unlock(reader.vault.Wipe)
+	t.Cleanup(sess.Lock)
+	return writer, reader, sess
+}
+
+// run запускает синхронизацию и возвращает канал с результатом Run
+func run(t *testing.T, s *syncer.Syncer) (context.CancelFunc, <-chan error) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.Run(ctx) }()
+	t.Cleanup(cancel)
+	return cancel, done
+}
+
+// started ждёт, пока Run запомнит текущий блок: подписка запрашивается после него
+func started(t *testing.T, service *mocks.MockBlockchainService) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for service.CallCount("WatchVault") == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Run did not start")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+// waitEvent ждёт событие синхронизации указанного вида
+func waitEvent(t *testing.T, events <-chan syncer.Event, kind syncer.EventKind) syncer.Event {
+	t.Helper()
+	timeout := time.After(2 * time.Second)
+	for {
+		select {
+		case ev := <-events:
+			if ev.Kind == kind {
+				return ev
+			}
+		case <-timeout:
+			t.Fatalf("No %s event", kind)
+		}
+	}
+}
+
+// TestSyncerPolls тестирует получение изменений с другого устройства опросом
+func TestSyncerPolls(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	writer, reader, sess := newDevices(t, service)
+
+	s := syncer.New("main", service.Address, service, reader.vm, reader.vault, sess,
+		syncer.Config{PollInterval: 20 * time.Millisecond})
+	events, unsubscribe := s.Subscribe(8)
+	defer unsubscribe()
+	cancel, done := run(t, s)
+
+	started(t, service)
+	entries := fixtures.GetTestPasswordEntries()
+	if err := writer.vm.AddEntries(context.Background(), writer.vault, entries); err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+
+	ev := waitEvent(t, events, syncer.EventChanged)
+	if ev.Vault != "main" || len(ev.Added) != len(entries) {
+		t.Errorf("Expected %d entries added to main, got %+v", len(entries), ev)
+	}
+	if reader.vault.Len() != len(entries) {
+		t.Errorf("Expected %d entries in the reader's vault, got %d", len(entries), reader.vault.Len())
+	}
+	if service.CallCount("WatchVault") != 1 {
+		t.Errorf("Expected one subscription attempt, got %d", service.CallCount("WatchVault"))
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("Run should return nil when cancelled, got %v", err)
+	}
+}
+
+// TestSyncerSubscription тестирует доставку изменений через подписку без ожидания опроса
+func TestSyncerSubscription(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	service.Subscriptions = true
+	writer, reader, sess := newDevices(t, service)
+
+	s := syncer.New("main", service.Address, service, reader.vm, reader.vault, sess,
+		syncer.Config{PollInterval: time.Hour})
+	events, unsubscribe := s.Subscribe(8)
+	defer unsubscribe()
+	run(t, s)
+
+	started(t, service)
+	entry := vault.NewPasswordEntry("Pushed", "user", "pass")
+	if err := writer.vm.AddEntry(context.Background(), writer.vault, entry); err != nil {
+		t.Fatalf("AddEntry failed: %v", err)
+	}
+
+	ev := waitEvent(t, events, syncer.EventChanged)
+	if len(ev.Added) != 1 || ev.Added[0] != entry.ID {
+		t.Errorf("Expected %s added, got %+v", entry.ID, ev)
+	}
+	if service.CallCount("VaultChanges") != 0 {
+		t.Error("Subscription should deliver the change before any poll")
+	}
+}
+
+// TestSyncerWithoutEvents тестирует перечитывание хранилища на контракте без событий
+func TestSyncerWithoutEvents(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	service.NoEvents = true
+	writer, reader, sess := newDevices(t, service)
+
+	s := syncer.New("main", service.Address, service, reader.vm, reader.vault, sess,
+		syncer.Config{PollInterval: 20 * time.Millisecond})
+	events, unsubscribe := s.Subscribe(8)
+	defer unsubscribe()
+	run(t, s)
+
+	started(t, service)
+	entries := fixtures.GetTestPasswordEntries()
+	if err := writer.vm.AddEntries(context.Background(), writer.vault, entries); err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+	if ev := waitEvent(t, events, syncer.EventChanged); len(ev.Added) != len(entries) {
+		t.Errorf("Expected %d entries added, got %+v", len(entries), ev)
+	}
+
+	// без новых блоков хранилище не перечитывается
+	calls := service.CallCount("GetActiveIds")
+	time.Sleep(60 * time.Millisecond)
+	if calls == 0 {
+		t.Fatal("The vault should have been re-read in full")
+	}
+	if service.CallCount("GetActiveIds") != calls {
+		t.Error("The vault should only be re-read when a new block arrives")
+	}
+}
+
+// TestSyncerStopsOnLock тестирует завершение Run после блокировки хранилища
+func TestSyncerStopsOnLock(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	_, reader, sess := newDevices(t, service)
+
+	s := syncer.New("main", service.Address, service, reader.vm, reader.vault, sess,
+		syncer.Config{PollInterval: 20 * time.Millisecond})
+	_, done := run(t, s)
+
+	sess.Lock()
+	select {
+	case err := <-done:
+		if !errors.Is(err, session.ErrLocked) {
+			t.Errorf("Expected ErrLocked, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("Run should stop after the vault locks")
+	}
+}
+
+// TestSyncerSlowSubscriber тестирует, что отставший подписчик не останавливает синхронизацию
+func TestSyncerSlowSubscriber(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	writer, reader, sess := newDevices(t, service)
+
+	s := syncer.New("main", service.Address, service, reader.vm, reader.vault, sess,
+		syncer.Config{PollInterval: 10 * time.Millisecond})
+	stalled, _ := s.Subscribe(0) // никогда не читается
+	events, unsubscribe := s.Subscribe(8)
+	defer unsubscribe()
+	run(t, s)
+
+	started(t, service)
+	for i := range 3 {
+		entry := vault.NewPasswordEntry("Entry", "user", "pass")
+		if err := writer.vm.AddEntry(context.Background(), writer.vault, entry); err != nil {
+			t.Fatalf("AddEntry %d failed: %v", i, err)
+		}
+		waitEvent(t, events, syncer.EventChanged)
+	}
+	if len(stalled) != 0 {
+		t.Error("Unbuffered subscriber should have missed the events")
+	}
+}
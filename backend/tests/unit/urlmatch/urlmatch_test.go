@@ -0,0 +1,169 @@
+package urlmatch_test
+
+import (
+	"slices"
+	"testing"
+
+	"encryptkeep-backend/internal/urlmatch"
+	"encryptkeep-backend/internal/vault"
+)
+
+// TestBaseDomain тестирует определение регистрируемого домена по списку публичных суффиксов
+func TestBaseDomain(t *testing.T) {
+	tests := map[string]string{
+		"login.example.com":      "example.com",
+		"a.b.example.co.uk":      "example.co.uk",
+		"Example.COM.":           "example.com",
+		"user.github.io":         "user.github.io",
+		"co.uk":                  "co.uk",
+		"localhost":              "localhost",
+		"192.168.1.10":           "192.168.1.10",
+		"accounts.google.com.au": "google.com.au",
+	}
+	for host, want := range tests {
+		if got := urlmatch.BaseDomain(host); got != want {
+			t.Errorf("BaseDomain(%q) = %q, want %q", host, got, want)
+		}
+	}
+}
+
+// TestMatch тестирует правила domain и host
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		entry, page string
+		rule        urlmatch.Rule
+		want        bool
+	}{
+		{"https://example.com/login", "https://login.example.com/signin", urlmatch.Domain, true},
+		{"example.co.uk", "https://www.example.co.uk", urlmatch.Domain, true},
+		{"example.co.uk", "https://example2.co.uk", urlmatch.Domain, false},
+		{"example.co.uk", "https://co.uk", urlmatch.Domain, false},
+		// соседние сайты на общем суффиксе — разные домены
+		{"https://alice.github.io", "https://mallory.github.io", urlmatch.Domain, false},
+		{"https://example.com", "https://example.com.evil.net", urlmatch.Domain, false},
+		{"https://example.com", "https://login.example.com", urlmatch.Host, false},
+		{"https://login.example.com/x", "https://LOGIN.example.com:443/y", urlmatch.Host, true},
+		{"https://example.com:8443", "https://example.com", urlmatch.Host, false},
+		{"https://example.com:8443", "https://example.com", urlmatch.Domain, true},
+		// https-запись не отдаётся странице по http
+		{"https://example.com", "http://example.com", urlmatch.Domain, false},
+		{"http://intranet.example.com", "https://intranet.example.com", urlmatch.Host, true},
+		{"https://example.com", "file:///etc/passwd", urlmatch.Domain, false},
+		{"ftp://example.com", "https://example.com", urlmatch.Domain, false},
+		{"", "https://example.com", urlmatch.Domain, false},
+		{"http://192.168.1.1", "http://192.168.1.1/admin", urlmatch.Domain, true},
+		{"http://192.168.1.1", "http://192.168.1.2", urlmatch.Domain, false},
+	}
+	for _, tt := range tests {
+		if got := urlmatch.Match(tt.entry, tt.page, tt.rule); got != tt.want {
+			t.Errorf("Match(%q, %q, %s) = %v, want %v", tt.entry, tt.page, tt.rule, got, tt.want)
+		}
+	}
+}
+
+// TestParseRule тестирует разбор названия правила
+func TestParseRule(t *testing.T) {
+	if r, err := urlmatch.ParseRule(""); err != nil || r != urlmatch.Domain {
+		t.Errorf("Expected the default domain rule, got %v, %v", r, err)
+	}
+	if r, err := urlmatch.ParseRule("HOST"); err != nil || r != urlmatch.Host {
+		t.Errorf("Expected the host rule, got %v, %v", r, err)
+	}
+	if _, err := urlmatch.ParseRule("fuzzy"); err == nil {
+		t.Error("Expected an error for an unknown rule")
+	}
+}
+
+// TestMatchOtherRules тестирует правила starts_with, regex и never
+func TestMatchOtherRules(t *testing.T) {
+	tests := []struct {
+		entry, page string
+		rule        urlmatch.Rule
+		want        bool
+	}{
+		{"https://example.com/admin", "https://example.com/admin/users", urlmatch.StartsWith, true},
+		{"https://example.com/admin", "https://example.com/blog", urlmatch.StartsWith, false},
+		// адрес без схемы считается https
+		{"example.com/admin", "https://example.com/admin", urlmatch.StartsWith, true},
+		{"", "https://example.com", urlmatch.StartsWith, false},
+		{`^https://[a-z]+\.example\.com/`, "https://SSO.example.com/login", urlmatch.Regex, true},
+		{`^https://[a-z]+\.example\.com/`, "https://example.net/", urlmatch.Regex, false},
+		// некорректное выражение ничего не находит
+		{`(`, "https://example.com", urlmatch.Regex, false},
+		{"https://example.com", "https://example.com", urlmatch.Never, false},
+	}
+	for _, tt := range tests {
+		if got := urlmatch.Match(tt.entry, tt.page, tt.rule); got != tt.want {
+			t.Errorf("Match(%q, %q, %s) = %v, want %v", tt.entry, tt.page, tt.rule, got, tt.want)
+		}
+	}
+}
+
+// TestMatchAndroid тестирует сопоставление идентификаторов Android-приложений
+func TestMatchAndroid(t *testing.T) {
+	app := "androidapp://com.example.app"
+	if !urlmatch.Match(app, "androidapp://com.example.app", urlmatch.Domain) {
+		t.Error("Expected an app ID to match itself")
+	}
+	// идентификатор приложения не является доменом example.app
+	if urlmatch.Match(app, "https://com.example.app", urlmatch.Domain) {
+		t.Error("Expected an app ID not to match a web page")
+	}
+	if urlmatch.Match("https://example.app", app, urlmatch.Domain) {
+		t.Error("Expected a web address not to match an app")
+	}
+	if urlmatch.Match(app, "androidapp://com.example.app.evil", urlmatch.Domain) {
+		t.Error("Expected a different app ID not to match")
+	}
+}
+
+// TestFilter тестирует отбор и порядок записей по адресу страницы
+func TestFilter(t *testing.T) {
+	domain := &vault.PasswordEntry{ID: "domain", URL: "https://example.com"}
+	host := &vault.PasswordEntry{ID: "host", URL: "https://login.example.com", URLMatch: "host"}
+	extra := &vault.PasswordEntry{ID: "extra", URL: "https://other.net", URIs: []vault.URI{
+		{URI: "https://login.example.com/sso", Match: "starts_with"},
+	}}
+	never := &vault.PasswordEntry{ID: "never", URL: "https://example.com", URLMatch: "never"}
+	broken := &vault.PasswordEntry{ID: "broken", URL: "https://example.com", URLMatch: "fuzzy"}
+	app := &vault.PasswordEntry{ID: "app", URIs: []vault.URI{{URI: "androidapp://com.example"}}}
+	entries := []*vault.PasswordEntry{domain, host, extra, never, broken, app}
+
+	var ids []string
+	for _, e := range urlmatch.Filter(entries, "https://login.example.com/sso/start", urlmatch.Domain) {
+		ids = append(ids, e.ID)
+	}
+	// более узкое правило идёт первым
+	if want := []string{"extra", "host", "domain"}; !slices.Equal(ids, want) {
+		t.Errorf("Expected %v, got %v", want, ids)
+	}
+
+	// правило по умолчанию применяется только к адресам без своего правила
+	ids = nil
+	for _, e := range urlmatch.Filter(entries, "https://www.example.com", urlmatch.Host) {
+		ids = append(ids, e.ID)
+	}
+	if len(ids) != 0 {
+		t.Errorf("Expected no entries under the host rule, got %v", ids)
+	}
+
+	if got := urlmatch.Filter(entries, "androidapp://com.example", urlmatch.Domain); len(got) != 1 || got[0] != app {
+		t.Errorf("Expected only the app entry, got %v", got)
+	}
+}
+
+// TestValidate тестирует проверку правила при сохранении адреса
+func TestValidate(t *testing.T) {
+	if err := urlmatch.Validate("https://example.com", ""); err != nil {
+		t.Errorf("Expected the default rule to be valid, got %v", err)
+	}
+	if err := urlmatch.Validate(`^https://example\.com/`, "regex"); err != nil {
+		t.Errorf("Expected a valid expression to pass, got %v", err)
+	}
+	if err := urlmatch.Validate(`(`, "regex"); err == nil {
+		t.Error("Expected an error for an invalid expression")
+	}
+	if err := urlmatch.Validate("https://example.com", "fuzzy"); err == nil {
+		t.Error("Expected an error for an unknown rule")
+	}
+}
@@ -0,0 +1,241 @@
+package vault_test
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"encryptkeep-backend/internal/vault"
+)
+
+// TestLocalVaultPutGet тестирует копирование записей при записи и чтении
+func TestLocalVaultPutGet(t *testing.T) {
+	v := vault.NewLocalVault()
+	entry := vault.NewPasswordEntry("Site", "user", "secret")
+
+	v.Put(entry, big.NewInt(5))
+	entry.Password = "changed" // изменение исходной записи не попадает в хранилище
+
+	got, ok := v.Get(entry.ID)
+	if !ok {
+		t.Fatal("Entry should be found")
+	}
+	if got.Password != "secret" {
+		t.Errorf("Put should store a copy, got password %q", got.Password)
+	}
+	got.Title = "edited"
+	if again, _ := v.Get(entry.ID); again.Title != "Site" {
+		t.Error("Get should return a copy")
+	}
+
+	// nil сохраняет прежний ID в контракте
+	v.Put(got, nil)
+	if id, ok := v.ContractID(entry.ID); !ok || id.Int64() != 5 {
+		t.Errorf("Expected contract ID 5, got %v", id)
+	}
+	if v.Len() != 1 || v.Metadata.TotalEntries != 1 {
+		t.Errorf("Expected 1 entry, got %d (TotalEntries %d)", v.Len(), v.Metadata.TotalEntries)
+	}
+
+	v.Remove(entry.ID)
+	if _, ok := v.Get(entry.ID); ok {
+		t.Error("Entry should be removed")
+	}
+	if _, ok := v.ContractID(entry.ID); ok {
+		t.Error("Contract ID should be removed")
+	}
+	if v.Metadata.TotalEntries != 0 {
+		t.Errorf("Expected TotalEntries 0, got %d", v.Metadata.TotalEntries)
+	}
+}
+
+// TestLocalVaultList тестирует порядок записей в списке
+func TestLocalVaultList(t *testing.T) {
+	v := vault.NewLocalVault()
+	for i, title := range []string{"Gamma", "Alpha", "Beta"} {
+		v.Put(vault.NewPasswordEntry(title, "user", "pass"), big.NewInt(int64(i)))
+	}
+
+	list := v.List()
+	if len(list) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(list))
+	}
+	for i, want := range []string{"Alpha", "Beta", "Gamma"} {
+		if list[i].Title != want {
+			t.Errorf("Expected %s at %d, got %s", want, i, list[i].Title)
+		}
+	}
+}
+
+// TestLocalVaultReplace тестирует замену содержимого результатом синхронизации
+func TestLocalVaultReplace(t *testing.T) {
+	v := vault.NewLocalVault()
+	v.IsDirty = true
+	v.Put(vault.NewPasswordEntry("Old", "user", "pass"), big.NewInt(1))
+
+	entry := vault.NewPasswordEntry("New", "user", "pass")
+	meta := &vault.UserMetadata{Version: "1.0", UpdatedAt: time.Unix(1700000000, 0), TotalEntries: 1}
+	v.Replace(meta,
+		map[string]*vault.PasswordEntry{entry.ID: entry},
+		map[string]*big.Int{entry.ID: big.NewInt(2)})
+
+	if v.Len() != 1 {
+		t.Fatalf("Expected 1 entry, got %d", v.Len())
+	}
+	if _, ok := v.Get(entry.ID); !ok {
+		t.Error("Synced entry should be present")
+	}
+	if !v.LastSync().Equal(meta.UpdatedAt) {
+		t.Errorf("Expected LastSync %s, got %s", meta.UpdatedAt, v.LastSync())
+	}
+	if v.IsDirty {
+		t.Error("Replace should clear IsDirty")
+	}
+}
+
+// TestLocalVaultConcurrentUse тестирует одновременную работу с хранилищем (запускать с -race)
+func TestLocalVaultConcurrentUse(t *testing.T) {
+	v := vault.NewLocalVault()
+	var wg sync.WaitGroup
+
+	// запись и удаление из нескольких горутин
+	for w := range 4 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 50 {
+				entry := vault.NewPasswordEntry(fmt.Sprintf("w%d-%d", w, i), "user", "pass")
+				v.Put(entry, big.NewInt(int64(w*100+i)))
+				if i%2 == 0 {
+					v.Remove(entry.ID)
+				}
+			}
+		}()
+	}
+
+	// чтение во время записи
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 100 {
+			for _, e := range v.List() {
+				v.Get(e.ID)
+				v.ContractID(e.ID)
+			}
+			v.Len()
+			v.LastSync()
+		}
+	}()
+
+	// синхронизация и блокировка посреди работы
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 10 {
+			if i%2 == 0 {
+				v.Replace(&vault.UserMetadata{UpdatedAt: time.Now()},
+					map[string]*vault.PasswordEntry{}, map[string]*big.Int{})
+			} else {
+				v.Wipe()
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	// после гонки хранилище согласовано
+	for _, e := range v.List() {
+		if _, ok := v.ContractID(e.ID); !ok {
+			t.Errorf("Entry %s has no contract ID", e.ID)
+		}
+	}
+}
+
+// TestLocalVaultMerge тестирует слияние инкрементальной синхронизации и отчёт об изменениях
+func TestLocalVaultMerge(t *testing.T) {
+	v := vault.NewLocalVault()
+	kept := vault.NewPasswordEntry("Kept", "user", "pass")
+	edited := vault.NewPasswordEntry("Edited", "user", "pass")
+	gone := vault.NewPasswordEntry("Gone", "user", "pass")
+	v.Put(kept, big.NewInt(1))
+	v.Put(edited, big.NewInt(2))
+	v.Put(gone, big.NewInt(3))
+
+	changedCopy := *edited
+	changedCopy.Password = "new"
+	added := vault.NewPasswordEntry("Added", "user", "pass")
+	unchanged := *kept
+
+	changes, err := v.Merge(v.Epoch(), nil,
+		[]*vault.PasswordEntry{&unchanged, &changedCopy, added},
+		[]*big.Int{big.NewInt(1), big.NewInt(2), big.NewInt(4)},
+		[]*big.Int{big.NewInt(3)})
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+
+	if len(changes.Added) != 1 || changes.Added[0] != added.ID {
+		t.Errorf("Expected %s added, got %v", added.ID, changes.Added)
+	}
+	if len(changes.Updated) != 1 || changes.Updated[0] != edited.ID {
+		t.Errorf("Expected %s updated, got %v", edited.ID, changes.Updated)
+	}
+	if len(changes.Removed) != 1 || changes.Removed[0] != gone.ID {
+		t.Errorf("Expected %s removed, got %v", gone.ID, changes.Removed)
+	}
+	if changes.Metadata {
+		t.Error("Metadata should not be reported without new metadata")
+	}
+	if got, _ := v.Get(edited.ID); got.Password != "new" {
+		t.Errorf("Expected the updated password, got %q", got.Password)
+	}
+	if v.Len() != 3 || v.Metadata.TotalEntries != 3 {
+		t.Errorf("Expected 3 entries, got %d (TotalEntries %d)", v.Len(), v.Metadata.TotalEntries)
+	}
+
+	// повторное слияние тех же данных ничего не меняет
+	changes, err = v.Merge(v.Epoch(), nil, []*vault.PasswordEntry{added}, []*big.Int{big.NewInt(4)}, nil)
+	if err != nil || !changes.Empty() {
+		t.Errorf("Expected no changes, got %+v (%v)", changes, err)
+	}
+}
+
+// TestLocalVaultMergeSlotReuse тестирует замену записи, занимавшей тот же ID в контракте
+func TestLocalVaultMergeSlotReuse(t *testing.T) {
+	v := vault.NewLocalVault()
+	old := vault.NewPasswordEntry("Old", "user", "pass")
+	v.Put(old, big.NewInt(7))
+
+	replacement := vault.NewPasswordEntry("New", "user", "pass")
+	changes, err := v.Merge(v.Epoch(), nil, []*vault.PasswordEntry{replacement}, []*big.Int{big.NewInt(7)}, nil)
+	if err != nil {
+		t.Fatalf("Merge failed: %v", err)
+	}
+	if len(changes.Removed) != 1 || changes.Removed[0] != old.ID || len(changes.Added) != 1 {
+		t.Errorf("Expected the old entry replaced, got %+v", changes)
+	}
+	if _, ok := v.Get(old.ID); ok {
+		t.Error("Entry in the reused slot should be dropped")
+	}
+}
+
+// TestLocalVaultMergeAfterWipe тестирует, что синхронизация не возвращает записи после блокировки
+func TestLocalVaultMergeAfterWipe(t *testing.T) {
+	v := vault.NewLocalVault()
+	epoch := v.Epoch()
+
+	// блокировка между чтением из сети и слиянием
+	v.Wipe()
+	_, err := v.Merge(epoch, &vault.UserMetadata{UpdatedAt: time.Now()},
+		[]*vault.PasswordEntry{vault.NewPasswordEntry("Site", "user", "pass")},
+		[]*big.Int{big.NewInt(1)}, nil)
+	if !errors.Is(err, vault.ErrWiped) {
+		t.Errorf("Expected ErrWiped, got %v", err)
+	}
+	if v.Len() != 0 {
+		t.Error("Merge after Wipe should leave the vault empty")
+	}
+}
@@ -355,3 +355,51 @@ func TestVaultConfigStruct(t *testing.T) {
 		t.Errorf("Argon2KeyLength mismatch: got %d, want 32", config.Argon2KeyLength)
 	}
 }
+
+// TestLocalVaultWipe тестирует стирание расшифрованных записей при блокировке
+func TestLocalVaultWipe(t *testing.T) {
+	v := vault.NewLocalVault()
+	entry := vault.NewPasswordEntry("Site", "user", "secret")
+	v.Entries[entry.ID] = entry
+	v.BlockchainEntries[entry.ID] = big.NewInt(1)
+	v.Metadata.PasswordIDs = []string{entry.ID}
+
+	v.Wipe()
+
+	if len(v.Entries) != 0 || len(v.BlockchainEntries) != 0 || len(v.Metadata.PasswordIDs) != 0 {
+		t.Error("Wipe should drop entries and metadata")
+	}
+	// ссылки, оставшиеся у вызывающего, тоже очищены
+	if entry.Password != "" || entry.Username != "" || entry.Title != "" {
+		t.Error("Wipe should clear the entry fields")
+	}
+}
+
+// TestSharedCollectionWipe тестирует стирание ключей и записей коллекции
+func TestSharedCollectionWipe(t *testing.T) {
+	key := []byte{1, 2, 3, 4}
+	entry := vault.NewPasswordEntry("Team", "user", "secret")
+	c := &vault.SharedCollection{
+		ID:                big.NewInt(7),
+		Key:               key,
+		Entries:           map[string]*vault.PasswordEntry{entry.ID: entry},
+		BlockchainEntries: map[string]*big.Int{entry.ID: big.NewInt(1)},
+	}
+
+	c.Wipe()
+
+	if c.Key != nil || len(c.Entries) != 0 || len(c.BlockchainEntries) != 0 {
+		t.Error("Wipe should drop the key and entries")
+	}
+	for _, b := range key {
+		if b != 0 {
+			t.Fatal("Wipe should zero the collection key")
+		}
+	}
+	if entry.Password != "" {
+		t.Error("Wipe should clear the entry fields")
+	}
+	if c.ID.Int64() != 7 {
+		t.Error("Wipe should keep the collection ID")
+	}
+}
@@ -0,0 +1,144 @@
+package vaultmanager_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"encryptkeep-backend/internal/blockchain"
+	"encryptkeep-backend/internal/codec"
+	"encryptkeep-backend/internal/vault"
+	"encryptkeep-backend/internal/vaultmanager"
+	"encryptkeep-backend/tests/fixtures"
+	mocks "encryptkeep-backend/tests/mocks/blockchain"
+)
+
+// TestAddEntries тестирует пакетное добавление записей одной транзакцией
+func TestAddEntries(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	vm := vaultmanager.NewVaultManager(service, fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address))
+	v := vault.NewLocalVault()
+
+	entries := fixtures.GetTestPasswordEntries()
+	if err := vm.AddEntries(context.Background(), v, entries); err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+
+	if service.Calls["StoreDataBatch"] != 1 {
+		t.Errorf("Expected 1 StoreDataBatch call, got %d", service.Calls["StoreDataBatch"])
+	}
+	if service.Calls["SyncVault"] != 0 {
+		t.Errorf("Expected no full resync, got %d", service.Calls["SyncVault"])
+	}
+	if len(v.Entries) != len(entries) {
+		t.Fatalf("Expected %d entries, got %d", len(entries), len(v.Entries))
+	}
+
+	cdc := codec.NewCodec()
+	for i, entry := range entries {
+		id, ok := v.BlockchainEntries[entry.ID]
+		if !ok {
+			t.Fatalf("Missing contract ID for entry %s", entry.ID)
+		}
+		if id.Int64() != int64(i) {
+			t.Errorf("Expected contract ID %d for entry %s, got %s", i, entry.ID, id)
+		}
+
+		// Данные должны упаковываться в порядке входных записей
+		unpacked, err := cdc.UnpackEntry(service.Data[id.String()], fixtures.TestMasterPassword)
+		if err != nil {
+			t.Fatalf("UnpackEntry failed: %v", err)
+		}
+		if unpacked.ID != entry.ID {
+			t.Errorf("Expected entry %s at ID %s, got %s", entry.ID, id, unpacked.ID)
+		}
+	}
+
+	if v.Metadata.TotalEntries != len(entries) {
+		t.Errorf("Expected TotalEntries %d, got %d", len(entries), v.Metadata.TotalEntries)
+	}
+}
+
+// TestAddEntriesPartialFailure тестирует частично записанный пакет
+func TestAddEntriesPartialFailure(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	service.FailAfter = 2
+	vm := vaultmanager.NewVaultManager(service, fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address))
+	v := vault.NewLocalVault()
+
+	err := vm.AddEntries(context.Background(), v, fixtures.GetTestPasswordEntries())
+	if !errors.Is(err, blockchain.ErrTransactionFailed) {
+		t.Fatalf("Expected ErrTransactionFailed, got %v", err)
+	}
+
+	// Записанные до ошибки элементы должны попасть в локальное хранилище
+	if len(v.Entries) != 2 {
+		t.Errorf("Expected 2 recorded entries, got %d", len(v.Entries))
+	}
+}
+
+// TestUpdateEntries тестирует пакетное обновление записей
+func TestUpdateEntries(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	vm := vaultmanager.NewVaultManager(service, fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address))
+	v := vault.NewLocalVault()
+
+	entries := fixtures.GetTestPasswordEntries()
+	if err := vm.AddEntries(context.Background(), v, entries); err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+
+	entries[0].Password = "changed-password"
+	if err := vm.UpdateEntries(context.Background(), v, entries[:1]); err != nil {
+		t.Fatalf("UpdateEntries failed: %v", err)
+	}
+
+	id := v.BlockchainEntries[entries[0].ID]
+	unpacked, err := codec.NewCodec().UnpackEntry(service.Data[id.String()], fixtures.TestMasterPassword)
+	if err != nil {
+		t.Fatalf("UnpackEntry failed: %v", err)
+	}
+	if unpacked.Password != "changed-password" {
+		t.Errorf("Expected updated password, got %s", unpacked.Password)
+	}
+}
+
+// TestUpdateEntriesUnknownEntry тестирует обновление записи без ID в контракте
+func TestUpdateEntriesUnknownEntry(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	vm := vaultmanager.NewVaultManager(service, fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address))
+
+	err := vm.UpdateEntries(context.Background(), vault.NewLocalVault(), fixtures.GetTestPasswordEntries())
+	if err == nil {
+		t.Error("Expected error for entries without contract IDs")
+	}
+	if service.Calls["ChangeDataBatch"] != 0 {
+		t.Error("No transaction should be sent")
+	}
+}
+
+// TestDeleteEntries тестирует пакетное удаление записей
+func TestDeleteEntries(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	vm := vaultmanager.NewVaultManager(service, fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address))
+	v := vault.NewLocalVault()
+
+	entries := fixtures.GetTestPasswordEntries()
+	if err := vm.AddEntries(context.Background(), v, entries); err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+
+	if err := vm.DeleteEntries(context.Background(), v, []string{entries[0].ID, entries[2].ID}); err != nil {
+		t.Fatalf("DeleteEntries failed: %v", err)
+	}
+
+	if len(v.Entries) != 1 || len(service.Data) != 1 {
+		t.Errorf("Expected 1 remaining entry, got %d local and %d on chain", len(v.Entries), len(service.Data))
+	}
+	if _, ok := v.Entries[entries[1].ID]; !ok {
+		t.Error("Untouched entry should remain")
+	}
+	if v.Metadata.TotalEntries != 1 {
+		t.Errorf("Expected TotalEntries 1, got %d", v.Metadata.TotalEntries)
+	}
+}
@@ -0,0 +1,235 @@
+package vaultmanager_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"testing"
+
+	"encryptkeep-backend/internal/blockchain"
+	"encryptkeep-backend/internal/codec"
+	"encryptkeep-backend/internal/vault"
+	"encryptkeep-backend/internal/vaultmanager"
+	"encryptkeep-backend/tests/fixtures"
+	mocks "encryptkeep-backend/tests/mocks/blockchain"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// member описывает участника коллекции с собственным ключом кошелька
+type member struct {
+	key      *ecdsa.PrivateKey
+	address  string
+	vaultKey *codec.VaultKey
+	vm       *vaultmanager.VaultManager
+}
+
+func newMember(t *testing.T, service *mocks.MockBlockchainService) *member {
+	t.Helper()
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+	address := crypto.PubkeyToAddress(key.PublicKey).Hex()
+	m := &member{
+		key:      key,
+		address:  address,
+		vaultKey: fixtures.NewTestVaultKey(fixtures.TestMasterPassword, address),
+	}
+	m.vm = vaultmanager.NewVaultManager(service, m.vaultKey)
+	if err := m.vm.SetIdentity(key); err != nil {
+		t.Fatalf("SetIdentity: %v", err)
+	}
+	return m
+}
+
+// act делает участника вызывающим в общем mock-сервисе
+func (m *member) act(t *testing.T, service *mocks.MockBlockchainService) {
+	t.Helper()
+	service.Address = m.address
+	signer, err := blockchain.NewKeySigner(m.key)
+	if err != nil {
+		t.Fatalf("signer: %v", err)
+	}
+	if _, err := service.StartSession(signer, m.vaultKey); err != nil {
+		t.Fatalf("start session: %v", err)
+	}
+}
+
+// TestSharedCollectionMembers тестирует совместный доступ участников к коллекции
+func TestSharedCollectionMembers(t *testing.T) {
+	ctx := context.Background()
+	service := mocks.NewMockBlockchainService()
+	owner, alice := newMember(t, service), newMember(t, service)
+
+	alice.act(t, service)
+	if err := alice.vm.PublishPublicKey(ctx); err != nil {
+		t.Fatalf("PublishPublicKey failed: %v", err)
+	}
+	if err := alice.vm.PublishPublicKey(ctx); err != nil || service.Calls["RegisterPublicKey"] != 1 {
+		t.Errorf("Public key should be registered once, got %d calls (%v)", service.Calls["RegisterPublicKey"], err)
+	}
+
+	owner.act(t, service)
+	collection, err := owner.vm.CreateCollection(ctx)
+	if err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+	if err := owner.vm.AddCollectionEntries(ctx, collection, fixtures.GetTestPasswordEntries()); err != nil {
+		t.Fatalf("AddCollectionEntries failed: %v", err)
+	}
+	if err := owner.vm.AddCollectionMember(ctx, collection, alice.address); err != nil {
+		t.Fatalf("AddCollectionMember failed: %v", err)
+	}
+
+	// участница читает и пишет своим ключом кошелька
+	alice.act(t, service)
+	shared, err := alice.vm.OpenCollection(ctx, collection.ID)
+	if err != nil {
+		t.Fatalf("OpenCollection failed: %v", err)
+	}
+	if len(shared.Entries) != len(collection.Entries) {
+		t.Fatalf("Expected %d entries, got %d", len(collection.Entries), len(shared.Entries))
+	}
+	if err := alice.vm.AddCollectionEntries(ctx, shared, []*vault.PasswordEntry{vault.NewPasswordEntry("VPN", "alice", "vpn-secret")}); err != nil {
+		t.Fatalf("member write failed: %v", err)
+	}
+
+	owner.act(t, service)
+	if err := owner.vm.SyncCollection(ctx, collection); err != nil {
+		t.Fatalf("SyncCollection failed: %v", err)
+	}
+	if len(collection.Entries) != len(shared.Entries) || len(collection.Members) != 2 {
+		t.Errorf("Owner should see the member's entry and both members, got %d entries, %d members",
+			len(collection.Entries), len(collection.Members))
+	}
+}
+
+// TestSharedCollectionRemoveMemberRotatesKey тестирует смену ключа при удалении участника
+func TestSharedCollectionRemoveMemberRotatesKey(t *testing.T) {
+	ctx := context.Background()
+	service := mocks.NewMockBlockchainService()
+	owner, alice, bob := newMember(t, service), newMember(t, service), newMember(t, service)
+
+	for _, m := range []*member{alice, bob} {
+		m.act(t, service)
+		if err := m.vm.PublishPublicKey(ctx); err != nil {
+			t.Fatalf("PublishPublicKey failed: %v", err)
+		}
+	}
+
+	owner.act(t, service)
+	collection, err := owner.vm.CreateCollection(ctx)
+	if err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+	if err := owner.vm.AddCollectionEntries(ctx, collection, fixtures.GetTestPasswordEntries()); err != nil {
+		t.Fatalf("AddCollectionEntries failed: %v", err)
+	}
+	for _, m := range []*member{alice, bob} {
+		if err := owner.vm.AddCollectionMember(ctx, collection, m.address); err != nil {
+			t.Fatalf("AddCollectionMember failed: %v", err)
+		}
+	}
+	oldKey := collection.Key
+
+	bob.act(t, service)
+	if _, err := bob.vm.OpenCollection(ctx, collection.ID); err != nil {
+		t.Fatalf("bob OpenCollection failed: %v", err)
+	}
+
+	owner.act(t, service)
+	if err := owner.vm.RemoveCollectionMember(ctx, collection, bob.address); err != nil {
+		t.Fatalf("RemoveCollectionMember failed: %v", err)
+	}
+	if string(collection.Key) == string(oldKey) || collection.RotatingKey != nil {
+		t.Error("Removing a member should rotate the collection key")
+	}
+	if collection.KeyEpoch.Int64() != 1 || len(collection.Members) != 2 {
+		t.Errorf("Expected epoch 1 and 2 members, got %s and %d", collection.KeyEpoch, len(collection.Members))
+	}
+
+	// удалённый участник больше не получает ключ и не может писать
+	bob.act(t, service)
+	if _, err := bob.vm.OpenCollection(ctx, collection.ID); !errors.Is(err, blockchain.ErrNotCollectionMember) {
+		t.Errorf("Expected ErrNotCollectionMember for removed member, got %v", err)
+	}
+	// и старый ключ не открывает перезапечатанные записи
+	cdc := codec.NewCodec()
+	for _, data := range service.Collections[collection.ID.String()].Data {
+		if _, err := cdc.UnpackEntryWithKey(data, oldKey); err == nil {
+			t.Fatal("Old collection key should not open re-sealed entries")
+		}
+	}
+
+	// оставшаяся участница получает новый ключ при следующей синхронизации
+	alice.act(t, service)
+	shared, err := alice.vm.OpenCollection(ctx, collection.ID)
+	if err != nil {
+		t.Fatalf("alice OpenCollection after rotation failed: %v", err)
+	}
+	if string(shared.Key) != string(collection.Key) || len(shared.Entries) != len(collection.Entries) {
+		t.Error("Remaining member should read entries with the rotated key")
+	}
+}
+
+// TestSharedCollectionResumeRotation тестирует продолжение прерванной смены ключа
+func TestSharedCollectionResumeRotation(t *testing.T) {
+	ctx := context.Background()
+	service := mocks.NewMockBlockchainService()
+	owner := newMember(t, service)
+
+	owner.act(t, service)
+	collection, err := owner.vm.CreateCollection(ctx)
+	if err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+	if err := owner.vm.AddCollectionEntries(ctx, collection, fixtures.GetTestPasswordEntries()); err != nil {
+		t.Fatalf("AddCollectionEntries failed: %v", err)
+	}
+
+	service.FailAfter = 1
+	if err := owner.vm.RotateCollectionKey(ctx, collection); err == nil {
+		t.Fatal("Expected rotation to fail")
+	}
+	if collection.RotatingKey == nil {
+		t.Fatal("Failed rotation should keep the new key")
+	}
+
+	// записи запечатаны разными ключами, но читаются
+	if err := owner.vm.SyncCollection(ctx, collection); err != nil {
+		t.Fatalf("SyncCollection during rotation failed: %v", err)
+	}
+
+	service.FailAfter = -1
+	if err := owner.vm.RotateCollectionKey(ctx, collection); err != nil {
+		t.Fatalf("resumed rotation failed: %v", err)
+	}
+	if collection.RotatingKey != nil || collection.KeyEpoch.Int64() != 1 {
+		t.Errorf("Rotation should finish at epoch 1, got %s", collection.KeyEpoch)
+	}
+	if err := owner.vm.SyncCollection(ctx, collection); err != nil {
+		t.Fatalf("SyncCollection after rotation failed: %v", err)
+	}
+}
+
+// TestSharedCollectionMemberWithoutPublicKey тестирует добавление участника без публичного ключа
+func TestSharedCollectionMemberWithoutPublicKey(t *testing.T) {
+	ctx := context.Background()
+	service := mocks.NewMockBlockchainService()
+	owner, stranger := newMember(t, service), newMember(t, service)
+
+	owner.act(t, service)
+	collection, err := owner.vm.CreateCollection(ctx)
+	if err != nil {
+		t.Fatalf("CreateCollection failed: %v", err)
+	}
+	if err := owner.vm.AddCollectionMember(ctx, collection, stranger.address); !errors.Is(err, blockchain.ErrPublicKeyNotRegistered) {
+		t.Errorf("Expected ErrPublicKeyNotRegistered, got %v", err)
+	}
+
+	noIdentity := vaultmanager.NewVaultManager(service, fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address))
+	if _, err := noIdentity.CreateCollection(ctx); !errors.Is(err, vaultmanager.ErrNoIdentity) {
+		t.Errorf("Expected ErrNoIdentity, got %v", err)
+	}
+}
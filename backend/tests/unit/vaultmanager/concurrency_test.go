@@ -0,0 +1,126 @@
+package vaultmanager_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"encryptkeep-backend/internal/pricing"
+	"encryptkeep-backend/internal/secret"
+	"encryptkeep-backend/internal/vault"
+	"encryptkeep-backend/internal/vaultmanager"
+	"encryptkeep-backend/tests/fixtures"
+	mocks "encryptkeep-backend/tests/mocks/blockchain"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TestVaultManagerConcurrentUse тестирует одновременные операции над одним хранилищем (запускать с -race)
+func TestVaultManagerConcurrentUse(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	vm := vaultmanager.NewVaultManager(service, fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address))
+	v := vault.NewLocalVault()
+	ctx := context.Background()
+
+	identity, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	const writers, perWriter = 4, 6
+	var wg sync.WaitGroup
+
+	// каждая горутина добавляет свои записи и удаляет половину
+	for w := range writers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entries := make([]*vault.PasswordEntry, perWriter)
+			for i := range entries {
+				entries[i] = vault.NewPasswordEntry(fmt.Sprintf("w%d-%d", w, i), "user", "pass")
+			}
+			if err := vm.AddEntries(ctx, v, entries); err != nil {
+				t.Errorf("AddEntries failed: %v", err)
+				return
+			}
+
+			entries[0].Password = "changed"
+			if err := vm.UpdateEntries(ctx, v, entries[:1]); err != nil {
+				t.Errorf("UpdateEntries failed: %v", err)
+			}
+			ids := []string{entries[1].ID, entries[2].ID, entries[3].ID}
+			if err := vm.DeleteEntries(ctx, v, ids); err != nil {
+				t.Errorf("DeleteEntries failed: %v", err)
+			}
+		}()
+	}
+
+	// чтение во время записи
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 50 {
+			for _, e := range vm.GetAllEntries(v) {
+				vm.GetEntryFromVault(v, e.ID)
+			}
+		}
+	}()
+
+	// смена источника цен во время оценки стоимости
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 20 {
+			if i%2 == 0 {
+				vm.SetPriceSource(pricing.StaticSource{"ETH": {"USD": 2000}}, "USD")
+			} else {
+				vm.SetPriceSource(nil, "")
+			}
+			if _, err := vm.PreviewAddEntries(ctx, fixtures.GetTestPasswordEntries()); err != nil {
+				t.Errorf("PreviewAddEntries failed: %v", err)
+			}
+		}
+	}()
+
+	// установка и стирание ключа кошелька во время работы с коллекциями
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 20 {
+			if err := vm.SetIdentity(identity); err != nil {
+				t.Errorf("SetIdentity failed: %v", err)
+			}
+			vm.ClearIdentity()
+		}
+	}()
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for range 20 {
+			_, err := vm.CreateCollection(ctx)
+			if err != nil && !errors.Is(err, vaultmanager.ErrNoIdentity) && !errors.Is(err, secret.ErrDestroyed) {
+				t.Errorf("CreateCollection failed: %v", err)
+			}
+		}
+	}()
+
+	wg.Wait()
+
+	want := writers * (perWriter - 3)
+	if got := len(vm.GetAllEntries(v)); got != want {
+		t.Errorf("Expected %d entries, got %d", want, got)
+	}
+	if v.Metadata.TotalEntries != want {
+		t.Errorf("Expected TotalEntries %d, got %d", want, v.Metadata.TotalEntries)
+	}
+	for _, e := range vm.GetAllEntries(v) {
+		if _, ok := v.ContractID(e.ID); !ok {
+			t.Errorf("Entry %s has no contract ID", e.ID)
+		}
+		if e.Title[len(e.Title)-2:] == "-0" && e.Password != "changed" {
+			t.Errorf("Update of %s was lost", e.Title)
+		}
+	}
+}
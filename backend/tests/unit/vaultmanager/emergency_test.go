@@ -0,0 +1,215 @@
+package vaultmanager_test
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"encryptkeep-backend/internal/blockchain"
+	"encryptkeep-backend/internal/keymanager"
+	"encryptkeep-backend/internal/vault"
+	"encryptkeep-backend/internal/vaultmanager"
+	"encryptkeep-backend/tests/fixtures"
+	mocks "encryptkeep-backend/tests/mocks/blockchain"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// keysFor создаёт менеджер ключей участника с сохранёнными ключами
+func keysFor(t *testing.T, m *member, password string) *keymanager.KeyManager {
+	t.Helper()
+	km := keymanager.NewKeyManager(keymanager.KeyManagerConfig{ConfigDir: t.TempDir()})
+	if err := km.InitializeFirstTime(hex.EncodeToString(crypto.FromECDSA(m.key)), password); err != nil {
+		t.Fatalf("InitializeFirstTime: %v", err)
+	}
+	return km
+}
+
+// emergencySetup создаёт владельца с записями и контакт с экстренным доступом
+func emergencySetup(t *testing.T, wait time.Duration) (*mocks.MockBlockchainService, *member, *member, *keymanager.KeyManager) {
+	t.Helper()
+	ctx := context.Background()
+	service := mocks.NewMockBlockchainService()
+	owner, contact := newMember(t, service), newMember(t, service)
+	ownerKeys := keysFor(t, owner, fixtures.TestMasterPassword)
+	contactKeys := keysFor(t, contact, "contact-password")
+
+	contact.act(t, service)
+	if err := contact.vm.PublishPublicKey(ctx); err != nil {
+		t.Fatalf("PublishPublicKey failed: %v", err)
+	}
+
+	owner.act(t, service)
+	if err := owner.vm.AddEntries(ctx, vault.NewLocalVault(), fixtures.GetTestPasswordEntries()); err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+	if err := owner.vm.AddEmergencyContact(ctx, ownerKeys, contact.address, wait); err != nil {
+		t.Fatalf("AddEmergencyContact failed: %v", err)
+	}
+	return service, owner, contact, contactKeys
+}
+
+// TestEmergencyAccessAfterWaitPeriod тестирует доступ контакта по истечении
+// периода ожидания, пока владелец ничего не делает
+func TestEmergencyAccessAfterWaitPeriod(t *testing.T) {
+	ctx := context.Background()
+	service, owner, contact, contactKeys := emergencySetup(t, 48*time.Hour)
+	now := time.Now()
+	service.Now = func() time.Time { return now }
+
+	contact.act(t, service)
+	if _, err := contact.vm.OpenEmergencyVault(ctx, contactKeys, owner.address); !errors.Is(err, blockchain.ErrEmergencyAccessNotGranted) {
+		t.Fatalf("Expected ErrEmergencyAccessNotGranted before a request, got %v", err)
+	}
+
+	access, err := contact.vm.RequestEmergencyAccess(ctx, owner.address)
+	if err != nil {
+		t.Fatalf("RequestEmergencyAccess failed: %v", err)
+	}
+	if !access.AvailableAt().Equal(now.Add(48 * time.Hour)) {
+		t.Errorf("Expected access at %v, got %v", now.Add(48*time.Hour), access.AvailableAt())
+	}
+	if _, err := contact.vm.RequestEmergencyAccess(ctx, owner.address); !errors.Is(err, blockchain.ErrEmergencyRequestPending) {
+		t.Errorf("Expected ErrEmergencyRequestPending, got %v", err)
+	}
+
+	now = now.Add(47 * time.Hour)
+	if _, err := contact.vm.OpenEmergencyVault(ctx, contactKeys, owner.address); !errors.Is(err, blockchain.ErrEmergencyAccessNotGranted) {
+		t.Fatalf("Expected ErrEmergencyAccessNotGranted during the wait, got %v", err)
+	}
+
+	now = now.Add(time.Hour)
+	v, err := contact.vm.OpenEmergencyVault(ctx, contactKeys, owner.address)
+	if err != nil {
+		t.Fatalf("OpenEmergencyVault failed: %v", err)
+	}
+	if len(v.Entries) != len(fixtures.GetTestPasswordEntries()) {
+		t.Errorf("Expected %d entries, got %d", len(fixtures.GetTestPasswordEntries()), len(v.Entries))
+	}
+}
+
+// TestEmergencyAccessRejectAndApprove тестирует отклонение и одобрение запроса владельцем
+func TestEmergencyAccessRejectAndApprove(t *testing.T) {
+	ctx := context.Background()
+	service, owner, contact, contactKeys := emergencySetup(t, 30*24*time.Hour)
+	now := time.Now()
+	service.Now = func() time.Time { return now }
+
+	contact.act(t, service)
+	if _, err := contact.vm.RequestEmergencyAccess(ctx, owner.address); err != nil {
+		t.Fatalf("RequestEmergencyAccess failed: %v", err)
+	}
+
+	owner.act(t, service)
+	contacts, err := owner.vm.EmergencyContacts(ctx)
+	if err != nil || len(contacts) != 1 || !contacts[0].Requested() {
+		t.Fatalf("Owner should see one pending request, got %+v (%v)", contacts, err)
+	}
+	if err := owner.vm.RejectEmergencyAccess(ctx, contact.address); err != nil {
+		t.Fatalf("RejectEmergencyAccess failed: %v", err)
+	}
+
+	// отклонённый запрос не открывает хранилище даже после периода ожидания
+	now = now.Add(31 * 24 * time.Hour)
+	contact.act(t, service)
+	if _, err := contact.vm.OpenEmergencyVault(ctx, contactKeys, owner.address); !errors.Is(err, blockchain.ErrEmergencyAccessNotGranted) {
+		t.Fatalf("Expected ErrEmergencyAccessNotGranted after rejection, got %v", err)
+	}
+
+	if _, err := contact.vm.RequestEmergencyAccess(ctx, owner.address); err != nil {
+		t.Fatalf("second RequestEmergencyAccess failed: %v", err)
+	}
+	owner.act(t, service)
+	if err := owner.vm.ApproveEmergencyAccess(ctx, contact.address); err != nil {
+		t.Fatalf("ApproveEmergencyAccess failed: %v", err)
+	}
+
+	contact.act(t, service)
+	if _, err := contact.vm.OpenEmergencyVault(ctx, contactKeys, owner.address); err != nil {
+		t.Errorf("Approved request should open the vault at once, got %v", err)
+	}
+}
+
+// TestAddEmergencyContactChecks тестирует проверки при добавлении экстренного контакта
+func TestAddEmergencyContactChecks(t *testing.T) {
+	ctx := context.Background()
+	service := mocks.NewMockBlockchainService()
+	owner, stranger := newMember(t, service), newMember(t, service)
+	ownerKeys := keysFor(t, owner, fixtures.TestMasterPassword)
+
+	owner.act(t, service)
+	if err := owner.vm.AddEmergencyContact(ctx, ownerKeys, owner.address, time.Hour); !errors.Is(err, vaultmanager.ErrSelfEmergencyContact) {
+		t.Errorf("Expected ErrSelfEmergencyContact, got %v", err)
+	}
+	if err := owner.vm.AddEmergencyContact(ctx, ownerKeys, stranger.address, time.Hour); !errors.Is(err, blockchain.ErrPublicKeyNotRegistered) {
+		t.Errorf("Expected ErrPublicKeyNotRegistered, got %v", err)
+	}
+
+	// без открытой сессии ключи хранилища не запечатываются
+	ownerKeys.ClearSession()
+	if _, err := ownerKeys.SealEmergencyKit(&stranger.key.PublicKey); err == nil {
+		t.Error("SealEmergencyKit should fail without an active session")
+	}
+}
+
+// TestRefreshEmergencyContactsKeepsRequest тестирует, что обновление набора
+// ключей не отменяет открытый запрос и не сбрасывает его срок
+func TestRefreshEmergencyContactsKeepsRequest(t *testing.T) {
+	ctx := context.Background()
+	service, owner, contact, contactKeys := emergencySetup(t, 48*time.Hour)
+	now := time.Now()
+	service.Now = func() time.Time { return now }
+
+	contact.act(t, service)
+	if _, err := contact.vm.RequestEmergencyAccess(ctx, owner.address); err != nil {
+		t.Fatalf("RequestEmergencyAccess failed: %v", err)
+	}
+
+	now = now.Add(24 * time.Hour)
+	owner.act(t, service)
+	refreshed, err := owner.vm.RefreshEmergencyContacts(ctx, keysFor(t, owner, fixtures.TestMasterPassword))
+	if err != nil || refreshed != 1 {
+		t.Fatalf("RefreshEmergencyContacts = %d, %v; want 1, nil", refreshed, err)
+	}
+
+	now = now.Add(24 * time.Hour)
+	contact.act(t, service)
+	if _, err := contact.vm.OpenEmergencyVault(ctx, contactKeys, owner.address); err != nil {
+		t.Errorf("Refreshed kit should open at the original deadline, got %v", err)
+	}
+}
+
+// TestOpenEmergencyVaultForeignKit тестирует, что набор ключей другого
+// владельца не открывает хранилище
+func TestOpenEmergencyVaultForeignKit(t *testing.T) {
+	ctx := context.Background()
+	service, owner, contact, contactKeys := emergencySetup(t, time.Hour)
+
+	other := newMember(t, service)
+	wrapped, err := keysFor(t, other, fixtures.TestMasterPassword).SealEmergencyKit(&contact.key.PublicKey)
+	if err != nil {
+		t.Fatalf("SealEmergencyKit failed: %v", err)
+	}
+	owner.act(t, service)
+	if _, err := service.SetEmergencyContact(ctx, contact.address, time.Hour, wrapped); err != nil {
+		t.Fatalf("SetEmergencyContact failed: %v", err)
+	}
+	if err := owner.vm.ApproveEmergencyAccess(ctx, contact.address); !errors.Is(err, blockchain.ErrNoEmergencyRequest) {
+		t.Errorf("Expected ErrNoEmergencyRequest before a request, got %v", err)
+	}
+
+	contact.act(t, service)
+	if _, err := contact.vm.RequestEmergencyAccess(ctx, owner.address); err != nil {
+		t.Fatalf("RequestEmergencyAccess failed: %v", err)
+	}
+	owner.act(t, service)
+	if err := owner.vm.ApproveEmergencyAccess(ctx, contact.address); err != nil {
+		t.Fatalf("ApproveEmergencyAccess failed: %v", err)
+	}
+	contact.act(t, service)
+	if _, err := contact.vm.OpenEmergencyVault(ctx, contactKeys, owner.address); !errors.Is(err, vaultmanager.ErrEmergencyKitMismatch) {
+		t.Errorf("Expected ErrEmergencyKitMismatch, got %v", err)
+	}
+}
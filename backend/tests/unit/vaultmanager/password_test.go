@@ -0,0 +1,97 @@
+package vaultmanager_test
+
+import (
+	"context"
+	"testing"
+
+	"encryptkeep-backend/internal/codec"
+	"encryptkeep-backend/internal/vault"
+	"encryptkeep-backend/internal/vaultmanager"
+	"encryptkeep-backend/tests/fixtures"
+	mocks "encryptkeep-backend/tests/mocks/blockchain"
+)
+
+// TestChangeMasterPasswordResumes тестирует перешифрование хранилища и его продолжение после сбоя
+func TestChangeMasterPasswordResumes(t *testing.T) {
+	ctx := context.Background()
+	service := mocks.NewMockBlockchainService()
+	vm := vaultmanager.NewVaultManager(service, fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address))
+
+	v := vault.NewLocalVault()
+	if err := vm.AddEntries(ctx, v, fixtures.GetTestPasswordEntries()); err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+	if err := vm.StoreMetadata(ctx, v.Metadata); err != nil {
+		t.Fatalf("StoreMetadata failed: %v", err)
+	}
+
+	const newPassword = "new-master-password"
+	newKey := fixtures.NewTestVaultKey(newPassword, service.Address)
+	service.FailAfter = 1
+	if err := vm.ChangeMasterPassword(ctx, service.Address, newKey); err == nil {
+		t.Fatal("Expected the interrupted change to fail")
+	}
+
+	service.FailAfter = -1
+	if err := vm.ChangeMasterPassword(ctx, service.Address, newKey); err != nil {
+		t.Fatalf("resumed ChangeMasterPassword failed: %v", err)
+	}
+
+	cdc := codec.NewCodec()
+	for id, data := range service.Data {
+		if _, err := cdc.UnpackEntry(data, newPassword); err != nil {
+			t.Errorf("Entry %s should open with the new password: %v", id, err)
+		}
+	}
+	if _, err := cdc.UnpackMetadata(service.Metadata, newPassword); err != nil {
+		t.Errorf("Metadata should open with the new password: %v", err)
+	}
+
+	// менеджер сразу пишет новым паролем
+	if err := vm.AddEntries(ctx, v, []*vault.PasswordEntry{vault.NewPasswordEntry("New", "user", "pass")}); err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+	for _, data := range service.Data {
+		if _, err := cdc.UnpackEntry(data, newPassword); err != nil {
+			t.Error("New entries should be sealed with the new password")
+		}
+	}
+}
+
+// TestDeriveOlderKeys тестирует вывод ключей записей, запечатанных старыми клиентами
+func TestDeriveOlderKeys(t *testing.T) {
+	ctx := context.Background()
+	service := mocks.NewMockBlockchainService()
+	key := fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address)
+	defer key.Destroy()
+	vm := vaultmanager.NewVaultManager(service, key)
+
+	v := vault.NewLocalVault()
+	if err := vm.AddEntries(ctx, v, fixtures.GetTestPasswordEntries()[:1]); err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+	// запись старого клиента со случайной солью
+	legacy, err := codec.NewCodec().PackEntry(vault.NewPasswordEntry("Old", "user", "pass"), fixtures.TestMasterPassword)
+	if err != nil {
+		t.Fatalf("PackEntry failed: %v", err)
+	}
+	if _, err := service.StoreData(ctx, legacy); err != nil {
+		t.Fatalf("StoreData failed: %v", err)
+	}
+
+	added, err := vm.DeriveOlderKeys(ctx, service.Address, fixtures.TestMasterPassword)
+	if err != nil {
+		t.Fatalf("DeriveOlderKeys failed: %v", err)
+	}
+	if added != 1 {
+		t.Errorf("Expected one older key, got %d", added)
+	}
+	if _, err := codec.NewCodec().UnpackEntryWithVaultKey(legacy, key); err != nil {
+		t.Errorf("Older entry should open without the password: %v", err)
+	}
+
+	// повторный вызов ничего не добавляет
+	if added, _ := vm.DeriveOlderKeys(ctx, service.Address, fixtures.TestMasterPassword); added != 0 {
+		t.Errorf("Expected no new keys, got %d", added)
+	}
+}
@@ -0,0 +1,132 @@
+package vaultmanager_test
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"encryptkeep-backend/internal/pricing"
+	"encryptkeep-backend/internal/vault"
+	"encryptkeep-backend/internal/vaultmanager"
+	"encryptkeep-backend/tests/fixtures"
+	mocks "encryptkeep-backend/tests/mocks/blockchain"
+)
+
+// TestPreviewAddEntries тестирует оценку стоимости без отправки транзакций
+func TestPreviewAddEntries(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	vm := vaultmanager.NewVaultManager(service, fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address))
+
+	entries := fixtures.GetTestPasswordEntries()
+	estimate, err := vm.PreviewAddEntries(context.Background(), entries)
+	if err != nil {
+		t.Fatalf("PreviewAddEntries failed: %v", err)
+	}
+
+	expectedGas := service.GasPerItem * uint64(len(entries))
+	if estimate.Gas != expectedGas {
+		t.Errorf("Expected %d gas, got %d", expectedGas, estimate.Gas)
+	}
+	if estimate.FiatCurrency != "" {
+		t.Errorf("Expected no fiat value without price source, got %s", estimate.FiatCurrency)
+	}
+	if service.Calls["StoreDataBatch"] != 0 || len(service.Data) != 0 {
+		t.Error("Preview must not write anything")
+	}
+}
+
+// TestPreviewFiatConversion тестирует пересчет комиссии в фиат
+func TestPreviewFiatConversion(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	vm := vaultmanager.NewVaultManager(service, fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address))
+	vm.SetPriceSource(pricing.StaticSource{"ETH": {"USD": 2000}}, "USD")
+
+	estimate, err := vm.PreviewAddEntries(context.Background(), fixtures.GetTestPasswordEntries()[:1])
+	if err != nil {
+		t.Fatalf("PreviewAddEntries failed: %v", err)
+	}
+
+	// 50 000 gas * 1 gwei = 0.00005 ETH = 0.1 USD
+	if math.Abs(estimate.FeeNative()-0.00005) > 1e-12 {
+		t.Errorf("Expected fee 0.00005 ETH, got %v", estimate.FeeNative())
+	}
+	if estimate.FiatCurrency != "USD" || math.Abs(estimate.FiatValue-0.1) > 1e-9 {
+		t.Errorf("Expected ~0.1 USD, got %v %s", estimate.FiatValue, estimate.FiatCurrency)
+	}
+
+	// Отсутствующая котировка не является ошибкой
+	vm.SetPriceSource(pricing.StaticSource{}, "EUR")
+	estimate, err = vm.PreviewAddEntries(context.Background(), fixtures.GetTestPasswordEntries()[:1])
+	if err != nil {
+		t.Fatalf("PreviewAddEntries failed: %v", err)
+	}
+	if estimate.FiatCurrency != "" {
+		t.Errorf("Expected no fiat value for missing quote, got %s", estimate.FiatCurrency)
+	}
+}
+
+// TestPreviewUpdateAndDelete тестирует оценку изменения и удаления записей
+func TestPreviewUpdateAndDelete(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	vm := vaultmanager.NewVaultManager(service, fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address))
+	v := vault.NewLocalVault()
+
+	entries := fixtures.GetTestPasswordEntries()
+	if err := vm.AddEntries(context.Background(), v, entries); err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+
+	if _, err := vm.PreviewUpdateEntries(context.Background(), v, entries[:2]); err != nil {
+		t.Fatalf("PreviewUpdateEntries failed: %v", err)
+	}
+	if _, err := vm.PreviewDeleteEntries(context.Background(), v, []string{entries[0].ID}); err != nil {
+		t.Fatalf("PreviewDeleteEntries failed: %v", err)
+	}
+	if _, err := vm.PreviewDeleteEntries(context.Background(), v, []string{"missing"}); err == nil {
+		t.Error("Expected error for unknown entry")
+	}
+
+	if service.Calls["ChangeDataBatch"] != 0 || service.Calls["RemoveDataBatch"] != 0 {
+		t.Error("Preview must not write anything")
+	}
+	if len(v.Entries) != len(entries) {
+		t.Errorf("Expected vault to be unchanged, got %d entries", len(v.Entries))
+	}
+}
+
+// TestPreviewSingleEntry тестирует, что оценка одной записи считает тот же вызов, что и отправка
+func TestPreviewSingleEntry(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	vm := vaultmanager.NewVaultManager(service, fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address))
+	v := vault.NewLocalVault()
+
+	entries := fixtures.GetTestPasswordEntries()
+	if err := vm.AddEntries(context.Background(), v, entries); err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+
+	if _, err := vm.PreviewAddEntry(context.Background(), entries[0]); err != nil {
+		t.Fatalf("PreviewAddEntry failed: %v", err)
+	}
+	if _, err := vm.PreviewUpdateEntry(context.Background(), v, entries[0]); err != nil {
+		t.Fatalf("PreviewUpdateEntry failed: %v", err)
+	}
+	if _, err := vm.PreviewDeleteEntry(context.Background(), v, entries[0].ID); err != nil {
+		t.Fatalf("PreviewDeleteEntry failed: %v", err)
+	}
+	if _, err := vm.PreviewDeleteEntry(context.Background(), v, "missing"); err == nil {
+		t.Error("Expected error for unknown entry")
+	}
+
+	// AddEntry, UpdateEntry и DeleteEntry отправляют одиночные вызовы, а не пакетные
+	for _, name := range []string{"EstimateStoreData", "EstimateChangeData", "EstimateRemoveData"} {
+		if service.CallCount(name) != 1 {
+			t.Errorf("Expected one %s call, got %d", name, service.CallCount(name))
+		}
+	}
+	for _, name := range []string{"EstimateStoreDataBatch", "EstimateChangeDataBatch", "EstimateRemoveDataBatch"} {
+		if service.CallCount(name) != 0 {
+			t.Errorf("Expected no %s calls, got %d", name, service.CallCount(name))
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package vaultmanager_test
+
+import (
+	"context"
+	"testing"
+
+	"encryptkeep-backend/internal/vault"
+	"encryptkeep-backend/internal/vaultmanager"
+	"encryptkeep-backend/tests/fixtures"
+	mocks "encryptkeep-backend/tests/mocks/blockchain"
+)
+
+// TestSyncChanges тестирует инкрементальную синхронизацию по событиям с другого устройства
+func TestSyncChanges(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	key := fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address)
+	ctx := context.Background()
+
+	// первое устройство пишет, второе следит за событиями
+	writer, writerVault := vaultmanager.NewVaultManager(service, key), vault.NewLocalVault()
+	reader, readerVault := vaultmanager.NewVaultManager(service, key), vault.NewLocalVault()
+
+	entries := fixtures.GetTestPasswordEntries()
+	if err := writer.AddEntries(ctx, writerVault, entries); err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+	changes, _ := service.VaultChanges(ctx, service.Address, 0, service.Block)
+	moved, err := reader.SyncChanges(ctx, readerVault, service.Address, changes)
+	if err != nil {
+		t.Fatalf("SyncChanges failed: %v", err)
+	}
+	if len(moved.Added) != len(entries) || readerVault.Len() != len(entries) {
+		t.Fatalf("Expected %d entries added, got %+v", len(entries), moved)
+	}
+	if service.Calls["SyncVault"] != 0 {
+		t.Error("SyncChanges should not resync the whole vault")
+	}
+
+	// изменение и удаление читаются только по затронутым ID
+	from := service.Block + 1
+	edited, _ := writerVault.Get(entries[0].ID)
+	edited.Password = "rotated"
+	if err := writer.UpdateEntries(ctx, writerVault, []*vault.PasswordEntry{edited}); err != nil {
+		t.Fatalf("UpdateEntries failed: %v", err)
+	}
+	if err := writer.DeleteEntries(ctx, writerVault, []string{entries[1].ID}); err != nil {
+		t.Fatalf("DeleteEntries failed: %v", err)
+	}
+	changes, _ = service.VaultChanges(ctx, service.Address, from, service.Block)
+	moved, err = reader.SyncChanges(ctx, readerVault, service.Address, changes)
+	if err != nil {
+		t.Fatalf("SyncChanges failed: %v", err)
+	}
+	if len(moved.Updated) != 1 || moved.Updated[0] != entries[0].ID {
+		t.Errorf("Expected %s updated, got %v", entries[0].ID, moved.Updated)
+	}
+	if len(moved.Removed) != 1 || moved.Removed[0] != entries[1].ID {
+		t.Errorf("Expected %s removed, got %v", entries[1].ID, moved.Removed)
+	}
+	if got, _ := readerVault.Get(entries[0].ID); got.Password != "rotated" {
+		t.Errorf("Expected the rotated password, got %q", got.Password)
+	}
+}
+
+// TestSyncChangesFullReread тестирует полное перечитывание хранилища без событий (контракт v1)
+func TestSyncChangesFullReread(t *testing.T) {
+	service := mocks.NewMockBlockchainService()
+	key := fixtures.NewTestVaultKey(fixtures.TestMasterPassword, service.Address)
+	ctx := context.Background()
+
+	writer, writerVault := vaultmanager.NewVaultManager(service, key), vault.NewLocalVault()
+	reader, readerVault := vaultmanager.NewVaultManager(service, key), vault.NewLocalVault()
+
+	entries := fixtures.GetTestPasswordEntries()
+	if err := writer.AddEntries(ctx, writerVault, entries); err != nil {
+		t.Fatalf("AddEntries failed: %v", err)
+	}
+	if _, err := reader.SyncChanges(ctx, readerVault, service.Address, nil); err != nil {
+		t.Fatalf("SyncChanges failed: %v", err)
+	}
+
+	// удалённая на другом устройстве запись пропадает при перечитывании
+	if err := writer.DeleteEntries(ctx, writerVault, []string{entries[0].ID}); err != nil {
+		t.Fatalf("DeleteEntries failed: %v", err)
+	}
+	moved, err := reader.SyncChanges(ctx, readerVault, service.Address, nil)
+	if err != nil {
+		t.Fatalf("SyncChanges failed: %v", err)
+	}
+	if len(moved.Removed) != 1 || len(moved.Added) != 0 || len(moved.Updated) != 0 {
+		t.Errorf("Expected only one removal, got %+v", moved)
+	}
+	if readerVault.Len() != len(entries)-1 {
+		t.Errorf("Expected %d entries, got %d", len(entries)-1, readerVault.Len())
+	}
+
+}
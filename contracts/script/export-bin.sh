@@ -0,0 +1,35 @@
+#!/usr/bin/env bash
+# Rebuilds bin/ from src/ with forge: for every contract, <name>.abi,
+# <name>.bin (creation bytecode, hex) and <name>.json with both plus the
+# function selectors, laid out like src/. Run it after changing a
+# contract, then regenerate the Go bindings from bin/src/Keeper.abi:
+#
+#   abigen --abi bin/src/Keeper.abi --pkg blockchain --type Keeper \
+#       --out ../backend/internal/blockchain/keeper_bindings.go
+set -euo pipefail
+cd "$(dirname "$0")/.."
+
+forge build --force
+rm -rf bin
+
+find src -name '*.sol' | sort | while read -r source; do
+    name=$(basename "$source" .sol)
+    dir=bin/$(dirname "$source")
+    artifact=out/$name.sol/$name.json
+    mkdir -p "$dir"
+
+    jq -c .abi "$artifact" >"$dir/$name.abi"
+    jq -j '.bytecode.object | ltrimstr("0x")' "$artifact" >"$dir/$name.bin"
+    jq --arg name "$name" --arg source "$source" '{
+        contractName: $name,
+        abi: .abi,
+        metadata: .rawMetadata,
+        bytecode: (.bytecode.object | ltrimstr("0x")),
+        deployedBytecode: (.deployedBytecode.object | ltrimstr("0x")),
+        sourceMap: .bytecode.sourceMap,
+        deployedSourceMap: .deployedBytecode.sourceMap,
+        sourcePath: $source,
+        compiler: {name: "solc", version: .metadata.compiler.version},
+        functionHashes: .methodIdentifiers
+    }' "$artifact" >"$dir/$name.json"
+done
@@ -2,8 +2,20 @@
 pragma solidity ^0.8.28;
 
 interface IKeeper {
+    event DataStored(address indexed account, uint256 indexed id, uint256 revision);
+    event DataChanged(address indexed account, uint256 indexed id, uint256 revision);
+    event DataRemoved(address indexed account, uint256 indexed id, uint256 revision);
+    event MetaDataStored(address indexed account);
+
     function storeMetaData(bytes calldata _data) external payable;
-    function storeData(bytes calldata _data) external payable;
+    function storeData(bytes calldata _data) external payable returns (uint256);
     function changeData(uint256 _id, bytes calldata _newData) external payable;
     function removeData(uint256 _id) external payable;
+
+    function storeDataBatch(bytes[] calldata _data) external payable returns (uint256[] memory);
+    function changeDataBatch(uint256[] calldata _ids, bytes[] calldata _newData) external payable;
+    function removeDataBatch(uint256[] calldata _ids) external payable;
+
+    function getActiveIds(address _account) external view returns (uint256[] memory);
+    function version() external pure returns (uint256);
 }
@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.28;
+
+/// Shared collections: entries sealed with a random collection key, which is
+/// stored wrapped (ECIES) to every member's secp256k1 public key. The owner
+/// decides who may write; reading is gated by the wrapped key alone.
+interface IKeeperCollections {
+    event PublicKeyRegistered(address indexed account);
+    event CollectionCreated(uint256 indexed collection, address indexed owner);
+    event MemberAdded(uint256 indexed collection, address indexed member);
+    event MemberRemoved(uint256 indexed collection, address indexed member);
+    event CollectionKeyRotated(uint256 indexed collection, uint256 epoch);
+    event CollectionDataStored(uint256 indexed collection, uint256 indexed id, uint256 revision);
+    event CollectionDataChanged(uint256 indexed collection, uint256 indexed id, uint256 revision);
+    event CollectionDataRemoved(uint256 indexed collection, uint256 indexed id, uint256 revision);
+
+    function registerPublicKey(bytes calldata _publicKey) external;
+
+    function createCollection(bytes calldata _wrappedKey) external returns (uint256);
+    function addMember(uint256 _collection, address _member, bytes calldata _wrappedKey) external;
+    function removeMember(uint256 _collection, address _member) external;
+    function rotateCollectionKey(uint256 _collection, address[] calldata _members, bytes[] calldata _wrappedKeys)
+        external;
+
+    function storeCollectionData(uint256 _collection, bytes[] calldata _data) external returns (uint256[] memory);
+    function changeCollectionData(uint256 _collection, uint256[] calldata _ids, bytes[] calldata _newData) external;
+    function removeCollectionData(uint256 _collection, uint256[] calldata _ids) external;
+
+    function getCollectionMembers(uint256 _collection) external view returns (address[] memory);
+    function getCollectionIds(uint256 _collection) external view returns (uint256[] memory);
+}
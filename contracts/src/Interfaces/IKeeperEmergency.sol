@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: MIT
+pragma solidity ^0.8.28;
+
+/// Emergency access: an owner stores a copy of the vault key wrapped to a
+/// trusted contact. The contact may request access; once the owner's wait
+/// period passes without a rejection, or the owner approves, the key view
+/// releases the wrapped copy, with no action needed from the owner.
+/// Storage is public, so the delay binds honest clients only: designate
+/// contacts you would trust with the key outright. A removed contact may
+/// already have read the key; only a new master password locks them out.
+interface IKeeperEmergency {
+    event EmergencyContactSet(address indexed owner, address indexed contact, uint256 waitPeriod);
+    event EmergencyContactRemoved(address indexed owner, address indexed contact);
+    event EmergencyAccessRequested(address indexed owner, address indexed contact, uint256 availableAt);
+    event EmergencyAccessApproved(address indexed owner, address indexed contact);
+    event EmergencyAccessRejected(address indexed owner, address indexed contact);
+
+    function setEmergencyContact(address _contact, uint256 _waitPeriod, bytes calldata _wrappedKey) external;
+    function removeEmergencyContact(address _contact) external;
+
+    function requestEmergencyAccess(address _owner) external;
+    function approveEmergencyAccess(address _contact) external;
+    function rejectEmergencyAccess(address _contact) external;
+
+    function getEmergencyContacts(address _owner) external view returns (address[] memory);
+    function getEmergencyAccess(address _owner, address _contact)
+        external
+        view
+        returns (uint256 waitPeriod, uint256 requestedAt, bool approved);
+    function getEmergencyKey(address _owner, address _contact) external view returns (bytes memory);
+}
@@ -2,48 +2,448 @@
 pragma solidity ^0.8.28;
 
 import {IKeeper} from "./Interfaces/IKeeper.sol";
+import {IKeeperCollections} from "./Interfaces/IKeeperCollections.sol";
+import {IKeeperEmergency} from "./Interfaces/IKeeperEmergency.sol";
 
 error InvalidDataLength();
 error CannotStoreExistingData(address, uint256);
 error CannotChangeNonExistentData(address, uint256);
 error CannotRemoveNonExistentData(address, uint256);
+error BatchLengthMismatch(uint256, uint256);
+error InvalidPublicKey(address);
+error UnknownCollection(uint256);
+error NotCollectionOwner(uint256, address);
+error NotCollectionMember(uint256, address);
+error AlreadyCollectionMember(uint256, address);
+error CannotRemoveCollectionOwner(uint256);
+error NotEmergencyContact(address, address);
+error EmergencyRequestPending(address, address);
+error NoEmergencyRequest(address, address);
+error EmergencyAccessNotGranted(address, address, uint256);
+
+contract Keeper is IKeeper, IKeeperCollections, IKeeperEmergency {
+    uint256 public constant VERSION = 3;
 
-contract Keeper is IKeeper {
     mapping(address => mapping(uint256 => bytes)) public userData;
     mapping(address => bytes) public userMetaData;
     mapping(address => uint256[]) public activeIdsForUser;
     mapping(address => uint256) public nextDataId;
+    mapping(address => mapping(uint256 => uint256)) public dataRevision;
+
+    mapping(address => bytes) public publicKeys;
+    uint256 public nextCollectionId;
+    mapping(uint256 => address) public collectionOwner;
+    mapping(uint256 => uint256) public collectionKeyEpoch;
+    mapping(uint256 => address[]) internal collectionMembers;
+    mapping(uint256 => mapping(address => bytes)) public wrappedKeys;
+    mapping(uint256 => mapping(address => uint256)) public memberKeyEpoch;
+    mapping(uint256 => mapping(uint256 => bytes)) public collectionData;
+    mapping(uint256 => uint256[]) internal activeIdsForCollection;
+    mapping(uint256 => uint256) public nextCollectionDataId;
+    mapping(uint256 => mapping(uint256 => uint256)) public collectionDataRevision;
+
+    mapping(address => address[]) internal emergencyContacts;
+    mapping(address => mapping(address => bytes)) internal emergencyKeys;
+    mapping(address => mapping(address => uint256)) internal emergencyWaitPeriod;
+    mapping(address => mapping(address => uint256)) internal emergencyRequestedAt;
+    mapping(address => mapping(address => bool)) internal emergencyApproved;
+
+    modifier onlyCollectionOwner(uint256 _collection) {
+        require(collectionOwner[_collection] != address(0), UnknownCollection(_collection));
+        require(collectionOwner[_collection] == msg.sender, NotCollectionOwner(_collection, msg.sender));
+        _;
+    }
+
+    modifier onlyCollectionMember(uint256 _collection) {
+        require(collectionOwner[_collection] != address(0), UnknownCollection(_collection));
+        require(wrappedKeys[_collection][msg.sender].length != 0, NotCollectionMember(_collection, msg.sender));
+        _;
+    }
 
     function storeMetaData(bytes calldata _data) external payable {
         require(_data.length > 0, InvalidDataLength());
         address account = msg.sender;
         userMetaData[account] = _data;
+
+        emit MetaDataStored(account);
     }
 
-    function storeData(bytes calldata _data) external payable {
-        require(_data.length > 0, InvalidDataLength());
+    function storeData(bytes calldata _data) external payable returns (uint256) {
+        return _storeData(msg.sender, _data);
+    }
+
+    function changeData(uint256 _id, bytes calldata _newData) external payable {
+        _changeData(msg.sender, _id, _newData);
+    }
+
+    function removeData(uint256 _id) external payable {
+        _removeData(msg.sender, _id);
+    }
+
+    function storeDataBatch(bytes[] calldata _data) external payable returns (uint256[] memory ids) {
+        uint256 length = _data.length;
+        require(length > 0, InvalidDataLength());
         address account = msg.sender;
-        uint256 id = nextDataId[account];
+
+        ids = new uint256[](length);
+        for (uint256 i = 0; i < length;) {
+            ids[i] = _storeData(account, _data[i]);
+            unchecked {
+                ++i;
+            }
+        }
+    }
+
+    function changeDataBatch(uint256[] calldata _ids, bytes[] calldata _newData) external payable {
+        uint256 length = _ids.length;
+        require(length > 0, InvalidDataLength());
+        require(length == _newData.length, BatchLengthMismatch(length, _newData.length));
+        address account = msg.sender;
+
+        for (uint256 i = 0; i < length;) {
+            _changeData(account, _ids[i], _newData[i]);
+            unchecked {
+                ++i;
+            }
+        }
+    }
+
+    function removeDataBatch(uint256[] calldata _ids) external payable {
+        uint256 length = _ids.length;
+        require(length > 0, InvalidDataLength());
+        address account = msg.sender;
+
+        for (uint256 i = 0; i < length;) {
+            _removeData(account, _ids[i]);
+            unchecked {
+                ++i;
+            }
+        }
+    }
+
+    function getActiveIds(address _account) external view returns (uint256[] memory) {
+        return activeIdsForUser[_account];
+    }
+
+    function version() external pure returns (uint256) {
+        return VERSION;
+    }
+
+    function supportsInterface(bytes4 _interfaceId) external pure returns (bool) {
+        return _interfaceId == type(IKeeper).interfaceId || _interfaceId == type(IKeeperCollections).interfaceId
+            || _interfaceId == type(IKeeperEmergency).interfaceId || _interfaceId == 0x01ffc9a7;
+    }
+
+    /// Publishes the caller's uncompressed secp256k1 public key (64 bytes,
+    /// without the 0x04 prefix) so collection owners can wrap keys to it.
+    function registerPublicKey(bytes calldata _publicKey) external {
+        require(
+            _publicKey.length == 64 && address(uint160(uint256(keccak256(_publicKey)))) == msg.sender,
+            InvalidPublicKey(msg.sender)
+        );
+        publicKeys[msg.sender] = _publicKey;
+
+        emit PublicKeyRegistered(msg.sender);
+    }
+
+    function createCollection(bytes calldata _wrappedKey) external returns (uint256 collection) {
+        require(_wrappedKey.length > 0, InvalidDataLength());
+        collection = nextCollectionId++;
+        collectionOwner[collection] = msg.sender;
+
+        emit CollectionCreated(collection, msg.sender);
+        _addMember(collection, msg.sender, _wrappedKey);
+    }
+
+    function addMember(uint256 _collection, address _member, bytes calldata _wrappedKey)
+        external
+        onlyCollectionOwner(_collection)
+    {
+        require(_wrappedKey.length > 0, InvalidDataLength());
+        _addMember(_collection, _member, _wrappedKey);
+    }
+
+    /// Revokes write access and the member's wrapped key. Anything the member
+    /// already read stays readable to them; the owner rotates the key so
+    /// later writes are not.
+    function removeMember(uint256 _collection, address _member) external onlyCollectionOwner(_collection) {
+        require(_member != msg.sender, CannotRemoveCollectionOwner(_collection));
+        require(wrappedKeys[_collection][_member].length != 0, NotCollectionMember(_collection, _member));
+
+        delete wrappedKeys[_collection][_member];
+        delete memberKeyEpoch[_collection][_member];
+        address[] storage members = collectionMembers[_collection];
+        uint256 length = members.length;
+        for (uint256 i = 0; i < length;) {
+            if (members[i] == _member) {
+                members[i] = members[length - 1];
+                members.pop();
+                break;
+            }
+            unchecked {
+                ++i;
+            }
+        }
+
+        emit MemberRemoved(_collection, _member);
+    }
+
+    /// Replaces the wrapped key of every member at once; the list has to
+    /// cover exactly the current members.
+    function rotateCollectionKey(uint256 _collection, address[] calldata _members, bytes[] calldata _wrappedKeys)
+        external
+        onlyCollectionOwner(_collection)
+    {
+        uint256 length = _members.length;
+        require(length == _wrappedKeys.length, BatchLengthMismatch(length, _wrappedKeys.length));
+        require(length == collectionMembers[_collection].length, BatchLengthMismatch(length, collectionMembers[_collection].length));
+
+        uint256 epoch = ++collectionKeyEpoch[_collection];
+        for (uint256 i = 0; i < length;) {
+            address member = _members[i];
+            // a member listed twice would leave another one out
+            require(
+                wrappedKeys[_collection][member].length != 0 && memberKeyEpoch[_collection][member] != epoch,
+                NotCollectionMember(_collection, member)
+            );
+            require(_wrappedKeys[i].length > 0, InvalidDataLength());
+            wrappedKeys[_collection][member] = _wrappedKeys[i];
+            memberKeyEpoch[_collection][member] = epoch;
+            unchecked {
+                ++i;
+            }
+        }
+
+        emit CollectionKeyRotated(_collection, epoch);
+    }
+
+    function storeCollectionData(uint256 _collection, bytes[] calldata _data)
+        external
+        onlyCollectionMember(_collection)
+        returns (uint256[] memory ids)
+    {
+        uint256 length = _data.length;
+        require(length > 0, InvalidDataLength());
+
+        ids = new uint256[](length);
+        for (uint256 i = 0; i < length;) {
+            require(_data[i].length > 0, InvalidDataLength());
+            uint256 id = nextCollectionDataId[_collection]++;
+            activeIdsForCollection[_collection].push(id);
+            collectionData[_collection][id] = _data[i];
+            ids[i] = id;
+
+            emit CollectionDataStored(_collection, id, ++collectionDataRevision[_collection][id]);
+            unchecked {
+                ++i;
+            }
+        }
+    }
+
+    function changeCollectionData(uint256 _collection, uint256[] calldata _ids, bytes[] calldata _newData)
+        external
+        onlyCollectionMember(_collection)
+    {
+        uint256 length = _ids.length;
+        require(length > 0, InvalidDataLength());
+        require(length == _newData.length, BatchLengthMismatch(length, _newData.length));
+
+        for (uint256 i = 0; i < length;) {
+            uint256 id = _ids[i];
+            require(_newData[i].length > 0, InvalidDataLength());
+            require(
+                collectionData[_collection][id].length != 0,
+                CannotChangeNonExistentData(collectionOwner[_collection], id)
+            );
+            collectionData[_collection][id] = _newData[i];
+
+            emit CollectionDataChanged(_collection, id, ++collectionDataRevision[_collection][id]);
+            unchecked {
+                ++i;
+            }
+        }
+    }
+
+    function removeCollectionData(uint256 _collection, uint256[] calldata _ids)
+        external
+        onlyCollectionMember(_collection)
+    {
+        uint256 length = _ids.length;
+        require(length > 0, InvalidDataLength());
+
+        for (uint256 i = 0; i < length;) {
+            uint256 id = _ids[i];
+            require(
+                collectionData[_collection][id].length != 0,
+                CannotRemoveNonExistentData(collectionOwner[_collection], id)
+            );
+            delete collectionData[_collection][id];
+
+            uint256[] storage activeIds = activeIdsForCollection[_collection];
+            uint256 count = activeIds.length;
+            for (uint256 j = 0; j < count;) {
+                if (activeIds[j] == id) {
+                    activeIds[j] = activeIds[count - 1];
+                    activeIds.pop();
+                    break;
+                }
+                unchecked {
+                    ++j;
+                }
+            }
+
+            emit CollectionDataRemoved(_collection, id, ++collectionDataRevision[_collection][id]);
+            unchecked {
+                ++i;
+            }
+        }
+    }
+
+    function getCollectionMembers(uint256 _collection) external view returns (address[] memory) {
+        return collectionMembers[_collection];
+    }
+
+    function getCollectionIds(uint256 _collection) external view returns (uint256[] memory) {
+        return activeIdsForCollection[_collection];
+    }
+
+    /// Adds a contact or replaces their wrapped key and wait period. An
+    /// open request stays open, so re-wrapping the key after a password
+    /// change does not restart the contact's wait; reject it to cancel.
+    function setEmergencyContact(address _contact, uint256 _waitPeriod, bytes calldata _wrappedKey) external {
+        require(_wrappedKey.length > 0, InvalidDataLength());
+        address owner = msg.sender;
+        if (emergencyKeys[owner][_contact].length == 0) {
+            emergencyContacts[owner].push(_contact);
+        }
+        emergencyKeys[owner][_contact] = _wrappedKey;
+        emergencyWaitPeriod[owner][_contact] = _waitPeriod;
+
+        emit EmergencyContactSet(owner, _contact, _waitPeriod);
+    }
+
+    function removeEmergencyContact(address _contact) external {
+        address owner = msg.sender;
+        require(emergencyKeys[owner][_contact].length != 0, NotEmergencyContact(owner, _contact));
+
+        delete emergencyKeys[owner][_contact];
+        delete emergencyWaitPeriod[owner][_contact];
+        delete emergencyRequestedAt[owner][_contact];
+        delete emergencyApproved[owner][_contact];
+
+        address[] storage contacts = emergencyContacts[owner];
+        uint256 length = contacts.length;
+        for (uint256 i = 0; i < length;) {
+            if (contacts[i] == _contact) {
+                contacts[i] = contacts[length - 1];
+                contacts.pop();
+                break;
+            }
+            unchecked {
+                ++i;
+            }
+        }
+
+        emit EmergencyContactRemoved(owner, _contact);
+    }
+
+    function requestEmergencyAccess(address _owner) external {
+        address contact = msg.sender;
+        require(emergencyKeys[_owner][contact].length != 0, NotEmergencyContact(_owner, contact));
+        require(emergencyRequestedAt[_owner][contact] == 0, EmergencyRequestPending(_owner, contact));
+
+        emergencyRequestedAt[_owner][contact] = block.timestamp;
+
+        emit EmergencyAccessRequested(_owner, contact, block.timestamp + emergencyWaitPeriod[_owner][contact]);
+    }
+
+    function approveEmergencyAccess(address _contact) external {
+        address owner = msg.sender;
+        require(emergencyRequestedAt[owner][_contact] != 0, NoEmergencyRequest(owner, _contact));
+
+        emergencyApproved[owner][_contact] = true;
+
+        emit EmergencyAccessApproved(owner, _contact);
+    }
+
+    function rejectEmergencyAccess(address _contact) external {
+        address owner = msg.sender;
+        require(emergencyRequestedAt[owner][_contact] != 0, NoEmergencyRequest(owner, _contact));
+
+        delete emergencyRequestedAt[owner][_contact];
+        delete emergencyApproved[owner][_contact];
+
+        emit EmergencyAccessRejected(owner, _contact);
+    }
+
+    function getEmergencyContacts(address _owner) external view returns (address[] memory) {
+        return emergencyContacts[_owner];
+    }
+
+    function getEmergencyAccess(address _owner, address _contact)
+        external
+        view
+        returns (uint256 waitPeriod, uint256 requestedAt, bool approved)
+    {
+        require(emergencyKeys[_owner][_contact].length != 0, NotEmergencyContact(_owner, _contact));
+        return (
+            emergencyWaitPeriod[_owner][_contact],
+            emergencyRequestedAt[_owner][_contact],
+            emergencyApproved[_owner][_contact]
+        );
+    }
+
+    /// Returns the key wrapped to a contact once the owner approved their
+    /// request or its wait period passed without a rejection. The owner
+    /// need not act for the latter.
+    function getEmergencyKey(address _owner, address _contact) external view returns (bytes memory) {
+        require(emergencyKeys[_owner][_contact].length != 0, NotEmergencyContact(_owner, _contact));
+        uint256 requestedAt = emergencyRequestedAt[_owner][_contact];
+        uint256 availableAt = requestedAt + emergencyWaitPeriod[_owner][_contact];
+        require(
+            requestedAt != 0 && (emergencyApproved[_owner][_contact] || block.timestamp >= availableAt),
+            EmergencyAccessNotGranted(_owner, _contact, requestedAt == 0 ? 0 : availableAt)
+        );
+        return emergencyKeys[_owner][_contact];
+    }
+
+    function _addMember(uint256 _collection, address _member, bytes calldata _wrappedKey) internal {
+        require(wrappedKeys[_collection][_member].length == 0, AlreadyCollectionMember(_collection, _member));
+        wrappedKeys[_collection][_member] = _wrappedKey;
+        memberKeyEpoch[_collection][_member] = collectionKeyEpoch[_collection];
+        collectionMembers[_collection].push(_member);
+
+        emit MemberAdded(_collection, _member);
+    }
+
+    function _storeData(address account, bytes calldata _data) internal returns (uint256 id) {
+        require(_data.length > 0, InvalidDataLength());
+        id = nextDataId[account];
         require(userData[account][id].length == 0, CannotStoreExistingData(account, id));
         nextDataId[account]++;
         activeIdsForUser[account].push(id);
 
         userData[account][id] = _data;
+        uint256 revision = ++dataRevision[account][id];
+
+        emit DataStored(account, id, revision);
     }
 
-    function changeData(uint256 _id, bytes calldata _newData) external payable {
+    function _changeData(address account, uint256 _id, bytes calldata _newData) internal {
         require(_newData.length > 0, InvalidDataLength());
-        address account = msg.sender;
         require(userData[account][_id].length != 0, CannotChangeNonExistentData(account, _id));
 
         userData[account][_id] = _newData;
+        uint256 revision = ++dataRevision[account][_id];
+
+        emit DataChanged(account, _id, revision);
     }
 
-    function removeData(uint256 _id) external payable {
-        address account = msg.sender;
+    function _removeData(address account, uint256 _id) internal {
         require(userData[account][_id].length != 0, CannotRemoveNonExistentData(account, _id));
 
         delete userData[account][_id];
+        uint256 revision = ++dataRevision[account][_id];
 
         uint256[] storage activeIds = activeIdsForUser[account];
         uint256 length = activeIds.length;
@@ -57,5 +457,7 @@ contract Keeper is IKeeper {
                 ++i;
             }
         }
+
+        emit DataRemoved(account, _id, revision);
     }
 }